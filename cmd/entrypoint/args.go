@@ -16,6 +16,11 @@ limitations under the License.
 
 package main
 
+import (
+	"fmt"
+	"strings"
+)
+
 func extractArgs(initialArgs []string) ([]string, []string) {
 	commandArgs := []string{}
 	args := initialArgs
@@ -36,3 +41,24 @@ func extractArgs(initialArgs []string) ([]string, []string) {
 	}
 	return args, commandArgs
 }
+
+// splitNonEmpty splits a comma separated list, which is empty if the string is.
+func splitNonEmpty(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
+
+// parsePromotedResults parses a comma separated list of taskResult=stepResult pairs.
+func parsePromotedResults(list string) (map[string]string, error) {
+	promoted := map[string]string{}
+	for _, pair := range splitNonEmpty(list) {
+		taskResult, stepResult, ok := strings.Cut(pair, "=")
+		if !ok || taskResult == "" || stepResult == "" {
+			return nil, fmt.Errorf("invalid promoted result %q, expected taskResult=stepResult", pair)
+		}
+		promoted[taskResult] = stepResult
+	}
+	return promoted, nil
+}
@@ -65,3 +65,20 @@ func TestExtractArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePromotedResults(t *testing.T) {
+	got, err := parsePromotedResults("digest=image-digest,tags=tags")
+	if err != nil {
+		t.Fatalf("Error parsing promoted results: %v", err)
+	}
+	want := map[string]string{"digest": "image-digest", "tags": "tags"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected promoted results (-want, +got): %s", d)
+	}
+	if got, err := parsePromotedResults(""); err != nil || len(got) != 0 {
+		t.Errorf("Expected no promoted results but got %v, %v", got, err)
+	}
+	if _, err := parsePromotedResults("digest"); err == nil {
+		t.Error("Expected an error parsing a promoted result without a step result")
+	}
+}
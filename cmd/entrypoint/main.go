@@ -48,14 +48,26 @@ var (
 	stdoutPath          = flag.String("stdout_path", "", "If specified, file to copy stdout to")
 	stderrPath          = flag.String("stderr_path", "", "If specified, file to copy stderr to")
 	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, expect steps to not skip on failure")
+	breakpointBefore    = flag.Bool("breakpoint_before_step", false, "If specified, pause before running the step until it is continued")
+	breakpointAfter     = flag.Bool("breakpoint_after_step", false, "If specified, pause after running the step until it is continued")
 	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code."+
 		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
-	stepMetadataDir = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
+	stepMetadataDir        = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
+	terminationGracePeriod = flag.Duration("termination_grace_period", time.Duration(0), "If specified, time given to the step to exit after SIGTERM when it times out or is cancelled, before SIGKILL")
+	cancelFile             = flag.String("cancel_file", "", "If specified, file whose content signals that the step is cancelled")
+	envFile                = flag.String("env_file", "", "If specified, file where the step exports environment variables to the next steps")
+	importEnvFile          = flag.String("import_env_file", "", "If specified, file with the environment variables exported by the previous steps")
+	exportEnvFile          = flag.String("export_env_file", "", "If specified, file to write the environment variables exported by the previous steps and this one to")
+	retries                = flag.Int("retries", 0, "If specified, number of times to re-execute the step when it exits with a non-zero exit code")
+	retryDelay             = flag.Duration("retry_delay", time.Duration(0), "If specified, time to wait before re-executing the step")
+	resourceUsageInterval  = flag.Duration("resource_usage_interval", time.Duration(0), "If specified, interval between the samples of the resources used by the step, which are reported in its status")
+	stepsDir               = flag.String("steps_dir", "", "If specified, directory of the steps metadata to resolve the references to the results of the previous steps from")
+	stepResults            = flag.String("step_results", "", "If specified, list of results the step writes")
+	promoteResults         = flag.String("promote_results", "", "If specified, list of taskResult=stepResult task results promoted from the step results")
 )
 
 const (
 	defaultWaitPollingInterval = time.Second
-	breakpointExitSuffix       = ".breakpointexit"
 )
 
 func checkForBreakpointOnFailure(e entrypoint.Entrypointer, breakpointExitPostFile string) {
@@ -131,23 +143,44 @@ func main() {
 		}
 	}
 
+	promoted, err := parsePromotedResults(*promoteResults)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	runner, flushSpans := traceRunner(&realRunner{
+		stdoutPath:             *stdoutPath,
+		stderrPath:             *stderrPath,
+		terminationGracePeriod: *terminationGracePeriod,
+	})
+
 	e := entrypoint.Entrypointer{
-		Command:         append(cmd, commandArgs...),
-		WaitFiles:       strings.Split(*waitFiles, ","),
-		WaitFileContent: *waitFileContent,
-		PostFile:        *postFile,
-		TerminationPath: *terminationPath,
-		Waiter:          &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
-		Runner: &realRunner{
-			stdoutPath: *stdoutPath,
-			stderrPath: *stderrPath,
-		},
-		PostWriter:          &realPostWriter{},
-		Results:             strings.Split(*results, ","),
-		Timeout:             timeout,
-		BreakpointOnFailure: *breakpointOnFailure,
-		OnError:             *onError,
-		StepMetadataDir:     *stepMetadataDir,
+		Command:               append(cmd, commandArgs...),
+		WaitFiles:             strings.Split(*waitFiles, ","),
+		WaitFileContent:       *waitFileContent,
+		PostFile:              *postFile,
+		TerminationPath:       *terminationPath,
+		Waiter:                &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
+		Runner:                runner,
+		PostWriter:            &realPostWriter{},
+		Results:               strings.Split(*results, ","),
+		Timeout:               timeout,
+		BreakpointOnFailure:   *breakpointOnFailure,
+		BreakpointBeforeStep:  *breakpointBefore,
+		BreakpointAfterStep:   *breakpointAfter,
+		CancelFile:            *cancelFile,
+		EnvFile:               *envFile,
+		ImportEnvFile:         *importEnvFile,
+		ExportEnvFile:         *exportEnvFile,
+		Retries:               *retries,
+		RetryDelay:            *retryDelay,
+		UsageSampler:          &realUsageSampler{root: defaultCgroupRoot},
+		UsageSamplingInterval: *resourceUsageInterval,
+		StepsDir:              *stepsDir,
+		StepResults:           splitNonEmpty(*stepResults),
+		PromotedResults:       promoted,
+		OnError:               *onError,
+		StepMetadataDir:       *stepMetadataDir,
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
@@ -156,8 +189,11 @@ func main() {
 		log.Printf("non-fatal error copying credentials: %q", err)
 	}
 
-	if err := e.Go(); err != nil {
-		breakpointExitPostFile := e.PostFile + breakpointExitSuffix
+	err = e.Go()
+	// The spans are exported before the entrypoint exits with the exit code of the step.
+	flushSpans()
+	if err != nil {
+		breakpointExitPostFile := e.PostFile + entrypoint.BreakpointExitSuffix
 		switch t := err.(type) {
 		case skipError:
 			log.Print("Skipping step because a previous step failed")
@@ -165,6 +201,9 @@ func main() {
 		case termination.MessageLengthError:
 			log.Print(err.Error())
 			os.Exit(1)
+		case entrypoint.BreakpointError:
+			log.Print(err.Error())
+			os.Exit(1)
 		case *exec.ExitError:
 			// Copied from https://stackoverflow.com/questions/10385551/get-exit-code-go
 			// This works on both Unix and Windows. Although
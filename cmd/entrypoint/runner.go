@@ -29,6 +29,7 @@ import (
 	"path/filepath"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/pod"
@@ -44,6 +45,9 @@ type realRunner struct {
 	signalsClosed bool
 	stdoutPath    string
 	stderrPath    string
+	// terminationGracePeriod is the time the command is given to exit after it is sent SIGTERM
+	// when its context is done, before it is sent SIGKILL. If zero, it is killed immediately.
+	terminationGracePeriod time.Duration
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -74,15 +78,20 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	}
 	name, args := args[0], args[1:]
 
-	// Receive system signals on "rr.signals"
-	if rr.signals == nil {
+	// Receive system signals on "rr.signals". Each run closes the channel when it returns, so a
+	// new one is made when the command is run again, e.g. to retry the step.
+	rr.Lock()
+	if rr.signals == nil || rr.signalsClosed {
 		rr.signals = make(chan os.Signal, 1)
+		rr.signalsClosed = false
 	}
+	signals := rr.signals
+	rr.Unlock()
 	defer rr.close()
-	signal.Notify(rr.signals)
+	signal.Notify(signals)
 	defer signal.Reset()
 
-	cmd := exec.CommandContext(ctx, name, args...)
+	cmd := exec.Command(name, args...)
 
 	// Build a list of tee readers that we'll read from after the command is
 	// is started. If we are not configured to tee stdout/stderr this will be
@@ -118,15 +127,26 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 
 	// Start defined command
 	if err := cmd.Start(); err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return context.DeadlineExceeded
-		}
 		return err
 	}
 
+	// Goroutine terminating the command when the context is done, i.e. the step timed out or its
+	// TaskRun was cancelled. It reports whether the command exited gracefully, unless the command
+	// exited before.
+	exited := make(chan struct{})
+	termination := make(chan bool, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			termination <- rr.terminate(cmd.Process.Pid, exited)
+		case <-exited:
+			close(termination)
+		}
+	}()
+
 	// Goroutine for signals forwarding
 	go func() {
-		for s := range rr.signals {
+		for s := range signals {
 			// Forward signal to main process and all children
 			if s != syscall.SIGCHLD {
 				_ = syscall.Kill(-cmd.Process.Pid, s.(syscall.Signal))
@@ -151,14 +171,34 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	wg.Wait()
 
 	// Wait for command to exit
-	if err := cmd.Wait(); err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return context.DeadlineExceeded
+	err := cmd.Wait()
+	close(exited)
+	if graceful, terminated := <-termination; terminated {
+		if rr.terminationGracePeriod > 0 {
+			return entrypoint.TerminationError{Err: ctx.Err(), Graceful: graceful}
 		}
-		return err
+		return ctx.Err()
 	}
+	return err
+}
 
-	return nil
+// terminate sends SIGTERM to the process group of the command, and SIGKILL if it hasn't exited
+// after the termination grace period. It returns true if the command exited gracefully.
+func (rr *realRunner) terminate(pid int, exited <-chan struct{}) bool {
+	if rr.terminationGracePeriod <= 0 {
+		_ = syscall.Kill(-pid, syscall.SIGKILL)
+		return false
+	}
+	log.Printf("Sending SIGTERM to the step, which has %s to exit", rr.terminationGracePeriod)
+	_ = syscall.Kill(-pid, syscall.SIGTERM)
+	select {
+	case <-exited:
+		return true
+	case <-time.After(rr.terminationGracePeriod):
+		log.Print("Sending SIGKILL to the step, which didn't exit within its termination grace period")
+		_ = syscall.Kill(-pid, syscall.SIGKILL)
+		return false
+	}
 }
 
 // newTeeReader creates a new Reader that copies data from the given pipe function
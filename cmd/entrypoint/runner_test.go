@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"syscall"
 	"testing"
 	"time"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
 // TestRealRunnerSignalForwarding will artificially put an interrupt signal (SIGINT) in the rr.signals chan.
@@ -28,6 +31,43 @@ func TestRealRunnerSignalForwarding(t *testing.T) {
 	}
 }
 
+// TestRealRunnerRunTwice runs a failing command twice with the same runner, as when a step is
+// retried, and checks that signals are still forwarded to the second run.
+func TestRealRunnerRunTwice(t *testing.T) {
+	rr := realRunner{}
+	var exitErr *exec.ExitError
+	if err := rr.Run(context.Background(), "sh", "-c", "exit 1"); !errors.As(err, &exitErr) {
+		t.Fatalf("Expected the first run to fail with an exit error, got %v", err)
+	}
+	if err := rr.Run(context.Background(), "sh", "-c", "exit 1"); !errors.As(err, &exitErr) {
+		t.Fatalf("Expected the second run to fail with an exit error, got %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- rr.Run(context.Background(), "sleep", "3600")
+	}()
+	// Wait for the third run to receive signals before sending it SIGINT.
+	for {
+		rr.Lock()
+		ready := !rr.signalsClosed
+		rr.Unlock()
+		if ready {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	rr.signal(syscall.SIGINT)
+	select {
+	case err := <-done:
+		if err == nil || err.Error() != "signal: interrupt" {
+			t.Errorf("Expected SIGINT to be forwarded to the third run, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("SIGINT was not forwarded to the third run")
+	}
+}
+
 func TestRealRunnerStdoutAndStderrPaths(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "")
 	if err != nil {
@@ -134,3 +174,40 @@ func TestRealRunnerTimeout(t *testing.T) {
 		t.Fatalf("step didn't timeout")
 	}
 }
+
+// TestRealRunnerTerminationGracePeriod tests whether cmd is sent SIGTERM when it times out, and
+// SIGKILL if it doesn't exit within its termination grace period.
+func TestRealRunnerTerminationGracePeriod(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		script       string
+		wantGraceful bool
+	}{{
+		desc:         "exits on SIGTERM",
+		script:       `trap "exit 0" TERM; sleep 10 & wait`,
+		wantGraceful: true,
+	}, {
+		desc:   "ignores SIGTERM",
+		script: `trap "" TERM; sleep 10`,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			rr := realRunner{terminationGracePeriod: 2 * time.Second}
+			if !tc.wantGraceful {
+				rr.terminationGracePeriod = 100 * time.Millisecond
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			err := rr.Run(ctx, "sh", "-c", tc.script)
+			var te entrypoint.TerminationError
+			if !errors.As(err, &te) {
+				t.Fatalf("expected a termination error but got: %v", err)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected the termination error to wrap %v but got: %v", context.DeadlineExceeded, err)
+			}
+			if te.Graceful != tc.wantGraceful {
+				t.Errorf("expected the step to exit gracefully: %t, but got: %t", tc.wantGraceful, te.Graceful)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
@@ -35,6 +36,8 @@ import (
 type realRunner struct {
 	stdoutPath string
 	stderrPath string
+	// terminationGracePeriod is not supported on Windows, where the command is killed immediately.
+	terminationGracePeriod time.Duration
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
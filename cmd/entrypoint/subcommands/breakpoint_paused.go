@@ -0,0 +1,35 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"fmt"
+	"os"
+)
+
+// breakpointPaused returns an error unless the breakpoint marker exists, which the entrypoint
+// writes while the step is paused before or after running. It is run by the readiness probe of
+// the steps with breakpoints, so that a step is ready only while it is paused.
+func breakpointPaused(marker string) error {
+	if _, err := os.Stat(marker); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("step is not paused at a breakpoint")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+func TestBreakpointPaused(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "out"+entrypoint.BreakpointMarkerSuffix)
+	if err := breakpointPaused(marker); err == nil {
+		t.Error("expected an error for a step which is not paused")
+	}
+
+	if err := ioutil.WriteFile(marker, []byte("before"), 0600); err != nil {
+		t.Fatalf("error writing breakpoint marker: %v", err)
+	}
+	if err := breakpointPaused(marker); err != nil {
+		t.Errorf("unexpected error for a paused step: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// sleep waits for the given number of seconds, or until the process is sent SIGTERM or SIGINT. It
+// keeps the debug pods of failed steps running until their lifetime elapses or they are deleted.
+func sleep(seconds string) error {
+	s, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil || s < 0 {
+		return fmt.Errorf("invalid number of seconds %q", seconds)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	select {
+	case <-time.After(time.Duration(s) * time.Second):
+	case <-ctx.Done():
+	}
+	return nil
+}
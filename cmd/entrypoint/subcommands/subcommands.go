@@ -18,6 +18,8 @@ package subcommands
 
 import (
 	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
 // SubcommandSuccessful is returned for successful subcommand executions.
@@ -89,6 +91,24 @@ func Process(args []string) error {
 			return SubcommandError{subcommand: StepInitCommand, message: err.Error()}
 		}
 		return SubcommandSuccessful{message: "Setup /step directories"}
+	case entrypoint.BreakpointPausedCommand:
+		// If invoked in "breakpoint-paused" mode (`entrypoint breakpoint-paused <marker>`),
+		// succeed only if the step is paused at a breakpoint.
+		if len(args) == 2 {
+			if err := breakpointPaused(args[1]); err != nil {
+				return SubcommandError{subcommand: entrypoint.BreakpointPausedCommand, message: err.Error()}
+			}
+			return SubcommandSuccessful{message: "Paused at a breakpoint"}
+		}
+	case entrypoint.SleepCommand:
+		// If invoked in "sleep" mode (`entrypoint sleep <seconds>`), sleep for the given number
+		// of seconds, or until terminated.
+		if len(args) == 2 {
+			if err := sleep(args[1]); err != nil {
+				return SubcommandError{subcommand: entrypoint.SleepCommand, message: err.Error()}
+			}
+			return SubcommandSuccessful{message: "Slept"}
+		}
 	default:
 	}
 	return nil
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
 const helloWorldBase64 = "aGVsbG8gd29ybGQK"
@@ -36,6 +38,14 @@ func TestProcessSuccessfulSubcommands(t *testing.T) {
 			command: DecodeScriptCommand,
 			args:    []string{src},
 		},
+		{
+			command: entrypoint.BreakpointPausedCommand,
+			args:    []string{src},
+		},
+		{
+			command: entrypoint.SleepCommand,
+			args:    []string{"0"},
+		},
 	} {
 		t.Run(tc.command, func(t *testing.T) {
 			returnValue := Process(append([]string{tc.command}, tc.args...))
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// flushSpansTimeout is how long the spans of the step are given to be exported before the
+// entrypoint exits.
+const flushSpansTimeout = 5 * time.Second
+
+// tracingRunner traces each execution of the step by the runner it wraps, as a child of the
+// span of the TaskRun.
+type tracingRunner struct {
+	runner   entrypoint.Runner
+	tracer   trace.Tracer
+	parent   trace.SpanContext
+	stepName string
+}
+
+var _ entrypoint.Runner = (*tracingRunner)(nil)
+
+func (tr *tracingRunner) Run(ctx context.Context, args ...string) error {
+	ctx, span := tr.tracer.Start(trace.ContextWithRemoteSpanContext(ctx, tr.parent), "Step:"+tr.stepName,
+		trace.WithAttributes(attribute.String("step", tr.stepName)))
+	defer span.End()
+	err := tr.runner.Run(ctx, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// traceRunner wraps the given runner to trace the execution of the step if the controller
+// passed the context of the span of the TaskRun and the collector the spans are exported to in
+// the environment. It returns the runner and the function exporting the spans of the step.
+func traceRunner(runner entrypoint.Runner) (entrypoint.Runner, func()) {
+	parent := trace.SpanContextFromContext(tracing.ExtractEnv(context.Background(), os.Getenv))
+	endpoint := os.Getenv(tracing.OTLPEndpointEnvVar)
+	if !parent.IsValid() || endpoint == "" {
+		return runner, func() {}
+	}
+	provider, shutdown, err := tracing.NewTracerProvider(context.Background(), config.Tracing{
+		Backend:      config.TracingBackendOTLP,
+		OTLPEndpoint: endpoint,
+	}, tracing.EntrypointServiceName)
+	if err != nil {
+		log.Printf("non-fatal error tracing the step: %v", err)
+		return runner, func() {}
+	}
+	traced := &tracingRunner{
+		runner:   runner,
+		tracer:   provider.Tracer(tracing.TracerName),
+		parent:   parent,
+		stepName: os.Getenv(tracing.StepNameEnvVar),
+	}
+	return traced, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), flushSpansTimeout)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			log.Printf("non-fatal error exporting the spans of the step: %v", err)
+		}
+	}
+}
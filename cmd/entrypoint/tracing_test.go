@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type fakeRunner struct {
+	err     error
+	spanCtx trace.SpanContext
+}
+
+func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
+	f.spanCtx = trace.SpanContextFromContext(ctx)
+	return f.err
+}
+
+func TestTracingRunner(t *testing.T) {
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	runner := &fakeRunner{err: errors.New("exit status 1")}
+	tr := &tracingRunner{
+		runner:   runner,
+		tracer:   provider.Tracer(tracing.TracerName),
+		parent:   parent,
+		stepName: "build",
+	}
+
+	if err := tr.Run(context.Background(), "make"); err == nil {
+		t.Error("Expected the error of the step to be returned")
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span but got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "Step:build" {
+		t.Errorf("Expected the span to be named after the step but got %q", span.Name())
+	}
+	if span.Parent().SpanID() != parent.SpanID() || span.SpanContext().TraceID() != parent.TraceID() {
+		t.Errorf("Expected the span to be a child of the span of the TaskRun but got parent %v", span.Parent())
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("Expected the span of the failed step to have an error status but got %v", span.Status())
+	}
+	if runner.spanCtx.SpanID() != span.SpanContext().SpanID() {
+		t.Error("Expected the step to run in the context of its span")
+	}
+}
+
+func TestTraceRunner_NotTraced(t *testing.T) {
+	t.Setenv(tracing.TraceParentEnvVar, "")
+	t.Setenv(tracing.OTLPEndpointEnvVar, "http://otel-collector:4318")
+	runner := &fakeRunner{}
+	traced, flush := traceRunner(runner)
+	defer flush()
+	if traced != runner {
+		t.Error("Expected the step not to be traced without the span of the TaskRun")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+// defaultCgroupRoot is where the cgroup of the container is mounted.
+const defaultCgroupRoot = "/sys/fs/cgroup"
+
+// realUsageSampler samples the resources used by the container from its cgroup, with either
+// cgroup v2 or v1.
+type realUsageSampler struct {
+	root string
+}
+
+var _ entrypoint.UsageSampler = (*realUsageSampler)(nil)
+
+func (s *realUsageSampler) Sample() (entrypoint.ResourceUsage, error) {
+	if _, err := os.Stat(filepath.Join(s.root, "cgroup.controllers")); err == nil {
+		return s.sampleV2()
+	}
+	return s.sampleV1()
+}
+
+func (s *realUsageSampler) sampleV2() (entrypoint.ResourceUsage, error) {
+	var usage entrypoint.ResourceUsage
+	var err error
+	// memory.peak is only available since Linux 5.19, otherwise the current usage is sampled.
+	if usage.MemoryBytes, err = readInt(filepath.Join(s.root, "memory.peak")); err != nil {
+		if usage.MemoryBytes, err = readInt(filepath.Join(s.root, "memory.current")); err != nil {
+			return usage, err
+		}
+	}
+	cpu, err := readKeyedInts(filepath.Join(s.root, "cpu.stat"))
+	if err != nil {
+		return usage, err
+	}
+	usage.CPUTime = time.Duration(cpu["usage_usec"]) * time.Microsecond
+	// io.stat has one line per device: <major>:<minor> rbytes=<n> wbytes=<n> ...
+	// It is missing if the io controller isn't enabled.
+	err = readLines(filepath.Join(s.root, "io.stat"), func(fields []string) {
+		for _, f := range fields[1:] {
+			key, value, _ := strings.Cut(f, "=")
+			n, _ := strconv.ParseInt(value, 10, 64)
+			switch key {
+			case "rbytes":
+				usage.IOReadBytes += n
+			case "wbytes":
+				usage.IOWriteBytes += n
+			}
+		}
+	})
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return usage, err
+}
+
+func (s *realUsageSampler) sampleV1() (entrypoint.ResourceUsage, error) {
+	var usage entrypoint.ResourceUsage
+	var err error
+	if usage.MemoryBytes, err = readInt(filepath.Join(s.root, "memory", "memory.max_usage_in_bytes")); err != nil {
+		return usage, err
+	}
+	cpu, err := readInt(filepath.Join(s.root, "cpuacct", "cpuacct.usage"))
+	if err != nil {
+		return usage, err
+	}
+	usage.CPUTime = time.Duration(cpu)
+	// blkio.throttle.io_service_bytes has one line per device and operation: <major>:<minor> <operation> <n>
+	err = readLines(filepath.Join(s.root, "blkio", "blkio.throttle.io_service_bytes"), func(fields []string) {
+		if len(fields) != 3 {
+			return
+		}
+		n, _ := strconv.ParseInt(fields[2], 10, 64)
+		switch fields[1] {
+		case "Read":
+			usage.IOReadBytes += n
+		case "Write":
+			usage.IOWriteBytes += n
+		}
+	})
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return usage, err
+}
+
+func readInt(path string) (int64, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return n, nil
+}
+
+// readKeyedInts reads a file of "<key> <n>" lines.
+func readKeyedInts(path string) (map[string]int64, error) {
+	values := map[string]int64{}
+	err := readLines(path, func(fields []string) {
+		if len(fields) == 2 {
+			values[fields[0]], _ = strconv.ParseInt(fields[1], 10, 64)
+		}
+	})
+	return values, err
+}
+
+// readLines calls parse with the fields of each non-empty line of the file.
+func readLines(path string, parse func(fields []string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			parse(fields)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+func writeCgroupFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestRealUsageSampler(t *testing.T) {
+	for _, c := range []struct {
+		desc  string
+		files map[string]string
+		want  entrypoint.ResourceUsage
+	}{{
+		desc: "cgroup v2",
+		files: map[string]string{
+			"cgroup.controllers": "cpu io memory pids\n",
+			"memory.peak":        "536870912\n",
+			"memory.current":     "268435456\n",
+			"cpu.stat":           "usage_usec 1500000\nuser_usec 1000000\nsystem_usec 500000\n",
+			"io.stat":            "8:0 rbytes=1024 wbytes=2048 rios=1 wios=2\n8:16 rbytes=1024 wbytes=0 rios=1 wios=0\n",
+		},
+		want: entrypoint.ResourceUsage{MemoryBytes: 536870912, CPUTime: 1500 * time.Millisecond, IOReadBytes: 2048, IOWriteBytes: 2048},
+	}, {
+		desc: "cgroup v2 without memory.peak nor io.stat",
+		files: map[string]string{
+			"cgroup.controllers": "cpu memory pids\n",
+			"memory.current":     "268435456\n",
+			"cpu.stat":           "usage_usec 2000000\n",
+		},
+		want: entrypoint.ResourceUsage{MemoryBytes: 268435456, CPUTime: 2 * time.Second},
+	}, {
+		desc: "cgroup v1",
+		files: map[string]string{
+			"memory/memory.max_usage_in_bytes":      "536870912\n",
+			"cpuacct/cpuacct.usage":                 "1500000000\n",
+			"blkio/blkio.throttle.io_service_bytes": "8:0 Read 1024\n8:0 Write 2048\n8:0 Sync 3072\n8:0 Total 3072\nTotal 3072\n",
+		},
+		want: entrypoint.ResourceUsage{MemoryBytes: 536870912, CPUTime: 1500 * time.Millisecond, IOReadBytes: 1024, IOWriteBytes: 2048},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := (&realUsageSampler{root: writeCgroupFiles(t, c.files)}).Sample()
+			if err != nil {
+				t.Fatalf("Error sampling the resource usage: %v", err)
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("Unexpected resource usage (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestRealUsageSamplerWithoutCgroup(t *testing.T) {
+	if _, err := (&realUsageSampler{root: t.TempDir()}).Sample(); err == nil {
+		t.Error("Expected an error sampling the resource usage without a cgroup")
+	}
+}
@@ -3,12 +3,14 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
-// realWaiter actually waits for files, by polling.
+// realWaiter actually waits for files, by watching their directory where it is supported,
+// which is on Linux with inotify, and by polling otherwise.
 type realWaiter struct {
 	waitPollingInterval time.Duration
 	breakpointOnFailure bool
@@ -24,7 +26,8 @@ func (rw *realWaiter) setWaitPollingInterval(pollingInterval time.Duration) *rea
 
 // Wait watches a file and returns when either a) the file exists and, if
 // the expectContent argument is true, the file has non-zero size or b) there
-// is an error polling the file.
+// is an error polling the file. The file is checked whenever its directory
+// changes, and at least every polling interval.
 //
 // If the passed-in file is an empty string then this function returns
 // immediately.
@@ -35,7 +38,13 @@ func (rw *realWaiter) Wait(file string, expectContent bool, breakpointOnFailure
 	if file == "" {
 		return nil
 	}
-	for ; ; time.Sleep(rw.waitPollingInterval) {
+	// The directory is watched before the file is first checked, not to miss its creation.
+	watcher, err := newDirWatcher(filepath.Dir(file))
+	if err != nil {
+		watcher = pollingWatcher{}
+	}
+	defer watcher.Close()
+	for ; ; watcher.Wait(rw.waitPollingInterval) {
 		if info, err := os.Stat(file); err == nil {
 			if !expectContent || info.Size() > 0 {
 				return nil
@@ -58,6 +67,24 @@ func (rw *realWaiter) Wait(file string, expectContent bool, breakpointOnFailure
 	}
 }
 
+// dirWatcher waits for changes in a directory.
+type dirWatcher interface {
+	// Wait blocks until the directory changes or the timeout expires.
+	Wait(timeout time.Duration)
+	Close() error
+}
+
+// pollingWatcher doesn't watch any directory and always waits for the whole timeout.
+type pollingWatcher struct{}
+
+func (pollingWatcher) Wait(timeout time.Duration) {
+	time.Sleep(timeout)
+}
+
+func (pollingWatcher) Close() error {
+	return nil
+}
+
 type skipError string
 
 func (e skipError) Error() string {
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// watchedEvents are the inotify events of a directory after which the waited file is checked:
+// files created, written, renamed into it (e.g. the downward API volume updates), or changed.
+const watchedEvents = unix.IN_CREATE | unix.IN_CLOSE_WRITE | unix.IN_MODIFY | unix.IN_MOVED_TO | unix.IN_ATTRIB
+
+// inotifyWatcher watches a directory with inotify.
+type inotifyWatcher struct {
+	fd  int
+	buf []byte
+}
+
+func newDirWatcher(dir string) (dirWatcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := unix.InotifyAddWatch(fd, dir, watchedEvents); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	return &inotifyWatcher{fd: fd, buf: make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))}, nil
+}
+
+// Wait blocks until an event is received for the directory or the timeout expires. The received
+// events are discarded since the waited file is checked after any of them.
+func (w *inotifyWatcher) Wait(timeout time.Duration) {
+	fds := []unix.PollFd{{Fd: int32(w.fd), Events: unix.POLLIN}}
+	n, err := unix.Poll(fds, int(timeout.Milliseconds()))
+	if err != nil && err != unix.EINTR {
+		// Fall back to polling rather than checking the file in a busy loop.
+		time.Sleep(timeout)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	for {
+		if _, err := unix.Read(w.fd, w.buf); err != nil {
+			return
+		}
+	}
+}
+
+func (w *inotifyWatcher) Close() error {
+	return unix.Close(w.fd)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// The polling interval is long enough for the tests to only pass if the waiter is notified of
+// the changes of the waited files.
+const longWaitPollingInterval = time.Hour
+
+func TestRealWaiterWaitNotifiedOfFile(t *testing.T) {
+	for _, c := range []struct {
+		desc          string
+		expectContent bool
+		write         func(file string) error
+	}{{
+		desc: "file created",
+		write: func(file string) error {
+			return ioutil.WriteFile(file, nil, 0666)
+		},
+	}, {
+		desc:          "file written",
+		expectContent: true,
+		write: func(file string) error {
+			if err := ioutil.WriteFile(file, nil, 0666); err != nil {
+				return err
+			}
+			return ioutil.WriteFile(file, []byte("done"), 0666)
+		},
+	}, {
+		desc: "file renamed",
+		write: func(file string) error {
+			if err := ioutil.WriteFile(file+".tmp", nil, 0666); err != nil {
+				return err
+			}
+			return os.Rename(file+".tmp", file)
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "out")
+			doneCh := make(chan error)
+			go func() {
+				doneCh <- (&realWaiter{}).setWaitPollingInterval(longWaitPollingInterval).Wait(file, c.expectContent, false)
+			}()
+			// Give the waiter time to watch the directory, which it does before checking the file.
+			time.Sleep(50 * time.Millisecond)
+			if err := c.write(file); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case err := <-doneCh:
+				if err != nil {
+					t.Errorf("error waiting on file %q: %v", file, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Errorf("expected Wait() to have been notified of the file %q", file)
+			}
+		})
+	}
+}
+
+func TestRealWaiterWaitNotifiedOfErrFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "out")
+	doneCh := make(chan error)
+	go func() {
+		doneCh <- (&realWaiter{}).setWaitPollingInterval(longWaitPollingInterval).Wait(file, false, false)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := ioutil.WriteFile(file+".err", nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-doneCh:
+		if _, ok := err.(skipError); !ok {
+			t.Errorf("expected skipError upon encounter error file but got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("expected Wait() to have been notified of the error file %q.err", file)
+	}
+}
+
+func TestRealWaiterWaitFallsBackToPolling(t *testing.T) {
+	// The directory of the file can't be watched since it doesn't exist yet.
+	dir := filepath.Join(t.TempDir(), "run")
+	file := filepath.Join(dir, "out")
+	doneCh := make(chan error)
+	go func() {
+		doneCh <- (&realWaiter{}).setWaitPollingInterval(testWaitPollingInterval).Wait(file, false, false)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-doneCh:
+		if err != nil {
+			t.Errorf("error waiting on file %q: %v", file, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("expected Wait() to have polled the file %q", file)
+	}
+}
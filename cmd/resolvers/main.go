@@ -21,10 +21,12 @@ import (
 	"os"
 	"strings"
 
-	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/bundle"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/cluster"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/git"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/http"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/hub"
 	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	"knative.dev/pkg/injection/sharedmain"
@@ -32,7 +34,7 @@ import (
 )
 
 func main() {
-	ctx := filteredinformerfactory.WithSelectors(signals.NewContext(), v1alpha1.ManagedByLabelKey)
+	ctx := filteredinformerfactory.WithSelectors(signals.NewContext(), v1beta1.ManagedByLabelKey)
 
 	apiURL := os.Getenv("HUB_API")
 	hubURL := hub.DefaultHubURL
@@ -47,7 +49,9 @@ func main() {
 	fmt.Println("RUNNING WITH HUB URL PATTERN:", hubURL)
 
 	sharedmain.MainWithContext(ctx, "controller",
-		framework.NewController(ctx, &git.Resolver{}),
-		framework.NewController(ctx, &hub.Resolver{HubURL: hubURL}),
-		framework.NewController(ctx, &bundle.Resolver{}))
+		framework.NewController(ctx, framework.FromStringParamsResolver(&git.Resolver{CacheDir: os.Getenv("GIT_RESOLVER_CACHE_DIR")})),
+		framework.NewController(ctx, framework.FromStringParamsResolver(&hub.Resolver{HubURL: hubURL})),
+		framework.NewController(ctx, framework.FromStringParamsResolver(&bundle.Resolver{})),
+		framework.NewController(ctx, framework.FromStringParamsResolver(&http.Resolver{})),
+		framework.NewController(ctx, framework.FromStringParamsResolver(&cluster.Resolver{})))
 }
@@ -17,7 +17,8 @@ import (
 	"context"
 	"errors"
 
-	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
@@ -25,7 +26,7 @@ import (
 )
 
 func main() {
-	ctx := filteredinformerfactory.WithSelectors(context.Background(), v1alpha1.ManagedByLabelKey)
+	ctx := filteredinformerfactory.WithSelectors(context.Background(), v1beta1.ManagedByLabelKey)
 	sharedmain.MainWithContext(ctx, "controller",
 		framework.NewController(ctx, &resolver{}),
 	)
@@ -51,7 +52,7 @@ func (r *resolver) GetSelector(context.Context) map[string]string {
 }
 
 // ValidateParams ensures parameters from a request are as expected.
-func (r *resolver) ValidateParams(ctx context.Context, params map[string]string) error {
+func (r *resolver) ValidateParams(ctx context.Context, params []pipelinev1beta1.Param) error {
 	if len(params) > 0 {
 		return errors.New("no params allowed")
 	}
@@ -59,7 +60,7 @@ func (r *resolver) ValidateParams(ctx context.Context, params map[string]string)
 }
 
 // Resolve uses the given params to resolve the requested file or resource.
-func (r *resolver) Resolve(ctx context.Context, params map[string]string) (framework.ResolvedResource, error) {
+func (r *resolver) Resolve(ctx context.Context, params []pipelinev1beta1.Param) (framework.ResolvedResource, error) {
 	return &myResolvedResource{}, nil
 }
 
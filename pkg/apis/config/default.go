@@ -41,6 +41,17 @@ const (
 	DefaultCloudEventSinkValue = ""
 	// DefaultMaxMatrixCombinationsCount is used when no max matrix combinations count is specified.
 	DefaultMaxMatrixCombinationsCount = 256
+	// DefaultRunTTLAfterFinished is used when no TTL is specified for completed runs, it disables
+	// their deletion.
+	DefaultRunTTLAfterFinished = 0 * time.Second
+	// DefaultRunsHistoryLimit is used when no limit is specified for the number of completed runs
+	// to keep, it disables their deletion.
+	DefaultRunsHistoryLimit = -1
+	// DefaultInfraRetries is used when no number of infra retries is specified.
+	DefaultInfraRetries = 2
+	// DefaultDebugRetentionTTL is used when no debug retention TTL is specified, it disables the
+	// retention of failed runs for debugging.
+	DefaultDebugRetentionTTL = 0 * time.Second
 
 	defaultTimeoutMinutesKey             = "default-timeout-minutes"
 	defaultServiceAccountKey             = "default-service-account"
@@ -50,6 +61,11 @@ const (
 	defaultCloudEventsSinkKey            = "default-cloud-events-sink"
 	defaultTaskRunWorkspaceBinding       = "default-task-run-workspace-binding"
 	defaultMaxMatrixCombinationsCountKey = "default-max-matrix-combinations-count"
+	defaultRunTTLAfterFinishedKey        = "default-run-ttl-after-finished"
+	defaultSuccessfulRunsHistoryLimitKey = "default-successful-runs-history-limit"
+	defaultFailedRunsHistoryLimitKey     = "default-failed-runs-history-limit"
+	defaultInfraRetriesKey               = "default-infra-retries"
+	defaultDebugRetentionTTLKey          = "default-debug-retention-ttl"
 )
 
 // Defaults holds the default configurations
@@ -63,6 +79,11 @@ type Defaults struct {
 	DefaultCloudEventsSink            string
 	DefaultTaskRunWorkspaceBinding    string
 	DefaultMaxMatrixCombinationsCount int
+	DefaultRunTTLAfterFinished        time.Duration
+	DefaultSuccessfulRunsHistoryLimit int
+	DefaultFailedRunsHistoryLimit     int
+	DefaultInfraRetries               int
+	DefaultDebugRetentionTTL          time.Duration
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -91,7 +112,12 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultAAPodTemplate.Equals(cfg.DefaultAAPodTemplate) &&
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
 		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
-		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount
+		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
+		other.DefaultRunTTLAfterFinished == cfg.DefaultRunTTLAfterFinished &&
+		other.DefaultSuccessfulRunsHistoryLimit == cfg.DefaultSuccessfulRunsHistoryLimit &&
+		other.DefaultFailedRunsHistoryLimit == cfg.DefaultFailedRunsHistoryLimit &&
+		other.DefaultInfraRetries == cfg.DefaultInfraRetries &&
+		other.DefaultDebugRetentionTTL == cfg.DefaultDebugRetentionTTL
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -102,6 +128,11 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		DefaultManagedByLabelValue:        DefaultManagedByLabelValue,
 		DefaultCloudEventsSink:            DefaultCloudEventSinkValue,
 		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
+		DefaultRunTTLAfterFinished:        DefaultRunTTLAfterFinished,
+		DefaultSuccessfulRunsHistoryLimit: DefaultRunsHistoryLimit,
+		DefaultFailedRunsHistoryLimit:     DefaultRunsHistoryLimit,
+		DefaultInfraRetries:               DefaultInfraRetries,
+		DefaultDebugRetentionTTL:          DefaultDebugRetentionTTL,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -152,6 +183,46 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultMaxMatrixCombinationsCount = int(matrixCombinationsCount)
 	}
 
+	if defaultRunTTLAfterFinished, ok := cfgMap[defaultRunTTLAfterFinishedKey]; ok {
+		ttl, err := time.ParseDuration(defaultRunTTLAfterFinished)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultRunTTLAfterFinishedKey)
+		}
+		tc.DefaultRunTTLAfterFinished = ttl
+	}
+
+	if defaultSuccessfulRunsHistoryLimit, ok := cfgMap[defaultSuccessfulRunsHistoryLimitKey]; ok {
+		limit, err := strconv.ParseInt(defaultSuccessfulRunsHistoryLimit, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultSuccessfulRunsHistoryLimitKey)
+		}
+		tc.DefaultSuccessfulRunsHistoryLimit = int(limit)
+	}
+
+	if defaultFailedRunsHistoryLimit, ok := cfgMap[defaultFailedRunsHistoryLimitKey]; ok {
+		limit, err := strconv.ParseInt(defaultFailedRunsHistoryLimit, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultFailedRunsHistoryLimitKey)
+		}
+		tc.DefaultFailedRunsHistoryLimit = int(limit)
+	}
+
+	if defaultInfraRetries, ok := cfgMap[defaultInfraRetriesKey]; ok {
+		retries, err := strconv.ParseInt(defaultInfraRetries, 10, 0)
+		if err != nil || retries < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultInfraRetriesKey)
+		}
+		tc.DefaultInfraRetries = int(retries)
+	}
+
+	if defaultDebugRetentionTTL, ok := cfgMap[defaultDebugRetentionTTLKey]; ok {
+		ttl, err := time.ParseDuration(defaultDebugRetentionTTL)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultDebugRetentionTTLKey)
+		}
+		tc.DefaultDebugRetentionTTL = ttl
+	}
+
 	return &tc, nil
 }
 
@@ -18,6 +18,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -40,6 +41,9 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultServiceAccount:             "tekton",
 				DefaultManagedByLabelValue:        "something-else",
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultSuccessfulRunsHistoryLimit: -1,
+				DefaultFailedRunsHistoryLimit:     -1,
+				DefaultInfraRetries:               2,
 			},
 			fileName: config.GetDefaultsConfigName(),
 		},
@@ -59,6 +63,9 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 					},
 				},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultSuccessfulRunsHistoryLimit: -1,
+				DefaultFailedRunsHistoryLimit:     -1,
+				DefaultInfraRetries:               2,
 			},
 			fileName: "config-defaults-with-pod-template",
 		},
@@ -78,6 +85,9 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
 				DefaultPodTemplate:                &pod.Template{},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultSuccessfulRunsHistoryLimit: -1,
+				DefaultFailedRunsHistoryLimit:     -1,
+				DefaultInfraRetries:               2,
 			},
 		},
 		{
@@ -89,17 +99,76 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
 				DefaultAAPodTemplate:              &pod.AffinityAssistantTemplate{},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultSuccessfulRunsHistoryLimit: -1,
+				DefaultFailedRunsHistoryLimit:     -1,
+				DefaultInfraRetries:               2,
 			},
 		},
 		{
 			expectedError: true,
 			fileName:      "config-defaults-matrix-err",
 		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-runs-pruning",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultRunTTLAfterFinished:        24 * time.Hour,
+				DefaultSuccessfulRunsHistoryLimit: 5,
+				DefaultFailedRunsHistoryLimit:     10,
+				DefaultInfraRetries:               2,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-runs-pruning-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-infra-retries",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultSuccessfulRunsHistoryLimit: -1,
+				DefaultFailedRunsHistoryLimit:     -1,
+				DefaultInfraRetries:               5,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-infra-retries-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-debug-retention",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultSuccessfulRunsHistoryLimit: -1,
+				DefaultFailedRunsHistoryLimit:     -1,
+				DefaultInfraRetries:               2,
+				DefaultDebugRetentionTTL:          72 * time.Hour,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-debug-retention-err",
+		},
 		{
 			expectedError: false,
 			fileName:      "config-defaults-matrix",
 			expectedConfig: &config.Defaults{
 				DefaultMaxMatrixCombinationsCount: 1024,
+				DefaultSuccessfulRunsHistoryLimit: -1,
+				DefaultFailedRunsHistoryLimit:     -1,
+				DefaultInfraRetries:               2,
 				DefaultTimeoutMinutes:             60,
 				DefaultServiceAccount:             "default",
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
@@ -125,6 +194,9 @@ func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 		DefaultManagedByLabelValue:        "tekton-pipelines",
 		DefaultServiceAccount:             "default",
 		DefaultMaxMatrixCombinationsCount: 256,
+		DefaultSuccessfulRunsHistoryLimit: -1,
+		DefaultFailedRunsHistoryLimit:     -1,
+		DefaultInfraRetries:               2,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
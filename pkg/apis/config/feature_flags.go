@@ -40,6 +40,14 @@ const (
 	// MinimalEmbeddedStatus is the value used for "embedded-status" when only ChildReferences should be used in
 	// PipelineRunStatusFields.
 	MinimalEmbeddedStatus = "minimal"
+	// EnforceResourceVerificationMode is the value used for "resource-verification-mode" when verification is applied
+	// and the TaskRun or PipelineRun fails if a referenced Task or Pipeline fails verification.
+	EnforceResourceVerificationMode = "enforce"
+	// WarnResourceVerificationMode is the value used for "resource-verification-mode" when verification is applied
+	// but a failure only results in a warning condition on the TaskRun or PipelineRun.
+	WarnResourceVerificationMode = "warn"
+	// SkipResourceVerificationMode is the value used for "resource-verification-mode" when verification is skipped.
+	SkipResourceVerificationMode = "skip"
 	// DefaultDisableAffinityAssistant is the default value for "disable-affinity-assistant".
 	DefaultDisableAffinityAssistant = false
 	// DefaultDisableCredsInit is the default value for "disable-creds-init".
@@ -60,12 +68,20 @@ const (
 	DefaultSendCloudEventsForRuns = false
 	// DefaultEmbeddedStatus is the default value for "embedded-status".
 	DefaultEmbeddedStatus = FullEmbeddedStatus
+	// DefaultEnableStepResourceUsage is the default value for "enable-step-resource-usage".
+	DefaultEnableStepResourceUsage = false
 	// DefaultEnableGitResolver is the default value for "enable-git-resolver".
 	DefaultEnableGitResolver = false
 	// DefaultEnableHubResolver is the default value for "enable-hub-resolver".
 	DefaultEnableHubResolver = false
 	// DefaultEnableBundlesResolver is the default value for "enable-bundles-resolver".
 	DefaultEnableBundlesResolver = false
+	// DefaultEnableHTTPResolver is the default value for "enable-http-resolver".
+	DefaultEnableHTTPResolver = false
+	// DefaultEnableClusterResolver is the default value for "enable-cluster-resolver".
+	DefaultEnableClusterResolver = false
+	// DefaultResourceVerificationMode is the default value for "resource-verification-mode".
+	DefaultResourceVerificationMode = SkipResourceVerificationMode
 
 	disableAffinityAssistantKey         = "disable-affinity-assistant"
 	disableCredsInitKey                 = "disable-creds-init"
@@ -77,6 +93,8 @@ const (
 	enableAPIFields                     = "enable-api-fields"
 	sendCloudEventsForRuns              = "send-cloudevents-for-runs"
 	embeddedStatus                      = "embedded-status"
+	enableStepResourceUsage             = "enable-step-resource-usage"
+	resourceVerificationMode            = "resource-verification-mode"
 
 	// EnableGitResolver is the flag used to enable the git remote resolver
 	EnableGitResolver = "enable-git-resolver"
@@ -84,6 +102,10 @@ const (
 	EnableHubResolver = "enable-hub-resolver"
 	// EnableBundlesResolver is the flag used to enable the bundle remote resolver
 	EnableBundlesResolver = "enable-bundles-resolver"
+	// EnableHTTPResolver is the flag used to enable the http remote resolver
+	EnableHTTPResolver = "enable-http-resolver"
+	// EnableClusterResolver is the flag used to enable the cluster remote resolver
+	EnableClusterResolver = "enable-cluster-resolver"
 )
 
 // FeatureFlags holds the features configurations
@@ -100,9 +122,13 @@ type FeatureFlags struct {
 	SendCloudEventsForRuns           bool
 	AwaitSidecarReadiness            bool
 	EmbeddedStatus                   string
+	EnableStepResourceUsage          bool
 	EnableGitResolver                bool
 	EnableHubResolver                bool
 	EnableBundleResolver             bool
+	EnableHTTPResolver               bool
+	EnableClusterResolver            bool
+	ResourceVerificationMode         string
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -154,6 +180,12 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setEmbeddedStatus(cfgMap, DefaultEmbeddedStatus, &tc.EmbeddedStatus); err != nil {
 		return nil, err
 	}
+	if err := setFeature(enableStepResourceUsage, DefaultEnableStepResourceUsage, &tc.EnableStepResourceUsage); err != nil {
+		return nil, err
+	}
+	if err := setResourceVerificationMode(cfgMap, DefaultResourceVerificationMode, &tc.ResourceVerificationMode); err != nil {
+		return nil, err
+	}
 	if err := setFeature(EnableGitResolver, DefaultEnableGitResolver, &tc.EnableGitResolver); err != nil {
 		return nil, err
 	}
@@ -163,6 +195,12 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(EnableBundlesResolver, DefaultEnableBundlesResolver, &tc.EnableBundleResolver); err != nil {
 		return nil, err
 	}
+	if err := setFeature(EnableHTTPResolver, DefaultEnableHTTPResolver, &tc.EnableHTTPResolver); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableClusterResolver, DefaultEnableClusterResolver, &tc.EnableClusterResolver); err != nil {
+		return nil, err
+	}
 
 	// Given that they are alpha features, Tekton Bundles and Custom Tasks should be switched on if
 	// enable-api-fields is "alpha". If enable-api-fields is not "alpha" then fall back to the value of
@@ -217,6 +255,22 @@ func setEmbeddedStatus(cfgMap map[string]string, defaultValue string, feature *s
 	return nil
 }
 
+// setResourceVerificationMode sets the "resource-verification-mode" flag based on the content of a given map.
+// If the feature gate is invalid then an error is returned.
+func setResourceVerificationMode(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[resourceVerificationMode]; ok {
+		value = strings.ToLower(cfg)
+	}
+	switch value {
+	case EnforceResourceVerificationMode, WarnResourceVerificationMode, SkipResourceVerificationMode:
+		*feature = value
+	default:
+		return fmt.Errorf("invalid value for feature flag %q: %q", resourceVerificationMode, value)
+	}
+	return nil
+}
+
 // NewFeatureFlagsFromConfigMap returns a Config for the given configmap
 func NewFeatureFlagsFromConfigMap(config *corev1.ConfigMap) (*FeatureFlags, error) {
 	return NewFeatureFlagsFromMap(config.Data)
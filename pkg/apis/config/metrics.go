@@ -89,6 +89,7 @@ type Metrics struct {
 	PipelinerunLevel        string
 	DurationTaskrunType     string
 	DurationPipelinerunType string
+	Tracing                 Tracing
 }
 
 // GetMetricsConfigName returns the name of the configmap containing all
@@ -110,7 +111,8 @@ func (cfg *Metrics) Equals(other *Metrics) bool {
 	return other.TaskrunLevel == cfg.TaskrunLevel &&
 		other.PipelinerunLevel == cfg.PipelinerunLevel &&
 		other.DurationTaskrunType == cfg.DurationTaskrunType &&
-		other.DurationPipelinerunType == cfg.DurationPipelinerunType
+		other.DurationPipelinerunType == cfg.DurationPipelinerunType &&
+		other.Tracing == cfg.Tracing
 }
 
 // newMetricsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -135,6 +137,11 @@ func newMetricsFromMap(cfgMap map[string]string) (*Metrics, error) {
 	if durationPipelinerun, ok := cfgMap[metricsDurationPipelinerunType]; ok {
 		tc.DurationPipelinerunType = durationPipelinerun
 	}
+	tracing, err := newTracingFromMap(cfgMap)
+	if err != nil {
+		return nil, err
+	}
+	tc.Tracing = tracing
 	return &tc, nil
 }
 
@@ -27,11 +27,12 @@ type cfgKey struct{}
 // Config holds the collection of configurations that we attach to contexts.
 // +k8s:deepcopy-gen=false
 type Config struct {
-	Defaults       *Defaults
-	FeatureFlags   *FeatureFlags
-	ArtifactBucket *ArtifactBucket
-	ArtifactPVC    *ArtifactPVC
-	Metrics        *Metrics
+	Defaults         *Defaults
+	FeatureFlags     *FeatureFlags
+	ArtifactBucket   *ArtifactBucket
+	ArtifactPVC      *ArtifactPVC
+	Metrics          *Metrics
+	TrustedResources *TrustedResources
 }
 
 // FromContext extracts a Config from the provided context.
@@ -54,12 +55,14 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	artifactBucket, _ := NewArtifactBucketFromMap(map[string]string{})
 	artifactPVC, _ := NewArtifactPVCFromMap(map[string]string{})
 	metrics, _ := newMetricsFromMap(map[string]string{})
+	trustedResources, _ := NewTrustedResourcesFromMap(map[string]string{})
 	return &Config{
-		Defaults:       defaults,
-		FeatureFlags:   featureFlags,
-		ArtifactBucket: artifactBucket,
-		ArtifactPVC:    artifactPVC,
-		Metrics:        metrics,
+		Defaults:         defaults,
+		FeatureFlags:     featureFlags,
+		ArtifactBucket:   artifactBucket,
+		ArtifactPVC:      artifactPVC,
+		Metrics:          metrics,
+		TrustedResources: trustedResources,
 	}
 }
 
@@ -82,11 +85,12 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 			"defaults/features/artifacts",
 			logger,
 			configmap.Constructors{
-				GetDefaultsConfigName():       NewDefaultsFromConfigMap,
-				GetFeatureFlagsConfigName():   NewFeatureFlagsFromConfigMap,
-				GetArtifactBucketConfigName(): NewArtifactBucketFromConfigMap,
-				GetArtifactPVCConfigName():    NewArtifactPVCFromConfigMap,
-				GetMetricsConfigName():        NewMetricsFromConfigMap,
+				GetDefaultsConfigName():         NewDefaultsFromConfigMap,
+				GetFeatureFlagsConfigName():     NewFeatureFlagsFromConfigMap,
+				GetArtifactBucketConfigName():   NewArtifactBucketFromConfigMap,
+				GetArtifactPVCConfigName():      NewArtifactPVCFromConfigMap,
+				GetMetricsConfigName():          NewMetricsFromConfigMap,
+				GetTrustedResourcesConfigName(): NewTrustedResourcesFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -123,11 +127,16 @@ func (s *Store) Load() *Config {
 	if metrics == nil {
 		metrics, _ = newMetricsFromMap(map[string]string{})
 	}
+	trustedResources := s.UntypedLoad(GetTrustedResourcesConfigName())
+	if trustedResources == nil {
+		trustedResources, _ = NewTrustedResourcesFromMap(map[string]string{})
+	}
 	return &Config{
-		Defaults:       defaults.(*Defaults).DeepCopy(),
-		FeatureFlags:   featureFlags.(*FeatureFlags).DeepCopy(),
-		ArtifactBucket: artifactBucket.(*ArtifactBucket).DeepCopy(),
-		ArtifactPVC:    artifactPVC.(*ArtifactPVC).DeepCopy(),
-		Metrics:        metrics.(*Metrics).DeepCopy(),
+		Defaults:         defaults.(*Defaults).DeepCopy(),
+		FeatureFlags:     featureFlags.(*FeatureFlags).DeepCopy(),
+		ArtifactBucket:   artifactBucket.(*ArtifactBucket).DeepCopy(),
+		ArtifactPVC:      artifactPVC.(*ArtifactPVC).DeepCopy(),
+		Metrics:          metrics.(*Metrics).DeepCopy(),
+		TrustedResources: trustedResources.(*TrustedResources).DeepCopy(),
 	}
 }
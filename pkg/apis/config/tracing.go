@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const (
+	// tracingBackendKey determines where the traces are exported
+	tracingBackendKey = "tracing.backend"
+	// tracingOTLPEndpointKey is the base URL of the OTLP/HTTP collector the traces are exported to
+	// with the otlp backend
+	tracingOTLPEndpointKey = "tracing.otlp-endpoint"
+	// tracingFilePathKey is the path of the file the traces are written to with the file backend
+	tracingFilePathKey = "tracing.file-path"
+
+	// DefaultTracingBackend determines where the traces are exported when it isn't specified
+	// in configmap
+	DefaultTracingBackend = TracingBackendNone
+	// TracingBackendNone specify that tracing is disabled
+	TracingBackendNone = "none"
+	// TracingBackendOTLP specify that the traces are exported to an OTLP/HTTP collector
+	TracingBackendOTLP = "otlp"
+	// TracingBackendFile specify that the traces are written to a file, one span per line
+	TracingBackendFile = "file"
+)
+
+// Tracing holds the configurations for the tracing
+// +k8s:deepcopy-gen=true
+type Tracing struct {
+	Backend      string
+	OTLPEndpoint string
+	FilePath     string
+}
+
+// Enabled returns true if the traces are exported.
+func (cfg Tracing) Enabled() bool {
+	return cfg.Backend == TracingBackendOTLP || cfg.Backend == TracingBackendFile
+}
+
+// newTracingFromMap returns a Tracing given a map corresponding to a ConfigMap
+func newTracingFromMap(cfgMap map[string]string) (Tracing, error) {
+	tc := Tracing{
+		Backend: DefaultTracingBackend,
+	}
+
+	if backend, ok := cfgMap[tracingBackendKey]; ok {
+		tc.Backend = backend
+	}
+	if endpoint, ok := cfgMap[tracingOTLPEndpointKey]; ok {
+		tc.OTLPEndpoint = endpoint
+	}
+	if path, ok := cfgMap[tracingFilePathKey]; ok {
+		tc.FilePath = path
+	}
+
+	switch tc.Backend {
+	case TracingBackendNone:
+	case TracingBackendOTLP:
+		if tc.OTLPEndpoint == "" {
+			return tc, fmt.Errorf("%s must be set when %s is %q", tracingOTLPEndpointKey, tracingBackendKey, TracingBackendOTLP)
+		}
+		if u, err := url.Parse(tc.OTLPEndpoint); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return tc, fmt.Errorf("%s must be an http or https URL but was %q", tracingOTLPEndpointKey, tc.OTLPEndpoint)
+		}
+	case TracingBackendFile:
+		if tc.FilePath == "" {
+			return tc, fmt.Errorf("%s must be set when %s is %q", tracingFilePathKey, tracingBackendKey, TracingBackendFile)
+		}
+	default:
+		return tc, fmt.Errorf("invalid value for %s: %q", tracingBackendKey, tc.Backend)
+	}
+	return tc, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewTracingFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		fileName string
+		want     config.Tracing
+	}{{
+		fileName: "config-observability-tracing-otlp",
+		want: config.Tracing{
+			Backend:      config.TracingBackendOTLP,
+			OTLPEndpoint: "http://otel-collector.observability:4318",
+		},
+	}, {
+		fileName: "config-observability-tracing-file",
+		want: config.Tracing{
+			Backend:  config.TracingBackendFile,
+			FilePath: "/var/log/tekton/traces.jsonl",
+		},
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			metrics, err := config.NewMetricsFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewMetricsFromConfigMap(actual) = %v", err)
+			}
+			if d := cmp.Diff(tc.want, metrics.Tracing); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+			if !metrics.Tracing.Enabled() {
+				t.Error("Expected tracing to be enabled")
+			}
+		})
+	}
+}
+
+func TestNewTracingFromInvalidConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data map[string]string
+	}{{
+		name: "unknown backend",
+		data: map[string]string{"tracing.backend": "jaeger"},
+	}, {
+		name: "otlp backend without endpoint",
+		data: map[string]string{"tracing.backend": "otlp"},
+	}, {
+		name: "otlp backend with an endpoint which isn't an URL",
+		data: map[string]string{"tracing.backend": "otlp", "tracing.otlp-endpoint": "otel-collector:4318"},
+	}, {
+		name: "file backend without path",
+		data: map[string]string{"tracing.backend": "file"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, "config-observability-empty")
+			cm.Data = tc.data
+			if _, err := config.NewMetricsFromConfigMap(cm); err == nil {
+				t.Error("Expected NewMetricsFromConfigMap to fail")
+			}
+		})
+	}
+}
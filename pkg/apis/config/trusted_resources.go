@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PublicKeysKey is the name of the configmap entry that specifies the PEM encoded public keys
+	// trusted to sign all Tasks and Pipelines
+	PublicKeysKey = "public-keys"
+
+	// NamespacePublicKeysPrefix prefixes the configmap entries that specify the PEM encoded public
+	// keys trusted to sign the Tasks and Pipelines referenced by runs in a namespace, e.g. "namespace.my-namespace"
+	NamespacePublicKeysPrefix = "namespace."
+
+	// ResolverPublicKeysPrefix prefixes the configmap entries that specify the PEM encoded public
+	// keys trusted to sign the Tasks and Pipelines fetched by a remote resolver, e.g. "resolver.git"
+	ResolverPublicKeysPrefix = "resolver."
+
+	// RequireBundleDigestKey is the name of the configmap entry that specifies whether the Tekton Bundles referenced
+	// with the bundle field of taskRef and pipelineRef must be pinned by digest
+	RequireBundleDigestKey = "require-bundle-digest"
+
+	// BundleImagePublicKeysKey is the name of the configmap entry that specifies the PEM encoded public keys trusted
+	// to sign the images of the Tekton Bundles referenced with the bundle field of taskRef and pipelineRef
+	BundleImagePublicKeysKey = "bundle-image-public-keys"
+
+	publicKeyPEMType = "PUBLIC KEY"
+)
+
+// TrustedResources holds the public keys used to verify the signatures of Tasks and Pipelines
+// +k8s:deepcopy-gen=true
+type TrustedResources struct {
+	// PublicKeys are trusted to sign all Tasks and Pipelines.
+	PublicKeys string
+	// NamespacePublicKeys maps namespaces to the keys trusted to sign the Tasks and Pipelines
+	// referenced by runs in that namespace.
+	NamespacePublicKeys map[string]string
+	// ResolverPublicKeys maps remote resolvers to the keys trusted to sign the Tasks and
+	// Pipelines they fetch.
+	ResolverPublicKeys map[string]string
+	// RequireBundleDigest requires Tekton Bundles to be referenced by digest.
+	RequireBundleDigest bool
+	// BundleImagePublicKeys are trusted to sign the images of Tekton Bundles.
+	BundleImagePublicKeys string
+}
+
+// GetTrustedResourcesConfigName returns the name of the configmap containing the public keys
+// used to verify Tasks and Pipelines.
+func GetTrustedResourcesConfigName() string {
+	if e := os.Getenv("CONFIG_TRUSTED_RESOURCES_NAME"); e != "" {
+		return e
+	}
+	return "config-trusted-resources"
+}
+
+// Equals returns true if two Configs are identical
+func (cfg *TrustedResources) Equals(other *TrustedResources) bool {
+	if cfg == nil && other == nil {
+		return true
+	}
+
+	if cfg == nil || other == nil {
+		return false
+	}
+
+	return reflect.DeepEqual(cfg, other)
+}
+
+// PublicKeysFor returns the public keys trusted to sign a Task or Pipeline referenced by a run in
+// the given namespace and fetched by the given remote resolver. resolver is empty for Tasks and
+// Pipelines fetched from the cluster.
+func (cfg *TrustedResources) PublicKeysFor(namespace, resolver string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for _, data := range []string{cfg.PublicKeys, cfg.NamespacePublicKeys[namespace], cfg.ResolverPublicKeys[resolver]} {
+		k, err := ParsePublicKeys(data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k...)
+	}
+	return keys, nil
+}
+
+// NewTrustedResourcesFromMap returns a Config given a map corresponding to a ConfigMap
+func NewTrustedResourcesFromMap(cfgMap map[string]string) (*TrustedResources, error) {
+	tc := TrustedResources{}
+
+	for key, value := range cfgMap {
+		switch {
+		case key == PublicKeysKey:
+			tc.PublicKeys = value
+		case strings.HasPrefix(key, NamespacePublicKeysPrefix):
+			if tc.NamespacePublicKeys == nil {
+				tc.NamespacePublicKeys = map[string]string{}
+			}
+			tc.NamespacePublicKeys[strings.TrimPrefix(key, NamespacePublicKeysPrefix)] = value
+		case strings.HasPrefix(key, ResolverPublicKeysPrefix):
+			if tc.ResolverPublicKeys == nil {
+				tc.ResolverPublicKeys = map[string]string{}
+			}
+			tc.ResolverPublicKeys[strings.TrimPrefix(key, ResolverPublicKeysPrefix)] = value
+		case key == BundleImagePublicKeysKey:
+			tc.BundleImagePublicKeys = value
+		case key == RequireBundleDigestKey:
+			requireDigest, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing trusted resources config %q: %w", key, err)
+			}
+			tc.RequireBundleDigest = requireDigest
+			continue
+		default:
+			continue
+		}
+		if _, err := ParsePublicKeys(value); err != nil {
+			return nil, fmt.Errorf("failed parsing trusted resources config %q: %w", key, err)
+		}
+	}
+
+	return &tc, nil
+}
+
+// NewTrustedResourcesFromConfigMap returns a Config for the given configmap
+func NewTrustedResourcesFromConfigMap(config *corev1.ConfigMap) (*TrustedResources, error) {
+	return NewTrustedResourcesFromMap(config.Data)
+}
+
+// ParsePublicKeys parses the PEM encoded PKIX public keys in data.
+func ParsePublicKeys(data string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	rest := []byte(strings.TrimSpace(data))
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no PEM encoded public key found")
+		}
+		if block.Type != publicKeyPEMType {
+			return nil, fmt.Errorf("unexpected PEM block type %q, must be %q", block.Type, publicKeyPEMType)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		rest = []byte(strings.TrimSpace(string(rest)))
+	}
+	return keys, nil
+}
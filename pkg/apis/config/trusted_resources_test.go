@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+const (
+	testPublicKey1 = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEJwuwZMdG+vnpzhLEe4MYFJAhLTLM
+b6cbbSpT6GfzVwnt90yg4ofL8EwZOGJ0NJy1w/MkzA4VJMbnGF0IG0J0HA==
+-----END PUBLIC KEY-----
+`
+	testPublicKey2 = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEfipqx6qsr60ZrhbX9UEuS1tSsayT
+MgBe9dE0gjt2Xt5gxPZKS8gedamhhFiPP5/r+Yqq65X0YGV/f/GapfF+CA==
+-----END PUBLIC KEY-----
+`
+)
+
+func TestNewTrustedResourcesFromConfigMap(t *testing.T) {
+	type testCase struct {
+		expectedConfig *config.TrustedResources
+		fileName       string
+	}
+
+	testCases := []testCase{
+		{
+			expectedConfig: &config.TrustedResources{
+				PublicKeys:            testPublicKey1,
+				NamespacePublicKeys:   map[string]string{"foo": testPublicKey2},
+				ResolverPublicKeys:    map[string]string{"git": testPublicKey1 + testPublicKey2},
+				RequireBundleDigest:   true,
+				BundleImagePublicKeys: testPublicKey2,
+			},
+			fileName: config.GetTrustedResourcesConfigName(),
+		},
+		{
+			expectedConfig: &config.TrustedResources{},
+			fileName:       "config-trusted-resources-empty",
+		},
+	}
+
+	for _, tc := range testCases {
+		verifyConfigFileWithExpectedTrustedResourcesConfig(t, tc.fileName, tc.expectedConfig)
+	}
+}
+
+func TestNewTrustedResourcesFromConfigMapError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-trusted-resources-invalid-key",
+		"config-trusted-resources-invalid-require-bundle-digest",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, fileName)
+			if _, err := config.NewTrustedResourcesFromConfigMap(cm); err == nil {
+				t.Error("expected error but received nil")
+			}
+		})
+	}
+}
+
+func TestTrustedResourcesPublicKeysFor(t *testing.T) {
+	cm := test.ConfigMapFromTestFile(t, config.GetTrustedResourcesConfigName())
+	cfg, err := config.NewTrustedResourcesFromConfigMap(cm)
+	if err != nil {
+		t.Fatalf("NewTrustedResourcesFromConfigMap() = %v", err)
+	}
+
+	for _, tc := range []struct {
+		description string
+		namespace   string
+		resolver    string
+		want        int
+	}{{
+		description: "cluster resource in namespace without keys",
+		namespace:   "bar",
+		want:        1,
+	}, {
+		description: "cluster resource in namespace with keys",
+		namespace:   "foo",
+		want:        2,
+	}, {
+		description: "remote resource from resolver with keys",
+		namespace:   "foo",
+		resolver:    "git",
+		want:        4,
+	}, {
+		description: "remote resource from resolver without keys",
+		namespace:   "bar",
+		resolver:    "hub",
+		want:        1,
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			keys, err := cfg.PublicKeysFor(tc.namespace, tc.resolver)
+			if err != nil {
+				t.Fatalf("PublicKeysFor() = %v", err)
+			}
+			if len(keys) != tc.want {
+				t.Errorf("PublicKeysFor() returned %d keys, want %d", len(keys), tc.want)
+			}
+		})
+	}
+}
+
+func TestGetTrustedResourcesConfigName(t *testing.T) {
+	for _, tc := range []struct {
+		description              string
+		trustedResourcesEnvValue string
+		expected                 string
+	}{{
+		description:              "Trusted resources config value not set",
+		trustedResourcesEnvValue: "",
+		expected:                 "config-trusted-resources",
+	}, {
+		description:              "Trusted resources config value set",
+		trustedResourcesEnvValue: "config-trusted-resources-test",
+		expected:                 "config-trusted-resources-test",
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.trustedResourcesEnvValue != "" {
+				t.Setenv("CONFIG_TRUSTED_RESOURCES_NAME", tc.trustedResourcesEnvValue)
+			}
+			got := config.GetTrustedResourcesConfigName()
+			want := tc.expected
+			if got != want {
+				t.Errorf("GetTrustedResourcesConfigName() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func verifyConfigFileWithExpectedTrustedResourcesConfig(t *testing.T, fileName string, expectedConfig *config.TrustedResources) {
+	t.Helper()
+	cm := test.ConfigMapFromTestFile(t, fileName)
+	if tr, err := config.NewTrustedResourcesFromConfigMap(cm); err == nil {
+		if d := cmp.Diff(expectedConfig, tr); d != "" {
+			t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+		}
+	} else {
+		t.Errorf("NewTrustedResourcesFromConfigMap(actual) = %v", err)
+	}
+}
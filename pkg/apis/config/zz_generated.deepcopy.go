@@ -102,6 +102,7 @@ func (in *FeatureFlags) DeepCopy() *FeatureFlags {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metrics) DeepCopyInto(out *Metrics) {
 	*out = *in
+	out.Tracing = in.Tracing
 	return
 }
 
@@ -114,3 +115,49 @@ func (in *Metrics) DeepCopy() *Metrics {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedResources) DeepCopyInto(out *TrustedResources) {
+	*out = *in
+	if in.NamespacePublicKeys != nil {
+		in, out := &in.NamespacePublicKeys, &out.NamespacePublicKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResolverPublicKeys != nil {
+		in, out := &in.ResolverPublicKeys, &out.ResolverPublicKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedResources.
+func (in *TrustedResources) DeepCopy() *TrustedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedResources)
+	in.DeepCopyInto(out)
+	return out
+}
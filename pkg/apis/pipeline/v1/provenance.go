@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
+// For now, it only contains the subfield `ConfigSource` that identifies the source where a build config file came from.
+// And in the future, a subfield will be added to record the input parameters of the build.
+type Provenance struct {
+	// ConfigSource identifies the source where a resource came from.
+	ConfigSource *ConfigSource `json:"configSource,omitempty"`
+}
+
+// ConfigSource identifies the source where a resource came from.
+// This can include Git repositories, Task Bundles, file checksums, or other information that allows users to identify where the resource came from.
+// The fields follow the ConfigSource of the SLSA provenance format.
+type ConfigSource struct {
+	// URI indicates the identity of the source of the config.
+	// Examples:
+	// - Git repository: "git+https://github.com/tektoncd/catalog.git"
+	// - Tekton Bundle: "gcr.io/tekton-releases/catalog/upstream/git-clone"
+	// +optional
+	URI string `json:"uri,omitempty"`
+
+	// Digest is a collection of cryptographic digests for the contents of the artifact specified by URI.
+	// Example: {"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"}
+	// +optional
+	Digest map[string]string `json:"digest,omitempty"`
+
+	// EntryPoint identifies the entry point into the build. This is often a path to a
+	// build definition file and/or a target label within that file.
+	// Example: "task/git-clone/0.8/git-clone.yaml"
+	// +optional
+	EntryPoint string `json:"entryPoint,omitempty"`
+
+	// Resolver is the name of the resolver that fetched the resource, such as "git".
+	// Resources fetched with the bundle field of taskRef and pipelineRef are recorded as
+	// fetched by the "bundles" resolver.
+	// +optional
+	Resolver ResolverName `json:"resolver,omitempty"`
+}
@@ -51,6 +51,16 @@ func TestTaskRef_Valid(t *testing.T) {
 			Value: *v1.NewStructuredValues("baz"),
 		}}}},
 		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "alpha feature: valid resolver with array and object params",
+		taskRef: &v1.TaskRef{ResolverRef: v1.ResolverRef{Resolver: "git", Params: []v1.Param{{
+			Name:  "paths",
+			Value: *v1.NewStructuredValues("task/a.yaml", "task/b.yaml"),
+		}, {
+			Name:  "signer",
+			Value: *v1.NewObject(map[string]string{"key": "k8s://tekton/signing-secrets"}),
+		}}}},
+		wc: config.EnableAlphaAPIFields,
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -124,7 +134,7 @@ func TestTaskRef_Invalid(t *testing.T) {
 		wantErr: apis.ErrMultipleOneOf("name", "params").Also(apis.ErrMissingField("resolver")),
 		wc:      config.EnableAlphaAPIFields,
 	}, {
-		name: "taskref param array not allowed",
+		name: "taskref param array requires alpha",
 		taskRef: &v1.TaskRef{
 			ResolverRef: v1.ResolverRef{
 				Resolver: "some-resolver",
@@ -137,8 +147,7 @@ func TestTaskRef_Invalid(t *testing.T) {
 				}},
 			},
 		},
-		wantErr: apis.ErrGeneric("remote resolution parameter type must be string, not array").
-			Also(apis.ErrGeneric("resolver requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"")).
+		wantErr: apis.ErrGeneric("resolver requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").
 			Also(apis.ErrGeneric("params requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"")),
 	}, {
 		name: "taskref param object requires alpha",
@@ -155,7 +164,6 @@ func TestTaskRef_Invalid(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("object type parameter requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").
-			Also(apis.ErrGeneric("remote resolution parameter type must be string, not object")).
 			Also(apis.ErrGeneric("resolver requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"")).
 			Also(apis.ErrGeneric("params requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"")),
 	}}
@@ -229,6 +229,11 @@ type TaskRunStatusFields struct {
 
 	// TaskSpec contains the Spec from the dereferenced Task definition used to instantiate this TaskRun.
 	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
+
+	// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
+	// Its ConfigSource records where the Task used by this TaskRun was resolved from.
+	// +optional
+	Provenance *Provenance `json:"provenance,omitempty"`
 }
 
 // TaskRunStepOverride is used to override the values of a Step in the corresponding Task.
@@ -53,6 +53,29 @@ func (in *ChildStatusReference) DeepCopy() *ChildStatusReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSource) DeepCopyInto(out *ConfigSource) {
+	*out = *in
+	if in.Digest != nil {
+		in, out := &in.Digest, &out.Digest
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSource.
+func (in *ConfigSource) DeepCopy() *ConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedTask) DeepCopyInto(out *EmbeddedTask) {
 	*out = *in
@@ -462,6 +485,11 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 		in, out := &in.FinallyStartTime, &out.FinallyStartTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(Provenance)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -775,6 +803,27 @@ func (in *PropertySpec) DeepCopy() *PropertySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Provenance) DeepCopyInto(out *Provenance) {
+	*out = *in
+	if in.ConfigSource != nil {
+		in, out := &in.ConfigSource, &out.ConfigSource
+		*out = new(ConfigSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provenance.
+func (in *Provenance) DeepCopy() *Provenance {
+	if in == nil {
+		return nil
+	}
+	out := new(Provenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolverRef) DeepCopyInto(out *ResolverRef) {
 	*out = *in
@@ -1486,6 +1535,11 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 		*out = new(TaskSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(Provenance)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -175,6 +175,40 @@ type Step struct {
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// TerminationGracePeriod is the time the step is given to exit after it is sent SIGTERM
+	// when it times out or its TaskRun is cancelled, before it is sent SIGKILL.
+	// Defaults to the terminationGracePeriod of the TaskRun, or to killing the step immediately.
+	// +optional
+	TerminationGracePeriod *metav1.Duration `json:"terminationGracePeriod,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Retries is the number of times the command of the step is re-executed when it exits
+	// with a non-zero exit code. The results of the step are cleared between attempts.
+	// +optional
+	Retries int `json:"retries,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// RetryDelay is the time to wait before re-executing the command of the step. Defaults to
+	// re-executing it immediately.
+	// +optional
+	RetryDelay *metav1.Duration `json:"retryDelay,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Results is a list of the results this step writes, which the next steps can reference
+	// through $(steps.<stepName>.results.<resultName>).
+	// +optional
+	// +listType=atomic
+	Results []StepResult `json:"results,omitempty"`
+
 	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
 	// for this field to be supported.
 	//
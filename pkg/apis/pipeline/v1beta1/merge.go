@@ -59,7 +59,17 @@ func MergeStepsWithStepTemplate(template *StepTemplate, steps []Step) ([]Step, e
 		}
 
 		// Pass through original step Script, for later conversion.
-		newStep := Step{Script: s.Script, OnError: s.OnError, Timeout: s.Timeout, StdoutConfig: s.StdoutConfig, StderrConfig: s.StderrConfig}
+		newStep := Step{
+			Script:                 s.Script,
+			OnError:                s.OnError,
+			Timeout:                s.Timeout,
+			StdoutConfig:           s.StdoutConfig,
+			StderrConfig:           s.StderrConfig,
+			TerminationGracePeriod: s.TerminationGracePeriod,
+			Retries:                s.Retries,
+			RetryDelay:             s.RetryDelay,
+			Results:                s.Results,
+		}
 		newStep.SetContainerFields(merged)
 		steps[i] = newStep
 	}
@@ -55,7 +55,6 @@ func (ref *PipelineRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 				errs = errs.Also(apis.ErrMissingField("resolver"))
 			}
 			errs = errs.Also(ValidateParameters(ctx, ref.Params))
-			errs = errs.Also(validateResolutionParamTypes(ref.Params).ViaField("params"))
 		}
 	} else {
 		if ref.Name == "" {
@@ -80,14 +79,3 @@ func validateBundleFeatureFlag(ctx context.Context, featureName string, wantValu
 	}
 	return nil
 }
-
-func validateResolutionParamTypes(params []Param) (errs *apis.FieldError) {
-	for i, p := range params {
-		if p.Value.Type == ParamTypeArray || p.Value.Type == ParamTypeObject {
-			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("remote resolution parameter type must be %s, not %s",
-				string(ParamTypeString), string(p.Value.Type))).ViaIndex(i))
-		}
-	}
-
-	return errs
-}
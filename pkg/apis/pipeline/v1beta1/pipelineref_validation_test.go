@@ -140,7 +140,7 @@ func TestPipelineRef_Invalid(t *testing.T) {
 		wantErr:     apis.ErrMultipleOneOf("bundle", "params").Also(apis.ErrMissingField("resolver")),
 		withContext: config.EnableAlphaAPIFields,
 	}, {
-		name: "pipelineref param array not allowed",
+		name: "pipelineref param array requires alpha",
 		ref: &v1beta1.PipelineRef{
 			ResolverRef: v1beta1.ResolverRef{
 				Resolver: "some-resolver",
@@ -153,8 +153,7 @@ func TestPipelineRef_Invalid(t *testing.T) {
 				}},
 			},
 		},
-		wantErr: apis.ErrGeneric("remote resolution parameter type must be string, not array").
-			Also(apis.ErrGeneric("resolver requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"")).
+		wantErr: apis.ErrGeneric("resolver requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").
 			Also(apis.ErrGeneric("params requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"")),
 	}, {
 		name: "pipelineref param object not allowed",
@@ -171,7 +170,6 @@ func TestPipelineRef_Invalid(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("object type parameter requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").
-			Also(apis.ErrGeneric("remote resolution parameter type must be string, not object")).
 			Also(apis.ErrGeneric("resolver requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"")).
 			Also(apis.ErrGeneric("params requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"")),
 	}}
@@ -218,6 +216,16 @@ func TestPipelineRef_Valid(t *testing.T) {
 			},
 		}}}},
 		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "alpha feature: valid resolver with array and object params",
+		ref: &v1beta1.PipelineRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git", Params: []v1beta1.Param{{
+			Name:  "paths",
+			Value: *v1beta1.NewStructuredValues("task/a.yaml", "task/b.yaml"),
+		}, {
+			Name:  "signer",
+			Value: *v1beta1.NewObject(map[string]string{"key": "k8s://tekton/signing-secrets"}),
+		}}}},
+		wc: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ts := range tests {
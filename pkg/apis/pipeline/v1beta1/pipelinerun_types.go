@@ -262,8 +262,48 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// TaskControls holds a set of actions to apply to individual PipelineTasks
+	// while the PipelineRun is running
+	// +optional
+	// +listType=atomic
+	TaskControls []PipelineTaskControl `json:"taskControls,omitempty"`
+	// Targets holds the names of the PipelineTasks to run. When set, only these
+	// PipelineTasks and the PipelineTasks they depend on are run
+	// +optional
+	// +listType=atomic
+	Targets []string `json:"targets,omitempty"`
+	// Skip holds the names of the PipelineTasks not to run
+	// +optional
+	// +listType=atomic
+	Skip []string `json:"skip,omitempty"`
+	// PriorityClassName is the name of the PriorityClass of the PipelineRun. It is
+	// used as the PriorityClass of the pods of its TaskRuns which don't set one in
+	// their pod template, and to order the work of the controllers.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// PipelineTaskControl requests an action on a single PipelineTask of a running PipelineRun
+type PipelineTaskControl struct {
+	// Name is the name of the PipelineTask the action applies to
+	Name string `json:"name"`
+	// Action is the action to apply to the PipelineTask
+	Action PipelineTaskControlAction `json:"action"`
 }
 
+// PipelineTaskControlAction defines the action the user can apply to a PipelineTask
+type PipelineTaskControlAction string
+
+const (
+	// PipelineTaskControlActionCancel indicates that the user wants to cancel the TaskRuns and Runs
+	// of the PipelineTask, which is then considered failed
+	PipelineTaskControlActionCancel PipelineTaskControlAction = "cancel"
+
+	// PipelineTaskControlActionSkip indicates that the user wants to cancel the TaskRuns and Runs
+	// of the PipelineTask, if any, and consider the PipelineTask skipped
+	PipelineTaskControlActionSkip PipelineTaskControlAction = "skip"
+)
+
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
 type TimeoutFields struct {
 	// Pipeline sets the maximum allowed duration for execution of the entire pipeline. The sum of individual timeouts for tasks and finally must not exceed this value.
@@ -456,6 +496,11 @@ type PipelineRunStatusFields struct {
 	// FinallyStartTime is when all non-finally tasks have been completed and only finally tasks are being executed.
 	// +optional
 	FinallyStartTime *metav1.Time `json:"finallyStartTime,omitempty"`
+
+	// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
+	// Its ConfigSource records where the Pipeline used by this PipelineRun was resolved from.
+	// +optional
+	Provenance *Provenance `json:"provenance,omitempty"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -494,6 +539,10 @@ const (
 	TasksTimedOutSkip SkippingReason = "PipelineRun Tasks timeout has been reached"
 	// FinallyTimedOutSkip means the task was skipped because the PipelineRun has passed its Timeouts.Finally.
 	FinallyTimedOutSkip SkippingReason = "PipelineRun Finally timeout has been reached"
+	// TaskControlSkip means the task was skipped because the user requested it through the PipelineRun's taskControls
+	TaskControlSkip SkippingReason = "PipelineTask was skipped on request"
+	// PrunedSkip means the task was skipped because it is not needed by the PipelineRun's targets or is listed in its skip list
+	PrunedSkip SkippingReason = "PipelineTask was pruned from the PipelineRun"
 	// None means the task was not skipped
 	None SkippingReason = "None"
 )
@@ -590,5 +639,26 @@ func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSp
 			s.ComputeResources = task.ComputeResources
 		}
 	}
+	if pr.Spec.PriorityClassName != "" && (s.TaskPodTemplate == nil || s.TaskPodTemplate.PriorityClassName == nil) {
+		tpl := &pod.PodTemplate{}
+		if s.TaskPodTemplate != nil {
+			tpl = s.TaskPodTemplate.DeepCopy()
+		}
+		priorityClassName := pr.Spec.PriorityClassName
+		tpl.PriorityClassName = &priorityClassName
+		s.TaskPodTemplate = tpl
+	}
 	return s
 }
+
+// GetPriorityClassName returns the name of the PriorityClass of the PipelineRun: its
+// priorityClassName, or else the one of its pod template.
+func (pr *PipelineRun) GetPriorityClassName() string {
+	if pr.Spec.PriorityClassName != "" {
+		return pr.Spec.PriorityClassName
+	}
+	if pr.Spec.PodTemplate != nil && pr.Spec.PodTemplate.PriorityClassName != nil {
+		return *pr.Spec.PodTemplate.PriorityClassName
+	}
+	return ""
+}
@@ -503,3 +503,37 @@ func TestPipelineRunGetPodSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelineRunGetTaskRunSpecPriorityClassName(t *testing.T) {
+	releaseClass := "release"
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:       &v1beta1.PipelineRef{Name: "prname"},
+			PriorityClassName: "nightly",
+			PodTemplate:       &pod.Template{SchedulerName: "scheduleTest"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "release",
+				TaskPodTemplate:  &pod.Template{PriorityClassName: &releaseClass},
+			}},
+		},
+	}
+	for taskName, want := range map[string]string{
+		"build":   "nightly",
+		"release": "release",
+	} {
+		s := pr.GetTaskRunSpec(taskName)
+		if s.TaskPodTemplate == nil || s.TaskPodTemplate.PriorityClassName == nil || *s.TaskPodTemplate.PriorityClassName != want {
+			t.Errorf("Expected the pod template of %s to have the PriorityClass %q, got %v", taskName, want, s.TaskPodTemplate)
+		}
+	}
+	if s := pr.GetTaskRunSpec("build"); s.TaskPodTemplate.SchedulerName != "scheduleTest" {
+		t.Errorf("Expected the pod template of the PipelineRun to be kept, got %v", s.TaskPodTemplate)
+	}
+	if pr.Spec.PodTemplate.PriorityClassName != nil {
+		t.Errorf("Expected the pod template of the PipelineRun not to be modified, got %v", pr.Spec.PodTemplate)
+	}
+	if got := pr.GetPriorityClassName(); got != "nightly" {
+		t.Errorf("GetPriorityClassName() = %q, want %q", got, "nightly")
+	}
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -118,6 +120,26 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		errs = errs.Also(validateTaskRunSpec(ctx, trs).ViaIndex(idx).ViaField("taskRunSpecs"))
 	}
 
+	if ps.TaskControls != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "taskControls", config.AlphaAPIFields).ViaField("taskControls"))
+		errs = errs.Also(validateTaskControls(ps.TaskControls).ViaField("taskControls"))
+	}
+
+	if ps.Targets != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "targets", config.AlphaAPIFields).ViaField("targets"))
+	}
+	if ps.Skip != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "skip", config.AlphaAPIFields).ViaField("skip"))
+	}
+	errs = errs.Also(validateTargetsAndSkip(ps.Targets, ps.Skip))
+
+	if ps.PriorityClassName != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "priorityClassName", config.AlphaAPIFields).ViaField("priorityClassName"))
+		for _, msg := range validation.IsDNS1123Subdomain(ps.PriorityClassName) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s: %s", ps.PriorityClassName, msg), "priorityClassName"))
+		}
+	}
+
 	return errs
 }
 
@@ -238,6 +260,53 @@ func validateSpecStatus(status PipelineRunSpecStatus) *apis.FieldError {
 
 }
 
+func validateTaskControls(taskControls []PipelineTaskControl) (errs *apis.FieldError) {
+	names := make(map[string]int)
+	for idx, tc := range taskControls {
+		if tc.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(idx))
+		} else if prevIdx, alreadyExists := names[tc.Name]; alreadyExists {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("taskControl for %q provided more than once, at index %d and %d", tc.Name, prevIdx, idx), "name").ViaIndex(idx))
+		}
+		names[tc.Name] = idx
+		switch tc.Action {
+		case PipelineTaskControlActionCancel, PipelineTaskControlActionSkip:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", tc.Action,
+				PipelineTaskControlActionCancel, PipelineTaskControlActionSkip), "action").ViaIndex(idx))
+		}
+	}
+	return errs
+}
+
+// validateTargetsAndSkip ensures the PipelineTask names listed in targets and skip are
+// neither empty nor duplicated, and that no PipelineTask is both a target and skipped.
+func validateTargetsAndSkip(targets, skip []string) (errs *apis.FieldError) {
+	targetNames := sets.NewString()
+	for idx, name := range targets {
+		switch {
+		case name == "":
+			errs = errs.Also(apis.ErrInvalidValue("PipelineTask name must not be empty", "").ViaFieldIndex("targets", idx))
+		case targetNames.Has(name):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("PipelineTask %q is listed more than once", name), "").ViaFieldIndex("targets", idx))
+		}
+		targetNames.Insert(name)
+	}
+	skipNames := sets.NewString()
+	for idx, name := range skip {
+		switch {
+		case name == "":
+			errs = errs.Also(apis.ErrInvalidValue("PipelineTask name must not be empty", "").ViaFieldIndex("skip", idx))
+		case skipNames.Has(name):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("PipelineTask %q is listed more than once", name), "").ViaFieldIndex("skip", idx))
+		case targetNames.Has(name):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("PipelineTask %q can not be both a target and skipped", name), "").ViaFieldIndex("skip", idx))
+		}
+		skipNames.Insert(name)
+	}
+	return errs
+}
+
 func validateTimeoutDuration(field string, d *metav1.Duration) (errs *apis.FieldError) {
 	if d != nil && d.Duration < 0 {
 		fieldPath := fmt.Sprintf("timeouts.%s", field)
@@ -728,6 +728,101 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaIndex(0).ViaField("taskRunSpecs"),
+	}, {
+		name: "taskControls disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			TaskControls: []v1beta1.PipelineTaskControl{{
+				Name:   "bar",
+				Action: v1beta1.PipelineTaskControlActionSkip,
+			}},
+		},
+		wantErr: apis.ErrGeneric("taskControls requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaField("taskControls"),
+	}, {
+		name: "taskControls with invalid action",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			TaskControls: []v1beta1.PipelineTaskControl{{
+				Name:   "bar",
+				Action: "force",
+			}},
+		},
+		wantErr:     apis.ErrInvalidValue("force should be cancel or skip", "taskControls[0].action"),
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "taskControls with missing name",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			TaskControls: []v1beta1.PipelineTaskControl{{
+				Action: v1beta1.PipelineTaskControlActionCancel,
+			}},
+		},
+		wantErr:     apis.ErrMissingField("taskControls[0].name"),
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "taskControls may only appear once per PipelineTask",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			TaskControls: []v1beta1.PipelineTaskControl{{
+				Name:   "bar",
+				Action: v1beta1.PipelineTaskControlActionCancel,
+			}, {
+				Name:   "bar",
+				Action: v1beta1.PipelineTaskControlActionSkip,
+			}},
+		},
+		wantErr: &apis.FieldError{
+			Message: `taskControl for "bar" provided more than once, at index 0 and 1`,
+			Paths:   []string{"taskControls[1].name"},
+		},
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "targets disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			Targets:     []string{"bar"},
+		},
+		wantErr: apis.ErrGeneric("targets requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaField("targets"),
+	}, {
+		name: "skip disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			Skip:        []string{"bar"},
+		},
+		wantErr: apis.ErrGeneric("skip requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaField("skip"),
+	}, {
+		name: "targets with empty and duplicated names",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			Targets:     []string{"bar", "", "bar"},
+		},
+		wantErr: apis.ErrInvalidValue("PipelineTask name must not be empty", "targets[1]").Also(
+			apis.ErrGeneric(`PipelineTask "bar" is listed more than once`, "targets[2]")),
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "PipelineTask both a target and skipped",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			Targets:     []string{"bar"},
+			Skip:        []string{"baz", "bar"},
+		},
+		wantErr:     apis.ErrGeneric(`PipelineTask "bar" can not be both a target and skipped`, "skip[1]"),
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "priorityClassName disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef:       &v1beta1.PipelineRef{Name: "foo"},
+			PriorityClassName: "release",
+		},
+		wantErr: apis.ErrGeneric("priorityClassName requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaField("priorityClassName"),
+	}, {
+		name: "invalid priorityClassName",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef:       &v1beta1.PipelineRef{Name: "foo"},
+			PriorityClassName: "Release_Pipelines",
+		},
+		wantErr:     apis.ErrInvalidValue("Release_Pipelines: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')", "priorityClassName"),
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
@@ -799,6 +894,34 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid taskControls",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			TaskControls: []v1beta1.PipelineTaskControl{{
+				Name:   "e2e",
+				Action: v1beta1.PipelineTaskControlActionSkip,
+			}, {
+				Name:   "lint",
+				Action: v1beta1.PipelineTaskControlActionCancel,
+			}},
+		},
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid targets and skip",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Targets:     []string{"deploy"},
+			Skip:        []string{"build", "unit-tests"},
+		},
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid priorityClassName",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef:       &v1beta1.PipelineRef{Name: "pipeline"},
+			PriorityClassName: "release-pipelines",
+		},
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
@@ -32,8 +32,39 @@ type TaskResult struct {
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Value promotes a result of a step to this result. It must be a reference to a
+	// result of the same type of one of the steps: $(steps.<stepName>.results.<resultName>).
+	// +optional
+	Value *ResultValue `json:"value,omitempty"`
+}
+
+// StepResult used to describe the results of a step, which later steps of the same task
+// can reference.
+type StepResult struct {
+	// Name the given name
+	Name string `json:"name"`
+
+	// Type is the user-specified type of the result: "string", "array" or "object".
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
+	// Properties is the JSON Schema properties to support key-value pairs results.
+	// +optional
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
+
+	// Description is a human-readable description of the result
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
+// StepResultPathVariableFormat is the format of the variable replaced with the path of the file
+// where the current step writes one of its results.
+const StepResultPathVariableFormat = "step.results.%s.path"
+
 // TaskRunResult used to describe the results of a task
 type TaskRunResult struct {
 	// Name the given name
@@ -16,9 +16,12 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
+	"github.com/tektoncd/pipeline/pkg/internal/stepresults"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -27,9 +30,12 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if !resultNameFormatRegex.MatchString(tr.Name) {
 		return apis.ErrInvalidKeyName(tr.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat))
 	}
+	if tr.Value != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "task result value", config.AlphaAPIFields).ViaField("value"))
+	}
 	// Array and Object is alpha feature
 	if tr.Type == ResultsTypeArray || tr.Type == ResultsTypeObject {
-		errs := validateObjectResult(tr)
+		errs = errs.Also(validateObjectResult(tr))
 		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "results type", config.AlphaAPIFields))
 		return errs
 	}
@@ -37,17 +43,126 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Resources created before the result. Type was introduced may not have Type set
 	// and should be considered valid
 	if tr.Type == "" {
-		return nil
+		return errs
 	}
 
 	// By default the result type is string
 	if tr.Type != ResultsTypeString {
-		return apis.ErrInvalidValue(tr.Type, "type", fmt.Sprintf("type must be string"))
+		return errs.Also(apis.ErrInvalidValue(tr.Type, "type", fmt.Sprintf("type must be string")))
+	}
+
+	return errs
+}
+
+// validateStepResults validates the results of a step, which must have unique names without dots.
+func validateStepResults(ctx context.Context, results []StepResult) (errs *apis.FieldError) {
+	names := sets.NewString()
+	for i, r := range results {
+		errs = errs.Also(TaskResult{Name: r.Name, Type: r.Type, Properties: r.Properties}.Validate(ctx).ViaIndex(i))
+		if strings.Contains(r.Name, ".") {
+			errs = errs.Also(apis.ErrInvalidValue(r.Name, "name", "step result names can't contain dots").ViaIndex(i))
+		}
+		if names.Has(r.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result %q is declared more than once", r.Name), "name").ViaIndex(i))
+		}
+		names.Insert(r.Name)
+	}
+	return errs
+}
+
+// validateStepResultRefs validates that the steps only reference the declared results of the
+// previous steps, outside of their scripts, and that the task results promoted from step
+// results reference declared step results of the same type.
+func validateStepResultRefs(steps []Step, results []TaskResult) (errs *apis.FieldError) {
+	declared := map[string]map[string]StepResult{}
+	for i, s := range steps {
+		validateRefs := func(path, value string, isArg bool) {
+			for _, ref := range stepresults.ParseRefs(value) {
+				if path == "script" {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s can't be referenced in a script, read the file /tekton/steps/step-%s/results/%s instead", ref.Expression, ref.Step, ref.Result), path).ViaIndex(i))
+					continue
+				}
+				result, ok := declared[ref.Step][ref.Result]
+				if !ok {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s must reference a result declared by a previous step", ref.Expression), path).ViaIndex(i))
+					continue
+				}
+				errs = errs.Also(validateStepResultRefType(ref, result, path, isArg && value == ref.Expression).ViaIndex(i))
+			}
+		}
+		validateRefs("script", s.Script, false)
+		for j, c := range s.Command {
+			validateRefs(fmt.Sprintf("command[%d]", j), c, true)
+		}
+		for j, a := range s.Args {
+			validateRefs(fmt.Sprintf("args[%d]", j), a, true)
+		}
+		for j, e := range s.Env {
+			validateRefs(fmt.Sprintf("env[%d].value", j), e.Value, false)
+		}
+		if s.Name != "" {
+			declared[s.Name] = map[string]StepResult{}
+			for _, r := range s.Results {
+				declared[s.Name][r.Name] = r
+			}
+		}
 	}
+	errs = errs.ViaField("steps")
 
+	for i, r := range results {
+		if r.Value == nil {
+			continue
+		}
+		refs := stepresults.ParseRefs(r.Value.StringVal)
+		if r.Value.Type != ParamTypeString || len(refs) != 1 || refs[0].Expression != r.Value.StringVal || refs[0].Index != "" || refs[0].Key != "" {
+			errs = errs.Also(apis.ErrInvalidValue(r.Value.StringVal, "value", "the value of a task result must be a reference to a step result: $(steps.<stepName>.results.<resultName>)").ViaFieldIndex("results", i))
+			continue
+		}
+		result, ok := declared[refs[0].Step][refs[0].Result]
+		if !ok {
+			errs = errs.Also(apis.ErrInvalidValue(r.Value.StringVal, "value", "the value of a task result must reference a result declared by a step").ViaFieldIndex("results", i))
+			continue
+		}
+		if resultsTypeOrDefault(result.Type) != resultsTypeOrDefault(r.Type) {
+			errs = errs.Also(apis.ErrInvalidValue(r.Value.StringVal, "value", fmt.Sprintf("the %s task result can't be promoted from the %s step result", resultsTypeOrDefault(r.Type), resultsTypeOrDefault(result.Type))).ViaFieldIndex("results", i))
+		}
+	}
+	return errs
+}
+
+// validateStepResultRefType validates that a reference to a step result matches its type. Whole
+// array results can only be referenced as an isolated argument, which they expand to.
+func validateStepResultRefType(ref stepresults.Ref, result StepResult, path string, isolatedArg bool) *apis.FieldError {
+	switch resultsTypeOrDefault(result.Type) {
+	case ResultsTypeArray:
+		if ref.Key != "" || ref.Index == "" {
+			return apis.ErrGeneric(fmt.Sprintf("%s must reference the array result with [*] or [<index>]", ref.Expression), path)
+		}
+		if ref.Index == "*" && !isolatedArg {
+			return apis.ErrGeneric(fmt.Sprintf("%s can only be used as an isolated element of command or args", ref.Expression), path)
+		}
+	case ResultsTypeObject:
+		if ref.Key == "" {
+			return apis.ErrGeneric(fmt.Sprintf("%s must reference a key of the object result", ref.Expression), path)
+		}
+		if _, ok := result.Properties[ref.Key]; !ok {
+			return apis.ErrGeneric(fmt.Sprintf("%s references a key not declared in the properties of the object result", ref.Expression), path)
+		}
+	default:
+		if ref.Key != "" || ref.Index != "" {
+			return apis.ErrGeneric(fmt.Sprintf("%s can't index the string result", ref.Expression), path)
+		}
+	}
 	return nil
 }
 
+func resultsTypeOrDefault(t ResultsType) ResultsType {
+	if t == "" {
+		return ResultsTypeString
+	}
+	return t
+}
+
 // validateObjectResult validates the object result and check if the Properties is missing
 // for Properties values it will check if the type is string.
 func validateObjectResult(tr TaskResult) (errs *apis.FieldError) {
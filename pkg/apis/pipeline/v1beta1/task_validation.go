@@ -93,6 +93,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ValidateResourcesVariables(ctx, ts.Steps, ts.Resources))
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
+	errs = errs.Also(validateStepResultRefs(mergedSteps, ts.Results))
 	return errs
 }
 
@@ -233,6 +234,35 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 		}
 	}
 
+	if s.TerminationGracePeriod != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step terminationGracePeriod", config.AlphaAPIFields).ViaField("terminationGracePeriod"))
+		if s.TerminationGracePeriod.Duration < time.Duration(0) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", s.TerminationGracePeriod.Duration.String()), "terminationGracePeriod"))
+		}
+	}
+
+	if s.Retries != 0 {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step retries", config.AlphaAPIFields).ViaField("retries"))
+		if s.Retries < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", s.Retries), "retries"))
+		}
+	}
+
+	if s.RetryDelay != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step retryDelay", config.AlphaAPIFields).ViaField("retryDelay"))
+		if s.RetryDelay.Duration < time.Duration(0) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", s.RetryDelay.Duration.String()), "retryDelay"))
+		}
+	}
+
+	if len(s.Results) > 0 {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step results", config.AlphaAPIFields).ViaField("results"))
+		if s.Name == "" {
+			errs = errs.Also(apis.ErrGeneric("only named steps can declare results", "name"))
+		}
+		errs = errs.Also(validateStepResults(ctx, s.Results).ViaField("results"))
+	}
+
 	for j, vm := range s.VolumeMounts {
 		if strings.HasPrefix(vm.MountPath, "/tekton/") &&
 			!strings.HasPrefix(vm.MountPath, "/tekton/home") {
@@ -488,6 +488,39 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.namespace)"`,
 			}},
 		},
+	}, {
+		name: "step results referenced by later steps and promoted to task results",
+		fields: fields{
+			StepTemplate: &v1beta1.StepTemplate{Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}}},
+			Steps: []v1beta1.Step{{
+				Name:  "build",
+				Image: "my-image",
+				Args:  []string{"--digest-file", "$(step.results.digest.path)"},
+				Results: []v1beta1.StepResult{{
+					Name: "digest",
+				}, {
+					Name: "tags",
+					Type: v1beta1.ResultsTypeArray,
+				}, {
+					Name:       "image",
+					Type:       v1beta1.ResultsTypeObject,
+					Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}},
+				}},
+			}, {
+				Name:  "push",
+				Image: "my-image",
+				Args:  []string{"--digest=$(steps.build.results.digest)", "$(steps.build.results.tags[*])", "$(steps.build.results.image.url)"},
+				Env:   []corev1.EnvVar{{Name: "TAG", Value: "$(steps.build.results.tags[0])"}},
+			}},
+			Results: []v1beta1.TaskResult{{
+				Name:  "digest",
+				Value: v1beta1.NewStructuredValues("$(steps.build.results.digest)"),
+			}, {
+				Name:  "tags",
+				Type:  v1beta1.ResultsTypeArray,
+				Value: v1beta1.NewStructuredValues("$(steps.build.results.tags)"),
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1381,6 +1414,202 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: "invalid value: -10s",
 			Paths:   []string{"steps[0].negative timeout"},
 		},
+	}, {
+		name: "negative terminationGracePeriod",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Image:                  "my-image",
+				TerminationGracePeriod: &metav1.Duration{Duration: -10 * time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -10s should be >= 0",
+			Paths:   []string{"steps[0].terminationGracePeriod"},
+		},
+	}, {
+		name: "negative retries",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Image:   "my-image",
+				Retries: -1,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1 should be >= 0",
+			Paths:   []string{"steps[0].retries"},
+		},
+	}, {
+		name: "negative retryDelay",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Image:      "my-image",
+				Retries:    2,
+				RetryDelay: &metav1.Duration{Duration: -time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1s should be >= 0",
+			Paths:   []string{"steps[0].retryDelay"},
+		},
+	}, {
+		name: "retries ignored by the step template",
+		fields: fields{
+			StepTemplate: &v1beta1.StepTemplate{Image: "my-image"},
+			Steps: []v1beta1.Step{{
+				Retries: -1,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1 should be >= 0",
+			Paths:   []string{"steps[0].retries"},
+		},
+	}, {
+		name: "results of an unnamed step",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Image:   "my-image",
+				Results: []v1beta1.StepResult{{Name: "digest"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "only named steps can declare results",
+			Paths:   []string{"steps[0].name"},
+		},
+	}, {
+		name: "duplicate step results",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "my-image",
+				Results: []v1beta1.StepResult{{Name: "digest"}, {Name: "digest"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `result "digest" is declared more than once`,
+			Paths:   []string{"steps[0].results[1].name"},
+		},
+	}, {
+		name: "step result name with a dot",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "my-image",
+				Results: []v1beta1.StepResult{{Name: "image.digest"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: image.digest",
+			Paths:   []string{"steps[0].results[0].name"},
+			Details: "step result names can't contain dots",
+		},
+	}, {
+		name: "reference to a result of a later step",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Name:  "push",
+				Image: "my-image",
+				Args:  []string{"$(steps.build.results.digest)"},
+			}, {
+				Name:    "build",
+				Image:   "my-image",
+				Results: []v1beta1.StepResult{{Name: "digest"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "$(steps.build.results.digest) must reference a result declared by a previous step",
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "step result referenced in a script",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "my-image",
+				Results: []v1beta1.StepResult{{Name: "digest"}},
+			}, {
+				Name:   "push",
+				Image:  "my-image",
+				Script: "echo $(steps.build.results.digest)",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "$(steps.build.results.digest) can't be referenced in a script, read the file /tekton/steps/step-build/results/digest instead",
+			Paths:   []string{"steps[1].script"},
+		},
+	}, {
+		name: "whole array step result in env",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "my-image",
+				Results: []v1beta1.StepResult{{Name: "tags", Type: v1beta1.ResultsTypeArray}},
+			}, {
+				Name:  "push",
+				Image: "my-image",
+				Env:   []corev1.EnvVar{{Name: "TAGS", Value: "$(steps.build.results.tags[*])"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "$(steps.build.results.tags[*]) can only be used as an isolated element of command or args",
+			Paths:   []string{"steps[1].env[0].value"},
+		},
+	}, {
+		name: "undeclared key of an object step result",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Name:  "build",
+				Image: "my-image",
+				Results: []v1beta1.StepResult{{
+					Name:       "image",
+					Type:       v1beta1.ResultsTypeObject,
+					Properties: map[string]v1beta1.PropertySpec{"digest": {Type: v1beta1.ParamTypeString}},
+				}},
+			}, {
+				Name:  "push",
+				Image: "my-image",
+				Args:  []string{"$(steps.build.results.image.url)"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "$(steps.build.results.image.url) references a key not declared in the properties of the object result",
+			Paths:   []string{"steps[1].args[0]"},
+		},
+	}, {
+		name: "task result promoted from a step result of another type",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "my-image",
+				Results: []v1beta1.StepResult{{Name: "tags", Type: v1beta1.ResultsTypeArray}},
+			}},
+			Results: []v1beta1.TaskResult{{
+				Name:  "tags",
+				Value: v1beta1.NewStructuredValues("$(steps.build.results.tags)"),
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: $(steps.build.results.tags)",
+			Paths:   []string{"results[0].value"},
+			Details: "the string task result can't be promoted from the array step result",
+		},
+	}, {
+		name: "task result value not a step result reference",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "my-image",
+				Results: []v1beta1.StepResult{{Name: "digest"}},
+			}},
+			Results: []v1beta1.TaskResult{{
+				Name:  "digest",
+				Value: v1beta1.NewStructuredValues("sha256:$(steps.build.results.digest)"),
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: sha256:$(steps.build.results.digest)",
+			Paths:   []string{"results[0].value"},
+			Details: "the value of a task result must be a reference to a step result: $(steps.<stepName>.results.<resultName>)",
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1663,6 +1892,49 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		name:            "step terminationGracePeriod requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:                  "foo",
+				TerminationGracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+			}},
+		},
+	}, {
+		name:            "step results require alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "foo",
+				Results: []v1beta1.StepResult{{Name: "digest"}},
+			}},
+		},
+	}, {
+		name:            "task result value requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "foo",
+				Results: []v1beta1.StepResult{{Name: "digest"}},
+			}},
+			Results: []v1beta1.TaskResult{{
+				Name:  "digest",
+				Value: v1beta1.NewStructuredValues("$(steps.build.results.digest)"),
+			}},
+		},
+	}, {
+		name:            "step retries requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:      "foo",
+				Retries:    3,
+				RetryDelay: &metav1.Duration{Duration: 10 * time.Second},
+			}},
+		},
 	}}
 	versions := []string{"alpha", "stable"}
 	for _, tt := range tests {
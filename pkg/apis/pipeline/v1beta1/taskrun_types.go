@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -61,6 +62,12 @@ type TaskRunSpec struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// TerminationGracePeriod is the time the steps of the TaskRun are given to exit after they
+	// are sent SIGTERM when they time out or the TaskRun is cancelled, before they are sent SIGKILL.
+	// It can be overridden by the terminationGracePeriod of each step.
+	// This field is only supported when the alpha feature gate is enabled.
+	// +optional
+	TerminationGracePeriod *metav1.Duration `json:"terminationGracePeriod,omitempty"`
 	// PodTemplate holds pod specific configuration
 	PodTemplate *pod.PodTemplate `json:"podTemplate,omitempty"`
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
@@ -110,6 +117,26 @@ type TaskRunDebug struct {
 	// +optional
 	// +listType=atomic
 	Breakpoint []string `json:"breakpoint,omitempty"`
+	// BeforeSteps are the names of the steps before which the TaskRun pauses until
+	// the debug-continue or debug-fail script is run in the step container.
+	// +optional
+	// +listType=atomic
+	BeforeSteps []string `json:"beforeSteps,omitempty"`
+	// AfterSteps are the names of the steps after which the TaskRun pauses until
+	// the debug-continue or debug-fail script is run in the step container.
+	// +optional
+	// +listType=atomic
+	AfterSteps []string `json:"afterSteps,omitempty"`
+}
+
+// HasBreakpoints returns true if the TaskRun can pause at breakpoints.
+func (trd *TaskRunDebug) HasBreakpoints() bool {
+	return trd != nil && (len(trd.Breakpoint) > 0 || len(trd.BeforeSteps) > 0 || len(trd.AfterSteps) > 0)
+}
+
+// HasStepBreakpoints returns true if the TaskRun pauses before or after any of its steps.
+func (trd *TaskRunDebug) HasStepBreakpoints() bool {
+	return trd != nil && (len(trd.BeforeSteps) > 0 || len(trd.AfterSteps) > 0)
 }
 
 // TaskRunInputs holds the input values that this task was invoked with.
@@ -162,6 +189,9 @@ const (
 	TaskRunReasonResolvingTaskRef = "ResolvingTaskRef"
 	// TaskRunReasonImagePullFailed is the reason set when the step of a task fails due to image not being pulled
 	TaskRunReasonImagePullFailed TaskRunReason = "TaskRunImagePullFailed"
+	// TaskRunReasonInfraFailure is the reason set when the pod of the TaskRun failed because of
+	// the infrastructure it ran on, and the TaskRun has no infra retries left to replace it
+	TaskRunReasonInfraFailure TaskRunReason = "TaskRunInfraFailure"
 )
 
 func (t TaskRunReason) String() string {
@@ -205,6 +235,44 @@ func (trs *TaskRunStatus) MarkResourceFailed(reason TaskRunReason, err error) {
 	trs.CompletionTime = &succeeded.LastTransitionTime.Inner
 }
 
+// SetPausedStep records that the TaskRun is paused at a breakpoint before or after the given
+// step at the given time, or that it isn't paused if step is empty. The time it was paused at a
+// breakpoint is added to PausedDuration when it continues.
+func (trs *TaskRunStatus) SetPausedStep(step string, now time.Time) {
+	if trs.Debug == nil {
+		if step == "" {
+			return
+		}
+		trs.Debug = &TaskRunDebugStatus{}
+	}
+	if trs.Debug.PausedStep == step {
+		return
+	}
+	if trs.Debug.PausedSince != nil {
+		trs.Debug.PausedDuration = &metav1.Duration{Duration: trs.GetPausedDuration(now)}
+		trs.Debug.PausedSince = nil
+	}
+	trs.Debug.PausedStep = step
+	if step != "" {
+		trs.Debug.PausedSince = &metav1.Time{Time: now}
+	}
+}
+
+// GetPausedDuration returns the time the TaskRun was paused at breakpoints until now.
+func (trs *TaskRunStatus) GetPausedDuration(now time.Time) time.Duration {
+	if trs.Debug == nil {
+		return 0
+	}
+	var paused time.Duration
+	if trs.Debug.PausedDuration != nil {
+		paused = trs.Debug.PausedDuration.Duration
+	}
+	if trs.Debug.PausedSince != nil {
+		paused += now.Sub(trs.Debug.PausedSince.Time)
+	}
+	return paused
+}
+
 // TaskRunStatusFields holds the fields of TaskRun's status.  This is defined
 // separately and inlined so that other types can readily consume these fields
 // via duck typing.
@@ -237,6 +305,17 @@ type TaskRunStatusFields struct {
 	// +listType=atomic
 	RetriesStatus []TaskRunStatus `json:"retriesStatus,omitempty"`
 
+	// InfraRetries records the pods of this TaskRun which failed because of the infrastructure
+	// they ran on, such as an eviction or a lost node, and were replaced by a new pod.
+	// They don't consume the retries of the TaskRun.
+	// +optional
+	// +listType=atomic
+	InfraRetries []InfraRetry `json:"infraRetries,omitempty"`
+
+	// Debug holds the resources retained to debug this TaskRun once it failed.
+	// +optional
+	Debug *TaskRunDebugStatus `json:"debug,omitempty"`
+
 	// Results from Resources built during the taskRun. currently includes
 	// the digest of build container images
 	// +optional
@@ -255,6 +334,11 @@ type TaskRunStatusFields struct {
 
 	// TaskSpec contains the Spec from the dereferenced Task definition used to instantiate this TaskRun.
 	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
+
+	// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
+	// Its ConfigSource records where the Task used by this TaskRun was resolved from.
+	// +optional
+	Provenance *Provenance `json:"provenance,omitempty"`
 }
 
 // TaskRunStepOverride is used to override the values of a Step in the corresponding Task.
@@ -320,6 +404,30 @@ type StepState struct {
 	Name                  string `json:"name,omitempty"`
 	ContainerName         string `json:"container,omitempty"`
 	ImageID               string `json:"imageID,omitempty"`
+	// ExitedGracefully is set when the step was terminated because it timed out or its TaskRun
+	// was cancelled: true if it exited within its termination grace period after it was sent
+	// SIGTERM, false if it was killed.
+	// +optional
+	ExitedGracefully *bool `json:"exitedGracefully,omitempty"`
+	// Attempts is the number of times the command of the step was executed, reported for the
+	// steps with retries.
+	// +optional
+	Attempts int `json:"attempts,omitempty"`
+	// ResourceUsage is the resources used by the container of the step while its command ran.
+	// +optional
+	ResourceUsage *StepResourceUsage `json:"resourceUsage,omitempty"`
+}
+
+// StepResourceUsage reports the resources used by the container of a step, sampled from its cgroup.
+type StepResourceUsage struct {
+	// PeakMemory is the highest memory usage of the container.
+	PeakMemory resource.Quantity `json:"peakMemory"`
+	// CPUTime is the CPU time used by the container.
+	CPUTime metav1.Duration `json:"cpuTime"`
+	// IOReadBytes is the number of bytes the container read from block devices.
+	IOReadBytes int64 `json:"ioReadBytes"`
+	// IOWriteBytes is the number of bytes the container wrote to block devices.
+	IOWriteBytes int64 `json:"ioWriteBytes"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -330,6 +438,50 @@ type SidecarState struct {
 	ImageID               string `json:"imageID,omitempty"`
 }
 
+// InfraRetry reports a pod of a TaskRun which was replaced after an infrastructure failure.
+type InfraRetry struct {
+	// PodName is the name of the pod which failed.
+	PodName string `json:"podName"`
+	// Reason is the reason the pod failed, such as Evicted or PodDeleted.
+	Reason string `json:"reason"`
+	// Message is a human readable message describing the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// FailureTime is the time the failure was observed.
+	FailureTime metav1.Time `json:"failureTime"`
+}
+
+// TaskRunDebugStatus reports the breakpoints a TaskRun is paused at, and the resources retained
+// to debug it once it failed.
+type TaskRunDebugStatus struct {
+	// PausedStep is the name of the step the TaskRun is paused before or after.
+	// +optional
+	PausedStep string `json:"pausedStep,omitempty"`
+	// PausedSince is the time the TaskRun was paused at PausedStep.
+	// +optional
+	PausedSince *metav1.Time `json:"pausedSince,omitempty"`
+	// PausedDuration is the time the TaskRun was paused at previous breakpoints, which doesn't
+	// count toward its timeout.
+	// +optional
+	PausedDuration *metav1.Duration `json:"pausedDuration,omitempty"`
+	// RetainedUntil is the time until which the failed TaskRun, its pod and the PersistentVolumeClaims
+	// created for its workspaces are retained for debugging.
+	// +optional
+	RetainedUntil *metav1.Time `json:"retainedUntil,omitempty"`
+	// DebugPods are the pods created to debug the failed steps of the TaskRun.
+	// +optional
+	// +listType=atomic
+	DebugPods []DebugPod `json:"debugPods,omitempty"`
+}
+
+// DebugPod reports a pod created to debug a failed step of a TaskRun.
+type DebugPod struct {
+	// StepName is the name of the failed step.
+	StepName string `json:"stepName"`
+	// PodName is the name of the debug pod.
+	PodName string `json:"podName"`
+}
+
 // CloudEventDelivery is the target of a cloud event along with the state of
 // delivery.
 type CloudEventDelivery struct {
@@ -434,6 +586,14 @@ func (tr *TaskRun) IsSuccessful() bool {
 	return tr != nil && tr.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
 }
 
+// GetPriorityClassName returns the name of the PriorityClass of the pod of the TaskRun
+func (tr *TaskRun) GetPriorityClassName() string {
+	if tr.Spec.PodTemplate != nil && tr.Spec.PodTemplate.PriorityClassName != nil {
+		return *tr.Spec.PodTemplate.PriorityClassName
+	}
+	return ""
+}
+
 // IsCancelled returns true if the TaskRun's spec status is set to Cancelled state
 func (tr *TaskRun) IsCancelled() bool {
 	return tr.Spec.Status == TaskRunSpecStatusCancelled
@@ -449,7 +609,8 @@ func (tr *TaskRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 	if timeout == apisconfig.NoTimeoutDuration {
 		return false
 	}
-	runtime := c.Since(tr.Status.StartTime.Time)
+	// The time the TaskRun is paused at breakpoints doesn't count toward its timeout.
+	runtime := c.Since(tr.Status.StartTime.Time) - tr.Status.GetPausedDuration(c.Now())
 	return runtime > timeout
 }
 
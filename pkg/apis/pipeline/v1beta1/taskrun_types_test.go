@@ -281,6 +281,26 @@ func TestHasTimedOut(t *testing.T) {
 			},
 		},
 		expectedStatus: true,
+	}, {
+		name: "TaskRun paused at a breakpoint",
+		taskRun: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{
+				Timeout: &metav1.Duration{
+					Duration: 10 * time.Second,
+				},
+			},
+			Status: v1beta1.TaskRunStatus{
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime: &metav1.Time{Time: now.Add(-15 * time.Minute)},
+					Debug: &v1beta1.TaskRunDebugStatus{
+						PausedStep:     "build",
+						PausedSince:    &metav1.Time{Time: now.Add(-10 * time.Minute)},
+						PausedDuration: &metav1.Duration{Duration: 4*time.Minute + 55*time.Second},
+					},
+				},
+			},
+		},
+		expectedStatus: false,
 	}}
 
 	for _, tc := range testCases {
@@ -293,6 +313,41 @@ func TestHasTimedOut(t *testing.T) {
 	}
 }
 
+func TestSetPausedStep(t *testing.T) {
+	status := &v1beta1.TaskRunStatus{}
+	status.SetPausedStep("", now)
+	if status.Debug != nil {
+		t.Errorf("Expected no debug status for a TaskRun which never paused but got %v", status.Debug)
+	}
+
+	status.SetPausedStep("build", now)
+	status.SetPausedStep("build", now.Add(time.Minute))
+	want := &v1beta1.TaskRunDebugStatus{
+		PausedStep:  "build",
+		PausedSince: &metav1.Time{Time: now},
+	}
+	if d := cmp.Diff(want, status.Debug); d != "" {
+		t.Errorf("Unexpected debug status %s", diff.PrintWantGot(d))
+	}
+	if got := status.GetPausedDuration(now.Add(time.Minute)); got != time.Minute {
+		t.Errorf("Expected paused duration of 1m but got %s", got)
+	}
+
+	status.SetPausedStep("", now.Add(2*time.Minute))
+	status.SetPausedStep("test", now.Add(5*time.Minute))
+	want = &v1beta1.TaskRunDebugStatus{
+		PausedStep:     "test",
+		PausedSince:    &metav1.Time{Time: now.Add(5 * time.Minute)},
+		PausedDuration: &metav1.Duration{Duration: 2 * time.Minute},
+	}
+	if d := cmp.Diff(want, status.Debug); d != "" {
+		t.Errorf("Unexpected debug status %s", diff.PrintWantGot(d))
+	}
+	if got := status.GetPausedDuration(now.Add(6 * time.Minute)); got != 3*time.Minute {
+		t.Errorf("Expected paused duration of 3m but got %s", got)
+	}
+}
+
 func TestInitializeTaskRunConditions(t *testing.T) {
 	tr := &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -82,6 +82,12 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "computeResources", config.AlphaAPIFields).ViaField("computeResources"))
 		errs = errs.Also(validateTaskRunComputeResources(ts.ComputeResources, ts.StepOverrides))
 	}
+	if ts.TerminationGracePeriod != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "terminationGracePeriod", config.AlphaAPIFields).ViaField("terminationGracePeriod"))
+		if ts.TerminationGracePeriod.Duration < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", ts.TerminationGracePeriod.Duration.String()), "terminationGracePeriod"))
+		}
+	}
 
 	if ts.Status != "" {
 		if ts.Status != TaskRunSpecStatusCancelled {
@@ -151,6 +157,24 @@ func validateDebug(db *TaskRunDebug) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s is not a valid breakpoint. Available valid breakpoints include %s", b, validBreakpoints.List()), "breakpoint"))
 		}
 	}
+	errs = errs.Also(validateBreakpointSteps(db.BeforeSteps).ViaField("beforeSteps"))
+	errs = errs.Also(validateBreakpointSteps(db.AfterSteps).ViaField("afterSteps"))
+	return errs
+}
+
+// validateBreakpointSteps validates the names of the steps to pause before or after.
+func validateBreakpointSteps(steps []string) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for idx, s := range steps {
+		if s == "" {
+			errs = errs.Also(apis.ErrInvalidValue("step name cannot be empty", "").ViaIndex(idx))
+			continue
+		}
+		if seen.Has(s) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("step %q is listed more than once", s), "").ViaIndex(idx))
+		}
+		seen.Insert(s)
+	}
 	return errs
 }
 
@@ -416,6 +416,39 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wantErr: apis.ErrInvalidValue("breakito is not a valid breakpoint. Available valid breakpoints include [onFailure]", "debug.breakpoint"),
 		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "terminationGracePeriod disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			TerminationGracePeriod: &metav1.Duration{Duration: time.Minute},
+		},
+		wantErr: apis.ErrGeneric("terminationGracePeriod requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "negative terminationGracePeriod",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			TerminationGracePeriod: &metav1.Duration{Duration: -time.Minute},
+		},
+		wantErr: apis.ErrInvalidValue("-1m0s should be >= 0", "terminationGracePeriod"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "invalid breakpoint steps",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			Debug: &v1beta1.TaskRunDebug{
+				BeforeSteps: []string{"build", ""},
+				AfterSteps:  []string{"test", "test"},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("step name cannot be empty", "debug.beforeSteps[1]").Also(
+			apis.ErrInvalidValue("step \"test\" is listed more than once", "debug.afterSteps[1]")),
+		wc: config.EnableAlphaAPIFields,
 	}, {
 		name: "stepOverride disallowed without alpha feature gate",
 		spec: v1beta1.TaskRunSpec{
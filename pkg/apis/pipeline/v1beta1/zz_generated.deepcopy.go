@@ -151,6 +151,45 @@ func (in *ClusterTaskList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSource) DeepCopyInto(out *ConfigSource) {
+	*out = *in
+	if in.Digest != nil {
+		in, out := &in.Digest, &out.Digest
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSource.
+func (in *ConfigSource) DeepCopy() *ConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugPod) DeepCopyInto(out *DebugPod) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugPod.
+func (in *DebugPod) DeepCopy() *DebugPod {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedTask) DeepCopyInto(out *EmbeddedTask) {
 	*out = *in
@@ -171,6 +210,23 @@ func (in *EmbeddedTask) DeepCopy() *EmbeddedTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraRetry) DeepCopyInto(out *InfraRetry) {
+	*out = *in
+	in.FailureTime.DeepCopyInto(&out.FailureTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraRetry.
+func (in *InfraRetry) DeepCopy() *InfraRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InternalTaskModifier) DeepCopyInto(out *InternalTaskModifier) {
 	*out = *in
@@ -611,6 +667,21 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TaskControls != nil {
+		in, out := &in.TaskControls, &out.TaskControls
+		*out = make([]PipelineTaskControl, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Skip != nil {
+		in, out := &in.Skip, &out.Skip
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -713,6 +784,11 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 		in, out := &in.FinallyStartTime, &out.FinallyStartTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(Provenance)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -875,6 +951,22 @@ func (in *PipelineTask) DeepCopy() *PipelineTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskControl) DeepCopyInto(out *PipelineTaskControl) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskControl.
+func (in *PipelineTaskControl) DeepCopy() *PipelineTaskControl {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskControl)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
 	*out = *in
@@ -1101,6 +1193,27 @@ func (in *PropertySpec) DeepCopy() *PropertySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Provenance) DeepCopyInto(out *Provenance) {
+	*out = *in
+	if in.ConfigSource != nil {
+		in, out := &in.ConfigSource, &out.ConfigSource
+		*out = new(ConfigSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provenance.
+func (in *Provenance) DeepCopy() *Provenance {
+	if in == nil {
+		return nil
+	}
+	out := new(Provenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolverRef) DeepCopyInto(out *ResolverRef) {
 	*out = *in
@@ -1343,6 +1456,23 @@ func (in *Step) DeepCopyInto(out *Step) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.TerminationGracePeriod != nil {
+		in, out := &in.TerminationGracePeriod, &out.TerminationGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryDelay != nil {
+		in, out := &in.RetryDelay, &out.RetryDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]StepResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Workspaces != nil {
 		in, out := &in.Workspaces, &out.Workspaces
 		*out = make([]WorkspaceUsage, len(*in))
@@ -1387,10 +1517,61 @@ func (in *StepOutputConfig) DeepCopy() *StepOutputConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepResourceUsage) DeepCopyInto(out *StepResourceUsage) {
+	*out = *in
+	out.PeakMemory = in.PeakMemory.DeepCopy()
+	out.CPUTime = in.CPUTime
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepResourceUsage.
+func (in *StepResourceUsage) DeepCopy() *StepResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(StepResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepResult) DeepCopyInto(out *StepResult) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]PropertySpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepResult.
+func (in *StepResult) DeepCopy() *StepResult {
+	if in == nil {
+		return nil
+	}
+	out := new(StepResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
 	in.ContainerState.DeepCopyInto(&out.ContainerState)
+	if in.ExitedGracefully != nil {
+		in, out := &in.ExitedGracefully, &out.ExitedGracefully
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(StepResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1639,6 +1820,11 @@ func (in *TaskResult) DeepCopyInto(out *TaskResult) {
 			(*out)[key] = val
 		}
 	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(ParamValue)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1688,6 +1874,16 @@ func (in *TaskRunDebug) DeepCopyInto(out *TaskRunDebug) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BeforeSteps != nil {
+		in, out := &in.BeforeSteps, &out.BeforeSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AfterSteps != nil {
+		in, out := &in.AfterSteps, &out.AfterSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1701,6 +1897,40 @@ func (in *TaskRunDebug) DeepCopy() *TaskRunDebug {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunDebugStatus) DeepCopyInto(out *TaskRunDebugStatus) {
+	*out = *in
+	if in.PausedSince != nil {
+		in, out := &in.PausedSince, &out.PausedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.PausedDuration != nil {
+		in, out := &in.PausedDuration, &out.PausedDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetainedUntil != nil {
+		in, out := &in.RetainedUntil, &out.RetainedUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.DebugPods != nil {
+		in, out := &in.DebugPods, &out.DebugPods
+		*out = make([]DebugPod, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunDebugStatus.
+func (in *TaskRunDebugStatus) DeepCopy() *TaskRunDebugStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunDebugStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunInputs) DeepCopyInto(out *TaskRunInputs) {
 	*out = *in
@@ -1886,6 +2116,11 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.TerminationGracePeriod != nil {
+		in, out := &in.TerminationGracePeriod, &out.TerminationGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.PodTemplate != nil {
 		in, out := &in.PodTemplate, &out.PodTemplate
 		*out = new(pod.Template)
@@ -1980,6 +2215,18 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InfraRetries != nil {
+		in, out := &in.InfraRetries, &out.InfraRetries
+		*out = make([]InfraRetry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(TaskRunDebugStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ResourcesResult != nil {
 		in, out := &in.ResourcesResult, &out.ResourcesResult
 		*out = make([]PipelineResourceResult, len(*in))
@@ -2004,6 +2251,11 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 		*out = new(TaskSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(Provenance)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
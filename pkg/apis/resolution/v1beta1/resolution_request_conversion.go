@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// paramsAnnotationKey is the annotation holding the array and object params
+// of a ResolutionRequest converted to v1alpha1, which only has string params.
+const paramsAnnotationKey = "resolution.tekton.dev/v1beta1Params"
+
+var _ apis.Convertible = (*ResolutionRequest)(nil)
+
+// ConvertTo implements apis.Convertible
+func (rr *ResolutionRequest) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	if apis.IsInDelete(ctx) {
+		return nil
+	}
+	switch sink := to.(type) {
+	case *v1alpha1.ResolutionRequest:
+		rr.ObjectMeta.DeepCopyInto(&sink.ObjectMeta)
+		if err := serializeParams(&sink.ObjectMeta, &rr.Spec); err != nil {
+			return err
+		}
+		rr.Spec.ConvertTo(ctx, &sink.Spec)
+		sink.Status.Status = rr.Status.Status
+		sink.Status.Data = rr.Status.Data
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertTo converts a v1beta1.ResolutionRequestSpec to a v1alpha1.ResolutionRequestSpec.
+// Only string params are kept: array and object params are serialized to an
+// annotation by the ResolutionRequest conversion.
+func (rrs *ResolutionRequestSpec) ConvertTo(ctx context.Context, sink *v1alpha1.ResolutionRequestSpec) {
+	sink.Parameters = nil
+	for _, p := range rrs.Params {
+		if p.Value.Type == pipelinev1beta1.ParamTypeArray || p.Value.Type == pipelinev1beta1.ParamTypeObject {
+			continue
+		}
+		if sink.Parameters == nil {
+			sink.Parameters = make(map[string]string, len(rrs.Params))
+		}
+		sink.Parameters[p.Name] = p.Value.StringVal
+	}
+}
+
+// ConvertFrom implements apis.Convertible
+func (rr *ResolutionRequest) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	if apis.IsInDelete(ctx) {
+		return nil
+	}
+	switch source := from.(type) {
+	case *v1alpha1.ResolutionRequest:
+		source.ObjectMeta.DeepCopyInto(&rr.ObjectMeta)
+		rr.Spec.ConvertFrom(ctx, &source.Spec)
+		if err := deserializeParams(&rr.ObjectMeta, &rr.Spec); err != nil {
+			return err
+		}
+		rr.Status.Status = source.Status.Status
+		rr.Status.Data = source.Status.Data
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+// ConvertFrom converts a v1alpha1.ResolutionRequestSpec to a v1beta1.ResolutionRequestSpec,
+// with a string param for each parameter sorted by name.
+func (rrs *ResolutionRequestSpec) ConvertFrom(ctx context.Context, source *v1alpha1.ResolutionRequestSpec) {
+	rrs.Params = nil
+	names := make([]string, 0, len(source.Parameters))
+	for name := range source.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rrs.Params = append(rrs.Params, pipelinev1beta1.Param{
+			Name:  name,
+			Value: *pipelinev1beta1.NewStructuredValues(source.Parameters[name]),
+		})
+	}
+}
+
+func serializeParams(meta *metav1.ObjectMeta, spec *ResolutionRequestSpec) error {
+	var params []pipelinev1beta1.Param
+	for _, p := range spec.Params {
+		if p.Value.Type == pipelinev1beta1.ParamTypeArray || p.Value.Type == pipelinev1beta1.ParamTypeObject {
+			params = append(params, p)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return version.SerializeToMetadata(meta, params, paramsAnnotationKey)
+}
+
+func deserializeParams(meta *metav1.ObjectMeta, spec *ResolutionRequestSpec) error {
+	var params []pipelinev1beta1.Param
+	if err := version.DeserializeFromMetadata(meta, &params, paramsAnnotationKey); err != nil {
+		return err
+	}
+	spec.Params = append(spec.Params, params...)
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestResolutionRequestConversionBadType(t *testing.T) {
+	good, bad := &v1beta1.ResolutionRequest{}, &pipelinev1beta1.Task{}
+
+	if err := good.ConvertTo(context.Background(), bad); err == nil {
+		t.Errorf("ConvertTo() = %#v, wanted error", bad)
+	}
+
+	if err := good.ConvertFrom(context.Background(), bad); err == nil {
+		t.Errorf("ConvertFrom() = %#v, wanted error", bad)
+	}
+}
+
+func TestResolutionRequestConversion(t *testing.T) {
+	versions := []apis.Convertible{&v1alpha1.ResolutionRequest{}}
+
+	tests := []struct {
+		name string
+		in   *v1beta1.ResolutionRequest
+	}{{
+		name: "no params",
+		in: &v1beta1.ResolutionRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+		},
+	}, {
+		name: "string params and status",
+		in: &v1beta1.ResolutionRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+				Labels:    map[string]string{"resolution.tekton.dev/type": "git"},
+			},
+			Spec: v1beta1.ResolutionRequestSpec{
+				Params: []pipelinev1beta1.Param{{
+					Name:  "pathInRepo",
+					Value: *pipelinev1beta1.NewStructuredValues("task/git-clone/0.6/git-clone.yaml"),
+				}, {
+					Name:  "revision",
+					Value: *pipelinev1beta1.NewStructuredValues("main"),
+				}, {
+					Name:  "url",
+					Value: *pipelinev1beta1.NewStructuredValues("https://github.com/tektoncd/catalog.git"),
+				}},
+			},
+			Status: v1beta1.ResolutionRequestStatus{
+				Status: duckv1.Status{
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				ResolutionRequestStatusFields: v1beta1.ResolutionRequestStatusFields{
+					Data: "c29tZSBkYXRh",
+				},
+			},
+		},
+	}, {
+		name: "array and object params",
+		in: &v1beta1.ResolutionRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   "bar",
+				Annotations: map[string]string{"foo": "bar"},
+			},
+			Spec: v1beta1.ResolutionRequestSpec{
+				Params: []pipelinev1beta1.Param{{
+					Name:  "url",
+					Value: *pipelinev1beta1.NewStructuredValues("https://github.com/tektoncd/catalog.git"),
+				}, {
+					Name:  "paths",
+					Value: *pipelinev1beta1.NewStructuredValues("task/git-clone/0.6/git-clone.yaml", "task/git-clone/0.7/git-clone.yaml"),
+				}, {
+					Name:  "options",
+					Value: *pipelinev1beta1.NewObject(map[string]string{"depth": "1"}),
+				}},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, version := range versions {
+			t.Run(test.name, func(t *testing.T) {
+				ver := version
+				if err := test.in.ConvertTo(context.Background(), ver); err != nil {
+					t.Errorf("ConvertTo() = %v", err)
+					return
+				}
+				t.Logf("ConvertTo() = %#v", ver)
+				got := &v1beta1.ResolutionRequest{}
+				if err := got.ConvertFrom(context.Background(), ver); err != nil {
+					t.Errorf("ConvertFrom() = %v", err)
+				}
+				t.Logf("ConvertFrom() = %#v", got)
+				if d := cmp.Diff(test.in, got); d != "" {
+					t.Errorf("roundtrip %s", diff.PrintWantGot(d))
+				}
+			})
+		}
+	}
+}
+
+func TestResolutionRequestConvertToTypedParams(t *testing.T) {
+	in := &v1beta1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.ResolutionRequestSpec{
+			Params: []pipelinev1beta1.Param{{
+				Name:  "url",
+				Value: *pipelinev1beta1.NewStructuredValues("https://github.com/tektoncd/catalog.git"),
+			}, {
+				Name:  "paths",
+				Value: *pipelinev1beta1.NewStructuredValues("a.yaml", "b.yaml"),
+			}},
+		},
+	}
+	want := &v1alpha1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+			Annotations: map[string]string{
+				"resolution.tekton.dev/v1beta1Params": `[{"name":"paths","value":["a.yaml","b.yaml"]}]`,
+			},
+		},
+		Spec: v1alpha1.ResolutionRequestSpec{
+			Parameters: map[string]string{"url": "https://github.com/tektoncd/catalog.git"},
+		},
+	}
+
+	got := &v1alpha1.ResolutionRequest{}
+	if err := in.ConvertTo(context.Background(), got); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected v1alpha1 ResolutionRequest %s", diff.PrintWantGot(d))
+	}
+	if in.Annotations != nil {
+		t.Errorf("ConvertTo() modified the annotations of the source: %v", in.Annotations)
+	}
+}
+
+func TestResolutionRequestConvertFromSortsParams(t *testing.T) {
+	in := &v1alpha1.ResolutionRequest{
+		Spec: v1alpha1.ResolutionRequestSpec{
+			Parameters: map[string]string{"b": "c", "a": "d"},
+		},
+	}
+	want := []pipelinev1beta1.Param{{
+		Name:  "a",
+		Value: *pipelinev1beta1.NewStructuredValues("d"),
+	}, {
+		Name:  "b",
+		Value: *pipelinev1beta1.NewStructuredValues("c"),
+	}}
+
+	got := &v1beta1.ResolutionRequest{}
+	if err := got.ConvertFrom(context.Background(), in); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	if d := cmp.Diff(want, got.Spec.Params); d != "" {
+		t.Errorf("unexpected params %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolutionRequestConversionFromV1Alpha1(t *testing.T) {
+	in := &v1alpha1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.ResolutionRequestSpec{
+			Parameters: map[string]string{
+				"url":      "https://github.com/tektoncd/catalog.git",
+				"revision": "main",
+			},
+		},
+		Status: v1alpha1.ResolutionRequestStatus{
+			ResolutionRequestStatusFields: v1alpha1.ResolutionRequestStatusFields{
+				Data: "c29tZSBkYXRh",
+			},
+		},
+	}
+
+	hub := &v1beta1.ResolutionRequest{}
+	if err := hub.ConvertFrom(context.Background(), in); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	got := &v1alpha1.ResolutionRequest{}
+	if err := hub.ConvertTo(context.Background(), got); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if d := cmp.Diff(in, got); d != "" {
+		t.Errorf("roundtrip %s", diff.PrintWantGot(d))
+	}
+}
@@ -42,6 +42,54 @@ const (
 	FailOnError     = "stopAndFail"
 )
 
+const (
+	// BreakpointMarkerSuffix is the suffix of the file written next to the post file while a
+	// step is paused at a breakpoint. It contains the position of the breakpoint.
+	BreakpointMarkerSuffix = ".breakpoint"
+	// BreakpointExitSuffix is the suffix of the file written next to the post file by the debug
+	// scripts. It contains the exit code with which to continue from a breakpoint.
+	BreakpointExitSuffix = ".breakpointexit"
+	// BreakpointPausedCommand is the name of the entrypoint subcommand succeeding only if a step
+	// is paused at a breakpoint, i.e. if its breakpoint marker exists.
+	BreakpointPausedCommand = "breakpoint-paused"
+
+	breakpointBeforeStep = "before"
+	breakpointAfterStep  = "after"
+)
+
+// SleepCommand is the name of the entrypoint subcommand sleeping for a number of seconds. It keeps
+// the debug pods of failed steps running without requiring a sleep binary in the step image.
+const SleepCommand = "sleep"
+
+// TerminationError is returned by a Runner with a termination grace period when the command was
+// terminated because its context was done, i.e. the step timed out or its TaskRun was cancelled.
+type TerminationError struct {
+	// Err is the error of the context
+	Err error
+	// Graceful is true if the command exited within its termination grace period after it was
+	// sent SIGTERM, false if it was killed.
+	Graceful bool
+}
+
+func (e TerminationError) Error() string {
+	if e.Graceful {
+		return fmt.Sprintf("step exited after it was sent SIGTERM: %v", e.Err)
+	}
+	return fmt.Sprintf("step was killed after its termination grace period: %v", e.Err)
+}
+
+// Unwrap returns the error of the context.
+func (e TerminationError) Unwrap() error {
+	return e.Err
+}
+
+// BreakpointError is returned when a step is failed from a breakpoint with the debug-fail script.
+type BreakpointError string
+
+func (e BreakpointError) Error() string {
+	return string(e)
+}
+
 // Entrypointer holds fields for running commands with redirected
 // entrypoints.
 type Entrypointer struct {
@@ -74,6 +122,38 @@ type Entrypointer struct {
 	Timeout *time.Duration
 	// BreakpointOnFailure helps determine if entrypoint execution needs to adapt debugging requirements
 	BreakpointOnFailure bool
+	// BreakpointBeforeStep pauses the step before running its command until it is continued
+	BreakpointBeforeStep bool
+	// BreakpointAfterStep pauses the step after running its command until it is continued
+	BreakpointAfterStep bool
+	// CancelFile is the file whose content signals that the TaskRun was cancelled. The running
+	// command is then terminated, as if it timed out. If empty, the command is never cancelled.
+	CancelFile string
+	// Retries is the number of times the command is re-executed when it exits with a non-zero exit code
+	Retries int
+	// RetryDelay is the time to wait before re-executing the command
+	RetryDelay time.Duration
+	// EnvFile is the file where the step exports environment variables to the next steps
+	EnvFile string
+	// ImportEnvFile is the file with the environment variables exported by the previous steps,
+	// which are set in the environment of the command
+	ImportEnvFile string
+	// ExportEnvFile is the file where the environment variables exported by the previous steps
+	// and by this one are written for the next steps. If empty, the step doesn't export any.
+	ExportEnvFile string
+	// UsageSampler samples the resources used by the container of the step while its command runs
+	UsageSampler UsageSampler
+	// UsageSamplingInterval is the interval between the samples of the resources used by the
+	// container. If zero, they aren't sampled.
+	UsageSamplingInterval time.Duration
+	// StepsDir is the directory with the metadata directories of the steps, where the references to
+	// the results of the previous steps in the command and the environment are resolved from. If
+	// empty, they aren't resolved.
+	StepsDir string
+	// StepResults is the set of results the step writes in its metadata directory
+	StepResults []string
+	// PromotedResults maps the task results promoted from the step results to them
+	PromotedResults map[string]string
 	// OnError defines exiting behavior of the entrypoint
 	// set it to "stopAndFail" to indicate the entrypoint to exit the taskRun if the container exits with non zero exit code
 	// set it to "continue" to indicate the entrypoint to continue executing the rest of the steps irrespective of the container exit code
@@ -141,25 +221,85 @@ func (e Entrypointer) Go() error {
 		err = fmt.Errorf("negative timeout specified")
 	}
 
+	if err == nil && e.ImportEnvFile != "" {
+		err = e.loadEnv()
+	}
+
+	if err == nil && len(e.StepResults) > 0 {
+		err = os.MkdirAll(filepath.Join(e.StepMetadataDir, stepResultsDir), os.ModePerm)
+	}
+
+	if err == nil && e.StepsDir != "" {
+		e.Command, err = e.resolveStepResultRefs()
+	}
+
+	if err == nil && e.BreakpointBeforeStep {
+		err = e.waitForBreakpoint(breakpointBeforeStep)
+	}
+
 	if err == nil {
 		ctx := context.Background()
-		var cancel context.CancelFunc
-		if e.Timeout != nil && *e.Timeout != time.Duration(0) {
-			ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
-			defer cancel()
+		if e.CancelFile != "" {
+			var cancelRun context.CancelFunc
+			ctx, cancelRun = context.WithCancel(ctx)
+			defer cancelRun()
+			go e.waitForCancellation(cancelRun)
+		}
+		var attempts int
+		stopSamplingUsage := e.startSamplingUsage()
+		attempts, err = e.runWithRetries(ctx, pipeline.DefaultResultPath)
+		output = e.reportUsage(stopSamplingUsage(), output)
+		if e.Retries > 0 {
+			output = append(output, v1beta1.PipelineResourceResult{
+				Key:        "Attempts",
+				Value:      strconv.Itoa(attempts),
+				ResultType: v1beta1.InternalTektonResultType,
+			})
+			e.PostWriter.Write(filepath.Join(e.StepMetadataDir, "attempts"), strconv.Itoa(attempts))
 		}
-		err = e.Runner.Run(ctx, e.Command...)
-		if err == context.DeadlineExceeded {
+		if errors.Is(err, context.DeadlineExceeded) {
 			output = append(output, v1beta1.PipelineResourceResult{
 				Key:        "Reason",
 				Value:      "TimeoutExceeded",
 				ResultType: v1beta1.InternalTektonResultType,
 			})
 		}
+		var te TerminationError
+		if errors.As(err, &te) {
+			output = append(output, v1beta1.PipelineResourceResult{
+				Key:        "ExitedGracefully",
+				Value:      strconv.FormatBool(te.Graceful),
+				ResultType: v1beta1.InternalTektonResultType,
+			})
+		}
+
+		// A failed step is paused by the onFailure breakpoint instead, if it is set.
+		if e.BreakpointAfterStep && (err == nil || !e.BreakpointOnFailure) {
+			if bErr := e.waitForBreakpoint(breakpointAfterStep); bErr != nil && err == nil {
+				err = bErr
+			}
+		}
+	}
+
+	if len(e.PromotedResults) > 0 {
+		if promoteErr := e.promoteStepResults(pipeline.DefaultResultPath); promoteErr != nil && err == nil {
+			err = promoteErr
+		}
+	}
+
+	// The environment is exported before the post file is written, which starts the next step.
+	if e.ExportEnvFile != "" {
+		if exportErr := e.exportEnv(); exportErr != nil && err == nil {
+			err = exportErr
+		}
 	}
 
 	var ee *exec.ExitError
+	var be BreakpointError
 	switch {
+	case errors.As(err, &be):
+		// a step failed from a breakpoint must fail even with breakpoint on failure
+		e.WritePostFile(e.PostFile, err)
 	case err != nil && e.BreakpointOnFailure:
 		logger.Info("Skipping writing to PostFile")
 	case e.OnError == ContinueOnError && errors.As(err, &ee):
@@ -220,6 +360,129 @@ func (e Entrypointer) readResultsFromDisk(resultDir string) error {
 	return nil
 }
 
+// runWithRetries runs the command, each attempt within the timeout, and re-executes it up to
+// Retries times while it exits with a non-zero exit code. The task and step results written by a
+// failed attempt are cleared before the next one. It returns the number of attempts and the error
+// of the last one.
+func (e Entrypointer) runWithRetries(ctx context.Context, resultDir string) (int, error) {
+	var previousResults map[string][]byte
+	if e.Retries > 0 {
+		previousResults = e.readResultFiles(resultDir)
+	}
+	for attempt := 1; ; attempt++ {
+		err := e.runAttempt(ctx)
+		var ee *exec.ExitError
+		if attempt > e.Retries || !errors.As(err, &ee) {
+			return attempt, err
+		}
+		log.Printf("Attempt %d of %d failed with exit code %d, retrying in %s", attempt, e.Retries+1, ee.ExitCode(), e.RetryDelay)
+		if rErr := e.restoreResultFiles(resultDir, previousResults); rErr != nil {
+			return attempt, fmt.Errorf("clearing the results of attempt %d: %w", attempt, rErr)
+		}
+		if rErr := e.clearStepResults(); rErr != nil {
+			return attempt, fmt.Errorf("clearing the step results of attempt %d: %w", attempt, rErr)
+		}
+		select {
+		case <-time.After(e.RetryDelay):
+		case <-ctx.Done():
+			// The step was cancelled while waiting to retry it.
+			return attempt, err
+		}
+	}
+}
+
+// runAttempt runs the command once, within the timeout if any.
+func (e Entrypointer) runAttempt(ctx context.Context) error {
+	if e.Timeout != nil && *e.Timeout != time.Duration(0) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
+		defer cancel()
+	}
+	return e.Runner.Run(ctx, e.Command...)
+}
+
+// readResultFiles returns the content of the result files which exist in resultDir.
+func (e Entrypointer) readResultFiles(resultDir string) map[string][]byte {
+	contents := map[string][]byte{}
+	for _, resultFile := range e.Results {
+		if resultFile == "" {
+			continue
+		}
+		if content, err := ioutil.ReadFile(filepath.Join(resultDir, resultFile)); err == nil {
+			contents[resultFile] = content
+		}
+	}
+	return contents
+}
+
+// restoreResultFiles resets the result files in resultDir to the given content, removing the
+// ones which didn't exist, so that the results of a failed attempt aren't reported.
+func (e Entrypointer) restoreResultFiles(resultDir string, contents map[string][]byte) error {
+	for _, resultFile := range e.Results {
+		if resultFile == "" {
+			continue
+		}
+		path := filepath.Join(resultDir, resultFile)
+		if content, ok := contents[resultFile]; ok {
+			if err := ioutil.WriteFile(path, content, 0666); err != nil {
+				return err
+			}
+		} else if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearStepResults removes the step results written by a failed attempt. The results directory
+// of the step only holds what the step wrote, since it is created when the step starts.
+func (e Entrypointer) clearStepResults() error {
+	for _, stepResult := range e.StepResults {
+		if err := os.Remove(filepath.Join(e.StepMetadataDir, stepResultsDir, stepResult)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForCancellation calls cancel once the cancel file has content.
+func (e Entrypointer) waitForCancellation(cancel context.CancelFunc) {
+	if err := e.Waiter.Wait(e.CancelFile, true, false); err != nil {
+		log.Printf("Error waiting for cancellation: %v", err)
+		return
+	}
+	log.Print("Terminating the step because the TaskRun was cancelled")
+	cancel()
+}
+
+// waitForBreakpoint pauses the step at the breakpoint at the given position: it writes the
+// breakpoint marker next to the post file, and waits for the debug-continue or debug-fail script
+// to write the exit code with which to continue. A non-zero exit code fails the step.
+func (e Entrypointer) waitForBreakpoint(position string) error {
+	marker := e.PostFile + BreakpointMarkerSuffix
+	breakpointExitPostFile := e.PostFile + BreakpointExitSuffix
+	log.Printf("Pausing %s the step until it is continued with the debug-continue or debug-fail script", position)
+	e.PostWriter.Write(marker, position)
+	defer func() {
+		// The marker is removed to report that the step continued, and the exit code so that
+		// a later breakpoint of the same step waits for the debug scripts again.
+		_ = os.Remove(marker)
+		_ = os.Remove(breakpointExitPostFile)
+	}()
+
+	if err := e.Waiter.Wait(breakpointExitPostFile, false, false); err != nil {
+		return fmt.Errorf("waiting for breakpoint %s the step: %w", position, err)
+	}
+	exitCode, err := e.BreakpointExitCode(breakpointExitPostFile)
+	if err != nil {
+		return fmt.Errorf("reading exit code of breakpoint %s the step: %w", position, err)
+	}
+	if exitCode != 0 {
+		return BreakpointError(fmt.Sprintf("step failed at breakpoint %s the step with exit code %d", position, exitCode))
+	}
+	return nil
+}
+
 // BreakpointExitCode reads the post file and returns the exit code it contains
 func (e Entrypointer) BreakpointExitCode(breakpointExitPostFile string) (int, error) {
 	exitCode, err := ioutil.ReadFile(breakpointExitPostFile)
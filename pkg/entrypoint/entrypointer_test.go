@@ -26,6 +26,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -353,6 +354,269 @@ func TestEntrypointer_ReadBreakpointExitCodeFromDisk(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_Breakpoints(t *testing.T) {
+	for _, c := range []struct {
+		desc                string
+		before, after       bool
+		breakpointOnFailure bool
+		exitCode            int
+		runner              Runner
+		wantPaused          []string
+		wantRun             bool
+		wantPostFile        string
+		wantErr             bool
+	}{{
+		desc:         "continue before the step",
+		before:       true,
+		runner:       &fakeRunner{},
+		wantPaused:   []string{"before"},
+		wantRun:      true,
+		wantPostFile: "out",
+	}, {
+		desc:         "fail before the step",
+		before:       true,
+		exitCode:     1,
+		runner:       &fakeRunner{},
+		wantPaused:   []string{"before"},
+		wantPostFile: "out.err",
+		wantErr:      true,
+	}, {
+		desc:         "continue after the step",
+		after:        true,
+		runner:       &fakeRunner{},
+		wantPaused:   []string{"after"},
+		wantRun:      true,
+		wantPostFile: "out",
+	}, {
+		desc:         "fail after the step",
+		after:        true,
+		exitCode:     1,
+		runner:       &fakeRunner{},
+		wantPaused:   []string{"after"},
+		wantRun:      true,
+		wantPostFile: "out.err",
+		wantErr:      true,
+	}, {
+		desc:         "continue before and after the step",
+		before:       true,
+		after:        true,
+		runner:       &fakeRunner{},
+		wantPaused:   []string{"before", "after"},
+		wantRun:      true,
+		wantPostFile: "out",
+	}, {
+		desc:                "failed step paused by the breakpoint on failure instead",
+		after:               true,
+		breakpointOnFailure: true,
+		runner:              &fakeErrorRunner{},
+		wantRun:             true,
+		wantErr:             true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			postFile := filepath.Join(dir, "out")
+			fw := &fakeBreakpointWaiter{exitCode: c.exitCode}
+			fpw := &fakeBreakpointPostWriter{}
+			err := Entrypointer{
+				Command:              []string{"echo", "some", "args"},
+				PostFile:             postFile,
+				Waiter:               fw,
+				Runner:               c.runner,
+				PostWriter:           fpw,
+				TerminationPath:      filepath.Join(dir, "termination"),
+				BreakpointOnFailure:  c.breakpointOnFailure,
+				BreakpointBeforeStep: c.before,
+				BreakpointAfterStep:  c.after,
+			}.Go()
+			if c.wantErr && err == nil {
+				t.Error("Entrypointer didn't fail")
+			} else if !c.wantErr && err != nil {
+				t.Errorf("Entrypointer failed: %v", err)
+			}
+
+			if d := cmp.Diff(c.wantPaused, fw.paused); d != "" {
+				t.Errorf("Unexpected breakpoints %s", diff.PrintWantGot(d))
+			}
+			var run bool
+			switch r := c.runner.(type) {
+			case *fakeRunner:
+				run = r.args != nil
+			case *fakeErrorRunner:
+				run = r.args != nil
+			}
+			if run != c.wantRun {
+				t.Errorf("Expected the command to run: %t, but it ran: %t", c.wantRun, run)
+			}
+			var wantPostFiles []string
+			if c.wantPostFile != "" {
+				wantPostFiles = []string{filepath.Join(dir, c.wantPostFile)}
+			}
+			if d := cmp.Diff(wantPostFiles, fpw.postFiles); d != "" {
+				t.Errorf("Unexpected post files %s", diff.PrintWantGot(d))
+			}
+			for _, f := range []string{postFile + BreakpointMarkerSuffix, postFile + BreakpointExitSuffix} {
+				if _, err := os.Stat(f); !os.IsNotExist(err) {
+					t.Errorf("Expected %s to be removed once the step continued", f)
+				}
+			}
+		})
+	}
+}
+
+func TestEntrypointer_Cancellation(t *testing.T) {
+	dir := t.TempDir()
+	terminationPath := filepath.Join(dir, "termination")
+	fpw := &fakePostWriter{}
+	err := Entrypointer{
+		Command:         []string{"echo", "some", "args"},
+		PostFile:        filepath.Join(dir, "out"),
+		Waiter:          &fakeWaiter{},
+		Runner:          &fakeTerminatedRunner{},
+		PostWriter:      fpw,
+		TerminationPath: terminationPath,
+		CancelFile:      "/tekton/cancel/cancelled",
+	}.Go()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the step to be cancelled but got: %v", err)
+	}
+	if fpw.wrote == nil || *fpw.wrote != filepath.Join(dir, "out.err") {
+		t.Errorf("Wanted post file %q written, got %v", filepath.Join(dir, "out.err"), fpw.wrote)
+	}
+
+	msg, err := ioutil.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatalf("Error reading termination message: %v", err)
+	}
+	var entries []v1beta1.PipelineResourceResult
+	if err := json.Unmarshal(msg, &entries); err != nil {
+		t.Fatalf("Error parsing termination message: %v", err)
+	}
+	var got *v1beta1.PipelineResourceResult
+	for i := range entries {
+		if entries[i].Key == "ExitedGracefully" {
+			got = &entries[i]
+		}
+	}
+	want := &v1beta1.PipelineResourceResult{Key: "ExitedGracefully", Value: "true", ResultType: v1beta1.InternalTektonResultType}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected termination message entry %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntrypointer_Retries(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		failures     int
+		retries      int
+		wantAttempts string
+		wantErr      bool
+	}{{
+		desc:         "succeeds after retrying",
+		failures:     2,
+		retries:      3,
+		wantAttempts: "3",
+	}, {
+		desc:         "fails once the retries are exhausted",
+		failures:     5,
+		retries:      2,
+		wantAttempts: "3",
+		wantErr:      true,
+	}, {
+		desc:     "not retried without retries",
+		failures: 1,
+		wantErr:  true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			terminationPath := filepath.Join(dir, "termination")
+			runner := &fakeFlakyRunner{failures: c.failures}
+			err := Entrypointer{
+				Command:         []string{"npm", "install"},
+				PostFile:        filepath.Join(dir, "out"),
+				Waiter:          &fakeWaiter{},
+				Runner:          runner,
+				PostWriter:      &fakePostWriter{},
+				TerminationPath: terminationPath,
+				StepMetadataDir: dir,
+				Retries:         c.retries,
+			}.Go()
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Expected error %t but got %v", c.wantErr, err)
+			}
+			wantRuns := c.retries + 1
+			if c.failures < c.retries {
+				wantRuns = c.failures + 1
+			}
+			if runner.attempts != wantRuns {
+				t.Errorf("Expected the command to run %d times but it ran %d times", wantRuns, runner.attempts)
+			}
+
+			msg, err := ioutil.ReadFile(terminationPath)
+			if err != nil {
+				t.Fatalf("Error reading termination message: %v", err)
+			}
+			var entries []v1beta1.PipelineResourceResult
+			if err := json.Unmarshal(msg, &entries); err != nil {
+				t.Fatalf("Error parsing termination message: %v", err)
+			}
+			var gotAttempts string
+			for _, entry := range entries {
+				if entry.Key == "Attempts" {
+					gotAttempts = entry.Value
+				}
+			}
+			if gotAttempts != c.wantAttempts {
+				t.Errorf("Expected %q attempts in the termination message but got %q", c.wantAttempts, gotAttempts)
+			}
+		})
+	}
+}
+
+func TestEntrypointer_RetriesClearResults(t *testing.T) {
+	resultDir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(resultDir, "kept"), []byte("before"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	runner := &fakeFlakyRunner{failures: 2, resultDir: resultDir}
+	attempts, err := Entrypointer{
+		Runner:  runner,
+		Results: []string{"kept", "cleared"},
+		Retries: 2,
+	}.runWithRetries(context.Background(), resultDir)
+	if err != nil {
+		t.Fatalf("Expected the step to succeed on its last attempt but got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts but got %d", attempts)
+	}
+	if kept, err := ioutil.ReadFile(filepath.Join(resultDir, "kept")); err != nil || string(kept) != "before" {
+		t.Errorf("Expected the result written before the step to be restored but got %q, %v", kept, err)
+	}
+	if _, err := os.Stat(filepath.Join(resultDir, "cleared")); !os.IsNotExist(err) {
+		t.Errorf("Expected the result written by a failed attempt to be cleared but got %v", err)
+	}
+}
+
+func TestEntrypointer_RetriesClearStepResults(t *testing.T) {
+	stepMetadataDir := t.TempDir()
+	stepResultDir := filepath.Join(stepMetadataDir, stepResultsDir)
+	if err := os.MkdirAll(stepResultDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	runner := &fakeFlakyRunner{failures: 1, stepResultDir: stepResultDir}
+	if _, err := (Entrypointer{
+		Runner:          runner,
+		StepMetadataDir: stepMetadataDir,
+		StepResults:     []string{"digest"},
+		Retries:         1,
+	}).runWithRetries(context.Background(), t.TempDir()); err != nil {
+		t.Fatalf("Expected the step to succeed on its last attempt but got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(stepResultDir, "digest")); !os.IsNotExist(err) {
+		t.Errorf("Expected the step result written by a failed attempt to be cleared but got %v", err)
+	}
+}
+
 func TestEntrypointer_OnError(t *testing.T) {
 	for _, c := range []struct {
 		desc, postFile, onError string
@@ -441,6 +705,76 @@ func (f *fakeWaiter) Wait(file string, _ bool, _ bool) error {
 	return nil
 }
 
+// fakeBreakpointWaiter continues from breakpoints with the given exit code, as the debug scripts do.
+type fakeBreakpointWaiter struct {
+	exitCode int
+	paused   []string
+}
+
+func (f *fakeBreakpointWaiter) Wait(file string, _ bool, _ bool) error {
+	if !strings.HasSuffix(file, BreakpointExitSuffix) {
+		return nil
+	}
+	position, err := ioutil.ReadFile(strings.TrimSuffix(file, BreakpointExitSuffix) + BreakpointMarkerSuffix)
+	if err != nil {
+		return err
+	}
+	f.paused = append(f.paused, string(position))
+	return ioutil.WriteFile(file, []byte(fmt.Sprintf("%d", f.exitCode)), 0600)
+}
+
+// fakeBreakpointPostWriter writes the breakpoint markers and records the post files.
+type fakeBreakpointPostWriter struct{ postFiles []string }
+
+func (f *fakeBreakpointPostWriter) Write(file, content string) {
+	if strings.HasSuffix(file, BreakpointMarkerSuffix) {
+		if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+			panic(err)
+		}
+		return
+	}
+	if filepath.Base(file) != "exitCode" {
+		f.postFiles = append(f.postFiles, file)
+	}
+}
+
+// fakeTerminatedRunner runs until its context is done and reports it exited gracefully.
+type fakeTerminatedRunner struct{}
+
+func (f *fakeTerminatedRunner) Run(ctx context.Context, args ...string) error {
+	<-ctx.Done()
+	return TerminationError{Err: ctx.Err(), Graceful: true}
+}
+
+// fakeFlakyRunner exits with a non-zero exit code the given number of times, writing results
+// in resultDir when it does, and then succeeds.
+type fakeFlakyRunner struct {
+	failures      int
+	attempts      int
+	resultDir     string
+	stepResultDir string
+}
+
+func (f *fakeFlakyRunner) Run(ctx context.Context, args ...string) error {
+	f.attempts++
+	if f.attempts > f.failures {
+		return nil
+	}
+	if f.resultDir != "" {
+		for _, result := range []string{"kept", "cleared"} {
+			if err := ioutil.WriteFile(filepath.Join(f.resultDir, result), []byte("failed"), 0666); err != nil {
+				return err
+			}
+		}
+	}
+	if f.stepResultDir != "" {
+		if err := ioutil.WriteFile(filepath.Join(f.stepResultDir, "digest"), []byte("failed"), 0666); err != nil {
+			return err
+		}
+	}
+	return exec.Command("ls", "/bogus/path").Run()
+}
+
 type fakeRunner struct{ args *[]string }
 
 func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// MaxEnvSize is the maximum size in bytes of the environment variables exported by the steps
+// of a TaskRun to their next steps.
+const MaxEnvSize = 64 * 1024
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// stepEnv is a list of environment variables in the order they were first exported.
+type stepEnv struct {
+	names  []string
+	values map[string]string
+}
+
+func (env *stepEnv) set(name, value string) {
+	if env.values == nil {
+		env.values = map[string]string{}
+	}
+	if _, ok := env.values[name]; !ok {
+		env.names = append(env.names, name)
+	}
+	env.values[name] = value
+}
+
+func (env *stepEnv) String() string {
+	var sb strings.Builder
+	for _, name := range env.names {
+		fmt.Fprintf(&sb, "%s=%s\n", name, env.values[name])
+	}
+	return sb.String()
+}
+
+// readEnvFile adds the KEY=VALUE lines of the given file to env. Empty lines and lines starting
+// with # are ignored. A missing file is an empty one.
+func readEnvFile(path string, env *stepEnv) error {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if len(content) > MaxEnvSize {
+		return fmt.Errorf("env file %s is larger than %d bytes", path, MaxEnvSize)
+	}
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d of env file %s is not of the form KEY=VALUE", i+1, path)
+		}
+		if !envNameRegex.MatchString(name) {
+			return fmt.Errorf("line %d of env file %s exports the invalid environment variable name %q", i+1, path, name)
+		}
+		env.set(name, value)
+	}
+	return nil
+}
+
+// loadEnv sets the environment variables exported by the previous steps in the environment of
+// the command.
+func (e Entrypointer) loadEnv() error {
+	var env stepEnv
+	if err := readEnvFile(e.ImportEnvFile, &env); err != nil {
+		return fmt.Errorf("loading the environment exported by the previous steps: %w", err)
+	}
+	for _, name := range env.names {
+		if err := os.Setenv(name, env.values[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportEnv writes the environment variables exported by the previous steps and by this one,
+// which override them, for the next steps to load.
+func (e Entrypointer) exportEnv() error {
+	var env stepEnv
+	if err := readEnvFile(e.ImportEnvFile, &env); err != nil {
+		return fmt.Errorf("loading the environment exported by the previous steps: %w", err)
+	}
+	if err := readEnvFile(e.EnvFile, &env); err != nil {
+		return fmt.Errorf("loading the environment exported by the step: %w", err)
+	}
+	content := env.String()
+	if len(content) > MaxEnvSize {
+		return fmt.Errorf("the environment exported by the steps is larger than %d bytes", MaxEnvSize)
+	}
+	return ioutil.WriteFile(e.ExportEnvFile, []byte(content), 0666)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestReadEnvFile(t *testing.T) {
+	for _, c := range []struct {
+		desc    string
+		content string
+		want    string
+		wantErr bool
+	}{{
+		desc:    "variables",
+		content: "VERSION=v1.2.3\n# comment\n\nFLAGS=-a=b -c\nVERSION=v1.2.4\n",
+		want:    "VERSION=v1.2.4\nFLAGS=-a=b -c\n",
+	}, {
+		desc:    "empty value",
+		content: "EMPTY=",
+		want:    "EMPTY=\n",
+	}, {
+		desc:    "not a variable",
+		content: "VERSION",
+		wantErr: true,
+	}, {
+		desc:    "invalid name",
+		content: "MY-VERSION=v1",
+		wantErr: true,
+	}, {
+		desc:    "name starting with a digit",
+		content: "1VERSION=v1",
+		wantErr: true,
+	}, {
+		desc:    "too large",
+		content: "BIG=" + strings.Repeat("a", MaxEnvSize),
+		wantErr: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "env")
+			if err := ioutil.WriteFile(path, []byte(c.content), 0666); err != nil {
+				t.Fatal(err)
+			}
+			var env stepEnv
+			err := readEnvFile(path, &env)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Expected error %t but got %v", c.wantErr, err)
+			}
+			if d := cmp.Diff(c.want, env.String()); !c.wantErr && d != "" {
+				t.Errorf("Unexpected env %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEntrypointer_ExportEnv(t *testing.T) {
+	dir := t.TempDir()
+	importEnvFile := filepath.Join(dir, "env.previous")
+	envFile := filepath.Join(dir, "env")
+	exportEnvFile := filepath.Join(dir, "env.all")
+	if err := ioutil.WriteFile(importEnvFile, []byte("VERSION=v1\nTEKTON_TEST_COMMIT=abc\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VERSION", "")
+	t.Setenv("TEKTON_TEST_COMMIT", "")
+
+	runner := &fakeEnvRunner{envFile: envFile, export: "VERSION=v2\nTEKTON_TEST_ARCH=amd64\n"}
+	if err := (Entrypointer{
+		Command:         []string{"git", "describe"},
+		PostFile:        filepath.Join(dir, "out"),
+		TerminationPath: filepath.Join(dir, "termination"),
+		Waiter:          &fakeWaiter{},
+		Runner:          runner,
+		PostWriter:      &fakePostWriter{},
+		EnvFile:         envFile,
+		ImportEnvFile:   importEnvFile,
+		ExportEnvFile:   exportEnvFile,
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	if runner.version != "v1" {
+		t.Errorf("Expected the command to run with the VERSION exported by the previous steps but got %q", runner.version)
+	}
+	exported, err := ioutil.ReadFile(exportEnvFile)
+	if err != nil {
+		t.Fatalf("Error reading the exported env: %v", err)
+	}
+	want := "VERSION=v2\nTEKTON_TEST_COMMIT=abc\nTEKTON_TEST_ARCH=amd64\n"
+	if d := cmp.Diff(want, string(exported)); d != "" {
+		t.Errorf("Unexpected exported env %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntrypointer_ExportInvalidEnv(t *testing.T) {
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, "env")
+	fpw := &fakePostWriter{}
+	err := Entrypointer{
+		Command:         []string{"git", "describe"},
+		PostFile:        filepath.Join(dir, "out"),
+		TerminationPath: filepath.Join(dir, "termination"),
+		Waiter:          &fakeWaiter{},
+		Runner:          &fakeEnvRunner{envFile: envFile, export: "NOT A VARIABLE\n"},
+		PostWriter:      fpw,
+		EnvFile:         envFile,
+		ExportEnvFile:   filepath.Join(dir, "env.all"),
+	}.Go()
+	if err == nil {
+		t.Fatal("Expected the step exporting an invalid env to fail")
+	}
+	if fpw.wrote == nil || *fpw.wrote != filepath.Join(dir, "out.err") {
+		t.Errorf("Wanted post file %q written, got %v", filepath.Join(dir, "out.err"), fpw.wrote)
+	}
+}
+
+// fakeEnvRunner records the VERSION environment variable and exports the given env.
+type fakeEnvRunner struct {
+	envFile string
+	export  string
+	version string
+}
+
+func (f *fakeEnvRunner) Run(ctx context.Context, args ...string) error {
+	f.version = os.Getenv("VERSION")
+	return ioutil.WriteFile(f.envFile, []byte(f.export), 0666)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/internal/stepresults"
+)
+
+// stepResultsDir is the directory in the metadata directory of a step where it writes its results.
+const stepResultsDir = "results"
+
+// stepResultPath returns the path of the file where a step writes one of its results. The metadata
+// directory of a step is named after its container.
+func stepResultPath(stepsDir string, ref stepresults.Ref) string {
+	return filepath.Join(stepsDir, "step-"+ref.Step, stepResultsDir, ref.Result)
+}
+
+// resolveStepResultRefs replaces the references to the results of the previous steps in the
+// command and in the environment with their values. A reference to a whole array result expands
+// to its elements when it is a whole argument.
+func (e Entrypointer) resolveStepResultRefs() ([]string, error) {
+	var command []string
+	for _, arg := range e.Command {
+		if refs := stepresults.ParseRefs(arg); len(refs) == 1 && refs[0].Expression == arg && refs[0].Index == "*" {
+			values, err := readArrayStepResult(e.StepsDir, refs[0])
+			if err != nil {
+				return nil, err
+			}
+			command = append(command, values...)
+			continue
+		}
+		resolved, err := stepresults.ReplaceRefs(arg, func(ref stepresults.Ref) (string, error) {
+			return readStepResult(e.StepsDir, ref)
+		})
+		if err != nil {
+			return nil, err
+		}
+		command = append(command, resolved)
+	}
+
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if len(stepresults.ParseRefs(value)) == 0 {
+			continue
+		}
+		resolved, err := stepresults.ReplaceRefs(value, func(ref stepresults.Ref) (string, error) {
+			return readStepResult(e.StepsDir, ref)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Setenv(name, resolved); err != nil {
+			return nil, err
+		}
+	}
+	return command, nil
+}
+
+// readStepResult returns the value of a string result, or of an element of an array or object result.
+func readStepResult(stepsDir string, ref stepresults.Ref) (string, error) {
+	switch {
+	case ref.Index == "*":
+		return "", fmt.Errorf("%s can only be used as a whole argument", ref.Expression)
+	case ref.Index != "":
+		values, err := readArrayStepResult(stepsDir, ref)
+		if err != nil {
+			return "", err
+		}
+		i, _ := strconv.Atoi(ref.Index)
+		if i >= len(values) {
+			return "", fmt.Errorf("%s is out of the bounds of the array result of length %d", ref.Expression, len(values))
+		}
+		return values[i], nil
+	case ref.Key != "":
+		content, err := readStepResultFile(stepsDir, ref)
+		if err != nil {
+			return "", err
+		}
+		object := map[string]string{}
+		if err := json.Unmarshal(content, &object); err != nil {
+			return "", fmt.Errorf("%s doesn't reference an object result: %w", ref.Expression, err)
+		}
+		value, ok := object[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("%s references a key missing from the object result", ref.Expression)
+		}
+		return value, nil
+	default:
+		content, err := readStepResultFile(stepsDir, ref)
+		return string(content), err
+	}
+}
+
+func readArrayStepResult(stepsDir string, ref stepresults.Ref) ([]string, error) {
+	content, err := readStepResultFile(stepsDir, ref)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("%s doesn't reference an array result: %w", ref.Expression, err)
+	}
+	return values, nil
+}
+
+func readStepResultFile(stepsDir string, ref stepresults.Ref) ([]byte, error) {
+	content, err := ioutil.ReadFile(stepResultPath(stepsDir, ref))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s references a result which step %q didn't write", ref.Expression, ref.Step)
+	}
+	return content, err
+}
+
+// promoteStepResults copies the step results promoted to task results to the directory of the
+// task results. Step results which weren't written aren't promoted.
+func (e Entrypointer) promoteStepResults(resultDir string) error {
+	for taskResult, stepResult := range e.PromotedResults {
+		content, err := ioutil.ReadFile(filepath.Join(e.StepMetadataDir, stepResultsDir, stepResult))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(resultDir, taskResult), content, 0666); err != nil {
+			return fmt.Errorf("promoting step result %q to task result %q: %w", stepResult, taskResult, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func writeStepResults(t *testing.T, stepsDir, step string, results map[string]string) {
+	t.Helper()
+	dir := filepath.Join(stepsDir, "step-"+step, "results")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range results {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEntrypointer_ResolveStepResultRefs(t *testing.T) {
+	dir := t.TempDir()
+	stepsDir := filepath.Join(dir, "steps")
+	writeStepResults(t, stepsDir, "build", map[string]string{
+		"digest": "sha256:abc",
+		"tags":   `["v1","latest"]`,
+		"image":  `{"url":"gcr.io/foo/bar"}`,
+	})
+	t.Setenv("TEKTON_TEST_IMAGE", "$(steps.build.results.image.url)@$(steps.build.results.digest)")
+	t.Setenv("TEKTON_TEST_TAG", "$(steps.build.results.tags[1])")
+
+	runner := &fakeRunner{}
+	if err := (Entrypointer{
+		Command:         []string{"push", "--digest=$(steps.build.results.digest)", "$(steps.build.results.tags[*])", "$(steps.exitCode.path)"},
+		PostFile:        filepath.Join(dir, "out"),
+		TerminationPath: filepath.Join(dir, "termination"),
+		Waiter:          &fakeWaiter{},
+		Runner:          runner,
+		PostWriter:      &fakePostWriter{},
+		StepsDir:        stepsDir,
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	want := []string{"push", "--digest=sha256:abc", "v1", "latest", "$(steps.exitCode.path)"}
+	if d := cmp.Diff(want, *runner.args); d != "" {
+		t.Errorf("Unexpected command %s", diff.PrintWantGot(d))
+	}
+	if got := os.Getenv("TEKTON_TEST_IMAGE"); got != "gcr.io/foo/bar@sha256:abc" {
+		t.Errorf("Unexpected TEKTON_TEST_IMAGE %q", got)
+	}
+	if got := os.Getenv("TEKTON_TEST_TAG"); got != "latest" {
+		t.Errorf("Unexpected TEKTON_TEST_TAG %q", got)
+	}
+}
+
+func TestEntrypointer_ResolveStepResultRefsError(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		arg  string
+	}{{
+		desc: "result not written",
+		arg:  "$(steps.build.results.missing)",
+	}, {
+		desc: "index out of bounds",
+		arg:  "$(steps.build.results.tags[2])",
+	}, {
+		desc: "whole array in a string",
+		arg:  "--tags=$(steps.build.results.tags[*])",
+	}, {
+		desc: "missing key",
+		arg:  "$(steps.build.results.image.digest)",
+	}, {
+		desc: "key of a string result",
+		arg:  "$(steps.build.results.digest.url)",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			stepsDir := filepath.Join(dir, "steps")
+			writeStepResults(t, stepsDir, "build", map[string]string{
+				"digest": "sha256:abc",
+				"tags":   `["v1","latest"]`,
+				"image":  `{"url":"gcr.io/foo/bar"}`,
+			})
+			runner := &fakeRunner{}
+			fpw := &fakePostWriter{}
+			if err := (Entrypointer{
+				Command:         []string{"push", c.arg},
+				PostFile:        filepath.Join(dir, "out"),
+				TerminationPath: filepath.Join(dir, "termination"),
+				Waiter:          &fakeWaiter{},
+				Runner:          runner,
+				PostWriter:      fpw,
+				StepsDir:        stepsDir,
+			}).Go(); err == nil {
+				t.Fatal("Expected resolving the step result reference to fail")
+			}
+			if runner.args != nil {
+				t.Errorf("Expected the command not to run but it ran with %v", *runner.args)
+			}
+			if fpw.wrote == nil || *fpw.wrote != filepath.Join(dir, "out.err") {
+				t.Errorf("Wanted post file %q written, got %v", filepath.Join(dir, "out.err"), fpw.wrote)
+			}
+		})
+	}
+}
+
+func TestEntrypointer_PromoteStepResults(t *testing.T) {
+	dir := t.TempDir()
+	stepsDir := filepath.Join(dir, "steps")
+	resultDir := filepath.Join(dir, "results")
+	if err := os.MkdirAll(resultDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	writeStepResults(t, stepsDir, "build", map[string]string{"image-digest": "sha256:abc"})
+
+	if err := (Entrypointer{
+		StepMetadataDir: filepath.Join(stepsDir, "step-build"),
+		PromotedResults: map[string]string{"digest": "image-digest", "tags": "tags"},
+	}).promoteStepResults(resultDir); err != nil {
+		t.Fatalf("Error promoting the step results: %v", err)
+	}
+
+	got, err := ioutil.ReadFile(filepath.Join(resultDir, "digest"))
+	if err != nil {
+		t.Fatalf("Error reading the promoted task result: %v", err)
+	}
+	if string(got) != "sha256:abc" {
+		t.Errorf("Expected the promoted task result sha256:abc but got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(resultDir, "tags")); !os.IsNotExist(err) {
+		t.Errorf("Expected the step result which wasn't written not to be promoted but got %v", err)
+	}
+}
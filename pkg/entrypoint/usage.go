@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceUsageFile is the name of the file in the metadata directory of a step where the
+// summary of the resources it used is written.
+const ResourceUsageFile = "resource-usage.json"
+
+// ResourceUsage is a sample of the resources used by the container of a step since it started.
+type ResourceUsage struct {
+	// MemoryBytes is the memory usage of the container, or its highest memory usage if known.
+	MemoryBytes int64
+	// CPUTime is the CPU time used by the container.
+	CPUTime time.Duration
+	// IOReadBytes is the number of bytes the container read from block devices.
+	IOReadBytes int64
+	// IOWriteBytes is the number of bytes the container wrote to block devices.
+	IOWriteBytes int64
+}
+
+// UsageSampler samples the resources used by the container of a step.
+type UsageSampler interface {
+	Sample() (ResourceUsage, error)
+}
+
+// sampleUsage samples the resources used by the container every UsageSamplingInterval until
+// done is closed, and returns the last sample with the highest memory usage sampled, or nil if
+// no sample could be taken.
+func (e Entrypointer) sampleUsage(done <-chan struct{}) *v1beta1.StepResourceUsage {
+	var last *ResourceUsage
+	var peakMemory int64
+	sample := func() {
+		usage, err := e.UsageSampler.Sample()
+		if err != nil {
+			return
+		}
+		if usage.MemoryBytes > peakMemory {
+			peakMemory = usage.MemoryBytes
+		}
+		last = &usage
+	}
+
+	ticker := time.NewTicker(e.UsageSamplingInterval)
+	defer ticker.Stop()
+	for sample(); ; sample() {
+		select {
+		case <-done:
+			sample()
+			if last == nil {
+				return nil
+			}
+			return &v1beta1.StepResourceUsage{
+				PeakMemory:   *resource.NewQuantity(peakMemory, resource.BinarySI),
+				CPUTime:      metav1.Duration{Duration: last.CPUTime},
+				IOReadBytes:  last.IOReadBytes,
+				IOWriteBytes: last.IOWriteBytes,
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// startSamplingUsage starts sampling the resources used by the container of the step if it is
+// enabled, and returns the function stopping it, which returns the summary of the samples.
+func (e Entrypointer) startSamplingUsage() func() *v1beta1.StepResourceUsage {
+	if e.UsageSampler == nil || e.UsageSamplingInterval <= 0 {
+		return func() *v1beta1.StepResourceUsage { return nil }
+	}
+	done := make(chan struct{})
+	usage := make(chan *v1beta1.StepResourceUsage, 1)
+	go func() {
+		usage <- e.sampleUsage(done)
+	}()
+	return func() *v1beta1.StepResourceUsage {
+		close(done)
+		return <-usage
+	}
+}
+
+// reportUsage adds the summary of the resources used by the step to its termination message,
+// and writes it in the metadata directory of the step.
+func (e Entrypointer) reportUsage(usage *v1beta1.StepResourceUsage, output []v1beta1.PipelineResourceResult) []v1beta1.PipelineResourceResult {
+	if usage == nil {
+		return output
+	}
+	content, err := json.Marshal(usage)
+	if err != nil {
+		return output
+	}
+	e.PostWriter.Write(filepath.Join(e.StepMetadataDir, ResourceUsageFile), string(content))
+	return append(output, v1beta1.PipelineResourceResult{
+		Key:        "ResourceUsage",
+		Value:      string(content),
+		ResultType: v1beta1.InternalTektonResultType,
+	})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEntrypointer_ReportsResourceUsage(t *testing.T) {
+	dir := t.TempDir()
+	terminationPath := filepath.Join(dir, "termination")
+	sampler := &fakeUsageSampler{samples: []ResourceUsage{
+		{MemoryBytes: 100, CPUTime: time.Second},
+		{MemoryBytes: 300, CPUTime: 2 * time.Second, IOReadBytes: 10},
+		{MemoryBytes: 200, CPUTime: 3 * time.Second, IOReadBytes: 20, IOWriteBytes: 30},
+	}}
+	fpw := &fakeWritesPostWriter{}
+	if err := (Entrypointer{
+		Command:               []string{"go", "build"},
+		PostFile:              filepath.Join(dir, "out"),
+		TerminationPath:       terminationPath,
+		Waiter:                &fakeWaiter{},
+		Runner:                &fakeSlowRunner{sampler: sampler, samples: 2},
+		PostWriter:            fpw,
+		StepMetadataDir:       dir,
+		UsageSampler:          sampler,
+		UsageSamplingInterval: time.Millisecond,
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	want := v1beta1.StepResourceUsage{
+		PeakMemory:   *resource.NewQuantity(300, resource.BinarySI),
+		CPUTime:      metav1.Duration{Duration: 3 * time.Second},
+		IOReadBytes:  20,
+		IOWriteBytes: 30,
+	}
+	msg, err := ioutil.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatalf("Error reading termination message: %v", err)
+	}
+	var entries []v1beta1.PipelineResourceResult
+	if err := json.Unmarshal(msg, &entries); err != nil {
+		t.Fatalf("Error parsing termination message: %v", err)
+	}
+	var got v1beta1.StepResourceUsage
+	for _, entry := range entries {
+		if entry.Key == "ResourceUsage" {
+			if err := json.Unmarshal([]byte(entry.Value), &got); err != nil {
+				t.Fatalf("Error parsing the resource usage: %v", err)
+			}
+		}
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected resource usage in the termination message %s", diff.PrintWantGot(d))
+	}
+	if _, ok := fpw.writes[filepath.Join(dir, ResourceUsageFile)]; !ok {
+		t.Errorf("Expected the resource usage to be written in the step metadata dir but got writes %v", fpw.writes)
+	}
+}
+
+func TestEntrypointer_ResourceUsageNotSampled(t *testing.T) {
+	dir := t.TempDir()
+	fpw := &fakeWritesPostWriter{}
+	if err := (Entrypointer{
+		Command:               []string{"go", "build"},
+		PostFile:              filepath.Join(dir, "out"),
+		TerminationPath:       filepath.Join(dir, "termination"),
+		Waiter:                &fakeWaiter{},
+		Runner:                &fakeRunner{},
+		PostWriter:            fpw,
+		StepMetadataDir:       dir,
+		UsageSampler:          &fakeUsageSampler{},
+		UsageSamplingInterval: time.Millisecond,
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+	if _, ok := fpw.writes[filepath.Join(dir, ResourceUsageFile)]; ok {
+		t.Error("Expected no resource usage to be reported when it can't be sampled")
+	}
+}
+
+// fakeUsageSampler returns the given samples in order, then the last one, and an error if there
+// are none.
+type fakeUsageSampler struct {
+	mutex   sync.Mutex
+	samples []ResourceUsage
+	sampled int
+}
+
+func (f *fakeUsageSampler) Sample() (ResourceUsage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if len(f.samples) == 0 {
+		return ResourceUsage{}, errors.New("no cgroup")
+	}
+	i := f.sampled
+	if i >= len(f.samples) {
+		i = len(f.samples) - 1
+	}
+	f.sampled++
+	return f.samples[i], nil
+}
+
+func (f *fakeUsageSampler) count() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.sampled
+}
+
+// fakeSlowRunner runs until the sampler has taken the given number of samples.
+type fakeSlowRunner struct {
+	sampler *fakeUsageSampler
+	samples int
+}
+
+func (f *fakeSlowRunner) Run(ctx context.Context, args ...string) error {
+	for f.sampler.count() < f.samples {
+		time.Sleep(time.Millisecond)
+	}
+	return nil
+}
+
+// fakeWritesPostWriter records all the files written.
+type fakeWritesPostWriter struct {
+	writes map[string]string
+}
+
+func (f *fakeWritesPostWriter) Write(file, content string) {
+	if f.writes == nil {
+		f.writes = map[string]string{}
+	}
+	f.writes[file] = content
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// computeResources are the compute resources a Pod is charged for by a ResourceQuota, along with the
+// quota resource names tracking their requests and limits.
+var computeResources = []struct {
+	name     corev1.ResourceName
+	requests []corev1.ResourceName
+	limits   corev1.ResourceName
+}{{
+	name:     corev1.ResourceCPU,
+	requests: []corev1.ResourceName{corev1.ResourceRequestsCPU, corev1.ResourceCPU},
+	limits:   corev1.ResourceLimitsCPU,
+}, {
+	name:     corev1.ResourceMemory,
+	requests: []corev1.ResourceName{corev1.ResourceRequestsMemory, corev1.ResourceMemory},
+	limits:   corev1.ResourceLimitsMemory,
+}, {
+	name:     corev1.ResourceEphemeralStorage,
+	requests: []corev1.ResourceName{corev1.ResourceRequestsEphemeralStorage, corev1.ResourceEphemeralStorage},
+	limits:   corev1.ResourceLimitsEphemeralStorage,
+}}
+
+// PodUsage returns the resources a ResourceQuota charges the Pod with, the same way the Kubernetes quota
+// admission does: the effective request (or limit) of a Pod is the larger of the sum over its containers
+// and the maximum over its init containers, plus the Pod overhead. The Pod is expected to have gone
+// through the compute resources transformer already, so that the defaults of the namespace LimitRanges
+// are accounted for.
+func PodUsage(pod *corev1.Pod) corev1.ResourceList {
+	usage := corev1.ResourceList{
+		corev1.ResourcePods:               resource.MustParse("1"),
+		corev1.ResourceName("count/pods"): resource.MustParse("1"),
+	}
+	for _, r := range computeResources {
+		if requests, ok := effective(pod, r.name, containerRequest); ok {
+			for _, name := range r.requests {
+				usage[name] = requests
+			}
+		}
+		if limits, ok := effective(pod, r.name, containerLimit); ok {
+			usage[r.limits] = limits
+		}
+	}
+	return usage
+}
+
+// Fits returns whether a Pod charged with usage can be created without exceeding any of the quotas which
+// apply to it. If it can't, the returned message describes the first exhausted resource.
+func Fits(pod *corev1.Pod, usage corev1.ResourceList, quotas []*corev1.ResourceQuota) (bool, string) {
+	for _, q := range quotas {
+		if !matchesScopes(pod, q) {
+			continue
+		}
+		hard := q.Status.Hard
+		if hard == nil {
+			hard = q.Spec.Hard
+		}
+		names := make([]string, 0, len(hard))
+		for name := range hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			name := corev1.ResourceName(n)
+			requested, ok := usage[name]
+			if !ok {
+				continue
+			}
+			used := q.Status.Used[name]
+			total := used.DeepCopy()
+			total.Add(requested)
+			if limit := hard[name]; total.Cmp(limit) > 0 {
+				return false, fmt.Sprintf("exceeded quota: %s, requested: %s=%s, used: %s=%s, limited: %s=%s",
+					q.Name, name, requested.String(), name, used.String(), name, limit.String())
+			}
+		}
+	}
+	return true, ""
+}
+
+func containerRequest(c corev1.Container, name corev1.ResourceName) (resource.Quantity, bool) {
+	if q, ok := c.Resources.Requests[name]; ok {
+		return q, true
+	}
+	// Kubernetes defaults the requests of a container to its limits.
+	q, ok := c.Resources.Limits[name]
+	return q, ok
+}
+
+func containerLimit(c corev1.Container, name corev1.ResourceName) (resource.Quantity, bool) {
+	q, ok := c.Resources.Limits[name]
+	return q, ok
+}
+
+// effective returns the effective value of the resource of the Pod, using get to read it from each
+// container, and whether any container sets it.
+func effective(pod *corev1.Pod, name corev1.ResourceName, get func(corev1.Container, corev1.ResourceName) (resource.Quantity, bool)) (resource.Quantity, bool) {
+	var sum resource.Quantity
+	found := false
+	for _, c := range pod.Spec.Containers {
+		if q, ok := get(c, name); ok {
+			sum.Add(q)
+			found = true
+		}
+	}
+	for _, c := range pod.Spec.InitContainers {
+		if q, ok := get(c, name); ok {
+			if q.Cmp(sum) > 0 {
+				sum = q.DeepCopy()
+			}
+			found = true
+		}
+	}
+	if overhead, ok := pod.Spec.Overhead[name]; ok && found {
+		sum.Add(overhead)
+	}
+	return sum, found
+}
+
+// matchesScopes returns whether the quota applies to the Pod. Scopes which can't be evaluated against the
+// Pod alone are assumed to match, so that the Pod waits rather than being rejected by the API server.
+func matchesScopes(pod *corev1.Pod, q *corev1.ResourceQuota) bool {
+	for _, scope := range q.Spec.Scopes {
+		if !matchesScope(pod, corev1.ScopedResourceSelectorRequirement{ScopeName: scope, Operator: corev1.ScopeSelectorOpExists}) {
+			return false
+		}
+	}
+	if q.Spec.ScopeSelector != nil {
+		for _, req := range q.Spec.ScopeSelector.MatchExpressions {
+			if !matchesScope(pod, req) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchesScope(pod *corev1.Pod, req corev1.ScopedResourceSelectorRequirement) bool {
+	var matches bool
+	switch req.ScopeName {
+	case corev1.ResourceQuotaScopeTerminating:
+		matches = pod.Spec.ActiveDeadlineSeconds != nil
+	case corev1.ResourceQuotaScopeNotTerminating:
+		matches = pod.Spec.ActiveDeadlineSeconds == nil
+	case corev1.ResourceQuotaScopeBestEffort:
+		matches = isBestEffort(pod)
+	case corev1.ResourceQuotaScopeNotBestEffort:
+		matches = !isBestEffort(pod)
+	case corev1.ResourceQuotaScopePriorityClass:
+		switch req.Operator {
+		case corev1.ScopeSelectorOpExists:
+			return pod.Spec.PriorityClassName != ""
+		case corev1.ScopeSelectorOpDoesNotExist:
+			return pod.Spec.PriorityClassName == ""
+		case corev1.ScopeSelectorOpIn, corev1.ScopeSelectorOpNotIn:
+			in := false
+			for _, v := range req.Values {
+				if v == pod.Spec.PriorityClassName {
+					in = true
+				}
+			}
+			return in == (req.Operator == corev1.ScopeSelectorOpIn)
+		}
+		return true
+	default:
+		return true
+	}
+	if req.Operator == corev1.ScopeSelectorOpDoesNotExist {
+		return !matches
+	}
+	return matches
+}
+
+func isBestEffort(pod *corev1.Pod) bool {
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, c := range containers {
+			if len(c.Resources.Requests) > 0 || len(c.Resources.Limits) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
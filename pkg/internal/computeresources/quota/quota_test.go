@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var resourceQuantityCmp = cmp.Comparer(func(x, y resource.Quantity) bool {
+	return x.Cmp(y) == 0
+})
+
+func container(requests, limits corev1.ResourceList) corev1.Container {
+	return corev1.Container{Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}}
+}
+
+func TestPodUsage(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		pod  corev1.PodSpec
+		want corev1.ResourceList
+	}{{
+		desc: "no requests",
+		pod:  corev1.PodSpec{Containers: []corev1.Container{{}, {}}},
+		want: corev1.ResourceList{
+			corev1.ResourcePods:               resource.MustParse("1"),
+			corev1.ResourceName("count/pods"): resource.MustParse("1"),
+		},
+	}, {
+		desc: "sum of containers",
+		pod: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				container(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}, nil),
+			},
+			Containers: []corev1.Container{
+				container(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("1Gi")}, nil),
+				container(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")}, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}),
+			},
+		},
+		want: corev1.ResourceList{
+			corev1.ResourcePods:               resource.MustParse("1"),
+			corev1.ResourceName("count/pods"): resource.MustParse("1"),
+			corev1.ResourceCPU:                resource.MustParse("750m"),
+			corev1.ResourceRequestsCPU:        resource.MustParse("750m"),
+			corev1.ResourceLimitsCPU:          resource.MustParse("1"),
+			corev1.ResourceMemory:             resource.MustParse("1Gi"),
+			corev1.ResourceRequestsMemory:     resource.MustParse("1Gi"),
+		},
+	}, {
+		desc: "init container larger than containers, with overhead",
+		pod: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				container(nil, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")}),
+			},
+			Containers: []corev1.Container{
+				container(corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}, nil),
+			},
+			Overhead: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("100Mi")},
+		},
+		want: corev1.ResourceList{
+			corev1.ResourcePods:               resource.MustParse("1"),
+			corev1.ResourceName("count/pods"): resource.MustParse("1"),
+			corev1.ResourceMemory:             resource.MustParse("2148Mi"),
+			corev1.ResourceRequestsMemory:     resource.MustParse("2148Mi"),
+			corev1.ResourceLimitsMemory:       resource.MustParse("2148Mi"),
+		},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := PodUsage(&corev1.Pod{Spec: tc.pod})
+			if d := cmp.Diff(tc.want, got, resourceQuantityCmp); d != "" {
+				t.Errorf("Unexpected usage %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestFits(t *testing.T) {
+	activeDeadlineSeconds := int64(3600)
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		ActiveDeadlineSeconds: &activeDeadlineSeconds,
+		Containers: []corev1.Container{
+			container(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}, nil),
+		},
+	}}
+	usage := PodUsage(pod)
+	quota := func(hard, used corev1.ResourceList, scopes ...corev1.ResourceQuotaScope) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+			Spec:       corev1.ResourceQuotaSpec{Hard: hard, Scopes: scopes},
+			Status:     corev1.ResourceQuotaStatus{Hard: hard, Used: used},
+		}
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		quotas  []*corev1.ResourceQuota
+		want    bool
+		wantMsg string
+	}{{
+		desc: "no quotas",
+		want: true,
+	}, {
+		desc: "enough quota left",
+		quotas: []*corev1.ResourceQuota{
+			quota(corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")}, corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")}),
+		},
+		want: true,
+	}, {
+		desc: "cpu quota exhausted",
+		quotas: []*corev1.ResourceQuota{
+			quota(corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")}, corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1500m")}),
+		},
+		want:    false,
+		wantMsg: "exceeded quota: compute, requested: requests.cpu=1, used: requests.cpu=1500m, limited: requests.cpu=2",
+	}, {
+		desc: "pod count exhausted",
+		quotas: []*corev1.ResourceQuota{
+			quota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("3")}, corev1.ResourceList{corev1.ResourcePods: resource.MustParse("3")}),
+		},
+		want:    false,
+		wantMsg: "exceeded quota: compute, requested: pods=1, used: pods=3, limited: pods=3",
+	}, {
+		desc: "untracked resource",
+		quotas: []*corev1.ResourceQuota{
+			quota(corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("1Gi")}, corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("1Gi")}),
+		},
+		want: true,
+	}, {
+		desc: "quota out of scope",
+		quotas: []*corev1.ResourceQuota{
+			quota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")}, nil, corev1.ResourceQuotaScopeNotTerminating),
+		},
+		want: true,
+	}, {
+		desc: "quota in scope",
+		quotas: []*corev1.ResourceQuota{
+			quota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")}, nil, corev1.ResourceQuotaScopeTerminating, corev1.ResourceQuotaScopeNotBestEffort),
+		},
+		want:    false,
+		wantMsg: "exceeded quota: compute, requested: pods=1, used: pods=0, limited: pods=0",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, msg := Fits(pod, usage, tc.quotas)
+			if got != tc.want {
+				t.Errorf("Fits() = %t, want %t", got, tc.want)
+			}
+			if msg != tc.wantMsg {
+				t.Errorf("Fits() message = %q, want %q", msg, tc.wantMsg)
+			}
+		})
+	}
+}
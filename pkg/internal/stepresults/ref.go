@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stepresults parses the references to the results of the steps of a task.
+package stepresults
+
+import (
+	"regexp"
+)
+
+// Ref is a reference to a result of a previous step of the same task:
+// $(steps.<stepName>.results.<resultName>), $(steps.<stepName>.results.<resultName>[*]),
+// $(steps.<stepName>.results.<resultName>[<index>]) or
+// $(steps.<stepName>.results.<resultName>.<key>).
+type Ref struct {
+	// Expression is the whole reference, e.g. $(steps.build.results.digest)
+	Expression string
+	Step       string
+	Result     string
+	// Index is "*" or the index of the referenced element of an array result
+	Index string
+	// Key is the key of the referenced element of an object result
+	Key string
+}
+
+// refFormat matches the references to the results of the steps. The step and result names
+// can't contain dots, which separate the key of an object result.
+const refFormat = `\$\(steps\.([a-z0-9][-a-z0-9]*)\.results\.([A-Za-z0-9][-A-Za-z0-9_]*)(?:\[(\*|[0-9]+)\]|\.([-A-Za-z0-9_]+))?\)`
+
+var refRegex = regexp.MustCompile(refFormat)
+
+// ParseRefs returns the references to the results of the steps in the given string.
+func ParseRefs(s string) []Ref {
+	var refs []Ref
+	for _, m := range refRegex.FindAllStringSubmatch(s, -1) {
+		refs = append(refs, Ref{
+			Expression: m[0],
+			Step:       m[1],
+			Result:     m[2],
+			Index:      m[3],
+			Key:        m[4],
+		})
+	}
+	return refs
+}
+
+// ReplaceRefs replaces the references to the results of the steps in the given string with
+// the values returned by replace.
+func ReplaceRefs(s string, replace func(Ref) (string, error)) (string, error) {
+	var err error
+	replaced := refRegex.ReplaceAllStringFunc(s, func(expression string) string {
+		if err != nil {
+			return expression
+		}
+		var value string
+		value, err = replace(ParseRefs(expression)[0])
+		return value
+	})
+	return replaced, err
+}
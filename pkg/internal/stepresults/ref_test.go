@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepresults_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/internal/stepresults"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestParseRefs(t *testing.T) {
+	got := stepresults.ParseRefs("$(steps.build.results.digest) $(steps.build.results.tags[*]) $(steps.build.results.tags[1]) " +
+		"$(steps.build.results.image.url) $(steps.step-build.exitCode.path) $(tasks.build.results.digest)")
+	want := []stepresults.Ref{{
+		Expression: "$(steps.build.results.digest)",
+		Step:       "build",
+		Result:     "digest",
+	}, {
+		Expression: "$(steps.build.results.tags[*])",
+		Step:       "build",
+		Result:     "tags",
+		Index:      "*",
+	}, {
+		Expression: "$(steps.build.results.tags[1])",
+		Step:       "build",
+		Result:     "tags",
+		Index:      "1",
+	}, {
+		Expression: "$(steps.build.results.image.url)",
+		Step:       "build",
+		Result:     "image",
+		Key:        "url",
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected references %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReplaceRefs(t *testing.T) {
+	got, err := stepresults.ReplaceRefs("$(steps.build.results.image.url)@$(steps.build.results.digest)", func(ref stepresults.Ref) (string, error) {
+		return ref.Result + "/" + ref.Key, nil
+	})
+	if err != nil {
+		t.Fatalf("Error replacing the references: %v", err)
+	}
+	if want := "image/url@digest/"; got != want {
+		t.Errorf("Expected %q but got %q", want, got)
+	}
+}
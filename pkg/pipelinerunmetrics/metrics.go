@@ -43,6 +43,8 @@ var (
 	pipelineTag    = tag.MustNewKey("pipeline")
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
+	reasonTag      = tag.MustNewKey("reason")
+	priorityTag    = tag.MustNewKey("priority_class")
 
 	prDuration = stats.Float64(
 		"pipelinerun_duration_seconds",
@@ -59,6 +61,16 @@ var (
 		"Number of pipelineruns executing currently",
 		stats.UnitDimensionless)
 	runningPRsCountView *view.View
+
+	prPrunedCount = stats.Float64("pipelinerun_pruned_count",
+		"number of completed pipelineruns deleted by the pruner",
+		stats.UnitDimensionless)
+	prPrunedCountView *view.View
+
+	prQueueWait = stats.Float64("pipelinerun_queue_wait_seconds",
+		"The time pipelineruns wait to be started after their creation in seconds",
+		stats.UnitDimensionless)
+	prQueueWaitView *view.View
 )
 
 const (
@@ -160,16 +172,30 @@ func viewRegister(cfg *config.Metrics) error {
 		Measure:     runningPRsCount,
 		Aggregation: view.LastValue(),
 	}
+	prPrunedCountView = &view.View{
+		Description: prPrunedCount.Description(),
+		Measure:     prPrunedCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{namespaceTag, reasonTag},
+	}
+	prQueueWaitView = &view.View{
+		Description: prQueueWait.Description(),
+		Measure:     prQueueWait,
+		Aggregation: view.Distribution(1, 5, 10, 30, 60, 300, 900, 1800, 3600),
+		TagKeys:     []tag.Key{namespaceTag, priorityTag},
+	}
 
 	return view.Register(
 		prDurationView,
 		prCountView,
 		runningPRsCountView,
+		prPrunedCountView,
+		prQueueWaitView,
 	)
 }
 
 func viewUnregister() {
-	view.Unregister(prDurationView, prCountView, runningPRsCountView)
+	view.Unregister(prDurationView, prCountView, runningPRsCountView, prPrunedCountView, prQueueWaitView)
 }
 
 // MetricsOnStore returns a function that checks if metrics are configured for a config.Store, and registers it if so
@@ -257,6 +283,55 @@ func (r *Recorder) DurationAndCount(pr *v1beta1.PipelineRun, beforeCondition *ap
 	return nil
 }
 
+// Pruned logs the deletion of a completed PipelineRun by the pruner, for the given reason
+// returns an error if its failed to log the metrics
+func (r *Recorder) Pruned(ctx context.Context, pr *v1beta1.PipelineRun, reason string) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", pr.Name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ctx, err := tag.New(
+		ctx,
+		tag.Insert(namespaceTag, pr.Namespace),
+		tag.Insert(reasonTag, reason))
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, prPrunedCount.M(1))
+	return nil
+}
+
+// QueueWait logs the time the PipelineRun waited to be started after its creation,
+// by PriorityClass
+// returns an error if its failed to log the metrics
+func (r *Recorder) QueueWait(pr *v1beta1.PipelineRun) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", pr.Name)
+	}
+	if pr.Status.StartTime == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(namespaceTag, pr.Namespace),
+		tag.Insert(priorityTag, pr.GetPriorityClassName()))
+	if err != nil {
+		return err
+	}
+
+	wait := pr.Status.StartTime.Sub(pr.CreationTimestamp.Time)
+	metrics.Record(ctx, prQueueWait.M(wait.Seconds()))
+	return nil
+}
+
 // RunningPipelineRuns logs the number of PipelineRuns running right now
 // returns an error if its failed to log the metrics
 func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
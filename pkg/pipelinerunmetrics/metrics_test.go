@@ -64,6 +64,12 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.RunningPipelineRuns(nil); err == nil {
 		t.Error("Current PR count recording expected to return error but got nil")
 	}
+	if err := metrics.Pruned(context.Background(), &v1beta1.PipelineRun{}, "TTLExpired"); err == nil {
+		t.Error("Pruned recording expected to return error but got nil")
+	}
+	if err := metrics.QueueWait(&v1beta1.PipelineRun{}); err == nil {
+		t.Error("QueueWait recording expected to return error but got nil")
+	}
 }
 
 func TestMetricsOnStore(t *testing.T) {
@@ -377,8 +383,57 @@ func TestRecordRunningPipelineRunsCount(t *testing.T) {
 
 }
 
+func TestRecordPrunedPipelineRuns(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1", Namespace: "ns"}}
+	for i := 0; i < 2; i++ {
+		if err := metrics.Pruned(ctx, pr, "HistoryLimitExceeded"); err != nil {
+			t.Errorf("Pruned: %v", err)
+		}
+	}
+	metricstest.CheckCountData(t, "pipelinerun_pruned_count", map[string]string{
+		"namespace": "ns",
+		"reason":    "HistoryLimitExceeded",
+	}, 2)
+}
+
+func TestRecordPipelineRunQueueWait(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	created := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, wait := range []time.Duration{5 * time.Second, 45 * time.Second} {
+		pr := &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1", Namespace: "ns", CreationTimestamp: metav1.Time{Time: created}},
+			Spec:       v1beta1.PipelineRunSpec{PriorityClassName: "release"},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime: &metav1.Time{Time: created.Add(wait)},
+			}},
+		}
+		if err := metrics.QueueWait(pr); err != nil {
+			t.Errorf("QueueWait: %v", err)
+		}
+	}
+	metricstest.CheckDistributionData(t, "pipelinerun_queue_wait_seconds", map[string]string{
+		"namespace":      "ns",
+		"priority_class": "release",
+	}, 2, 5, 45)
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count")
+	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count", "pipelinerun_pruned_count", "pipelinerun_queue_wait_seconds")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// DebugTaskRunLabelKey is the label set on debug pods to the name of the TaskRun they debug
+	DebugTaskRunLabelKey = pipeline.GroupName + "/debugTaskRun"
+	// DebugStepLabelKey is the label set on debug pods to the name of the step they debug
+	DebugStepLabelKey = pipeline.GroupName + "/debugStep"
+)
+
+// MakeDebugPod returns a pod to debug the given failed step of a TaskRun from the pod which ran it.
+// The debug pod runs the same init containers and mounts the same volumes as the failed pod, and its
+// only container has the image, environment and volume mounts of the step, but the entrypoint sleeps
+// until lifetime elapses instead of running the step, so that it can be debugged with `kubectl exec`.
+func MakeDebugPod(taskRun *v1beta1.TaskRun, failedPod *corev1.Pod, step v1beta1.StepState, lifetime time.Duration) (*corev1.Pod, error) {
+	var stepContainer *corev1.Container
+	for i := range failedPod.Spec.Containers {
+		if failedPod.Spec.Containers[i].Name == step.ContainerName {
+			stepContainer = failedPod.Spec.Containers[i].DeepCopy()
+			break
+		}
+	}
+	if stepContainer == nil {
+		return nil, fmt.Errorf("pod %s has no container %s for step %q", failedPod.Name, step.ContainerName, step.Name)
+	}
+
+	seconds := int64(lifetime.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	// The step image may not have a sleep binary: the entrypoint binary copied in the tools volume
+	// by the init containers sleeps instead.
+	stepContainer.Command = []string{entrypointBinary, entrypoint.SleepCommand, strconv.FormatInt(seconds, 10)}
+	stepContainer.Args = nil
+	stepContainer.LivenessProbe = nil
+	stepContainer.ReadinessProbe = nil
+	stepContainer.StartupProbe = nil
+	stepContainer.Lifecycle = nil
+
+	initContainers := make([]corev1.Container, 0, len(failedPod.Spec.InitContainers))
+	for _, c := range failedPod.Spec.InitContainers {
+		initContainers = append(initContainers, *c.DeepCopy())
+	}
+	volumes := make([]corev1.Volume, 0, len(failedPod.Spec.Volumes))
+	for _, v := range failedPod.Spec.Volumes {
+		volumes = append(volumes, *v.DeepCopy())
+	}
+
+	labels := map[string]string{
+		DebugTaskRunLabelKey: taskRun.Name,
+		DebugStepLabelKey:    step.Name,
+	}
+	if managedBy, ok := failedPod.Labels[v1beta1.ManagedByLabelKey]; ok {
+		labels[v1beta1.ManagedByLabelKey] = managedBy
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: taskRun.Namespace,
+			Name:      kmeta.ChildName(taskRun.Name, "-debug-"+step.Name),
+			// The debug pod is deleted along with the TaskRun it debugs.
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(taskRun, groupVersionKind),
+			},
+			Labels: labels,
+		},
+		// The affinity of the failed pod is not kept: it may require the affinity assistant of
+		// a completed PipelineRun, which is deleted.
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &seconds,
+			InitContainers:        initContainers,
+			Containers:            []corev1.Container{*stepContainer},
+			ServiceAccountName:    failedPod.Spec.ServiceAccountName,
+			Volumes:               volumes,
+			NodeSelector:          failedPod.Spec.NodeSelector,
+			Tolerations:           failedPod.Spec.Tolerations,
+			SecurityContext:       failedPod.Spec.SecurityContext,
+			RuntimeClassName:      failedPod.Spec.RuntimeClassName,
+			ImagePullSecrets:      failedPod.Spec.ImagePullSecrets,
+			HostAliases:           failedPod.Spec.HostAliases,
+			DNSPolicy:             failedPod.Spec.DNSPolicy,
+			DNSConfig:             failedPod.Spec.DNSConfig,
+			HostNetwork:           failedPod.Spec.HostNetwork,
+			EnableServiceLinks:    failedPod.Spec.EnableServiceLinks,
+		},
+	}, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMakeDebugPod(t *testing.T) {
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "foo"}}
+	env := []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+	mounts := []corev1.VolumeMount{{Name: "ws-source", MountPath: "/workspace/source"}}
+	failedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "taskrun-pod",
+			Labels: map[string]string{v1beta1.ManagedByLabelKey: "tekton-pipelines", "tekton.dev/taskRun": "taskrun"},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "builder",
+			InitContainers:     []corev1.Container{{Name: "place-scripts", Image: "busybox"}},
+			Containers: []corev1.Container{{
+				Name:    "step-fetch",
+				Image:   "alpine/git",
+				Command: []string{"/tekton/bin/entrypoint"},
+			}, {
+				Name:           "step-build",
+				Image:          "golang",
+				Command:        []string{"/tekton/bin/entrypoint"},
+				Args:           []string{"-entrypoint", "go", "--", "build"},
+				Env:            env,
+				VolumeMounts:   mounts,
+				ReadinessProbe: &corev1.Probe{},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "ws-source",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"}},
+			}},
+			Affinity: &corev1.Affinity{PodAffinity: &corev1.PodAffinity{}},
+		},
+	}
+
+	got, err := MakeDebugPod(tr, failedPod, v1beta1.StepState{Name: "build", ContainerName: "step-build"}, 90*time.Minute)
+	if err != nil {
+		t.Fatalf("MakeDebugPod: %v", err)
+	}
+	seconds := int64(5400)
+	want := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskrun-debug-build",
+			Namespace: "foo",
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(tr, groupVersionKind),
+			},
+			Labels: map[string]string{
+				v1beta1.ManagedByLabelKey: "tekton-pipelines",
+				DebugTaskRunLabelKey:      "taskrun",
+				DebugStepLabelKey:         "build",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &seconds,
+			ServiceAccountName:    "builder",
+			InitContainers:        []corev1.Container{{Name: "place-scripts", Image: "busybox"}},
+			Containers: []corev1.Container{{
+				Name:         "step-build",
+				Image:        "golang",
+				Command:      []string{"/tekton/bin/entrypoint", "sleep", "5400"},
+				Env:          env,
+				VolumeMounts: mounts,
+			}},
+			Volumes: failedPod.Spec.Volumes,
+		},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected debug pod %s", diff.PrintWantGot(d))
+	}
+
+	if _, err := MakeDebugPod(tr, failedPod, v1beta1.StepState{Name: "missing", ContainerName: "step-missing"}, time.Hour); err == nil {
+		t.Error("Expected an error for a step without container but got none")
+	}
+}
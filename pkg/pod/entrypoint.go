@@ -25,14 +25,17 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	"gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -51,6 +54,11 @@ const (
 	readyAnnotation        = "tekton.dev/ready"
 	readyAnnotationValue   = "READY"
 
+	cancelVolumeName    = "tekton-internal-cancel"
+	cancelMountPoint    = "/tekton/cancel"
+	cancelFile          = "cancelled"
+	cancelledAnnotation = "tekton.dev/cancelled"
+
 	stepPrefix    = "step-"
 	sidecarPrefix = "sidecar-"
 
@@ -99,6 +107,27 @@ var (
 		// since the volume itself is readonly, but including for completeness.
 		ReadOnly: true,
 	}
+
+	// cancelVolume projects the cancelled annotation of the pod, which the entrypoint
+	// of steps with a termination grace period watches to terminate them gracefully.
+	cancelVolume = corev1.Volume{
+		Name: cancelVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path: cancelFile,
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.annotations['%s']", cancelledAnnotation),
+					},
+				}},
+			},
+		},
+	}
+	cancelMount = corev1.VolumeMount{
+		Name:      cancelVolumeName,
+		MountPath: cancelMountPoint,
+		ReadOnly:  true,
+	}
 )
 
 // orderContainers returns the specified steps, modified so that they are
@@ -113,6 +142,18 @@ func orderContainers(commonExtraEntrypointArgs []string, steps []corev1.Containe
 		return nil, errors.New("No steps specified")
 	}
 
+	var beforeSteps, afterSteps sets.String
+	if breakpointConfig != nil {
+		beforeSteps, afterSteps = sets.NewString(breakpointConfig.BeforeSteps...), sets.NewString(breakpointConfig.AfterSteps...)
+		stepNames := sets.NewString()
+		for _, s := range steps {
+			stepNames.Insert(trimStepPrefix(s.Name))
+		}
+		if missing := beforeSteps.Union(afterSteps).Difference(stepNames); missing.Len() > 0 {
+			return nil, fmt.Errorf("breakpoints set on steps %v which don't exist", missing.List())
+		}
+	}
+
 	for i, s := range steps {
 		var argsForEntrypoint = []string{}
 		idx := strconv.Itoa(i)
@@ -146,6 +187,19 @@ func orderContainers(commonExtraEntrypointArgs []string, steps []corev1.Containe
 				if taskSpec.Steps[i].Timeout != nil {
 					argsForEntrypoint = append(argsForEntrypoint, "-timeout", taskSpec.Steps[i].Timeout.Duration.String())
 				}
+				if gracePeriod := taskSpec.Steps[i].TerminationGracePeriod; gracePeriod != nil && gracePeriod.Duration > 0 {
+					argsForEntrypoint = append(argsForEntrypoint,
+						"-termination_grace_period", gracePeriod.Duration.String(),
+						"-cancel_file", filepath.Join(cancelMountPoint, cancelFile),
+					)
+					steps[i].VolumeMounts = append(steps[i].VolumeMounts, cancelMount)
+				}
+				if taskSpec.Steps[i].Retries > 0 {
+					argsForEntrypoint = append(argsForEntrypoint, "-retries", strconv.Itoa(taskSpec.Steps[i].Retries))
+					if taskSpec.Steps[i].RetryDelay != nil {
+						argsForEntrypoint = append(argsForEntrypoint, "-retry_delay", taskSpec.Steps[i].RetryDelay.Duration.String())
+					}
+				}
 				if taskSpec.Steps[i].StdoutConfig != nil {
 					argsForEntrypoint = append(argsForEntrypoint, "-stdout_path", taskSpec.Steps[i].StdoutConfig.Path)
 				}
@@ -165,6 +219,18 @@ func orderContainers(commonExtraEntrypointArgs []string, steps []corev1.Containe
 				}
 			}
 		}
+		stepName := trimStepPrefix(s.Name)
+		if beforeSteps.Has(stepName) {
+			argsForEntrypoint = append(argsForEntrypoint, "-breakpoint_before_step")
+		}
+		if afterSteps.Has(stepName) {
+			argsForEntrypoint = append(argsForEntrypoint, "-breakpoint_after_step")
+		}
+		if beforeSteps.Has(stepName) || afterSteps.Has(stepName) {
+			// The step is ready only while it is paused at a breakpoint, which is how the
+			// controller reports the paused step in the status of the TaskRun.
+			steps[i].ReadinessProbe = breakpointPausedProbe(filepath.Join(runDir, idx, "out"+entrypoint.BreakpointMarkerSuffix))
+		}
 
 		cmd, args := s.Command, s.Args
 		if len(cmd) > 0 {
@@ -188,6 +254,25 @@ func orderContainers(commonExtraEntrypointArgs []string, steps []corev1.Containe
 	return steps, nil
 }
 
+// breakpointPausedProbe returns the readiness probe of a step with breakpoints, which succeeds
+// only while the given breakpoint marker exists.
+func breakpointPausedProbe(marker string) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{entrypointBinary, entrypoint.BreakpointPausedCommand, marker},
+			},
+		},
+		PeriodSeconds: 1,
+	}
+}
+
+// isBreakpointPausedProbe returns true if the given probe is the one of a step with breakpoints.
+func isBreakpointPausedProbe(probe *corev1.Probe) bool {
+	return probe != nil && probe.Exec != nil && len(probe.Exec.Command) == 3 &&
+		probe.Exec.Command[0] == entrypointBinary && probe.Exec.Command[1] == entrypoint.BreakpointPausedCommand
+}
+
 func resultArgument(steps []corev1.Container, results []v1beta1.TaskResult) []string {
 	if len(results) == 0 {
 		return nil
@@ -233,6 +318,36 @@ func UpdateReady(ctx context.Context, kubeclient kubernetes.Interface, pod corev
 	return err
 }
 
+// SignalCancellation updates the Pod's annotations to signal its running step to
+// terminate gracefully by projecting the cancelled annotation via the Downward API.
+func SignalCancellation(ctx context.Context, kubeclient kubernetes.Interface, pod corev1.Pod, now time.Time) error {
+	// Don't PATCH if the cancellation was already signalled.
+	if _, ok := pod.Annotations[cancelledAnnotation]; ok {
+		return nil
+	}
+
+	patchBytes, err := json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/metadata/annotations/" + strings.Replace(cancelledAnnotation, "/", "~1", 1),
+		Value:     now.UTC().Format(time.RFC3339),
+	}})
+	if err != nil {
+		return err
+	}
+	_, err = kubeclient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
+
+// CancellationSignalledAt returns when the cancellation of the given pod was signalled
+// to its steps, and false if it wasn't.
+func CancellationSignalledAt(pod *corev1.Pod) (time.Time, bool) {
+	signalledAt, err := time.Parse(time.RFC3339, pod.Annotations[cancelledAnnotation])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return signalledAt, true
+}
+
 // StopSidecars updates sidecar containers in the Pod to a nop image, which
 // exits successfully immediately.
 func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.Interface, namespace, name string) (*corev1.Pod, error) {
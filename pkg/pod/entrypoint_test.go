@@ -182,6 +182,61 @@ func TestOrderContainersWithDebugOnFailure(t *testing.T) {
 	}
 }
 
+func TestOrderContainersWithDebugBeforeAndAfterSteps(t *testing.T) {
+	steps := []corev1.Container{{
+		Name:    "step-fetch",
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Name:    "step-build",
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Name:    "step-fetch",
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Name:    "step-build",
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-post_file", "/tekton/run/1/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/1/status",
+			"-breakpoint_before_step",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+		ReadinessProbe:         breakpointPausedProbe("/tekton/run/1/out.breakpoint"),
+	}}
+	taskRunDebugConfig := &v1beta1.TaskRunDebug{
+		BeforeSteps: []string{"build"},
+	}
+	got, err := orderContainers([]string{}, steps, nil, taskRunDebugConfig, true)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+
+	if _, err := orderContainers([]string{}, steps, nil, &v1beta1.TaskRunDebug{AfterSteps: []string{"test"}}, true); err == nil {
+		t.Error("Expected an error for a breakpoint on a step which doesn't exist but got none")
+	}
+}
+
 func TestEntryPointResults(t *testing.T) {
 	taskSpec := v1beta1.TaskSpec{
 		Results: []v1beta1.TaskResult{{
@@ -509,6 +564,57 @@ func TestEntryPointStepOutputConfigs(t *testing.T) {
 	}
 }
 
+func TestEntryPointStepRetries(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Retries: 2,
+		}, {
+			Retries:    3,
+			RetryDelay: &metav1.Duration{Duration: 10 * time.Second},
+		}},
+	}
+
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-retries", "2",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-post_file", "/tekton/run/1/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/1/status",
+			"-retries", "3",
+			"-retry_delay", "10s",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers([]string{}, steps, &taskSpec, nil, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestUpdateReady(t *testing.T) {
 	for _, c := range []struct {
 		desc            string
@@ -621,6 +727,70 @@ func TestUpdateReady(t *testing.T) {
 	}
 }
 
+func TestSignalCancellation(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		desc            string
+		annotations     map[string]string
+		wantAnnotations map[string]string
+		wantPatch       bool
+	}{{
+		desc:        "Pod without cancelled annotation has it added",
+		annotations: map[string]string{"something": "else"},
+		wantAnnotations: map[string]string{
+			"something":         "else",
+			cancelledAnnotation: "2022-06-01T12:00:00Z",
+		},
+		wantPatch: true,
+	}, {
+		desc: "Pod already cancelled isn't patched",
+		annotations: map[string]string{
+			"something":         "else",
+			cancelledAnnotation: "2022-06-01T11:00:00Z",
+		},
+		wantAnnotations: map[string]string{
+			"something":         "else",
+			cancelledAnnotation: "2022-06-01T11:00:00Z",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Annotations: c.annotations}}
+			kubeclient := fakek8s.NewSimpleClientset(&pod)
+			patchCalled := false
+			kubeclient.PrependReactor("patch", "pods", func(a k8stesting.Action) (bool, runtime.Object, error) {
+				if !c.wantPatch {
+					t.Fatal("Pod was patched unexpectedly")
+				}
+				patchCalled = true
+				return false, nil, nil
+			})
+			if err := SignalCancellation(ctx, kubeclient, pod, now); err != nil {
+				t.Errorf("SignalCancellation: %v", err)
+			}
+			if c.wantPatch && !patchCalled {
+				t.Fatal("Pod was not patched")
+			}
+
+			got, err := kubeclient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Getting pod %q after update: %v", pod.Name, err)
+			}
+			if d := cmp.Diff(c.wantAnnotations, got.Annotations); d != "" {
+				t.Errorf("Annotations Diff %s", diff.PrintWantGot(d))
+			}
+			signalledAt, ok := CancellationSignalledAt(got)
+			if !ok {
+				t.Fatal("Expected the cancellation to be signalled")
+			}
+			if want := c.wantAnnotations[cancelledAnnotation]; signalledAt.Format(time.RFC3339) != want {
+				t.Errorf("Expected the cancellation to be signalled at %s but got %s", want, signalledAt)
+			}
+		})
+	}
+}
+
 const nopImage = "nop-image"
 
 // TestStopSidecars tests stopping sidecars by updating their images to a nop
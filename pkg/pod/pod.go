@@ -23,6 +23,7 @@ import (
 	"math"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -51,6 +52,10 @@ const (
 	// deadlineFactor is the factor we multiply the taskrun timeout with to determine the activeDeadlineSeconds of the Pod.
 	// It has to be higher than the timeout (to not be killed before)
 	deadlineFactor = 1.5
+
+	// resourceUsageSamplingInterval is how often the entrypoint samples the resources used by
+	// a step when the enable-step-resource-usage feature flag is set.
+	resourceUsageSamplingInterval = "1s"
 )
 
 // These are effectively const, but Go doesn't have such an annotation.
@@ -152,6 +157,19 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		return nil, err
 	}
 
+	// $(step.env.path) depends on the index of each step, and its steps export their
+	// environment to the next ones if any of them uses it.
+	exportEnv := false
+	if alphaAPIEnabled {
+		steps, exportEnv = applyStepEnvPath(steps)
+	}
+	// $(step.results.<resultName>.path) depends on the name of each step, and the steps pass
+	// their results to the next ones if any of them declares results.
+	stepResults := false
+	if alphaAPIEnabled {
+		steps, stepResults = applyStepResultsPath(steps)
+	}
+
 	initContainers = []corev1.Container{
 		entrypointInitContainer(b.Images.EntrypointImage, steps),
 	}
@@ -189,8 +207,19 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		return nil, err
 	}
 
+	if featureFlags.EnableStepResourceUsage {
+		credEntrypointArgs = append(credEntrypointArgs, "-resource_usage_interval", resourceUsageSamplingInterval)
+	}
+
 	readyImmediately := isPodReadyImmediately(*featureFlags, taskSpec.Sidecars)
 
+	// The entrypoint of each step is configured from the step merged with the step template and
+	// the overrides of the TaskRun, e.g. its timeout and retries.
+	taskSpec.Steps = steps
+	if alphaAPIEnabled {
+		taskSpec.Steps = applyTerminationGracePeriod(taskSpec.Steps, taskRun.Spec.TerminationGracePeriod)
+	}
+
 	if alphaAPIEnabled {
 		stepContainers, err = orderContainers(credEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug, !readyImmediately)
 	} else {
@@ -199,10 +228,19 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	if err != nil {
 		return nil, err
 	}
+	if exportEnv {
+		exportStepEnv(stepContainers)
+	}
+	if stepResults {
+		passStepResults(stepContainers, steps, taskSpec.Results)
+	}
 	volumes = append(volumes, binVolume)
 	if !readyImmediately {
 		volumes = append(volumes, downwardVolume)
 	}
+	if alphaAPIEnabled && hasTerminationGracePeriod(taskSpec.Steps) {
+		volumes = append(volumes, cancelVolume)
+	}
 
 	// Add implicit env vars.
 	// They're prepended to the list, so that if the user specified any
@@ -214,6 +252,8 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		}
 	}
 
+	traceSteps(ctx, taskRun, stepContainers)
+
 	// Add env var if hermetic execution was requested & if the alpha API is enabled
 	if taskRun.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic && alphaAPIEnabled {
 		for i, s := range stepContainers {
@@ -303,7 +343,8 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	// calculate the activeDeadlineSeconds based on the specified timeout (uses default timeout if it's not specified)
 	activeDeadlineSeconds := int64(taskRun.GetTimeout(ctx).Seconds() * deadlineFactor)
 	// set activeDeadlineSeconds to the max. allowed value i.e. max int32 when timeout is explicitly set to 0
-	if taskRun.GetTimeout(ctx) == config.NoTimeoutDuration {
+	// or when the TaskRun can pause at breakpoints, since its timeout is suspended while it is paused
+	if taskRun.GetTimeout(ctx) == config.NoTimeoutDuration || (alphaAPIEnabled && taskRun.Spec.Debug.HasStepBreakpoints()) {
 		activeDeadlineSeconds = MaxActiveDeadlineSeconds
 	}
 
@@ -311,6 +352,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	if taskRunRetries := len(taskRun.Status.RetriesStatus); taskRunRetries > 0 {
 		podNameSuffix = fmt.Sprintf("%s-retry%d", podNameSuffix, taskRunRetries)
 	}
+	if infraRetries := len(taskRun.Status.InfraRetries); infraRetries > 0 {
+		podNameSuffix = fmt.Sprintf("%s-infra%d", podNameSuffix, infraRetries)
+	}
 	newPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			// We execute the build's pod in the same namespace as where the build was
@@ -394,6 +438,38 @@ func isPodReadyImmediately(featureFlags config.FeatureFlags, sidecars []v1beta1.
 	return true
 }
 
+// applyTerminationGracePeriod returns a copy of the given steps where the ones without a
+// termination grace period use the one of their TaskRun, if any.
+func applyTerminationGracePeriod(steps []v1beta1.Step, gracePeriod *metav1.Duration) []v1beta1.Step {
+	if gracePeriod == nil {
+		return steps
+	}
+	withGracePeriod := make([]v1beta1.Step, len(steps))
+	for i, s := range steps {
+		if s.TerminationGracePeriod == nil {
+			s.TerminationGracePeriod = gracePeriod
+		}
+		withGracePeriod[i] = s
+	}
+	return withGracePeriod
+}
+
+func hasTerminationGracePeriod(steps []v1beta1.Step) bool {
+	return MaxTerminationGracePeriod(steps, nil) > 0
+}
+
+// MaxTerminationGracePeriod returns the longest termination grace period of the given steps,
+// the ones without their own using the given TaskRun one.
+func MaxTerminationGracePeriod(steps []v1beta1.Step, taskRunGracePeriod *metav1.Duration) time.Duration {
+	var max time.Duration
+	for _, s := range applyTerminationGracePeriod(steps, taskRunGracePeriod) {
+		if s.TerminationGracePeriod != nil && s.TerminationGracePeriod.Duration > max {
+			max = s.TerminationGracePeriod.Duration
+		}
+	}
+	return max
+}
+
 func runMount(i int, ro bool) corev1.VolumeMount {
 	return corev1.VolumeMount{
 		Name:      fmt.Sprintf("%s-%d", runVolumeName, i),
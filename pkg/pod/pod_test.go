@@ -232,6 +232,53 @@ func TestPodBuild(t *testing.T) {
 		wantAnnotations: map[string]string{
 			readyAnnotation: readyAnnotationValue,
 		},
+	}, {
+		desc: "step resource usage enabled",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		featureFlags: map[string]string{
+			"enable-step-resource-usage": "true",
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-resource_usage_interval",
+					"1s",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
 	}, {
 		desc: "with service account",
 		ts: v1beta1.TaskSpec{
@@ -904,6 +951,60 @@ _EOF_
 			}),
 			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 		},
+	}, {
+		desc: "with step retries and stepTemplate",
+		ts: v1beta1.TaskSpec{
+			StepTemplate: &v1beta1.StepTemplate{
+				Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			},
+			Steps: []v1beta1.Step{{
+				Name:       "step1",
+				Image:      "image",
+				Command:    []string{"cmd"},
+				Retries:    2,
+				RetryDelay: &metav1.Duration{Duration: 5 * time.Second},
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{
+				{Name: "step1"},
+			})},
+			Containers: []corev1.Container{{
+				Name:    "step-step1",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-retries",
+					"2",
+					"-retry_delay",
+					"5s",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+				VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
 	}, {
 		desc: "with sidecarOverrides",
 		ts: v1beta1.TaskSpec{
@@ -1670,6 +1771,72 @@ _EOF_
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 			wantPodName: fmt.Sprintf("%s-pod-retry2", taskRunName),
+		}, {
+			desc: "pod for a taskRun with retries and infra retries",
+			ts: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			trStatus: v1beta1.TaskRunStatus{
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					RetriesStatus: []v1beta1.TaskRunStatus{{
+						Status: duckv1beta1.Status{
+							Conditions: []apis.Condition{{
+								Type:   apis.ConditionSucceeded,
+								Status: corev1.ConditionFalse,
+							}},
+						},
+					}, {
+						Status: duckv1beta1.Status{
+							Conditions: []apis.Condition{{
+								Type:   apis.ConditionSucceeded,
+								Status: corev1.ConditionFalse,
+							}},
+						},
+					}},
+					InfraRetries: []v1beta1.InfraRetry{{
+						PodName: "taskrun-name-pod-retry2",
+						Reason:  "Evicted",
+					}},
+				},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+			wantPodName: fmt.Sprintf("%s-pod-retry2-infra1", taskRunName),
 		}, {
 			desc: "long-taskrun-name",
 			ts: v1beta1.TaskSpec{
@@ -1892,22 +2059,53 @@ set -e
 numberOfSteps=1
 debugInfo=/tekton/debug/info
 tektonRun=/tekton/run
+breakpointMarker=out.breakpoint
+breakpointExit=out.breakpointexit
 
 postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
 stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
 
+if [ -f ${tektonRun}/${stepNumber}/${breakpointMarker} ]; then
+	echo "0" > ${tektonRun}/${stepNumber}/${breakpointExit} # Continue from breakpoint before or after step
+	echo "Continuing step $stepNumber..."
+	exit 0
+fi
+
 if [ $stepNumber -lt $numberOfSteps ]; then
 	touch ${tektonRun}/${stepNumber}/out # Mark step as success
-	echo "0" > ${tektonRun}/${stepNumber}/out.breakpointexit
+	echo "0" > ${tektonRun}/${stepNumber}/${breakpointExit}
 	echo "Executing step $stepNumber..."
 else
 	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
 	exit 0
 fi
 debug-continue-heredoc-randomly-generated-9l9zj
+tmpfile="/tekton/debug/scripts/debug-fail"
+touch ${tmpfile} && chmod +x ${tmpfile}
+cat > ${tmpfile} << 'debug-fail-heredoc-randomly-generated-mz4c7'
+#!/bin/sh
+set -e
+
+debugInfo=/tekton/debug/info
+tektonRun=/tekton/run
+breakpointMarker=out.breakpoint
+breakpointExit=out.breakpointexit
+
+postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
+stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
+
+if [ -f ${tektonRun}/${stepNumber}/${breakpointMarker} ]; then
+	echo "1" > ${tektonRun}/${stepNumber}/${breakpointExit} # Fail step from breakpoint before or after it
+	echo "Failing step $stepNumber..."
+	exit 0
+fi
+
+echo "Step $stepNumber is not paused before or after it, use debug-fail-continue to fail it at a breakpoint on failure !"
+exit 1
+debug-fail-heredoc-randomly-generated-mz4c7
 tmpfile="/tekton/debug/scripts/debug-fail-continue"
 touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'debug-fail-continue-heredoc-randomly-generated-mz4c7'
+cat > ${tmpfile} << 'debug-fail-continue-heredoc-randomly-generated-mssqb'
 #!/bin/sh
 set -e
 
@@ -1926,7 +2124,7 @@ else
 	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
 	exit 0
 fi
-debug-fail-continue-heredoc-randomly-generated-mz4c7
+debug-fail-continue-heredoc-randomly-generated-mssqb
 `},
 	}
 
@@ -1992,6 +2190,111 @@ debug-fail-continue-heredoc-randomly-generated-mz4c7
 			}),
 			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 		},
+	}, {
+		desc: "simple with debug breakpoints before and after step",
+		trs: v1beta1.TaskRunSpec{
+			Debug: &v1beta1.TaskRunDebug{
+				BeforeSteps: []string{"name"},
+				AfterSteps:  []string{"name"},
+			},
+		},
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}}), placeScriptsContainer},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-breakpoint_before_step",
+					"-breakpoint_after_step",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts:           containersVolumeMounts,
+				TerminationMessagePath: "/tekton/termination",
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						Exec: &corev1.ExecAction{
+							Command: []string{"/tekton/bin/entrypoint", "breakpoint-paused", "/tekton/run/0/out.breakpoint"},
+						},
+					},
+					PeriodSeconds: 1,
+				},
+			}},
+			Volumes: append(implicitVolumes, debugScriptsVolume, debugInfoVolume, binVolume, scriptsVolume, runVolume(0), downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+			// The timeout of the TaskRun is suspended while it is paused at a breakpoint.
+			ActiveDeadlineSeconds: &MaxActiveDeadlineSeconds,
+		},
+	}, {
+		desc: "simple with termination grace period",
+		trs: v1beta1.TaskRunSpec{
+			TerminationGracePeriod: &metav1.Duration{Duration: time.Minute},
+		},
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-termination_grace_period",
+					"1m0s",
+					"-cancel_file",
+					"/tekton/cancel/cancelled",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, cancelMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, binVolume, cancelVolume, runVolume(0), downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			featureFlags := map[string]string{
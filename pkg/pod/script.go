@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -95,7 +96,6 @@ func convertScripts(shellImageLinux string, shellImageWin string, steps []v1beta
 		VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, binMount},
 	}
 
-	breakpoints := []string{}
 	sideCarSteps := []v1beta1.Step{}
 	for _, sidecar := range sidecars {
 		c := sidecar.ToK8sContainer()
@@ -108,15 +108,15 @@ func convertScripts(shellImageLinux string, shellImageWin string, steps []v1beta
 	}
 
 	// Add mounts for debug
-	if debugConfig != nil && len(debugConfig.Breakpoint) > 0 {
-		breakpoints = debugConfig.Breakpoint
+	hasBreakpoints := debugConfig.HasBreakpoints()
+	if hasBreakpoints {
 		placeScriptsInit.VolumeMounts = append(placeScriptsInit.VolumeMounts, debugScriptsVolumeMount)
 	}
 
-	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, &placeScripts, breakpoints, "script")
+	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, &placeScripts, hasBreakpoints, "script")
 
-	// Pass empty breakpoint list in "sidecar step to container" converter to not rewrite the scripts and add breakpoints to sidecar
-	sidecarContainers := convertListOfSteps(sideCarSteps, &placeScriptsInit, &placeScripts, []string{}, "sidecar-script")
+	// Disable breakpoints in "sidecar step to container" converter to not rewrite the scripts and add breakpoints to sidecar
+	sidecarContainers := convertListOfSteps(sideCarSteps, &placeScriptsInit, &placeScripts, false, "sidecar-script")
 	if placeScripts {
 		return &placeScriptsInit, convertedStepContainers, sidecarContainers
 	}
@@ -127,11 +127,11 @@ func convertScripts(shellImageLinux string, shellImageWin string, steps []v1beta
 //
 // It iterates through the list of steps (or sidecars), generates the script file name and heredoc termination string,
 // adds an entry to the init container args, sets up the step container to run the script, and sets the volume mounts.
-func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, placeScripts *bool, breakpoints []string, namePrefix string) []corev1.Container {
+func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, placeScripts *bool, hasBreakpoints bool, namePrefix string) []corev1.Container {
 	containers := []corev1.Container{}
 	for i, s := range steps {
 		// Add debug mounts if breakpoints are present
-		if len(breakpoints) > 0 {
+		if hasBreakpoints {
 			debugInfoVolumeMount := corev1.VolumeMount{
 				Name:      debugInfoVolumeName,
 				MountPath: filepath.Join(debugInfoDir, fmt.Sprintf("%d", i)),
@@ -200,7 +200,7 @@ cat > ${scriptfile} << '%s'
 	}
 
 	// Place debug scripts if breakpoints are enabled
-	if len(breakpoints) > 0 {
+	if hasBreakpoints {
 		// If breakpoint is not nil then should add the init container
 		// to write debug script files
 		*placeScripts = true
@@ -211,7 +211,10 @@ cat > ${scriptfile} << '%s'
 		}
 		debugScripts := []script{{
 			name:    "continue",
-			content: defaultScriptPreamble + fmt.Sprintf(debugContinueScriptTemplate, len(steps), debugInfoDir, runDir),
+			content: defaultScriptPreamble + fmt.Sprintf(debugContinueScriptTemplate, len(steps), debugInfoDir, runDir, entrypoint.BreakpointMarkerSuffix, entrypoint.BreakpointExitSuffix),
+		}, {
+			name:    "fail",
+			content: defaultScriptPreamble + fmt.Sprintf(debugFailBreakpointScriptTemplate, debugInfoDir, runDir, entrypoint.BreakpointMarkerSuffix, entrypoint.BreakpointExitSuffix),
 		}, {
 			name:    "fail-continue",
 			content: defaultScriptPreamble + fmt.Sprintf(debugFailScriptTemplate, len(steps), debugInfoDir, runDir),
@@ -259,22 +259,53 @@ set -e
 numberOfSteps=4
 debugInfo=/tekton/debug/info
 tektonRun=/tekton/run
+breakpointMarker=out.breakpoint
+breakpointExit=out.breakpointexit
 
 postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
 stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
 
+if [ -f ${tektonRun}/${stepNumber}/${breakpointMarker} ]; then
+	echo "0" > ${tektonRun}/${stepNumber}/${breakpointExit} # Continue from breakpoint before or after step
+	echo "Continuing step $stepNumber..."
+	exit 0
+fi
+
 if [ $stepNumber -lt $numberOfSteps ]; then
 	touch ${tektonRun}/${stepNumber}/out # Mark step as success
-	echo "0" > ${tektonRun}/${stepNumber}/out.breakpointexit
+	echo "0" > ${tektonRun}/${stepNumber}/${breakpointExit}
 	echo "Executing step $stepNumber..."
 else
 	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
 	exit 0
 fi
 debug-continue-heredoc-randomly-generated-78c5n
+tmpfile="/tekton/debug/scripts/debug-fail"
+touch ${tmpfile} && chmod +x ${tmpfile}
+cat > ${tmpfile} << 'debug-fail-heredoc-randomly-generated-6nl7g'
+#!/bin/sh
+set -e
+
+debugInfo=/tekton/debug/info
+tektonRun=/tekton/run
+breakpointMarker=out.breakpoint
+breakpointExit=out.breakpointexit
+
+postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
+stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
+
+if [ -f ${tektonRun}/${stepNumber}/${breakpointMarker} ]; then
+	echo "1" > ${tektonRun}/${stepNumber}/${breakpointExit} # Fail step from breakpoint before or after it
+	echo "Failing step $stepNumber..."
+	exit 0
+fi
+
+echo "Step $stepNumber is not paused before or after it, use debug-fail-continue to fail it at a breakpoint on failure !"
+exit 1
+debug-fail-heredoc-randomly-generated-6nl7g
 tmpfile="/tekton/debug/scripts/debug-fail-continue"
 touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'debug-fail-continue-heredoc-randomly-generated-6nl7g'
+cat > ${tmpfile} << 'debug-fail-continue-heredoc-randomly-generated-j2tds'
 #!/bin/sh
 set -e
 
@@ -293,7 +324,7 @@ else
 	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
 	exit 0
 fi
-debug-fail-continue-heredoc-randomly-generated-6nl7g
+debug-fail-continue-heredoc-randomly-generated-j2tds
 `},
 		VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, binMount, debugScriptsVolumeMount},
 	}
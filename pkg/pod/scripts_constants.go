@@ -6,13 +6,21 @@ const (
 numberOfSteps=%d
 debugInfo=%s
 tektonRun=%s
+breakpointMarker=out%s
+breakpointExit=out%s
 
 postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
 stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
 
+if [ -f ${tektonRun}/${stepNumber}/${breakpointMarker} ]; then
+	echo "0" > ${tektonRun}/${stepNumber}/${breakpointExit} # Continue from breakpoint before or after step
+	echo "Continuing step $stepNumber..."
+	exit 0
+fi
+
 if [ $stepNumber -lt $numberOfSteps ]; then
 	touch ${tektonRun}/${stepNumber}/out # Mark step as success
-	echo "0" > ${tektonRun}/${stepNumber}/out.breakpointexit
+	echo "0" > ${tektonRun}/${stepNumber}/${breakpointExit}
 	echo "Executing step $stepNumber..."
 else
 	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
@@ -34,6 +42,23 @@ else
 	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
 	exit 0
 fi`
+	debugFailBreakpointScriptTemplate = `
+debugInfo=%s
+tektonRun=%s
+breakpointMarker=out%s
+breakpointExit=out%s
+
+postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
+stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
+
+if [ -f ${tektonRun}/${stepNumber}/${breakpointMarker} ]; then
+	echo "1" > ${tektonRun}/${stepNumber}/${breakpointExit} # Fail step from breakpoint before or after it
+	echo "Failing step $stepNumber..."
+	exit 0
+fi
+
+echo "Step $stepNumber is not paused before or after it, use debug-fail-continue to fail it at a breakpoint on failure !"
+exit 1`
 	initScriptDirective = `tmpfile="%s"
 touch ${tmpfile} && chmod +x ${tmpfile}
 cat > ${tmpfile} << '%s'
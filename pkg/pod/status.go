@@ -29,6 +29,7 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -49,6 +50,10 @@ const (
 	// a ResourceQuota in the namespace
 	ReasonExceededResourceQuota = "ExceededResourceQuota"
 
+	// ReasonQueuedForResourceQuota indicates that the TaskRun is waiting to create its pod
+	// because other TaskRuns are queued before it for a ResourceQuota in the namespace
+	ReasonQueuedForResourceQuota = "QueuedForResourceQuota"
+
 	// ReasonExceededNodeResources indicates that the TaskRun's pod has failed to start due
 	// to resource constraints on the node
 	ReasonExceededNodeResources = "ExceededNodeResources"
@@ -64,6 +69,9 @@ const (
 	// is that the creation of the pod backing the TaskRun failed
 	ReasonPodCreationFailed = "PodCreationFailed"
 
+	// ReasonPodDeleted indicates that the TaskRun's pod was deleted before the TaskRun completed
+	ReasonPodDeleted = "PodDeleted"
+
 	// ReasonPending indicates that the pod is in corev1.Pending, and the reason is not
 	// ReasonExceededNodeResources or isPodHitConfigError
 	ReasonPending = "Pending"
@@ -99,6 +107,25 @@ func SidecarsReady(podStatus corev1.PodStatus) bool {
 	return true
 }
 
+// PausedStep returns the name of the step the Pod is paused before or after at a breakpoint, or
+// an empty string if it isn't paused. The steps with breakpoints are ready only while paused.
+func PausedStep(pod *corev1.Pod) string {
+	if pod == nil || pod.Status.Phase != corev1.PodRunning {
+		return ""
+	}
+	for _, c := range pod.Spec.Containers {
+		if !IsContainerStep(c.Name) || !isBreakpointPausedProbe(c.ReadinessProbe) {
+			continue
+		}
+		for _, s := range pod.Status.ContainerStatuses {
+			if s.Name == c.Name && s.State.Running != nil && s.Ready {
+				return trimStepPrefix(c.Name)
+			}
+		}
+	}
+	return ""
+}
+
 // MakeTaskRunStatus returns a TaskRunStatus based on the Pod's status.
 func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev1.Pod) (v1beta1.TaskRunStatus, error) {
 	trs := &tr.Status
@@ -148,6 +175,9 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 	var merr *multierror.Error
 
 	for _, s := range stepStatuses {
+		var exitedGracefully *bool
+		var attempts int
+		var resourceUsage *v1beta1.StepResourceUsage
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -166,6 +196,21 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
 					merr = multierror.Append(merr, err)
 				}
+				exitedGracefully, err = extractExitedGracefullyFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting whether step %q in taskrun %q exited gracefully: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
+				attempts, err = extractAttemptsFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the attempts of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
+				resourceUsage, err = extractResourceUsageFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the resource usage of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
 				taskResults, pipelineResourceResults, filteredResults := filterResultsAndResources(results)
 				if tr.IsSuccessful() {
 					trs.TaskRunResults = append(trs.TaskRunResults, taskResults...)
@@ -187,10 +232,13 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 			}
 		}
 		trs.Steps = append(trs.Steps, v1beta1.StepState{
-			ContainerState: *s.State.DeepCopy(),
-			Name:           trimStepPrefix(s.Name),
-			ContainerName:  s.Name,
-			ImageID:        s.ImageID,
+			ContainerState:   *s.State.DeepCopy(),
+			Name:             trimStepPrefix(s.Name),
+			ContainerName:    s.Name,
+			ImageID:          s.ImageID,
+			ExitedGracefully: exitedGracefully,
+			Attempts:         attempts,
+			ResourceUsage:    resourceUsage,
 		})
 	}
 
@@ -301,6 +349,45 @@ func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int3
 	return nil, nil
 }
 
+func extractExitedGracefullyFromResults(results []v1beta1.PipelineResourceResult) (*bool, error) {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "ExitedGracefully" {
+			exitedGracefully, err := strconv.ParseBool(result.Value)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse bool value %q in ExitedGracefully field: %w", result.Value, err)
+			}
+			return &exitedGracefully, nil
+		}
+	}
+	return nil, nil
+}
+
+func extractAttemptsFromResults(results []v1beta1.PipelineResourceResult) (int, error) {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Attempts" {
+			attempts, err := strconv.Atoi(result.Value)
+			if err != nil {
+				return 0, fmt.Errorf("could not parse int value %q in Attempts field: %w", result.Value, err)
+			}
+			return attempts, nil
+		}
+	}
+	return 0, nil
+}
+
+func extractResourceUsageFromResults(results []v1beta1.PipelineResourceResult) (*v1beta1.StepResourceUsage, error) {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "ResourceUsage" {
+			var usage v1beta1.StepResourceUsage
+			if err := json.Unmarshal([]byte(result.Value), &usage); err != nil {
+				return nil, fmt.Errorf("could not parse value %q in ResourceUsage field: %w", result.Value, err)
+			}
+			return &usage, nil
+		}
+	}
+	return nil, nil
+}
+
 func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
@@ -409,6 +496,37 @@ func IsPodExceedingNodeResources(pod *corev1.Pod) bool {
 	return false
 }
 
+// infraFailureReasons are the reasons set on a Pod which failed because of the node it was
+// scheduled on rather than because of its containers.
+var infraFailureReasons = sets.NewString(
+	// The kubelet evicted the Pod, e.g. because of node pressure
+	"Evicted",
+	// The kubelet rejected the Pod because the node is out of pods
+	"OutOfpods",
+	// The node controller marked the Pod of an unreachable node
+	"NodeLost",
+	// The scheduler preempted the Pod for a Pod of a higher priority
+	"Preempting",
+)
+
+// podDisruptionTargetCondition is the condition set on a Pod about to be deleted because of a
+// disruption, such as a preemption or a taint based eviction.
+const podDisruptionTargetCondition corev1.PodConditionType = "DisruptionTarget"
+
+// GetInfraFailure returns the reason and message of the failure of the Pod, and true, if the Pod
+// failed because of the infrastructure it ran on, in which case it is worth running again.
+func GetInfraFailure(pod *corev1.Pod) (string, string, bool) {
+	if infraFailureReasons.Has(pod.Status.Reason) {
+		return pod.Status.Reason, pod.Status.Message, true
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == podDisruptionTargetCondition && cond.Status == corev1.ConditionTrue {
+			return cond.Reason, cond.Message, true
+		}
+	}
+	return "", "", false
+}
+
 // isPodHitConfigError returns true if the Pod's status undicates there are config error raised
 func isPodHitConfigError(pod *corev1.Pod) bool {
 	for _, containerStatus := range pod.Status.ContainerStatuses {
@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -90,6 +91,7 @@ func TestSetTaskRunStatusBasedOnStepStatus(t *testing.T) {
 }
 
 func TestMakeTaskRunStatus(t *testing.T) {
+	exitedGracefully := true
 	for _, c := range []struct {
 		desc      string
 		podStatus corev1.PodStatus
@@ -994,6 +996,132 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "step terminated gracefully",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "foo",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "step-first",
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-first",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  `[{"key":"ExitedGracefully","value":"true","type":"InternalTektonResult"}]`,
+						},
+					},
+				}},
+			},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure(v1beta1.TaskRunReasonFailed.String(), "\"step-first\" exited with code 1 (image: \"\"); for logs run: kubectl -n foo logs pod -c step-first\n"),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+						},
+					},
+					Name:             "first",
+					ContainerName:    "step-first",
+					ExitedGracefully: &exitedGracefully,
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step succeeded after retries",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "step-first",
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-first",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"Attempts","value":"3","type":"InternalTektonResult"}]`,
+						},
+					},
+				}},
+			},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:          "first",
+					ContainerName: "step-first",
+					Attempts:      3,
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step reported its resource usage",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "step-first",
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-first",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"ResourceUsage","value":"{\"peakMemory\":\"64Mi\",\"cpuTime\":\"1.5s\",\"ioReadBytes\":1024,\"ioWriteBytes\":2048}","type":"InternalTektonResult"}]`,
+						},
+					},
+				}},
+			},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:          "first",
+					ContainerName: "step-first",
+					ResourceUsage: &v1beta1.StepResourceUsage{
+						PeakMemory:   resource.MustParse("64Mi"),
+						CPUTime:      metav1.Duration{Duration: 1500 * time.Millisecond},
+						IOReadBytes:  1024,
+						IOWriteBytes: 2048,
+					},
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "when pod is pending because of pulling image then the error should bubble up to taskrun status",
 		pod: corev1.Pod{
@@ -1503,6 +1631,51 @@ func TestSidecarsReady(t *testing.T) {
 	}
 }
 
+func TestPausedStep(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}}
+	spec := corev1.PodSpec{Containers: []corev1.Container{{
+		Name: "step-fetch",
+	}, {
+		Name:           "step-build",
+		ReadinessProbe: breakpointPausedProbe("/tekton/run/1/out.breakpoint"),
+	}}}
+	for _, c := range []struct {
+		desc     string
+		phase    corev1.PodPhase
+		statuses []corev1.ContainerStatus
+		want     string
+	}{{
+		desc:  "paused at a breakpoint",
+		phase: corev1.PodRunning,
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-fetch", State: running, Ready: true},
+			{Name: "step-build", State: running, Ready: true},
+		},
+		want: "build",
+	}, {
+		desc:  "not paused",
+		phase: corev1.PodRunning,
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-fetch", State: running, Ready: true},
+			{Name: "step-build", State: running},
+		},
+	}, {
+		desc:  "pod completed",
+		phase: corev1.PodSucceeded,
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-fetch", State: running, Ready: true},
+			{Name: "step-build", State: running, Ready: true},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: spec, Status: corev1.PodStatus{Phase: c.phase, ContainerStatuses: c.statuses}}
+			if got := PausedStep(pod); got != c.want {
+				t.Errorf("PausedStep() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
 func TestMarkStatusRunning(t *testing.T) {
 	trs := v1beta1.TaskRunStatus{}
 	markStatusRunning(&trs, v1beta1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
@@ -1660,3 +1833,84 @@ func TestSortPodContainerStatuses(t *testing.T) {
 		}
 	}
 }
+
+func TestGetInfraFailure(t *testing.T) {
+	for _, c := range []struct {
+		desc        string
+		podStatus   corev1.PodStatus
+		wantReason  string
+		wantMessage string
+		wantFailure bool
+	}{{
+		desc: "evicted",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+		wantReason:  "Evicted",
+		wantMessage: "The node was low on resource: memory.",
+		wantFailure: true,
+	}, {
+		desc: "out of pods",
+		podStatus: corev1.PodStatus{
+			Phase:  corev1.PodFailed,
+			Reason: "OutOfpods",
+		},
+		wantReason:  "OutOfpods",
+		wantFailure: true,
+	}, {
+		desc: "node lost",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodRunning,
+			Reason:  "NodeLost",
+			Message: "Node node-1 which was running pod pod-1 is unresponsive",
+		},
+		wantReason:  "NodeLost",
+		wantMessage: "Node node-1 which was running pod pod-1 is unresponsive",
+		wantFailure: true,
+	}, {
+		desc: "preempted",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{
+				Type:    "DisruptionTarget",
+				Status:  corev1.ConditionTrue,
+				Reason:  "PreemptionByScheduler",
+				Message: "Preempted in order to admit critical pod",
+			}},
+		},
+		wantReason:  "PreemptionByScheduler",
+		wantMessage: "Preempted in order to admit critical pod",
+		wantFailure: true,
+	}, {
+		desc: "failed step",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-foo",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+				},
+			}},
+		},
+	}, {
+		desc: "unschedulable",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/1 nodes are available: 1 Insufficient cpu.",
+			}},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			reason, message, failure := GetInfraFailure(&corev1.Pod{Status: c.podStatus})
+			if failure != c.wantFailure || reason != c.wantReason || message != c.wantMessage {
+				t.Errorf("GetInfraFailure() = (%q, %q, %t), want (%q, %q, %t)", reason, message, failure, c.wantReason, c.wantMessage, c.wantFailure)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/container"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// stepEnvPathVariable is replaced with the path of the file where a step exports
+	// environment variables to the next steps.
+	stepEnvPathVariable = "step.env.path"
+	// stepEnvFile is the name of the file in the run directory of a step where it
+	// exports environment variables.
+	stepEnvFile = "env"
+	// exportedEnvFile is the name of the file in the run directory of a step where its
+	// entrypoint writes the environment variables exported by it and the previous steps.
+	exportedEnvFile = "env.all"
+)
+
+// applyStepEnvPath returns a copy of the given steps where $(step.env.path) is replaced with
+// the path of the file where each step exports environment variables, and true if any of them
+// uses it.
+func applyStepEnvPath(steps []v1beta1.Step) ([]v1beta1.Step, bool) {
+	used := false
+	for _, s := range steps {
+		used = used || usesStepEnvPath(s)
+	}
+	if !used {
+		return steps, false
+	}
+	replaced := make([]v1beta1.Step, len(steps))
+	for i, s := range steps {
+		replaced[i] = *s.DeepCopy()
+		container.ApplyStepReplacements(&replaced[i], map[string]string{
+			stepEnvPathVariable: filepath.Join(runDir, strconv.Itoa(i), stepEnvFile),
+		}, map[string][]string{})
+	}
+	return replaced, true
+}
+
+func usesStepEnvPath(step v1beta1.Step) bool {
+	variable := "$(" + stepEnvPathVariable + ")"
+	values := []string{step.Script, step.WorkingDir}
+	values = append(values, step.Command...)
+	values = append(values, step.Args...)
+	for _, env := range step.Env {
+		values = append(values, env.Value)
+	}
+	for _, v := range values {
+		if strings.Contains(v, variable) {
+			return true
+		}
+	}
+	return false
+}
+
+// exportStepEnv makes the entrypoint of each of the given ordered steps load the environment
+// variables exported by the previous steps, and export the ones exported by the step.
+func exportStepEnv(steps []corev1.Container) {
+	for i := range steps {
+		idx := strconv.Itoa(i)
+		args := []string{
+			"-env_file", filepath.Join(runDir, idx, stepEnvFile),
+			"-export_env_file", filepath.Join(runDir, idx, exportedEnvFile),
+		}
+		if i > 0 {
+			args = append(args, "-import_env_file", filepath.Join(runDir, strconv.Itoa(i-1), exportedEnvFile))
+		}
+		steps[i].Args = append(args, steps[i].Args...)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyStepEnvPath(t *testing.T) {
+	steps := []v1beta1.Step{{
+		Name:   "describe",
+		Script: "echo VERSION=$(git describe) >> $(step.env.path)",
+	}, {
+		Name: "build",
+		Args: []string{"--env-file", "$(step.env.path)"},
+		Env:  []corev1.EnvVar{{Name: "ENV_FILE", Value: "$(step.env.path)"}},
+	}}
+	got, used := applyStepEnvPath(steps)
+	if !used {
+		t.Error("Expected $(step.env.path) to be used")
+	}
+	want := []v1beta1.Step{{
+		Name:   "describe",
+		Script: "echo VERSION=$(git describe) >> /tekton/run/0/env",
+	}, {
+		Name: "build",
+		Args: []string{"--env-file", "/tekton/run/1/env"},
+		Env:  []corev1.EnvVar{{Name: "ENV_FILE", Value: "/tekton/run/1/env"}},
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+	if steps[1].Env[0].Value != "$(step.env.path)" {
+		t.Errorf("Expected the original steps to be unchanged but got %v", steps[1].Env)
+	}
+
+	unused := []v1beta1.Step{{Name: "build", Script: "echo $(params.version)"}}
+	if got, used := applyStepEnvPath(unused); used || cmp.Diff(unused, got) != "" {
+		t.Errorf("Expected steps without $(step.env.path) to be unchanged but got %v, %t", got, used)
+	}
+}
+
+func TestExportStepEnv(t *testing.T) {
+	steps := []corev1.Container{{
+		Args: []string{"-post_file", "/tekton/run/0/out", "--"},
+	}, {
+		Args: []string{"-wait_file", "/tekton/run/0/out", "-post_file", "/tekton/run/1/out", "--"},
+	}}
+	exportStepEnv(steps)
+	want := []corev1.Container{{
+		Args: []string{
+			"-env_file", "/tekton/run/0/env",
+			"-export_env_file", "/tekton/run/0/env.all",
+			"-post_file", "/tekton/run/0/out", "--",
+		},
+	}, {
+		Args: []string{
+			"-env_file", "/tekton/run/1/env",
+			"-export_env_file", "/tekton/run/1/env.all",
+			"-import_env_file", "/tekton/run/0/env.all",
+			"-wait_file", "/tekton/run/0/out", "-post_file", "/tekton/run/1/out", "--",
+		},
+	}}
+	if d := cmp.Diff(want, steps); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/container"
+	"github.com/tektoncd/pipeline/pkg/internal/stepresults"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyStepResultsPath returns a copy of the given steps where $(step.results.<resultName>.path)
+// is replaced with the path of the file where each step writes its result, and true if any of
+// them declares results.
+func applyStepResultsPath(steps []v1beta1.Step) ([]v1beta1.Step, bool) {
+	declared := false
+	for _, s := range steps {
+		declared = declared || len(s.Results) > 0
+	}
+	if !declared {
+		return steps, false
+	}
+	replaced := make([]v1beta1.Step, len(steps))
+	for i, s := range steps {
+		replaced[i] = *s.DeepCopy()
+		stringReplacements := map[string]string{}
+		for _, r := range s.Results {
+			stringReplacements[fmt.Sprintf(v1beta1.StepResultPathVariableFormat, r.Name)] = filepath.Join(pipeline.StepsDir, StepName(s.Name, i), "results", r.Name)
+		}
+		container.ApplyStepReplacements(&replaced[i], stringReplacements, map[string][]string{})
+	}
+	return replaced, true
+}
+
+// passStepResults makes the entrypoint of each of the given ordered steps resolve the references
+// to the results of the previous steps, create the directory of its own results and promote them
+// to the task results.
+func passStepResults(containers []corev1.Container, steps []v1beta1.Step, results []v1beta1.TaskResult) {
+	for i := range containers {
+		args := []string{"-steps_dir", pipeline.StepsDir}
+		if i < len(steps) && len(steps[i].Results) > 0 {
+			var names, promoted []string
+			for _, r := range steps[i].Results {
+				names = append(names, r.Name)
+			}
+			for _, r := range results {
+				if r.Value == nil {
+					continue
+				}
+				if refs := stepresults.ParseRefs(r.Value.StringVal); len(refs) == 1 && refs[0].Step == steps[i].Name {
+					promoted = append(promoted, r.Name+"="+refs[0].Result)
+				}
+			}
+			args = append(args, "-step_results", strings.Join(names, ","))
+			if len(promoted) > 0 {
+				args = append(args, "-promote_results", strings.Join(promoted, ","))
+			}
+		}
+		containers[i].Args = append(args, containers[i].Args...)
+	}
+}
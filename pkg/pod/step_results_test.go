@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyStepResultsPath(t *testing.T) {
+	steps := []v1beta1.Step{{
+		Name:    "build",
+		Script:  "build --digest-file $(step.results.digest.path)",
+		Results: []v1beta1.StepResult{{Name: "digest"}},
+	}, {
+		Name: "push",
+		Args: []string{"--digest", "$(steps.build.results.digest)", "$(step.results.digest.path)"},
+	}}
+	got, declared := applyStepResultsPath(steps)
+	if !declared {
+		t.Error("Expected step results to be declared")
+	}
+	want := []v1beta1.Step{{
+		Name:    "build",
+		Script:  "build --digest-file /tekton/steps/step-build/results/digest",
+		Results: []v1beta1.StepResult{{Name: "digest"}},
+	}, {
+		Name: "push",
+		Args: []string{"--digest", "$(steps.build.results.digest)", "$(step.results.digest.path)"},
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+	if steps[0].Script != "build --digest-file $(step.results.digest.path)" {
+		t.Errorf("Expected the original steps to be unchanged but got %q", steps[0].Script)
+	}
+
+	undeclared := []v1beta1.Step{{Name: "build", Script: "echo $(params.version)"}}
+	if got, declared := applyStepResultsPath(undeclared); declared || cmp.Diff(undeclared, got) != "" {
+		t.Errorf("Expected steps without results to be unchanged but got %v, %t", got, declared)
+	}
+}
+
+func TestPassStepResults(t *testing.T) {
+	containers := []corev1.Container{{
+		Args: []string{"-post_file", "/tekton/run/0/out", "--"},
+	}, {
+		Args: []string{"-wait_file", "/tekton/run/0/out", "-post_file", "/tekton/run/1/out", "--"},
+	}}
+	steps := []v1beta1.Step{{
+		Name:    "build",
+		Results: []v1beta1.StepResult{{Name: "image-digest"}, {Name: "tags", Type: v1beta1.ResultsTypeArray}},
+	}, {
+		Name: "push",
+		Args: []string{"$(steps.build.results.tags[*])"},
+	}}
+	results := []v1beta1.TaskResult{{
+		Name: "url",
+	}, {
+		Name:  "digest",
+		Value: v1beta1.NewStructuredValues("$(steps.build.results.image-digest)"),
+	}}
+	passStepResults(containers, steps, results)
+	want := []corev1.Container{{
+		Args: []string{
+			"-steps_dir", "/tekton/steps",
+			"-step_results", "image-digest,tags",
+			"-promote_results", "digest=image-digest",
+			"-post_file", "/tekton/run/0/out", "--",
+		},
+	}, {
+		Args: []string{
+			"-steps_dir", "/tekton/steps",
+			"-wait_file", "/tekton/run/0/out", "-post_file", "/tekton/run/1/out", "--",
+		},
+	}}
+	if d := cmp.Diff(want, containers); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// traceSteps passes the context of the span of the TaskRun and the collector the spans are
+// exported to to the entrypoint of the given steps, so that it traces their execution. The
+// steps are only traced when the spans are exported to an OTLP collector, which they can reach.
+func traceSteps(ctx context.Context, taskRun *v1beta1.TaskRun, steps []corev1.Container) {
+	metrics := config.FromContextOrDefaults(ctx).Metrics
+	if metrics == nil || metrics.Tracing.Backend != config.TracingBackendOTLP {
+		return
+	}
+	env := tracing.Env(tracing.ExtractAnnotation(ctx, taskRun.Annotations, tracing.TaskRunSpanContextAnnotation))
+	if len(env) == 0 {
+		return
+	}
+	env = append(env, corev1.EnvVar{Name: tracing.OTLPEndpointEnvVar, Value: metrics.Tracing.OTLPEndpoint})
+	for i, s := range steps {
+		stepEnv := append([]corev1.EnvVar{}, env...)
+		stepEnv = append(stepEnv, corev1.EnvVar{Name: tracing.StepNameEnvVar, Value: trimStepPrefix(s.Name)})
+		// The environment of the step takes precedence.
+		steps[i].Env = append(stepEnv, s.Env...)
+	}
+}
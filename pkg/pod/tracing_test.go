@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTraceSteps(t *testing.T) {
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	taskRun := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{
+			tracing.TaskRunSpanContextAnnotation: `{"traceparent":"` + traceParent + `"}`,
+		},
+	}}
+	otlp := config.ToContext(context.Background(), &config.Config{Metrics: &config.Metrics{
+		Tracing: config.Tracing{Backend: config.TracingBackendOTLP, OTLPEndpoint: "http://otel-collector:4318"},
+	}})
+	file := config.ToContext(context.Background(), &config.Config{Metrics: &config.Metrics{
+		Tracing: config.Tracing{Backend: config.TracingBackendFile, FilePath: "/var/log/spans.jsonl"},
+	}})
+
+	for _, tc := range []struct {
+		desc    string
+		ctx     context.Context
+		taskRun *v1beta1.TaskRun
+		want    []corev1.Container
+	}{{
+		desc:    "steps traced",
+		ctx:     otlp,
+		taskRun: taskRun,
+		want: []corev1.Container{{
+			Name: "step-build",
+			Env: []corev1.EnvVar{
+				{Name: "TRACEPARENT", Value: traceParent},
+				{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://otel-collector:4318"},
+				{Name: "TEKTON_STEP_NAME", Value: "build"},
+				{Name: "FOO", Value: "bar"},
+			},
+		}, {
+			Name: "step-push",
+			Env: []corev1.EnvVar{
+				{Name: "TRACEPARENT", Value: traceParent},
+				{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://otel-collector:4318"},
+				{Name: "TEKTON_STEP_NAME", Value: "push"},
+			},
+		}},
+	}, {
+		desc:    "TaskRun without span",
+		ctx:     otlp,
+		taskRun: &v1beta1.TaskRun{},
+	}, {
+		desc:    "spans not exported to a collector",
+		ctx:     file,
+		taskRun: taskRun,
+	}, {
+		desc:    "tracing disabled",
+		ctx:     context.Background(),
+		taskRun: taskRun,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			steps := []corev1.Container{{
+				Name: "step-build",
+				Env:  []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			}, {
+				Name: "step-push",
+			}}
+			want := tc.want
+			if want == nil {
+				want = []corev1.Container{{
+					Name: "step-build",
+					Env:  []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+				}, {
+					Name: "step-push",
+				}}
+			}
+			traceSteps(tc.ctx, tc.taskRun, steps)
+			if d := cmp.Diff(want, steps); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// AddIndexer adds the given index to the informer, unless an index with the same name was
+// already added, e.g. by another controller sharing the informer.
+func AddIndexer(informer cache.SharedIndexInformer, name string, indexFunc cache.IndexFunc) error {
+	if _, ok := informer.GetIndexer().GetIndexers()[name]; ok {
+		return nil
+	}
+	if err := informer.AddIndexers(cache.Indexers{name: indexFunc}); err != nil {
+		return fmt.Errorf("failed to add the %s index: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestAddIndexer(t *testing.T) {
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &corev1.Pod{}, 0, cache.Indexers{})
+	indexFunc := func(obj interface{}) ([]string, error) {
+		return []string{obj.(*corev1.Pod).Namespace}, nil
+	}
+	if err := AddIndexer(informer, "namespace", indexFunc); err != nil {
+		t.Fatalf("AddIndexer() = %v", err)
+	}
+	if err := informer.GetIndexer().Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Adding the same index again, once the informer holds objects, is a no-op.
+	if err := AddIndexer(informer, "namespace", indexFunc); err != nil {
+		t.Errorf("AddIndexer() of an existing index = %v", err)
+	}
+	objs, err := informer.GetIndexer().ByIndex("namespace", "foo")
+	if err != nil {
+		t.Fatalf("ByIndex() = %v", err)
+	}
+	if len(objs) != 1 {
+		t.Errorf("Expected 1 pod in the index, got %d", len(objs))
+	}
+}
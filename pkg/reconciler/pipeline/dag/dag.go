@@ -126,6 +126,77 @@ func GetCandidateTasks(g *Graph, doneTasks ...string) (sets.String, error) {
 	return d, nil
 }
 
+// Prune returns a new Graph holding only the given targets and the tasks they transitively
+// depend on, minus the tasks to skip and the tasks depending on them, along with the names
+// of the tasks which were pruned. The targets are kept even if they depend on tasks to skip.
+// All the tasks of the Graph are considered targets when no targets are given. An error is
+// returned if a target or a task to skip isn't present in the Graph.
+func Prune(g *Graph, targets []string, skip []string) (*Graph, sets.String, error) {
+	for _, name := range append(append([]string{}, targets...), skip...) {
+		if _, ok := g.Nodes[name]; !ok {
+			return nil, nil, fmt.Errorf("task %s wasn't present in Pipeline", name)
+		}
+	}
+
+	kept := sets.NewString()
+	if len(targets) == 0 {
+		kept.Insert(getNames(g)...)
+	} else {
+		for _, target := range targets {
+			addAncestors(g.Nodes[target], kept)
+		}
+	}
+	kept.Delete(skip...)
+	for _, name := range skip {
+		removeDescendants(g.Nodes[name], kept, sets.NewString(targets...))
+	}
+
+	pruned := newGraph()
+	for name := range kept {
+		// errors can't happen since names are unique in the source Graph
+		_, _ = pruned.addPipelineTask(g.Nodes[name].Task)
+	}
+	for name := range kept {
+		for _, prev := range g.Nodes[name].Prev {
+			if kept.Has(prev.Task.HashKey()) {
+				linkPipelineTasks(pruned.Nodes[prev.Task.HashKey()], pruned.Nodes[name])
+			}
+		}
+	}
+	return pruned, sets.NewString(getNames(g)...).Difference(kept), nil
+}
+
+func addAncestors(n *Node, ancestors sets.String) {
+	if ancestors.Has(n.Task.HashKey()) {
+		return
+	}
+	ancestors.Insert(n.Task.HashKey())
+	for _, prev := range n.Prev {
+		addAncestors(prev, ancestors)
+	}
+}
+
+// removeDescendants removes the descendants of n from kept, apart from the given targets,
+// which stop the removal of their own descendants as well.
+func removeDescendants(n *Node, kept sets.String, targets sets.String) {
+	for _, next := range n.Next {
+		name := next.Task.HashKey()
+		if targets.Has(name) || !kept.Has(name) {
+			continue
+		}
+		kept.Delete(name)
+		removeDescendants(next, kept, targets)
+	}
+}
+
+func getNames(g *Graph) []string {
+	names := make([]string, 0, len(g.Nodes))
+	for name := range g.Nodes {
+		names = append(names, name)
+	}
+	return names
+}
+
 func linkPipelineTasks(prev *Node, next *Node) {
 	next.Prev = append(next.Prev, prev)
 	prev.Next = append(prev.Next, next)
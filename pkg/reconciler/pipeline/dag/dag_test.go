@@ -622,6 +622,99 @@ func TestBuildGraphWithHundredsOfTasks_InvalidDAG(t *testing.T) {
 	}
 }
 
+func TestPrune(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		targets    []string
+		skip       []string
+		want       []v1beta1.PipelineTask
+		wantPruned sets.String
+	}{{
+		name:    "target and its ancestors",
+		targets: []string{"y"},
+		want: []v1beta1.PipelineTask{
+			{Name: "a"},
+			{Name: "x", RunAfter: []string{"a"}},
+			{Name: "y", RunAfter: []string{"a", "x"}},
+		},
+		wantPruned: sets.NewString("b", "w", "z"),
+	}, {
+		name:    "skipped ancestor of a target",
+		targets: []string{"w"},
+		skip:    []string{"y"},
+		want: []v1beta1.PipelineTask{
+			{Name: "a"},
+			{Name: "b"},
+			{Name: "w", RunAfter: []string{"b"}},
+			{Name: "x", RunAfter: []string{"a"}},
+		},
+		wantPruned: sets.NewString("y", "z"),
+	}, {
+		name: "skip without targets prunes the dependents",
+		skip: []string{"x"},
+		want: []v1beta1.PipelineTask{
+			{Name: "a"},
+			{Name: "b"},
+		},
+		wantPruned: sets.NewString("w", "x", "y", "z"),
+	}, {
+		name:    "skip keeps the targets but prunes the other dependents",
+		targets: []string{"w"},
+		skip:    []string{"x"},
+		want: []v1beta1.PipelineTask{
+			{Name: "a"},
+			{Name: "b"},
+			{Name: "w", RunAfter: []string{"b"}},
+		},
+		wantPruned: sets.NewString("x", "y", "z"),
+	}, {
+		name:    "multiple targets",
+		targets: []string{"z", "b"},
+		want: []v1beta1.PipelineTask{
+			{Name: "a"},
+			{Name: "b"},
+			{Name: "x", RunAfter: []string{"a"}},
+			{Name: "z", RunAfter: []string{"x"}},
+		},
+		wantPruned: sets.NewString("w", "y"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := dag.Build(v1beta1.PipelineTaskList(tc.want), v1beta1.PipelineTaskList(tc.want).Deps())
+			if err != nil {
+				t.Fatal(err)
+			}
+			g, pruned, err := dag.Prune(testGraph(t), tc.targets, tc.skip)
+			if err != nil {
+				t.Fatalf("didn't expect error pruning graph but got %v", err)
+			}
+			assertSameDAG(t, want, g)
+			if d := cmp.Diff(tc.wantPruned, pruned); d != "" {
+				t.Errorf("unexpected pruned tasks %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPrune_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		targets []string
+		skip    []string
+	}{{
+		name:    "unknown target",
+		targets: []string{"a", "notthere"},
+	}, {
+		name: "unknown task to skip",
+		skip: []string{"notthere"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := dag.Prune(testGraph(t), tc.targets, tc.skip); err == nil {
+				t.Errorf("expected to see an error for invalid targets or skip %v %v but had none", tc.targets, tc.skip)
+			}
+		})
+	}
+}
+
 func testGraph(t *testing.T) *dag.Graph {
 	//  b     a
 	//  |    / \
@@ -28,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	"go.uber.org/zap"
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -200,6 +201,26 @@ func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.Pi
 	return trNames, runNames, err
 }
 
+// cancelPipelineTasksForTaskControls patches the `TaskRun`s and `Run`s of the running PipelineTasks which the
+// user requested to cancel or skip through the PipelineRun's taskControls with canceled status
+func cancelPipelineTasksForTaskControls(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, facts *resources.PipelineRunFacts, clientSet clientset.Interface) error {
+	taskNames := sets.NewString()
+	for _, rpt := range facts.State {
+		if _, ok := facts.TaskControls[rpt.PipelineTask.Name]; ok && rpt.IsRunning() {
+			taskNames.Insert(rpt.PipelineTask.Name)
+		}
+	}
+	if taskNames.Len() == 0 {
+		return nil
+	}
+
+	logger.Infof("cancelling PipelineTasks %v of PipelineRun %s as requested by its taskControls", taskNames.List(), pr.Name)
+	if errs := cancelPipelineTaskRunsForTaskNames(ctx, logger, pr, clientSet, taskNames); len(errs) > 0 {
+		return fmt.Errorf("error(s) from cancelling TaskRun(s) from PipelineRun %s: %s", pr.Name, strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
 // gracefullyCancelPipelineRun marks any non-final resolved TaskRun(s) as cancelled and runs finally.
 func gracefullyCancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) error {
 	errs := cancelPipelineTaskRuns(ctx, logger, pr, clientSet)
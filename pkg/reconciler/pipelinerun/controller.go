@@ -28,15 +28,21 @@ import (
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
 	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/pipelinerun"
 	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
-	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1alpha1/resolutionrequest"
+	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
 	resourceinformer "github.com/tektoncd/pipeline/pkg/client/resource/injection/informers/resource/v1alpha1/pipelineresource"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/priority"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	priorityclassinformer "knative.dev/pkg/client/injection/kube/informers/scheduling/v1/priorityclass"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -53,18 +59,26 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		pipelineRunInformer := pipelineruninformer.Get(ctx)
 		resourceInformer := resourceinformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
-		configStore := config.NewStore(logger.Named("config-store"), pipelinerunmetrics.MetricsOnStore(logger))
+		namespaceInformer := namespaceinformer.Get(ctx)
+		priorityClassInformer := priorityclassinformer.Get(ctx)
+		configStore := config.NewStore(logger.Named("config-store"), pipelinerunmetrics.MetricsOnStore(logger), tracing.OnStore(logger, tracing.ControllerServiceName))
 		configStore.WatchConfigs(cmw)
 
+		if err := tknreconciler.AddIndexer(pipelineRunInformer.Informer(), pruner.PipelineIndex, pruner.PipelineIndexFunc); err != nil {
+			logger.Fatalf("Error indexing PipelineRuns: %v", err)
+		}
+
 		c := &Reconciler{
 			KubeClientSet:       kubeclientset,
 			PipelineClientSet:   pipelineclientset,
 			Images:              opts.Images,
 			Clock:               clock,
 			pipelineRunLister:   pipelineRunInformer.Lister(),
+			pipelineRunIndexer:  pipelineRunInformer.Informer().GetIndexer(),
 			taskRunLister:       taskRunInformer.Lister(),
 			runLister:           runInformer.Lister(),
 			resourceLister:      resourceInformer.Lister(),
+			namespaceLister:     namespaceInformer.Lister(),
 			cloudEventClient:    cloudeventclient.Get(ctx),
 			metrics:             pipelinerunmetrics.Get(ctx),
 			pvcHandler:          volumeclaim.NewPVCHandler(kubeclientset, logger),
@@ -77,7 +91,7 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			}
 		})
 
-		pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(priority.Enqueue(impl, priorityClassInformer.Lister())))
 
 		taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
@@ -40,6 +40,7 @@ import (
 	listersv1alpha1 "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
 	resourcelisters "github.com/tektoncd/pipeline/pkg/client/resource/listers/resource/v1alpha1"
+	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	"github.com/tektoncd/pipeline/pkg/matrix"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
@@ -53,7 +54,11 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -62,6 +67,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
@@ -119,6 +126,9 @@ const (
 	// ReasonRequiredWorkspaceMarkedOptional indicates an optional workspace
 	// has been passed to a Task that is expecting a non-optional workspace
 	ReasonRequiredWorkspaceMarkedOptional = "RequiredWorkspaceMarkedOptional"
+	// ReasonInvalidTargets indicates that the PipelineRun's targets or skip list reference
+	// PipelineTasks which aren't part of the Pipeline's tasks
+	ReasonInvalidTargets = "InvalidTargets"
 	// ReasonResolvingPipelineRef indicates that the PipelineRun is waiting for
 	// its pipelineRef to be asynchronously resolved.
 	ReasonResolvingPipelineRef = "ResolvingPipelineRef"
@@ -133,9 +143,11 @@ type Reconciler struct {
 
 	// listers index properties about resources
 	pipelineRunLister   listers.PipelineRunLister
+	pipelineRunIndexer  cache.Indexer
 	taskRunLister       listers.TaskRunLister
 	runLister           listersv1alpha1.RunLister
 	resourceLister      resourcelisters.PipelineResourceLister
+	namespaceLister     corev1listers.NamespaceLister
 	cloudEventClient    cloudevent.CEClient
 	metrics             *pipelinerunmetrics.Recorder
 	pvcHandler          volumeclaim.PvcHandler
@@ -153,6 +165,8 @@ var (
 func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx, span := tracing.StartRun(ctx, &pr.ObjectMeta, tracing.PipelineRunSpanContextAnnotation, "PipelineRun", "PipelineRun:ReconcileKind")
+	defer span.End()
 
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
@@ -164,6 +178,9 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Warnf("PipelineRun %s createTimestamp %s is after the pipelineRun started %s", pr.GetNamespacedName().String(), pr.CreationTimestamp, pr.Status.StartTime)
 			pr.Status.StartTime = &pr.CreationTimestamp
 		}
+		if err := c.metrics.QueueWait(pr); err != nil {
+			logger.Warnf("Failed to log the metrics : %v", err)
+		}
 
 		// Emit events. During the first reconcile the status of the PipelineRun may change twice
 		// from not Started to Started and then to Running, so we need to sent the event here
@@ -204,7 +221,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to update Run status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
-		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+		if err := c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil); err != nil {
+			return err
+		}
+		return c.pruneCompletedPipelineRuns(ctx, pr)
 	}
 
 	if err := propagatePipelineNameLabelToPipelineRun(pr); err != nil {
@@ -282,6 +302,14 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 
 	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
 	events.Emit(ctx, beforeCondition, afterCondition, pr)
+	if pr.IsDone() {
+		// The root span of the PipelineRun lasts until it completes.
+		end := c.Clock.Now()
+		if pr.Status.CompletionTime != nil {
+			end = pr.Status.CompletionTime.Time
+		}
+		tracing.EndRun(ctx, &pr.ObjectMeta, tracing.PipelineRunSpanContextAnnotation, "PipelineRun", end)
+	}
 	_, err := c.updateLabelsAndAnnotations(ctx, pr)
 	if err != nil {
 		logger.Warn("Failed to update PipelineRun labels/annotations", zap.Error(err))
@@ -303,6 +331,8 @@ func (c *Reconciler) resolvePipelineState(
 	pipelineMeta *metav1.ObjectMeta,
 	pr *v1beta1.PipelineRun,
 	providedResources map[string]*resourcev1alpha1.PipelineResource) (resources.PipelineRunState, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "resolvePipelineState")
+	defer span.End()
 	pst := resources.PipelineRunState{}
 	// Resolve each task individually because they each could have a different reference context (remote or local).
 	for _, task := range tasks {
@@ -335,6 +365,14 @@ func (c *Reconciler) resolvePipelineState(
 			if errors.Is(err, remote.ErrorRequestInProgress) {
 				return nil, err
 			}
+			if errors.Is(err, trustedresources.ErrResourceVerificationFailed) {
+				vr := trustedresources.VerificationResult{Type: trustedresources.VerificationError, Err: err}
+				pr.Status.SetCondition(vr.Condition())
+				pr.Status.MarkFailed(trustedresources.ReasonResourceVerificationFailed,
+					"Pipeline %s/%s can't be Run; it contains Tasks that failed verification: %s",
+					pipelineMeta.Namespace, pipelineMeta.Name, err)
+				return nil, controller.NewPermanentError(err)
+			}
 			switch err := err.(type) {
 			case *resources.TaskNotFoundError:
 				pr.Status.MarkFailed(ReasonCouldntGetTask,
@@ -365,11 +403,19 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	}
 
 	pipelineMeta, pipelineSpec, err := rprp.GetPipelineData(ctx, pr, getPipelineFunc)
+	if pipelineMeta != nil && pipelineMeta.VerificationResult != nil {
+		pr.Status.SetCondition(pipelineMeta.VerificationResult.Condition())
+	}
 	switch {
 	case errors.Is(err, remote.ErrorRequestInProgress):
 		message := fmt.Sprintf("PipelineRun %s/%s awaiting remote resource", pr.Namespace, pr.Name)
 		pr.Status.MarkRunning(ReasonResolvingPipelineRef, message)
 		return nil
+	case errors.Is(err, trustedresources.ErrResourceVerificationFailed):
+		logger.Errorf("PipelineRun %s/%s references a Pipeline that failed verification: %v", pr.Namespace, pr.Name, err)
+		pr.Status.MarkFailed(trustedresources.ReasonResourceVerificationFailed,
+			"PipelineRun %s/%s can't be Run; %s", pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
 	case err != nil:
 		logger.Errorf("Failed to determine Pipeline spec to use for pipelinerun %s: %v", pr.Name, err)
 		pr.Status.MarkFailed(ReasonCouldntGetPipeline,
@@ -405,6 +451,19 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// prune the DAG tasks the PipelineRun's targets don't need, or which it asks to skip
+	prunedTasks := sets.NewString()
+	if len(pr.Spec.Targets) > 0 || len(pr.Spec.Skip) > 0 {
+		d, prunedTasks, err = dag.Prune(d, pr.Spec.Targets, pr.Spec.Skip)
+		if err != nil {
+			// This Run has failed, so we need to mark it as failed and stop reconciling it
+			pr.Status.MarkFailed(ReasonInvalidTargets,
+				"PipelineRun %s/%s's targets or skip list are invalid: %s",
+				pr.Namespace, pr.Name, err)
+			return controller.NewPermanentError(err)
+		}
+	}
+
 	// Because of parameter propagation, we skip validating it inside the pipelineSpec since it may
 	// not have the full list of defined parameters
 	ctx = config.SkipValidationDueToPropagatedParametersAndWorkspaces(ctx, true)
@@ -493,10 +552,19 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Ensure that the PipelineTasks which are run get the results they need from pruned PipelineTasks.
+	if err := resources.ValidatePrunedTaskResults(pipelineSpec, pr, prunedTasks); err != nil {
+		pr.Status.MarkFailed(ReasonInvalidTaskResultReference,
+			"PipelineRun %s/%s doesn't provide the results of its pruned PipelineTasks: %s",
+			pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// Apply parameter substitution from the PipelineRun
 	pipelineSpec = resources.ApplyParameters(ctx, pipelineSpec, pr)
 	pipelineSpec = resources.ApplyContexts(ctx, pipelineSpec, pipelineMeta.Name, pr)
 	pipelineSpec = resources.ApplyWorkspaces(ctx, pipelineSpec, pr)
+	pipelineSpec = resources.ApplyPrunedTaskResults(ctx, pipelineSpec, pr, prunedTasks)
 	// Update pipelinespec of pipelinerun's status field
 	pr.Status.PipelineSpec = pipelineSpec
 
@@ -508,7 +576,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	if len(pipelineSpec.Finally) > 0 {
 		tasks = append(tasks, pipelineSpec.Finally...)
 	}
-	pipelineRunState, err := c.resolvePipelineState(ctx, tasks, pipelineMeta, pr, providedResources)
+	pipelineRunState, err := c.resolvePipelineState(ctx, tasks, pipelineMeta.ObjectMeta, pr, providedResources)
 	switch {
 	case errors.Is(err, remote.ErrorRequestInProgress):
 		message := fmt.Sprintf("PipelineRun %s/%s awaiting remote resource", pr.Namespace, pr.Name)
@@ -529,6 +597,11 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		TimeoutsState: resources.PipelineRunTimeoutsState{
 			Clock: c.Clock,
 		},
+		TaskControls: make(map[string]v1beta1.PipelineTaskControlAction),
+		PrunedTasks:  prunedTasks,
+	}
+	for _, tc := range pr.Spec.TaskControls {
+		pipelineRunFacts.TaskControls[tc.Name] = tc.Action
 	}
 	if pr.Status.StartTime != nil {
 		pipelineRunFacts.TimeoutsState.StartTime = &pr.Status.StartTime.Time
@@ -567,6 +640,12 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		}
 	}
 
+	// cancel the running PipelineTasks the user requested to cancel or skip through taskControls
+	if err := cancelPipelineTasksForTaskControls(ctx, logger, pr, pipelineRunFacts, c.PipelineClientSet); err != nil {
+		// failed to cancel tasks, maybe retry would help (don't return permanent error)
+		return err
+	}
+
 	if pipelineRunFacts.State.IsBeforeFirstTaskRun() {
 		if err := resources.ValidatePipelineTaskResults(pipelineRunFacts.State); err != nil {
 			logger.Errorf("Failed to resolve task result reference for %q with error %v", pr.Name, err)
@@ -877,6 +956,8 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 }
 
 func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params []v1beta1.Param, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun, storageBasePath string) (*v1beta1.TaskRun, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "createTaskRun", trace.WithAttributes(attribute.String("taskrun", taskRunName)))
+	defer span.End()
 	logger := logging.FromContext(ctx)
 
 	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
@@ -924,6 +1005,11 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		tr.Spec.TaskRef = rpt.PipelineTask.TaskRef
 	} else if rpt.ResolvedTaskResources.TaskSpec != nil {
 		tr.Spec.TaskSpec = rpt.ResolvedTaskResources.TaskSpec
+		// A Task embedded in a Pipeline comes from the same source as the Pipeline. Its provenance is
+		// passed on creation, since the status of the PipelineRun is only updated after this reconcile.
+		if err := addPipelineProvenance(tr, pr.Status.Provenance); err != nil {
+			return nil, err
+		}
 	}
 
 	var pipelinePVCWorkspaceName string
@@ -937,11 +1023,30 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		tr.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
 	}
 
+	// The span of the creation of the TaskRun is the parent of the spans of the TaskRun.
+	tracing.InjectAnnotation(ctx, &tr.Annotations, tracing.TaskRunSpanContextAnnotation)
+
 	resources.WrapSteps(&tr.Spec, rpt.PipelineTask, rpt.ResolvedTaskResources.Inputs, rpt.ResolvedTaskResources.Outputs, storageBasePath)
 	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", taskRunName, rpt.PipelineTask.Name)
 	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
 }
 
+// addPipelineProvenance annotates tr with the provenance of the Pipeline of its PipelineRun, if any.
+func addPipelineProvenance(tr *v1beta1.TaskRun, provenance *v1beta1.Provenance) error {
+	if provenance == nil {
+		return nil
+	}
+	b, err := json.Marshal(provenance)
+	if err != nil {
+		return fmt.Errorf("failed to serialize the provenance of the Pipeline: %w", err)
+	}
+	if tr.Annotations == nil {
+		tr.Annotations = map[string]string{}
+	}
+	tr.Annotations[v1beta1.PipelineProvenanceAnnotationKey] = string(b)
+	return nil
+}
+
 func (c *Reconciler) createRuns(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun) ([]*v1alpha1.Run, error) {
 	var runs []*v1alpha1.Run
 	matrixCombinations := matrix.FanOut(rpt.PipelineTask.Matrix).ToMap()
@@ -1096,6 +1201,8 @@ func clearStatus(tr *v1beta1.TaskRun) {
 	tr.Status.StartTime = nil
 	tr.Status.CompletionTime = nil
 	tr.Status.PodName = ""
+	// Each retry gets its own infra retries, the previous ones are kept in the retry history.
+	tr.Status.InfraRetries = nil
 }
 
 func getTaskrunAnnotations(pr *v1beta1.PipelineRun) map[string]string {
@@ -1216,10 +1323,14 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, pr *v1beta1
 	return newPr, nil
 }
 
-func storePipelineSpecAndMergeMeta(pr *v1beta1.PipelineRun, ps *v1beta1.PipelineSpec, meta *metav1.ObjectMeta) error {
+func storePipelineSpecAndMergeMeta(pr *v1beta1.PipelineRun, ps *v1beta1.PipelineSpec, meta *resolutionutil.ResolvedObjectMeta) error {
 	// Only store the PipelineSpec once, if it has never been set before.
 	if pr.Status.PipelineSpec == nil {
 		pr.Status.PipelineSpec = ps
+		// Record the source the Pipeline was resolved from.
+		if meta.ConfigSource != nil {
+			pr.Status.Provenance = &v1beta1.Provenance{ConfigSource: meta.ConfigSource}
+		}
 
 		// Propagate labels from Pipeline to PipelineRun.
 		if pr.ObjectMeta.Labels == nil {
@@ -25,6 +25,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -37,16 +38,28 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	fakepipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun/fake"
+	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	trtesting "github.com/tektoncd/pipeline/pkg/trustedresources/testing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	eventstest "github.com/tektoncd/pipeline/test/events"
 	"github.com/tektoncd/pipeline/test/names"
 	"github.com/tektoncd/pipeline/test/parse"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"gomodules.xyz/jsonpatch/v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -124,7 +137,7 @@ type PipelineRunTest struct {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, trustedResourcesExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -141,6 +154,9 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetMetricsConfigName() {
 			metricsExists = true
 		}
+		if cm.Name == config.GetTrustedResourcesConfigName() {
+			trustedResourcesExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -172,6 +188,12 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !trustedResourcesExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getPipelineRunController returns an instance of the PipelineRun controller/reconciler that has been seeded with
@@ -186,6 +208,10 @@ func initializePipelineRunControllerAssets(t *testing.T, d test.Data, opts pipel
 	ctx, _ := ttesting.SetupFakeContext(t)
 	ctx, cancel := context.WithCancel(ctx)
 	ensureConfigurationConfigMapsExist(&d)
+	// The controller indexes the PipelineRuns, which can only be done before the informer holds any object.
+	if err := tknreconciler.AddIndexer(fakepipelineruninformer.Get(ctx).Informer(), pruner.PipelineIndex, pruner.PipelineIndexFunc); err != nil {
+		t.Fatal(err)
+	}
 	c, informers := test.SeedTestData(t, ctx, d)
 	configMapWatcher := cminformer.NewInformedWatcher(c.Kube, system.Namespace())
 	ctl := NewController(&opts, testClock)(ctx, configMapWatcher)
@@ -1349,6 +1375,103 @@ spec:
 	}
 }
 
+// TestReconcile_PipelineResourceVerification runs "Reconcile" on PipelineRuns referencing signed and
+// unsigned Pipelines with the "resource-verification-mode" feature flag set to "enforce".
+func TestReconcile_PipelineResourceVerification(t *testing.T) {
+	signer, publicKey := trtesting.GetSignerAndPublicKey(t)
+	signedPipeline := parse.MustParsePipeline(t, `
+metadata:
+  name: signed-pipeline
+  namespace: foo
+spec:
+  tasks:
+    - name: unit-test-task-spec
+      taskSpec:
+        steps:
+          - name: mystep
+            image: myimage
+`)
+	if err := trustedresources.SignPipeline(signedPipeline, signer); err != nil {
+		t.Fatalf("SignPipeline() = %v", err)
+	}
+	unsignedPipeline := signedPipeline.DeepCopy()
+	unsignedPipeline.Name = "unsigned-pipeline"
+	unsignedPipeline.Annotations = nil
+
+	prs := []*v1beta1.PipelineRun{
+		parse.MustParsePipelineRun(t, `
+metadata:
+  name: pipelinerun-signed
+  namespace: foo
+spec:
+  pipelineRef:
+    name: signed-pipeline
+`),
+		parse.MustParsePipelineRun(t, `
+metadata:
+  name: pipelinerun-unsigned
+  namespace: foo
+spec:
+  pipelineRef:
+    name: unsigned-pipeline
+`),
+	}
+	cms := []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"resource-verification-mode": config.EnforceResourceVerificationMode,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				config.PublicKeysKey: publicKey,
+			},
+		},
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    []*v1beta1.Pipeline{signedPipeline, unsignedPipeline},
+		ConfigMaps:   cms,
+	}
+
+	for _, tc := range []struct {
+		name              string
+		pipelineRun       string
+		permanentError    bool
+		wantEvents        []string
+		wantVerified      corev1.ConditionStatus
+		wantFailureReason string
+	}{{
+		name:         "signed pipeline",
+		pipelineRun:  "pipelinerun-signed",
+		wantEvents:   []string{"Normal Started", "Normal Running Tasks Completed: 0"},
+		wantVerified: corev1.ConditionTrue,
+	}, {
+		name:              "unsigned pipeline",
+		pipelineRun:       "pipelinerun-unsigned",
+		permanentError:    true,
+		wantEvents:        []string{"Normal Started", "Warning Failed PipelineRun foo/pipelinerun-unsigned can't be Run", "Warning InternalError 1 error occurred"},
+		wantVerified:      corev1.ConditionFalse,
+		wantFailureReason: trustedresources.ReasonResourceVerificationFailed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, _ := prt.reconcileRun("foo", tc.pipelineRun, tc.wantEvents, tc.permanentError)
+			verified := reconciledRun.Status.GetCondition(trustedresources.ConditionTrustedResourcesVerified)
+			if verified == nil || verified.Status != tc.wantVerified {
+				t.Errorf("Expected %s condition with status %s, but had %v", trustedresources.ConditionTrustedResourcesVerified, tc.wantVerified, verified)
+			}
+			if tc.wantFailureReason != "" {
+				checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, tc.wantFailureReason)
+			}
+		})
+	}
+}
+
 func TestReconcile_InvalidPipelineRunNames(t *testing.T) {
 	// TestReconcile_InvalidPipelineRunNames runs "Reconcile" on several PipelineRuns that have invalid names.
 	// It verifies that reconcile fails, how it fails and which events are triggered.
@@ -2090,6 +2213,256 @@ spec:
 	}
 }
 
+func TestReconcileWithTaskControls(t *testing.T) {
+	// TestReconcileWithTaskControls runs "Reconcile" on a PipelineRun that requests one of its running PipelineTasks to be skipped.
+	// It verifies that the TaskRun of that PipelineTask is cancelled, while the PipelineRun keeps running and the dependent
+	// PipelineTask is not scheduled.
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-task-controls
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa
+  taskControls:
+  - name: hello-world-1
+    action: skip
+status:
+  startTime: "2022-01-01T00:00:00Z"
+  taskRuns:
+    test-pipeline-run-task-controls-hello-world-1:
+      pipelineTaskName: hello-world-1
+`)}
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+  - name: hello-world-2
+    runAfter:
+    - hello-world-1
+    taskRef:
+      name: hello-world
+`)}
+	ts := []*v1beta1.Task{simpleHelloWorldTask}
+	trs := []*v1beta1.TaskRun{
+		createHelloWorldTaskRun(t, "test-pipeline-run-task-controls-hello-world-1", "foo",
+			"test-pipeline-run-task-controls", "test-pipeline"),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-task-controls", wantEvents, false)
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun status to be running, but was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+
+	if len(reconciledRun.Status.TaskRuns) != 1 {
+		t.Errorf("Expected PipelineRun status to have 1 task run, but was %v", len(reconciledRun.Status.TaskRuns))
+	}
+
+	actions := clients.Pipeline.Actions()
+	patchActions := make([]ktesting.PatchAction, 0)
+	for _, action := range actions {
+		if patchAction, ok := action.(ktesting.PatchAction); ok {
+			patchActions = append(patchActions, patchAction)
+		}
+	}
+	if len(patchActions) != 1 {
+		t.Fatalf("Expected 1 patch action, but was %v", len(patchActions))
+	}
+	if patchActions[0].GetName() != "test-pipeline-run-task-controls-hello-world-1" {
+		t.Errorf("Expected TaskRun test-pipeline-run-task-controls-hello-world-1 to be patched, but was %s", patchActions[0].GetName())
+	}
+	if d := cmp.Diff(cancelTaskRunPatchBytes, patchActions[0].GetPatch()); d != "" {
+		t.Errorf("Unexpected patch %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithTargetsAndSkip(t *testing.T) {
+	// TestReconcileWithTargetsAndSkip runs "Reconcile" on PipelineRuns running a subset of their Pipeline.
+	// It verifies that only the selected PipelineTasks are scheduled, that the pruned ones are reported as
+	// skipped, and that results of pruned PipelineTasks must be provided through the PipelineRun's params.
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: hello-world
+  - name: deploy
+    params:
+    - name: image
+      value: $(tasks.build.results.image)
+    taskRef:
+      name: hello-world
+  - name: e2e
+    runAfter:
+    - deploy
+    taskRef:
+      name: hello-world
+`)}
+	ts := []*v1beta1.Task{parse.MustParseTask(t, `
+metadata:
+  name: hello-world
+  namespace: foo
+spec:
+  params:
+  - name: image
+    default: none
+  results:
+  - name: image
+  steps:
+  - name: simple-step
+    image: foo
+    command: ["/mycmd"]
+`)}
+
+	for _, tc := range []struct {
+		name                 string
+		spec                 string
+		wantTaskRuns         []string
+		wantImage            string
+		wantSkippedTasks     []v1beta1.SkippedTask
+		wantFailedReason     string
+		wantPermanentFailure bool
+	}{{
+		name: "targets",
+		spec: `
+  targets:
+  - build
+`,
+		wantTaskRuns: []string{"build"},
+		wantSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "deploy",
+			Reason: v1beta1.PrunedSkip,
+		}, {
+			Name:   "e2e",
+			Reason: v1beta1.PrunedSkip,
+		}},
+	}, {
+		name: "skip prunes the dependents",
+		spec: `
+  skip:
+  - deploy
+`,
+		wantTaskRuns: []string{"build"},
+		wantSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "deploy",
+			Reason: v1beta1.PrunedSkip,
+		}, {
+			Name:   "e2e",
+			Reason: v1beta1.PrunedSkip,
+		}},
+	}, {
+		name: "skip with result provided through params",
+		spec: `
+  params:
+  - name: tasks.build.results.image
+    value: gcr.io/foo/bar
+  targets:
+  - deploy
+  skip:
+  - build
+`,
+		wantTaskRuns: []string{"deploy"},
+		wantImage:    "gcr.io/foo/bar",
+		wantSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "build",
+			Reason: v1beta1.PrunedSkip,
+		}, {
+			Name:   "e2e",
+			Reason: v1beta1.PrunedSkip,
+		}},
+	}, {
+		name: "skip without result provided",
+		spec: `
+  targets:
+  - deploy
+  skip:
+  - build
+`,
+		wantFailedReason:     ReasonInvalidTaskResultReference,
+		wantPermanentFailure: true,
+	}, {
+		name: "target not in pipeline",
+		spec: `
+  targets:
+  - notthere
+`,
+		wantFailedReason:     ReasonInvalidTargets,
+		wantPermanentFailure: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-targets
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa
+`+tc.spec)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			wantEvents := []string{"Normal Started", "Normal Running Tasks Completed: 0"}
+			if tc.wantFailedReason != "" {
+				wantEvents = []string{"Normal Started", "Warning Failed", "Warning InternalError 1 error occurred"}
+			}
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-targets", wantEvents, tc.wantPermanentFailure)
+
+			if tc.wantFailedReason != "" {
+				condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+				if !condition.IsFalse() || condition.Reason != tc.wantFailedReason {
+					t.Errorf("Expected PipelineRun to fail with reason %s, but condition was %v", tc.wantFailedReason, condition)
+				}
+				return
+			}
+
+			var gotTaskRuns []string
+			for _, tr := range getTaskRunCreations(t, clients.Pipeline.Actions(), 2) {
+				pipelineTaskName := tr.Labels[pipeline.PipelineTaskLabelKey]
+				gotTaskRuns = append(gotTaskRuns, pipelineTaskName)
+				if pipelineTaskName == "deploy" {
+					if d := cmp.Diff(tc.wantImage, tr.Spec.Params[0].Value.StringVal); d != "" {
+						t.Errorf("Unexpected image param %s", diff.PrintWantGot(d))
+					}
+				}
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, gotTaskRuns); d != "" {
+				t.Errorf("Unexpected TaskRuns %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantSkippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
+				t.Errorf("Unexpected skipped tasks %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconcileOnCancelledRunFinallyPipelineRunWithFinalTaskAndRetries(t *testing.T) {
 	// TestReconcileOnCancelledRunFinallyPipelineRunWithFinalTaskAndRetries runs "Reconcile" on a PipelineRun that has
 	// been gracefully cancelled. It verifies that reconcile is successful, the pipeline status updated and events generated.
@@ -3494,6 +3867,77 @@ status:
 	}
 }
 
+// TestReconcileRetryResetsInfraRetries verifies that when a PipelineRun retries a TaskRun,
+// the infra retries of the failed attempt are moved to the retry history instead of
+// being carried over to the new attempt.
+func TestReconcileRetryResetsInfraRetries(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline-retry
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    retries: 1
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-retry-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline-retry
+  serviceAccountName: test-sa
+status:
+  taskRuns:
+    hello-world-1:
+      pipelineTaskName: hello-world-1
+`)}
+	trs := []*v1beta1.TaskRun{parse.MustParseTaskRun(t, `
+metadata:
+  name: hello-world-1
+  namespace: foo
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+  podName: hello-world-1-pod-infra2
+  infraRetries:
+  - podName: hello-world-1-pod
+    reason: Evicted
+    failureTime: "2021-12-31T00:00:00Z"
+  - podName: hello-world-1-pod-infra1
+    reason: Evicted
+    failureTime: "2021-12-31T00:01:00Z"
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	prt.reconcileRun("foo", "test-pipeline-retry-run", []string{}, false)
+
+	tr, err := prt.TestAssets.Clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "hello-world-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the retried TaskRun: %v", err)
+	}
+	if len(tr.Status.InfraRetries) != 0 {
+		t.Errorf("Expected the infra retries to be reset on retry, but got %v", tr.Status.InfraRetries)
+	}
+	if len(tr.Status.RetriesStatus) != 1 {
+		t.Fatalf("Expected 1 retry but got %d", len(tr.Status.RetriesStatus))
+	}
+	if d := cmp.Diff(trs[0].Status.InfraRetries, tr.Status.RetriesStatus[0].InfraRetries); d != "" {
+		t.Errorf("Infra retries of the failed attempt %s", diff.PrintWantGot(d))
+	}
+}
+
 // TestReconcileAndPropagateCustomPipelineTaskRunSpec tests that custom PipelineTaskRunSpec declared
 // in PipelineRun is propagated to created TaskRuns
 func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
@@ -5599,17 +6043,23 @@ func Test_storePipelineSpec(t *testing.T) {
 
 	ps := v1beta1.PipelineSpec{Description: "foo-pipeline"}
 	ps1 := v1beta1.PipelineSpec{Description: "bar-pipeline"}
+	configSource := &v1beta1.ConfigSource{
+		URI:      "https://abc.com.git",
+		Digest:   map[string]string{"sha1": "xyz"},
+		Resolver: "git",
+	}
 
 	want := pr.DeepCopy()
 	want.Status = v1beta1.PipelineRunStatus{
 		PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
 			PipelineSpec: ps.DeepCopy(),
+			Provenance:   &v1beta1.Provenance{ConfigSource: configSource.DeepCopy()},
 		},
 	}
 	want.ObjectMeta.Labels["tekton.dev/pipeline"] = pr.ObjectMeta.Name
 
 	// The first time we set it, it should get copied.
-	if err := storePipelineSpecAndMergeMeta(pr, &ps, &pr.ObjectMeta); err != nil {
+	if err := storePipelineSpecAndMergeMeta(pr, &ps, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &pr.ObjectMeta, ConfigSource: configSource}); err != nil {
 		t.Errorf("storePipelineSpec() error = %v", err)
 	}
 	if d := cmp.Diff(pr, want); d != "" {
@@ -5617,7 +6067,7 @@ func Test_storePipelineSpec(t *testing.T) {
 	}
 
 	// The next time, it should not get overwritten
-	if err := storePipelineSpecAndMergeMeta(pr, &ps1, &metav1.ObjectMeta{}); err != nil {
+	if err := storePipelineSpecAndMergeMeta(pr, &ps1, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &metav1.ObjectMeta{}, ConfigSource: &v1beta1.ConfigSource{URI: "https://other.com.git"}}); err != nil {
 		t.Errorf("storePipelineSpec() error = %v", err)
 	}
 	if d := cmp.Diff(pr, want); d != "" {
@@ -5637,7 +6087,7 @@ func Test_storePipelineSpec_metadata(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: pipelinerunlabels, Annotations: pipelinerunannotations},
 	}
 	meta := metav1.ObjectMeta{Name: "bar", Labels: pipelinelabels, Annotations: pipelineannotations}
-	if err := storePipelineSpecAndMergeMeta(pr, &v1beta1.PipelineSpec{}, &meta); err != nil {
+	if err := storePipelineSpecAndMergeMeta(pr, &v1beta1.PipelineSpec{}, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &meta}); err != nil {
 		t.Errorf("storePipelineSpecAndMergeMeta error = %v", err)
 	}
 	if d := cmp.Diff(pr.ObjectMeta.Labels, wantedlabels); d != "" {
@@ -7045,6 +7495,110 @@ spec:
 	}
 }
 
+func TestReconcile_Tracing(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-traced
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+      - name: unit-test
+        taskSpec:
+          steps:
+            - name: mystep
+              image: myimage
+`)}
+	d := test.Data{PipelineRuns: prs}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-traced", []string{}, false)
+
+	prCtx := tracing.ExtractAnnotation(context.Background(), reconciledRun.Annotations, tracing.PipelineRunSpanContextAnnotation)
+	prSpan := oteltrace.SpanContextFromContext(prCtx)
+	if !prSpan.IsValid() {
+		t.Fatalf("Expected the span context of the PipelineRun to be stored in its annotations but got %v", reconciledRun.Annotations)
+	}
+	actual := getTaskRunCreations(t, clients.Pipeline.Actions(), 2)[0]
+	trCtx := tracing.ExtractAnnotation(context.Background(), actual.Annotations, tracing.TaskRunSpanContextAnnotation)
+	if trSpan := oteltrace.SpanContextFromContext(trCtx); trSpan.TraceID() != prSpan.TraceID() {
+		t.Errorf("Expected the span context of the TaskRun to be in the trace of the PipelineRun but got %v", actual.Annotations)
+	}
+
+	var names []string
+	for _, s := range recorder.Ended() {
+		if s.SpanContext().TraceID() != prSpan.TraceID() {
+			t.Errorf("Expected span %q to be in the trace of the PipelineRun", s.Name())
+		}
+		names = append(names, s.Name())
+	}
+	// The root span of the PipelineRun is only ended once it is done.
+	want := []string{"resolvePipelineState", "createTaskRun", "PipelineRun:ReconcileKind"}
+	if d := cmp.Diff(want, names); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_TracingEndsRootSpanOnCompletion(t *testing.T) {
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	pr := parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-traced-completed
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+      - name: unit-test
+        taskSpec:
+          steps:
+            - name: mystep
+              image: myimage
+status:
+  conditions:
+  - message: All Tasks have completed executing
+    reason: Succeeded
+    status: "True"
+    type: Succeeded
+`)
+	// The root span of the PipelineRun was started by its first reconcile.
+	_, span := tracing.StartRun(context.Background(), &pr.ObjectMeta, tracing.PipelineRunSpanContextAnnotation, "PipelineRun", "PipelineRun:ReconcileKind")
+	span.End()
+	completionTime := time.Now().Add(time.Minute).Truncate(time.Second)
+	pr.Status.CompletionTime = &metav1.Time{Time: completionTime}
+
+	prt := newPipelineRunTest(test.Data{PipelineRuns: []*v1beta1.PipelineRun{pr}}, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Succeeded All Tasks have completed executing",
+	}
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-traced-completed", wantEvents, false)
+
+	var root sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "PipelineRun" {
+			root = s
+		}
+	}
+	if root == nil {
+		t.Fatalf("Expected the root span of the PipelineRun to be ended")
+	}
+	if !root.EndTime().Equal(completionTime) || root.StartTime().After(span.(sdktrace.ReadOnlySpan).StartTime()) {
+		t.Errorf("Expected the root span of the PipelineRun to last from its first reconcile to %v but got %v to %v", completionTime, root.StartTime(), root.EndTime())
+	}
+	if reconciledRun.Annotations[tracing.PipelineRunSpanContextAnnotation] == pr.Annotations[tracing.PipelineRunSpanContextAnnotation] {
+		t.Errorf("Expected the start time of the ended root span to be removed from the annotations of the PipelineRun but got %v", reconciledRun.Annotations)
+	}
+}
+
 // newPipelineRunTest returns PipelineRunTest with a new PipelineRun controller created with specified state through data
 // This PipelineRunTest can be reused for multiple PipelineRuns by calling reconcileRun for each pipelineRun
 func newPipelineRunTest(data test.Data, t *testing.T) *PipelineRunTest {
@@ -7173,7 +7727,8 @@ metadata:
 `)
 
 	// Create a bundle from our pipeline and tasks.
-	if _, err := test.CreateImage(ref, ps, remoteTask); err != nil {
+	imgRef, err := test.CreateImage(ref, ps, remoteTask)
+	if err != nil {
 		t.Fatalf("failed to create image in pipeline renconcile: %s", err.Error())
 	}
 
@@ -7209,6 +7764,8 @@ spec:
     kind: Task
     name: unit-test-task
 `, ref))
+	// The annotations of the Pipeline, including the digest of the bundle it was fetched from, are propagated.
+	expectedTaskRun.Annotations[oci.DigestAnnotation] = strings.SplitN(imgRef, "@", 2)[1]
 
 	if d := cmp.Diff(expectedTaskRun, actual, ignoreTypeMeta, cmpopts.SortSlices(lessTaskResourceBindings)); d != "" {
 		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
@@ -7468,7 +8025,7 @@ spec:
 	pipelinerun, _ := prt.reconcileRun(pr.Namespace, pr.Name, wantEvents, false)
 	checkPipelineRunConditionStatusAndReason(t, pipelinerun, corev1.ConditionUnknown, ReasonResolvingPipelineRef)
 
-	client := prt.TestAssets.Clients.ResolutionRequests.ResolutionV1alpha1().ResolutionRequests("default")
+	client := prt.TestAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("default")
 	resolutionrequests, err := client.List(prt.TestAssets.Ctx, metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error listing resource requests: %v", err)
@@ -7501,6 +8058,11 @@ spec:
                     echo "hello world!"
         `)
 	resreq.Status.ResolutionRequestStatusFields.Data = base64.StdEncoding.Strict().EncodeToString(pipelineBytes)
+	resreq.Status.Annotations = map[string]string{
+		resolutioncommon.AnnotationKeySourceURI:        "git+https://github.com/tektoncd/catalog.git",
+		resolutioncommon.AnnotationKeySourceDigest:     "sha1:a123",
+		resolutioncommon.AnnotationKeySourceEntryPoint: "pipeline/foo/foo.yaml",
+	}
 	resreq.Status.MarkSucceeded()
 	resreq, err = client.UpdateStatus(prt.TestAssets.Ctx, resreq, metav1.UpdateOptions{})
 	if err != nil {
@@ -7512,6 +8074,32 @@ spec:
 	// started executing.
 	updatedPipelineRun, _ := prt.reconcileRun("default", "pr", nil, false)
 	checkPipelineRunConditionStatusAndReason(t, updatedPipelineRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+
+	wantProvenance := &v1beta1.Provenance{
+		ConfigSource: &v1beta1.ConfigSource{
+			URI:        "git+https://github.com/tektoncd/catalog.git",
+			Digest:     map[string]string{"sha1": "a123"},
+			EntryPoint: "pipeline/foo/foo.yaml",
+			Resolver:   v1beta1.ResolverName(resolverName),
+		},
+	}
+	if d := cmp.Diff(wantProvenance, updatedPipelineRun.Status.Provenance); d != "" {
+		t.Errorf("Unexpected provenance %s", diff.PrintWantGot(d))
+	}
+
+	// The TaskRun of the Task embedded in the Pipeline is created with the provenance of the
+	// Pipeline, before the status of the PipelineRun recording it is updated.
+	taskRuns, err := prt.TestAssets.Clients.Pipeline.TektonV1beta1().TaskRuns("default").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error listing taskruns: %v", err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Fatalf("expected exactly 1 taskrun but found %d", len(taskRuns.Items))
+	}
+	wantAnnotation := `{"configSource":{"uri":"git+https://github.com/tektoncd/catalog.git","digest":{"sha1":"a123"},"entryPoint":"pipeline/foo/foo.yaml","resolver":"` + resolverName + `"}}`
+	if d := cmp.Diff(wantAnnotation, taskRuns.Items[0].Annotations[v1beta1.PipelineProvenanceAnnotationKey]); d != "" {
+		t.Errorf("Unexpected provenance annotation %s", diff.PrintWantGot(d))
+	}
 }
 
 // TestReconcileWithFailingResolver checks that a PipelineRun with a failing Resolver
@@ -7546,7 +8134,7 @@ spec:
 	pipelinerun, _ := prt.reconcileRun(pr.Namespace, pr.Name, wantEvents, false)
 	checkPipelineRunConditionStatusAndReason(t, pipelinerun, corev1.ConditionUnknown, ReasonResolvingPipelineRef)
 
-	client := prt.TestAssets.Clients.ResolutionRequests.ResolutionV1alpha1().ResolutionRequests("default")
+	client := prt.TestAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("default")
 	resolutionrequests, err := client.List(prt.TestAssets.Ctx, metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error listing resource requests: %v", err)
@@ -7608,7 +8196,7 @@ spec:
 	pipelinerun, _ := prt.reconcileRun(pr.Namespace, pr.Name, wantEvents, false)
 	checkPipelineRunConditionStatusAndReason(t, pipelinerun, corev1.ConditionUnknown, v1beta1.TaskRunReasonResolvingTaskRef)
 
-	client := prt.TestAssets.Clients.ResolutionRequests.ResolutionV1alpha1().ResolutionRequests("default")
+	client := prt.TestAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("default")
 	resolutionrequests, err := client.List(prt.TestAssets.Ctx, metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error listing resource requests: %v", err)
@@ -7673,7 +8261,7 @@ spec:
 	pipelinerun, _ := prt.reconcileRun(pr.Namespace, pr.Name, wantEvents, false)
 	checkPipelineRunConditionStatusAndReason(t, pipelinerun, corev1.ConditionUnknown, v1beta1.TaskRunReasonResolvingTaskRef)
 
-	client := prt.TestAssets.Clients.ResolutionRequests.ResolutionV1alpha1().ResolutionRequests("default")
+	client := prt.TestAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("default")
 	resolutionrequests, err := client.List(prt.TestAssets.Ctx, metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error listing resource requests: %v", err)
@@ -7688,9 +8276,9 @@ spec:
 	if resolutionRequestType != resolverName {
 		t.Fatalf("expected resource request type %q but saw %q", resolutionRequestType, resolverName)
 	}
-	reqParams := resreq.Spec.Parameters
-	if reqParams["foo"] != "bar" {
-		t.Fatalf("expected resource request parameter 'bar', but got '%s'", reqParams["foo"])
+	reqParams := resreq.Spec.Params
+	if len(reqParams) != 1 || reqParams[0].Name != "foo" || reqParams[0].Value.StringVal != "bar" {
+		t.Fatalf("expected resource request parameter 'foo' with value 'bar', but got %v", reqParams)
 	}
 
 	taskBytes := []byte(`
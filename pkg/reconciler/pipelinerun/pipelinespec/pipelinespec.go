@@ -23,26 +23,36 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// GetPipeline is a function used to retrieve Pipelines.
-type GetPipeline func(context.Context, string) (v1beta1.PipelineObject, error)
+// GetPipeline is a function used to retrieve Pipelines. It also returns the source the Pipeline
+// was resolved from, which is nil for Pipelines fetched from the cluster, and the result of the
+// verification of the Pipeline, which is nil if the Pipeline was not verified.
+type GetPipeline func(context.Context, string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error)
 
 // GetPipelineData will retrieve the Pipeline metadata and Spec associated with the
 // provided PipelineRun. This can come from a reference Pipeline or from the PipelineRun's
-// metadata and embedded PipelineSpec.
-func GetPipelineData(ctx context.Context, pipelineRun *v1beta1.PipelineRun, getPipeline GetPipeline) (*metav1.ObjectMeta, *v1beta1.PipelineSpec, error) {
+// metadata and embedded PipelineSpec. The source a referenced Pipeline was resolved from
+// and the result of its verification are returned along with its metadata, which is also
+// returned when the Pipeline fails verification.
+func GetPipelineData(ctx context.Context, pipelineRun *v1beta1.PipelineRun, getPipeline GetPipeline) (*resolutionutil.ResolvedObjectMeta, *v1beta1.PipelineSpec, error) {
 	pipelineMeta := metav1.ObjectMeta{}
 	pipelineSpec := v1beta1.PipelineSpec{}
+	var configSource *v1beta1.ConfigSource
+	var vr *trustedresources.VerificationResult
 	cfg := config.FromContextOrDefaults(ctx)
 	switch {
 	case pipelineRun.Spec.PipelineRef != nil && pipelineRun.Spec.PipelineRef.Name != "":
 		// Get related pipeline for pipelinerun
-		t, err := getPipeline(ctx, pipelineRun.Spec.PipelineRef.Name)
+		t, source, verificationResult, err := getPipeline(ctx, pipelineRun.Spec.PipelineRef.Name)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error when listing pipelines for pipelineRun %s: %w", pipelineRun.Name, err)
+			return &resolutionutil.ResolvedObjectMeta{VerificationResult: verificationResult}, nil, fmt.Errorf("error when listing pipelines for pipelineRun %s: %w", pipelineRun.Name, err)
 		}
+		configSource = source
+		vr = verificationResult
 		pipelineMeta = t.PipelineMetadata()
 		pipelineSpec = t.PipelineSpec()
 		pipelineSpec.SetDefaults(ctx)
@@ -50,18 +60,24 @@ func GetPipelineData(ctx context.Context, pipelineRun *v1beta1.PipelineRun, getP
 		pipelineMeta = pipelineRun.ObjectMeta
 		pipelineSpec = *pipelineRun.Spec.PipelineSpec
 	case cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields && pipelineRun.Spec.PipelineRef != nil && pipelineRun.Spec.PipelineRef.Resolver != "":
-		pipeline, err := getPipeline(ctx, "")
+		pipeline, source, verificationResult, err := getPipeline(ctx, "")
 		switch {
 		case err != nil:
-			return nil, nil, err
+			return &resolutionutil.ResolvedObjectMeta{VerificationResult: verificationResult}, nil, err
 		case pipeline == nil:
 			return nil, nil, errors.New("resolution of remote resource completed successfully but no pipeline was returned")
 		default:
 			pipelineMeta = pipeline.PipelineMetadata()
 			pipelineSpec = pipeline.PipelineSpec()
+			configSource = source
+			vr = verificationResult
 		}
 	default:
 		return nil, nil, fmt.Errorf("pipelineRun %s not providing PipelineRef or PipelineSpec", pipelineRun.Name)
 	}
-	return &pipelineMeta, &pipelineSpec, nil
+	return &resolutionutil.ResolvedObjectMeta{
+		ObjectMeta:         &pipelineMeta,
+		ConfigSource:       configSource,
+		VerificationResult: vr,
+	}, &pipelineSpec, nil
 }
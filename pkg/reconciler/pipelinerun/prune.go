@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// pruneCompletedPipelineRuns deletes the completed PipelineRuns of the Pipeline of pr which exceed
+// the history limits of the namespace, and pr itself once its TTL expired. The TaskRuns and Runs of
+// a deleted PipelineRun are deleted with it through their owner references. If the TTL of pr has not
+// expired yet, pr is requeued for when it does.
+func (c *Reconciler) pruneCompletedPipelineRuns(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	logger := logging.FromContext(ctx)
+
+	// The lister returns a nil namespace when it is not found, which leaves the defaults.
+	ns, err := c.namespaceLister.Get(pr.Namespace)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get namespace %s: %w", pr.Namespace, err)
+	}
+	settings, err := pruner.GetSettings(ctx, ns)
+	if err != nil {
+		// An invalid annotation is a user error which retrying won't fix: fall back to the defaults.
+		logger.Warnf("Failed to get the pruning settings of namespace %s, using the defaults: %v", pr.Namespace, err)
+		settings, _ = pruner.GetSettings(ctx, nil)
+	}
+
+	if pipelineName, ok := pr.Labels[pipeline.PipelineLabelKey]; ok && settings.HasHistoryLimits() {
+		if err := c.prunePipelineRunsExceedingHistory(ctx, pr.Namespace, pipelineName, settings); err != nil {
+			return err
+		}
+	}
+
+	if pruner.IsRetained(pr) || pr.Status.CompletionTime == nil {
+		return nil
+	}
+	// A failed PipelineRun retained for debugging keeps its TaskRuns and the PersistentVolumeClaims
+	// of its volumeClaimTemplate workspaces until its retention expires.
+	if remaining := debugRetention(ctx, pr, c.Clock.Now()); remaining > 0 {
+		return controller.NewRequeueAfter(remaining)
+	}
+	remaining, expires := settings.TimeToExpiry(pr.Status.CompletionTime.Time, c.Clock.Now())
+	if !expires {
+		return nil
+	}
+	if remaining > 0 {
+		return controller.NewRequeueAfter(remaining)
+	}
+	logger.Infof("Deleting PipelineRun %s: completed more than %s ago", pr.Name, settings.TTLAfterFinished)
+	return c.deletePipelineRun(ctx, pr, pruner.ReasonTTLExpired)
+}
+
+// prunePipelineRunsExceedingHistory deletes the completed PipelineRuns of the given Pipeline which
+// are older than the most recent ones to keep.
+func (c *Reconciler) prunePipelineRunsExceedingHistory(ctx context.Context, namespace, pipelineName string, settings pruner.Settings) error {
+	logger := logging.FromContext(ctx)
+
+	objs, err := c.pipelineRunIndexer.ByIndex(pruner.PipelineIndex, pruner.IndexKey(namespace, pipelineName))
+	if err != nil {
+		return fmt.Errorf("failed to list PipelineRuns of Pipeline %s: %w", pipelineName, err)
+	}
+
+	byName := map[string]*v1beta1.PipelineRun{}
+	runs := []pruner.Run{}
+	for _, obj := range objs {
+		p, ok := obj.(*v1beta1.PipelineRun)
+		if !ok {
+			continue
+		}
+		if !p.IsDone() || p.Status.CompletionTime == nil || pruner.IsRetained(p) || debugRetention(ctx, p, c.Clock.Now()) > 0 {
+			continue
+		}
+		byName[p.Name] = p
+		runs = append(runs, pruner.Run{
+			Name:           p.Name,
+			CompletionTime: p.Status.CompletionTime.Time,
+			Succeeded:      p.Status.GetCondition(apis.ConditionSucceeded).IsTrue(),
+		})
+	}
+
+	for _, name := range settings.RunsExceedingHistory(runs) {
+		logger.Infof("Deleting PipelineRun %s: history limit of Pipeline %s exceeded", name, pipelineName)
+		if err := c.deletePipelineRun(ctx, byName[name], pruner.ReasonHistoryLimitExceeded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Reconciler) deletePipelineRun(ctx context.Context, pr *v1beta1.PipelineRun, reason string) error {
+	propagationPolicy := metav1.DeletePropagationBackground
+	err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Delete(ctx, pr.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete PipelineRun %s: %w", pr.Name, err)
+	}
+	controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeNormal, reason, "Deleted completed PipelineRun %s", pr.Name)
+	if err := c.metrics.Pruned(ctx, pr, reason); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to log the metrics : %v", err)
+	}
+	return nil
+}
+
+// debugRetention returns how long is left before pr stops being retained for debugging, which is
+// not positive if pr didn't fail or is not retained.
+func debugRetention(ctx context.Context, pr *v1beta1.PipelineRun, now time.Time) time.Duration {
+	if pr.Status.CompletionTime == nil || !pr.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+		return 0
+	}
+	ttl, err := pruner.DebugRetentionTTL(ctx, pr)
+	if err != nil {
+		// An invalid annotation is a user error which retrying won't fix: fall back to the default.
+		logging.FromContext(ctx).Warnf("Failed to get the debug retention TTL of PipelineRun %s, using the default: %v", pr.Name, err)
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	return pr.Status.CompletionTime.Add(ttl).Sub(now)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/parse"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/controller"
+)
+
+func withAnnotations(pr *v1beta1.PipelineRun, annotations map[string]string) *v1beta1.PipelineRun {
+	pr.Annotations = annotations
+	return pr
+}
+
+func completedPipelineRun(t *testing.T, name string, status corev1.ConditionStatus, completedAgo time.Duration, retain bool) *v1beta1.PipelineRun {
+	t.Helper()
+	pr := parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+  labels:
+    tekton.dev/pipeline: test-pipeline
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - reason: Completed
+    status: %q
+    type: Succeeded
+  startTime: %s
+  completionTime: %s
+`, name, status, now.Add(-completedAgo-time.Minute).Format(time.RFC3339), now.Add(-completedAgo).Format(time.RFC3339)))
+	if retain {
+		pr.Annotations = map[string]string{pruner.RetainAnnotationKey: "true"}
+	}
+	return pr
+}
+
+func getPipelineRunDeletions(actions []ktesting.Action) []string {
+	var deleted []string
+	for _, a := range actions {
+		if action, ok := a.(ktesting.DeleteAction); ok && action.GetResource().Resource == "pipelineruns" {
+			deleted = append(deleted, action.GetName())
+		}
+	}
+	return deleted
+}
+
+func TestReconcilePruneCompletedPipelineRuns(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		prs         []*v1beta1.PipelineRun
+		wantDeleted []string
+		wantRequeue time.Duration
+	}{{
+		name: "no pruning by default",
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun(t, "test-pipeline-run", corev1.ConditionTrue, time.Hour, false),
+			completedPipelineRun(t, "old-pipeline-run", corev1.ConditionTrue, 48*time.Hour, false),
+		},
+	}, {
+		name:        "ttl expired",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "1h"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun(t, "test-pipeline-run", corev1.ConditionTrue, 2*time.Hour, false),
+		},
+		wantDeleted: []string{"test-pipeline-run"},
+	}, {
+		name:        "ttl not expired yet",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "1h"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun(t, "test-pipeline-run", corev1.ConditionTrue, 20*time.Minute, false),
+		},
+		wantRequeue: 40 * time.Minute,
+	}, {
+		name:        "ttl expired but retained",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "1h"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun(t, "test-pipeline-run", corev1.ConditionTrue, 2*time.Hour, true),
+		},
+	}, {
+		name: "history limits exceeded",
+		annotations: map[string]string{
+			pruner.SuccessfulRunsHistoryLimitAnnotationKey: "1",
+			pruner.FailedRunsHistoryLimitAnnotationKey:     "1",
+		},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun(t, "test-pipeline-run", corev1.ConditionTrue, time.Minute, false),
+			completedPipelineRun(t, "successful-1", corev1.ConditionTrue, 2*time.Hour, false),
+			completedPipelineRun(t, "successful-2", corev1.ConditionTrue, 3*time.Hour, true),
+			completedPipelineRun(t, "failed-1", corev1.ConditionFalse, 2*time.Hour, false),
+			completedPipelineRun(t, "failed-2", corev1.ConditionFalse, 3*time.Hour, false),
+		},
+		wantDeleted: []string{"successful-1", "failed-2"},
+	}, {
+		name:        "ttl expired but failed and retained for debugging",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "1h"},
+		prs: []*v1beta1.PipelineRun{
+			withAnnotations(completedPipelineRun(t, "test-pipeline-run", corev1.ConditionFalse, 2*time.Hour, false),
+				map[string]string{pruner.DebugRetentionTTLAnnotationKey: "3h"}),
+		},
+		wantRequeue: time.Hour,
+	}, {
+		name:        "history limits exceeded by a failed pipelinerun retained for debugging",
+		annotations: map[string]string{pruner.FailedRunsHistoryLimitAnnotationKey: "1"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun(t, "test-pipeline-run", corev1.ConditionFalse, time.Minute, false),
+			withAnnotations(completedPipelineRun(t, "failed-1", corev1.ConditionFalse, 2*time.Hour, false),
+				map[string]string{pruner.DebugRetentionTTLAnnotationKey: "3h"}),
+			completedPipelineRun(t, "failed-2", corev1.ConditionFalse, 3*time.Hour, false),
+		},
+		wantDeleted: []string{"failed-2"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns: tc.prs,
+				Namespaces: []*corev1.Namespace{{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: tc.annotations},
+				}},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			c := prt.TestAssets.Controller
+			err := c.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipeline-run")
+			if tc.wantRequeue > 0 {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay != tc.wantRequeue {
+					t.Errorf("Expected the PipelineRun to be requeued after %s but got %v", tc.wantRequeue, err)
+				}
+			} else if err != nil {
+				t.Errorf("Error reconciling: %s", err)
+			}
+
+			if d := cmp.Diff(tc.wantDeleted, getPipelineRunDeletions(prt.TestAssets.Clients.Pipeline.Actions())); d != "" {
+				t.Errorf("Unexpected deleted PipelineRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
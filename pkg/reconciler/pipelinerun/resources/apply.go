@@ -28,6 +28,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/substitution"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -140,6 +141,19 @@ func paramsFromPipelineRun(ctx context.Context, pr *v1beta1.PipelineRun) (map[st
 	return stringReplacements, arrayReplacements, objectReplacements
 }
 
+// ApplyPrunedTaskResults replaces references to the results of the PipelineTasks pruned from the
+// PipelineRun through its targets or skip list with the values provided for them in the PipelineRun's params.
+func ApplyPrunedTaskResults(ctx context.Context, p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun, pruned sets.String) *v1beta1.PipelineSpec {
+	var resolvedResultRefs ResolvedResultRefs
+	for _, ref := range prunedTaskResultsFromParams(pr, pruned) {
+		resolvedResultRefs = append(resolvedResultRefs, ref)
+	}
+	if len(resolvedResultRefs) == 0 {
+		return p
+	}
+	return ApplyReplacements(ctx, p, resolvedResultRefs.getStringReplacements(), resolvedResultRefs.getArrayReplacements(), resolvedResultRefs.getObjectReplacements())
+}
+
 // ApplyContexts applies the substitution from $(context.(pipelineRun|pipeline).*) with the specified values.
 // Currently supports only name substitution. Uses "" as a default if name is not specified.
 func ApplyContexts(ctx context.Context, spec *v1beta1.PipelineSpec, pipelineName string, pr *v1beta1.PipelineRun) *v1beta1.PipelineSpec {
@@ -28,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func TestApplyParameters(t *testing.T) {
@@ -2360,6 +2361,72 @@ func TestApplyWorkspaces(t *testing.T) {
 	}
 }
 
+func TestApplyPrunedTaskResults(t *testing.T) {
+	ps := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "deploy",
+			Params: []v1beta1.Param{{
+				Name:  "image",
+				Value: *v1beta1.NewStructuredValues("$(tasks.build.results.image)"),
+			}, {
+				Name:  "tags",
+				Value: *v1beta1.NewStructuredValues("$(tasks.build.results.tags[*])"),
+			}, {
+				Name:  "commit",
+				Value: *v1beta1.NewStructuredValues("$(tasks.clone.results.commit)"),
+			}},
+		}},
+		Finally: []v1beta1.PipelineTask{{
+			Name: "notify",
+			Params: []v1beta1.Param{{
+				Name:  "message",
+				Value: *v1beta1.NewStructuredValues("deployed $(tasks.build.results.image)"),
+			}},
+		}},
+	}
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "tasks.build.results.image",
+				Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar"),
+			}, {
+				Name:  "tasks.build.results.tags",
+				Value: *v1beta1.NewStructuredValues("latest", "v1"),
+			}, {
+				Name:  "tasks.clone.results.commit",
+				Value: *v1beta1.NewStructuredValues("abcd"),
+			}},
+		},
+	}
+	expected := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "deploy",
+			Params: []v1beta1.Param{{
+				Name:  "image",
+				Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar"),
+			}, {
+				Name:  "tags",
+				Value: *v1beta1.NewStructuredValues("latest", "v1"),
+			}, {
+				// clone wasn't pruned, its result is resolved from its TaskRun
+				Name:  "commit",
+				Value: *v1beta1.NewStructuredValues("$(tasks.clone.results.commit)"),
+			}},
+		}},
+		Finally: []v1beta1.PipelineTask{{
+			Name: "notify",
+			Params: []v1beta1.Param{{
+				Name:  "message",
+				Value: *v1beta1.NewStructuredValues("deployed gcr.io/foo/bar"),
+			}},
+		}},
+	}
+	got := ApplyPrunedTaskResults(context.Background(), ps, pr, sets.NewString("build"))
+	if d := cmp.Diff(expected, got); d != "" {
+		t.Errorf("ApplyPrunedTaskResults() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyFinallyResultsToPipelineResults(t *testing.T) {
 	for _, tc := range []struct {
 		description   string
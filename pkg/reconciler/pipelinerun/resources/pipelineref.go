@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
 	"github.com/tektoncd/pipeline/pkg/remote/resolution"
 	remoteresource "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -37,49 +38,59 @@ import (
 
 // GetPipelineFunc is a factory function that will use the given PipelineRef to return a valid GetPipeline function that
 // looks up the pipeline. It uses as context a k8s client, tekton client, namespace, and service account name to return
-// the pipeline. It knows whether it needs to look in the cluster or in a remote location to fetch the reference.
+// the pipeline. It knows whether it needs to look in the cluster or in a remote location to fetch the reference. The
+// returned function verifies the signature of the fetched pipeline according to the "resource-verification-mode"
+// feature flag.
 func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, requester remoteresource.Requester, pipelineRun *v1beta1.PipelineRun) (rprp.GetPipeline, error) {
 	cfg := config.FromContextOrDefaults(ctx)
 	pr := pipelineRun.Spec.PipelineRef
 	namespace := pipelineRun.Namespace
 	// if the spec is already in the status, do not try to fetch it again, just use it as source of truth
 	if pipelineRun.Status.PipelineSpec != nil {
-		return func(_ context.Context, name string) (v1beta1.PipelineObject, error) {
+		return func(_ context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			return &v1beta1.Pipeline{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      name,
 					Namespace: namespace,
 				},
 				Spec: *pipelineRun.Status.PipelineSpec,
-			}, nil
+			}, nil, nil, nil
 		}, nil
 	}
 	switch {
 	case cfg.FeatureFlags.EnableTektonOCIBundles && pr != nil && pr.Bundle != "":
 		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
 		// casting it to a PipelineObject.
-		return func(ctx context.Context, name string) (v1beta1.PipelineObject, error) {
+		return func(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			// If there is a bundle url at all, construct an OCI resolver to fetch the pipeline.
 			kc, err := k8schain.New(ctx, k8s, k8schain.Options{
 				Namespace:          namespace,
 				ServiceAccountName: pipelineRun.Spec.ServiceAccountName,
 			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to get keychain: %w", err)
+				return nil, nil, nil, fmt.Errorf("failed to get keychain: %w", err)
 			}
-			resolver := oci.NewResolver(pr.Bundle, kc)
-			return resolvePipeline(ctx, resolver, name)
+			verification, err := oci.ImageVerificationFromConfig(cfg.TrustedResources)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			resolver := oci.NewResolver(pr.Bundle, kc, oci.WithImageVerification(verification))
+			pipeline, source, err := resolvePipeline(ctx, resolver, name)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return verifyPipeline(ctx, pipeline, source, namespace, trustedresources.BundleResolver)
 		}, nil
 	case cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields && pr != nil && pr.Resolver != "" && requester != nil:
-		return func(ctx context.Context, name string) (v1beta1.PipelineObject, error) {
-			params := map[string]string{}
+		return func(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			stringReplacements, arrayReplacements, objectReplacements := paramsFromPipelineRun(ctx, pipelineRun)
 			replacedParams := replaceParamValues(pr.Params, stringReplacements, arrayReplacements, objectReplacements)
-			for _, p := range replacedParams {
-				params[p.Name] = p.Value.StringVal
+			resolver := resolution.NewResolver(requester, pipelineRun, string(pr.Resolver), "", "", replacedParams)
+			pipeline, source, err := resolvePipeline(ctx, resolver, name)
+			if err != nil {
+				return nil, nil, nil, err
 			}
-			resolver := resolution.NewResolver(requester, pipelineRun, string(pr.Resolver), "", "", params)
-			return resolvePipeline(ctx, resolver, name)
+			return verifyPipeline(ctx, pipeline, source, namespace, string(pr.Resolver))
 		}, nil
 	default:
 		// Even if there is no task ref, we should try to return a local resolver.
@@ -87,8 +98,24 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 			Namespace:    namespace,
 			Tektonclient: tekton,
 		}
-		return local.GetPipeline, nil
+		return func(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+			pipeline, err := local.GetPipeline(ctx, name)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return verifyPipeline(ctx, pipeline, nil, namespace, "")
+		}, nil
+	}
+}
+
+// verifyPipeline verifies the signature of pipeline, resolved from source, with the public keys trusted for the
+// given namespace and resolver. The pipeline is not returned if it fails verification and the failure is enforced.
+func verifyPipeline(ctx context.Context, pipeline v1beta1.PipelineObject, source *v1beta1.ConfigSource, namespace, resolver string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+	vr := trustedresources.VerifyPipeline(ctx, pipeline, namespace, resolver)
+	if vr != nil && vr.Type == trustedresources.VerificationError {
+		return nil, nil, vr, vr.Err
 	}
+	return pipeline, source, vr, nil
 }
 
 // LocalPipelineRefResolver uses the current cluster to resolve a pipeline reference.
@@ -110,17 +137,18 @@ func (l *LocalPipelineRefResolver) GetPipeline(ctx context.Context, name string)
 // resolvePipeline accepts an impl of remote.Resolver and attempts to
 // fetch a pipeline with given name. An error is returned if the
 // resolution doesn't work or the returned data isn't a valid
-// v1beta1.PipelineObject.
-func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string) (v1beta1.PipelineObject, error) {
-	obj, err := resolver.Get(ctx, "pipeline", name)
+// v1beta1.PipelineObject. The source the pipeline was resolved from
+// is returned along with it.
+func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, error) {
+	obj, source, err := resolver.Get(ctx, "pipeline", name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	pipelineObj, err := readRuntimeObjectAsPipeline(ctx, obj)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert obj %s into Pipeline", obj.GetObjectKind().GroupVersionKind().String())
+		return nil, nil, fmt.Errorf("failed to convert obj %s into Pipeline", obj.GetObjectKind().GroupVersionKind().String())
 	}
-	return pipelineObj, nil
+	return pipelineObj, source, nil
 }
 
 // readRuntimeObjectAsPipeline tries to convert a generic runtime.Object
@@ -18,6 +18,7 @@ package resources_test
 
 import (
 	"context"
+	"errors"
 	"net/http/httptest"
 	"net/url"
 	"strings"
@@ -29,6 +30,9 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	ttesting "github.com/tektoncd/pipeline/pkg/trustedresources/testing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/parse"
@@ -38,6 +42,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	logtesting "knative.dev/pkg/logging/testing"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -178,10 +183,16 @@ func TestGetPipelineFunc(t *testing.T) {
 				},
 			})
 
-			_, err := test.CreateImage(u.Host+"/"+tc.name, tc.remotePipelines...)
+			imgRef, err := test.CreateImage(u.Host+"/"+tc.name, tc.remotePipelines...)
 			if err != nil {
 				t.Fatalf("failed to upload test image: %s", err.Error())
 			}
+			expected := tc.expected
+			if tc.ref.Bundle != "" {
+				// Pipelines fetched from bundles are annotated with the digest of the bundle image.
+				expected = expected.DeepCopyObject()
+				expected.(metav1.Object).SetAnnotations(map[string]string{oci.DigestAnnotation: strings.SplitN(imgRef, "@", 2)[1]})
+			}
 
 			fn, err := resources.GetPipelineFunc(ctx, kubeclient, tektonclient, nil, &v1beta1.PipelineRun{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
@@ -194,12 +205,12 @@ func TestGetPipelineFunc(t *testing.T) {
 				t.Fatalf("failed to get pipeline fn: %s", err.Error())
 			}
 
-			pipeline, err := fn(ctx, tc.ref.Name)
+			pipeline, _, _, err := fn(ctx, tc.ref.Name)
 			if err != nil {
 				t.Fatalf("failed to call pipelinefn: %s", err.Error())
 			}
 
-			if diff := cmp.Diff(pipeline, tc.expected); tc.expected != nil && diff != "" {
+			if diff := cmp.Diff(pipeline, expected); tc.expected != nil && diff != "" {
 				t.Error(diff)
 			}
 		})
@@ -251,7 +262,7 @@ func TestGetPipelineFuncSpecAlreadyFetched(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
-	actualPipeline, err := fn(ctx, name)
+	actualPipeline, _, _, err := fn(ctx, name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -286,7 +297,7 @@ func TestGetPipelineFunc_RemoteResolution(t *testing.T) {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
 
-	resolvedPipeline, err := fn(ctx, pipelineRef.Name)
+	resolvedPipeline, _, _, err := fn(ctx, pipelineRef.Name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -319,7 +330,7 @@ func TestGetPipelineFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 	resolved := test.NewResolvedResource([]byte(pipelineYAML), nil, nil)
 	requester := &test.Requester{
 		ResolvedResource: resolved,
-		Params:           map[string]string{"foo": "bar"},
+		Params:           []v1beta1.Param{{Name: "foo", Value: *v1beta1.NewStructuredValues("bar")}},
 	}
 	fn, err := resources.GetPipelineFunc(ctx, nil, nil, requester, &v1beta1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
@@ -336,7 +347,7 @@ func TestGetPipelineFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
 
-	resolvedPipeline, err := fn(ctx, pipelineRef.Name)
+	resolvedPipeline, _, _, err := fn(ctx, pipelineRef.Name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -370,7 +381,7 @@ func TestGetPipelineFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
 
-	_, err = fnNotMatching(ctx, pipelineRefNotMatching.Name)
+	_, _, _, err = fnNotMatching(ctx, pipelineRefNotMatching.Name)
 	if err == nil {
 		t.Fatal("expected error for non-matching params, did not get one")
 	}
@@ -398,11 +409,126 @@ func TestGetPipelineFunc_RemoteResolutionInvalidData(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
-	if _, err := fn(ctx, pipelineRef.Name); err == nil {
+	if _, _, _, err := fn(ctx, pipelineRef.Name); err == nil {
 		t.Fatalf("expected error due to invalid pipeline data but saw none")
 	}
 }
 
+func TestGetPipelineFunc_Verification(t *testing.T) {
+	ctx := context.Background()
+	signer, publicKey := ttesting.GetSignerAndPublicKey(t)
+
+	signedPipeline := simplePipelineWithBaseSpec()
+	signedPipeline.Name = "signed"
+	if err := trustedresources.SignPipeline(signedPipeline, signer); err != nil {
+		t.Fatalf("SignPipeline() = %v", err)
+	}
+	unsignedPipeline := simplePipelineWithBaseSpec()
+	unsignedPipeline.Name = "unsigned"
+	tektonclient := fake.NewSimpleClientset(signedPipeline, unsignedPipeline)
+
+	for _, tc := range []struct {
+		name        string
+		mode        string
+		keys        *config.TrustedResources
+		pipeline    *v1beta1.Pipeline
+		remote      bool
+		wantResult  trustedresources.VerificationResultType
+		wantSkipped bool
+		wantErr     bool
+	}{{
+		name:       "signed local pipeline",
+		mode:       config.EnforceResourceVerificationMode,
+		keys:       &config.TrustedResources{NamespacePublicKeys: map[string]string{"default": publicKey}},
+		pipeline:   signedPipeline,
+		wantResult: trustedresources.VerificationPass,
+	}, {
+		name:     "unsigned local pipeline enforced",
+		mode:     config.EnforceResourceVerificationMode,
+		keys:     &config.TrustedResources{PublicKeys: publicKey},
+		pipeline: unsignedPipeline,
+		wantErr:  true,
+	}, {
+		name:       "unsigned local pipeline warned",
+		mode:       config.WarnResourceVerificationMode,
+		keys:       &config.TrustedResources{PublicKeys: publicKey},
+		pipeline:   unsignedPipeline,
+		wantResult: trustedresources.VerificationWarn,
+	}, {
+		name:        "unsigned local pipeline skipped",
+		mode:        config.SkipResourceVerificationMode,
+		keys:        &config.TrustedResources{},
+		pipeline:    unsignedPipeline,
+		wantSkipped: true,
+	}, {
+		name:       "signed remote pipeline",
+		mode:       config.EnforceResourceVerificationMode,
+		keys:       &config.TrustedResources{ResolverPublicKeys: map[string]string{"git": publicKey}},
+		pipeline:   signedPipeline,
+		remote:     true,
+		wantResult: trustedresources.VerificationPass,
+	}, {
+		name:     "signed remote pipeline with keys of another resolver",
+		mode:     config.EnforceResourceVerificationMode,
+		keys:     &config.TrustedResources{ResolverPublicKeys: map[string]string{"hub": publicKey}},
+		pipeline: signedPipeline,
+		remote:   true,
+		wantErr:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := ctx
+			if tc.remote {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			ctx = ttesting.SetupTrustedResourcesContext(ctx, tc.mode, tc.keys)
+
+			pipelineRef := &v1beta1.PipelineRef{Name: tc.pipeline.Name}
+			var requester *test.Requester
+			if tc.remote {
+				pipelineRef = &v1beta1.PipelineRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git"}}
+				data, err := yaml.Marshal(tc.pipeline)
+				if err != nil {
+					t.Fatalf("error marshaling pipeline: %v", err)
+				}
+				requester = test.NewRequester(test.NewResolvedResource(data, nil, nil), nil)
+			}
+			fn, err := resources.GetPipelineFunc(ctx, nil, tektonclient, requester, &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       v1beta1.PipelineRunSpec{PipelineRef: pipelineRef},
+			})
+			if err != nil {
+				t.Fatalf("failed to get pipeline fn: %v", err)
+			}
+
+			pipeline, _, vr, err := fn(ctx, pipelineRef.Name)
+			if tc.wantErr {
+				if !errors.Is(err, trustedresources.ErrResourceVerificationFailed) {
+					t.Fatalf("expected %v, got %v", trustedresources.ErrResourceVerificationFailed, err)
+				}
+				if pipeline != nil {
+					t.Errorf("expected no pipeline to be returned, got %v", pipeline)
+				}
+				if vr == nil || vr.Type != trustedresources.VerificationError {
+					t.Errorf("expected an error verification result, got %v", vr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pipeline.PipelineMetadata().Name != tc.pipeline.Name {
+				t.Errorf("expected pipeline %q, got %q", tc.pipeline.Name, pipeline.PipelineMetadata().Name)
+			}
+			switch {
+			case tc.wantSkipped && vr != nil:
+				t.Errorf("expected verification to be skipped, got %v", vr)
+			case !tc.wantSkipped && (vr == nil || vr.Type != tc.wantResult):
+				t.Errorf("expected verification result %d, got %v", tc.wantResult, vr)
+			}
+		})
+	}
+}
+
 func basePipeline(name string) *v1beta1.Pipeline {
 	return &v1beta1.Pipeline{
 		ObjectMeta: metav1.ObjectMeta{
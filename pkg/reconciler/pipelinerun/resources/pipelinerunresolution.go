@@ -29,6 +29,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
@@ -74,7 +75,7 @@ type ResolvedPipelineTask struct {
 
 // isDone returns true only if the task is skipped, succeeded or failed
 func (t ResolvedPipelineTask) isDone(facts *PipelineRunFacts) bool {
-	return t.Skip(facts).IsSkipped || t.isSuccessful() || t.isFailure()
+	return t.Skip(facts).IsSkipped || t.isSuccessful() || t.isFailure() || t.isCancelledByTaskControl(facts)
 }
 
 // IsRunning returns true only if the task is neither succeeded, cancelled nor failed
@@ -388,7 +389,13 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 	var skippingReason v1beta1.SkippingReason
 
 	switch {
-	case facts.isFinalTask(t.PipelineTask.Name) || t.isScheduled():
+	case facts.isFinalTask(t.PipelineTask.Name):
+		skippingReason = v1beta1.None
+	case facts.isPrunedTask(t.PipelineTask.Name):
+		skippingReason = v1beta1.PrunedSkip
+	case t.skipBecauseOfTaskControl(facts):
+		skippingReason = v1beta1.TaskControlSkip
+	case t.isScheduled() || t.isCancelledByTaskControl(facts):
 		skippingReason = v1beta1.None
 	case facts.IsStopping():
 		skippingReason = v1beta1.StoppingSkip
@@ -422,6 +429,8 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 // (3) its parent task was skipped
 // (4) Pipeline is in stopping state (one of the PipelineTasks failed)
 // (5) Pipeline is gracefully cancelled or stopped
+// (6) the user requested it to be skipped through the PipelineRun's taskControls
+// (7) it was pruned from the PipelineRun through its targets or skip list
 func (t *ResolvedPipelineTask) Skip(facts *PipelineRunFacts) TaskSkipStatus {
 	if facts.SkipCache == nil {
 		facts.SkipCache = make(map[string]TaskSkipStatus)
@@ -482,6 +491,26 @@ func (t *ResolvedPipelineTask) skipBecauseResultReferencesAreMissing(facts *Pipe
 	return false
 }
 
+// skipBecauseOfTaskControl returns true if the user requested the task to be skipped through the
+// PipelineRun's taskControls, and the task has neither succeeded nor is still running. The TaskRuns
+// and Runs of a running task are cancelled by the reconciler first, and the task is skipped once they are done.
+func (t *ResolvedPipelineTask) skipBecauseOfTaskControl(facts *PipelineRunFacts) bool {
+	if facts.isPrunedTask(t.PipelineTask.Name) || facts.TaskControls[t.PipelineTask.Name] != v1beta1.PipelineTaskControlActionSkip {
+		return false
+	}
+	return t.checkParentsDone(facts) && !t.isSuccessful() && !t.IsRunning()
+}
+
+// isCancelledByTaskControl returns true if the user requested the task to be cancelled through the
+// PipelineRun's taskControls, and the task has neither succeeded nor is still running. Such a task is
+// considered failed, whether or not it was started before the cancellation was requested.
+func (t *ResolvedPipelineTask) isCancelledByTaskControl(facts *PipelineRunFacts) bool {
+	if facts.isPrunedTask(t.PipelineTask.Name) || facts.TaskControls[t.PipelineTask.Name] != v1beta1.PipelineTaskControlActionCancel {
+		return false
+	}
+	return t.checkParentsDone(facts) && !t.isSuccessful() && !t.IsRunning()
+}
+
 // skipBecausePipelineRunPipelineTimeoutReached returns true if the task shouldn't be launched because the elapsed time since
 // the PipelineRun started is greater than the PipelineRun's pipeline timeout
 func (t *ResolvedPipelineTask) skipBecausePipelineRunPipelineTimeoutReached(facts *PipelineRunFacts) bool {
@@ -531,6 +560,8 @@ func (t *ResolvedPipelineTask) IsFinallySkipped(facts *PipelineRunFacts) TaskSki
 	var skippingReason v1beta1.SkippingReason
 
 	switch {
+	case facts.isFinalTask(t.PipelineTask.Name) && t.skipBecauseOfTaskControl(facts):
+		skippingReason = v1beta1.TaskControlSkip
 	case t.isScheduled():
 		skippingReason = v1beta1.None
 	case facts.checkDAGTasksDone() && facts.isFinalTask(t.PipelineTask.Name):
@@ -776,10 +807,14 @@ func resolveTask(
 			spec = *taskRun.Status.TaskSpec
 			taskName = pipelineTask.TaskRef.Name
 		} else {
-			t, err = getTask(ctx, pipelineTask.TaskRef.Name)
+			// The result of the verification of the Task is recorded by the TaskRun
+			// created for it; the PipelineRun only fails if it is enforced.
+			t, _, _, err = getTask(ctx, pipelineTask.TaskRef.Name)
 			switch {
 			case errors.Is(err, remote.ErrorRequestInProgress):
 				return v1beta1.TaskSpec{}, "", "", err
+			case errors.Is(err, trustedresources.ErrResourceVerificationFailed):
+				return v1beta1.TaskSpec{}, "", "", err
 			case err != nil:
 				return v1beta1.TaskSpec{}, "", "", &TaskNotFoundError{
 					Name: pipelineTask.TaskRef.Name,
@@ -58,6 +58,14 @@ type PipelineRunFacts struct {
 	FinalTasksGraph *dag.Graph
 	TimeoutsState   PipelineRunTimeoutsState
 
+	// TaskControls maps PipelineTask names to the action the user requested for them
+	// through the PipelineRun's taskControls.
+	TaskControls map[string]v1beta1.PipelineTaskControlAction
+
+	// PrunedTasks holds the names of the PipelineTasks which were pruned from the TasksGraph
+	// because they are not needed by the PipelineRun's targets or are listed in its skip list.
+	PrunedTasks sets.String
+
 	// SkipCache is a hash of PipelineTask names that stores whether a task will be
 	// executed or not, because it's either not reachable via the DAG due to the pipeline
 	// state, or because it was skipped due to when expressions.
@@ -365,7 +373,10 @@ func (state PipelineRunState) getRetryableTasks(candidateTasks sets.String) []*R
 func (facts *PipelineRunFacts) IsStopping() bool {
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
-			if t.isFailure() {
+			if t.skipBecauseOfTaskControl(facts) {
+				continue
+			}
+			if t.isFailure() || t.isCancelledByTaskControl(facts) {
 				return true
 			}
 		}
@@ -458,7 +469,7 @@ func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 	if facts.checkDAGTasksDone() {
 		// return list of tasks with all final tasks
 		for _, t := range facts.State {
-			if facts.isFinalTask(t.PipelineTask.Name) {
+			if facts.isFinalTask(t.PipelineTask.Name) && !t.isCancelledByTaskControl(facts) {
 				finalCandidates.Insert(t.PipelineTask.Name)
 			}
 		}
@@ -588,14 +599,18 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 	// construct a map of tasks.<pipelineTask>.status and its state
 	tStatus := make(map[string]string)
 	for _, t := range facts.State {
-		if facts.isDAGTask(t.PipelineTask.Name) {
+		if facts.isDAGTask(t.PipelineTask.Name) || facts.isPrunedTask(t.PipelineTask.Name) {
 			var s string
 			switch {
 			// execution status is Succeeded when a task has succeeded condition with status set to true
 			case t.isSuccessful():
 				s = v1beta1.TaskRunReasonSuccessful.String()
+			// a task skipped on request may have a cancelled TaskRun or Run, its execution status is None
+			case t.skipBecauseOfTaskControl(facts):
+				s = PipelineTaskStateNone
 			// execution status is Failed when a task has succeeded condition with status set to false
-			case t.isConditionStatusFalse():
+			// or when it was cancelled on request
+			case t.isConditionStatusFalse() || t.isCancelledByTaskControl(facts):
 				s = v1beta1.TaskRunReasonFailed.String()
 			default:
 				// None includes skipped as well
@@ -611,9 +626,9 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 		// will reset it to failed/skipped if needed
 		aggregateStatus = v1beta1.PipelineRunReasonSuccessful.String()
 		for _, t := range facts.State {
-			if facts.isDAGTask(t.PipelineTask.Name) {
+			if facts.isDAGTask(t.PipelineTask.Name) || facts.isPrunedTask(t.PipelineTask.Name) {
 				// if any of the dag task failed, change the aggregate status to failed and return
-				if t.isConditionStatusFalse() {
+				if !t.skipBecauseOfTaskControl(facts) && (t.isConditionStatusFalse() || t.isCancelledByTaskControl(facts)) {
 					aggregateStatus = v1beta1.PipelineRunReasonFailed.String()
 					break
 				}
@@ -681,6 +696,16 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 		// increment success counter since the task is successful
 		case t.isSuccessful():
 			s.Succeeded++
+		// increment skip counter since the task was pruned from the PipelineRun
+		case facts.isPrunedTask(t.PipelineTask.Name):
+			s.Skipped++
+		// increment skip counter since the task was skipped on request, its TaskRuns or Runs may have been cancelled
+		case t.Skip(facts).SkippingReason == v1beta1.TaskControlSkip ||
+			t.IsFinallySkipped(facts).SkippingReason == v1beta1.TaskControlSkip:
+			s.Skipped++
+		// increment failure counter since the task was cancelled on request
+		case t.isCancelledByTaskControl(facts):
+			s.Failed++
 		// increment failure counter since the task is cancelled due to a timeout
 		case t.isCancelledForTimeOut():
 			s.Failed++
@@ -726,6 +751,11 @@ func (facts *PipelineRunFacts) isFinalTask(pipelineTaskName string) bool {
 	return false
 }
 
+// check if a specified pipelineTask was pruned from the DAG by the PipelineRun's targets or skip list
+func (facts *PipelineRunFacts) isPrunedTask(pipelineTaskName string) bool {
+	return facts.PrunedTasks.Has(pipelineTaskName)
+}
+
 // Check if a PipelineTask belongs to the specified Graph
 func isTaskInGraph(pipelineTaskName string, d *dag.Graph) bool {
 	if _, ok := d.Nodes[pipelineTaskName]; ok {
@@ -2431,6 +2431,279 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 	}
 }
 
+func TestPipelineRunFacts_TaskControls(t *testing.T) {
+	// mytask7 runs after mytask6
+	dagTasks := []v1beta1.PipelineTask{pts[5], pts[6]}
+	for _, tc := range []struct {
+		name                 string
+		state                PipelineRunState
+		taskControls         map[string]v1beta1.PipelineTaskControlAction
+		expectedStatus       corev1.ConditionStatus
+		expectedReason       string
+		expectedSkippedTasks []v1beta1.SkippedTask
+	}{{
+		name: "skip cancelled task and its dependent",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+			TaskRunName:  "pipelinerun-mytask6",
+			TaskRun:      withCancelled(makeFailed(trs[0])),
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		taskControls:   map[string]v1beta1.PipelineTaskControlAction{"mytask6": v1beta1.PipelineTaskControlActionSkip},
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: v1beta1.PipelineRunReasonCompleted.String(),
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "mytask6",
+			Reason: v1beta1.TaskControlSkip,
+		}, {
+			Name:   "mytask7",
+			Reason: v1beta1.ParentTasksSkip,
+		}},
+	}, {
+		name: "skip task not started yet",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		taskControls:   map[string]v1beta1.PipelineTaskControlAction{"mytask6": v1beta1.PipelineTaskControlActionSkip},
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: v1beta1.PipelineRunReasonCompleted.String(),
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "mytask6",
+			Reason: v1beta1.TaskControlSkip,
+		}, {
+			Name:   "mytask7",
+			Reason: v1beta1.ParentTasksSkip,
+		}},
+	}, {
+		name: "skip task still running",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+			TaskRunName:  "pipelinerun-mytask6",
+			TaskRun:      makeStarted(trs[0]),
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		taskControls:   map[string]v1beta1.PipelineTaskControlAction{"mytask6": v1beta1.PipelineTaskControlActionSkip},
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: v1beta1.PipelineRunReasonRunning.String(),
+	}, {
+		name: "skip task already succeeded",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+			TaskRunName:  "pipelinerun-mytask6",
+			TaskRun:      makeSucceeded(trs[0]),
+		}, {
+			PipelineTask: &pts[6],
+			TaskRunName:  "pipelinerun-mytask7",
+			TaskRun:      makeSucceeded(trs[1]),
+		}},
+		taskControls:   map[string]v1beta1.PipelineTaskControlAction{"mytask6": v1beta1.PipelineTaskControlActionSkip},
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: v1beta1.PipelineRunReasonSuccessful.String(),
+	}, {
+		name: "cancel task not started yet",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		taskControls:   map[string]v1beta1.PipelineTaskControlAction{"mytask6": v1beta1.PipelineTaskControlActionCancel},
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: v1beta1.PipelineRunReasonFailed.String(),
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "mytask7",
+			Reason: v1beta1.StoppingSkip,
+		}},
+	}, {
+		name: "cancel cancelled task",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+			TaskRunName:  "pipelinerun-mytask6",
+			TaskRun:      withCancelled(makeFailed(trs[0])),
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		taskControls:   map[string]v1beta1.PipelineTaskControlAction{"mytask6": v1beta1.PipelineTaskControlActionCancel},
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: v1beta1.PipelineRunReasonFailed.String(),
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "mytask7",
+			Reason: v1beta1.StoppingSkip,
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dag.Build(v1beta1.PipelineTaskList(dagTasks), v1beta1.PipelineTaskList(dagTasks).Deps())
+			if err != nil {
+				t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", dagTasks, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+				TaskControls: tc.taskControls,
+			}
+			pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "somepipelinerun"}}
+			c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar(), testClock)
+			if c.Status != tc.expectedStatus || c.Reason != tc.expectedReason {
+				t.Errorf("Expected condition status %s with reason %s but got %s with reason %s", tc.expectedStatus, tc.expectedReason, c.Status, c.Reason)
+			}
+			if d := cmp.Diff(tc.expectedSkippedTasks, facts.GetSkippedTasks()); d != "" {
+				t.Errorf("Mismatch skipped tasks %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunFacts_PrunedTasks(t *testing.T) {
+	// mytask7 runs after mytask6
+	dagTasks := []v1beta1.PipelineTask{pts[5], pts[6]}
+	for _, tc := range []struct {
+		name                 string
+		state                PipelineRunState
+		targets              []string
+		skip                 []string
+		expectedStatus       corev1.ConditionStatus
+		expectedReason       string
+		expectedNext         sets.String
+		expectedSkippedTasks []v1beta1.SkippedTask
+		expectedTaskStatus   map[string]string
+	}{{
+		name: "dependent of the target is pruned",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+			TaskRunName:  "pipelinerun-mytask6",
+			TaskRun:      makeSucceeded(trs[0]),
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		targets:        []string{"mytask6"},
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: v1beta1.PipelineRunReasonCompleted.String(),
+		expectedNext:   sets.NewString(),
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "mytask7",
+			Reason: v1beta1.PrunedSkip,
+		}},
+		expectedTaskStatus: map[string]string{
+			PipelineTaskStatusPrefix + pts[5].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[6].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              v1beta1.PipelineRunReasonCompleted.String(),
+		},
+	}, {
+		name: "target not started yet",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		targets:        []string{"mytask6"},
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: v1beta1.PipelineRunReasonRunning.String(),
+		expectedNext:   sets.NewString("mytask6"),
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "mytask7",
+			Reason: v1beta1.PrunedSkip,
+		}},
+		expectedTaskStatus: map[string]string{
+			PipelineTaskStatusPrefix + pts[5].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[6].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
+		},
+	}, {
+		name: "dependent of a skipped task is pruned",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		skip:           []string{"mytask6"},
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: v1beta1.PipelineRunReasonCompleted.String(),
+		expectedNext:   sets.NewString(),
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "mytask6",
+			Reason: v1beta1.PrunedSkip,
+		}, {
+			Name:   "mytask7",
+			Reason: v1beta1.PrunedSkip,
+		}},
+		expectedTaskStatus: map[string]string{
+			PipelineTaskStatusPrefix + pts[5].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[6].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              v1beta1.PipelineRunReasonCompleted.String(),
+		},
+	}, {
+		name: "target depending on a skipped task is run",
+		state: PipelineRunState{{
+			PipelineTask: &pts[5],
+		}, {
+			PipelineTask: &pts[6],
+		}},
+		targets:        []string{"mytask7"},
+		skip:           []string{"mytask6"},
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: v1beta1.PipelineRunReasonRunning.String(),
+		expectedNext:   sets.NewString("mytask7"),
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:   "mytask6",
+			Reason: v1beta1.PrunedSkip,
+		}},
+		expectedTaskStatus: map[string]string{
+			PipelineTaskStatusPrefix + pts[5].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[6].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dag.Build(v1beta1.PipelineTaskList(dagTasks), v1beta1.PipelineTaskList(dagTasks).Deps())
+			if err != nil {
+				t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", dagTasks, err)
+			}
+			d, pruned, err := dag.Prune(d, tc.targets, tc.skip)
+			if err != nil {
+				t.Fatalf("Unexpected error while pruning graph for DAG tasks %v: %v", dagTasks, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+				PrunedTasks: pruned,
+			}
+			pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "somepipelinerun"}}
+			c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar(), testClock)
+			if c.Status != tc.expectedStatus || c.Reason != tc.expectedReason {
+				t.Errorf("Expected condition status %s with reason %s but got %s with reason %s", tc.expectedStatus, tc.expectedReason, c.Status, c.Reason)
+			}
+			next, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting next tasks: %v", err)
+			}
+			nextNames := sets.NewString()
+			for _, rpt := range next {
+				nextNames.Insert(rpt.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.expectedNext, nextNames); d != "" {
+				t.Errorf("Mismatch next tasks %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.expectedSkippedTasks, facts.GetSkippedTasks()); d != "" {
+				t.Errorf("Mismatch skipped tasks %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.expectedTaskStatus, facts.GetPipelineTaskStatus()); d != "" {
+				t.Errorf("Mismatch task status %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineRunFacts_IsRunning(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
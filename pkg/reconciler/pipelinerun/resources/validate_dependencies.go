@@ -113,3 +113,49 @@ func ValidateOptionalWorkspaces(pipelineWorkspaces []v1beta1.PipelineWorkspaceDe
 	}
 	return nil
 }
+
+// ValidatePrunedTaskResults ensures that the PipelineTasks which were not pruned from the PipelineRun
+// through its targets or skip list, including its finally tasks, don't reference results of pruned PipelineTasks, unless the
+// PipelineRun provides the values of those results through params named after the result
+// references, e.g. "tasks.build.results.image".
+func ValidatePrunedTaskResults(ps *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun, pruned sets.String) error {
+	provided := prunedTaskResultsFromParams(pr, pruned)
+	tasks := append(append([]v1beta1.PipelineTask{}, ps.Tasks...), ps.Finally...)
+	for i := range tasks {
+		pt := tasks[i]
+		if pruned.Has(pt.Name) {
+			continue
+		}
+		for _, ref := range v1beta1.PipelineTaskResultRefs(&pt) {
+			if !pruned.Has(ref.PipelineTask) {
+				continue
+			}
+			if _, ok := provided[prunedTaskResultParamName(ref)]; !ok {
+				return fmt.Errorf("pipeline task %q references result %q of pruned pipeline task %q, which must then be provided through the param %q",
+					pt.Name, ref.Result, ref.PipelineTask, prunedTaskResultParamName(ref))
+			}
+		}
+	}
+	return nil
+}
+
+// prunedTaskResultsFromParams returns the result references of the pruned PipelineTasks for which
+// the PipelineRun provides values through its params, mapped by param name.
+func prunedTaskResultsFromParams(pr *v1beta1.PipelineRun, pruned sets.String) map[string]*ResolvedResultRef {
+	provided := map[string]*ResolvedResultRef{}
+	for _, p := range pr.Spec.Params {
+		for _, ref := range v1beta1.NewResultRefs([]string{p.Name}) {
+			if pruned.Has(ref.PipelineTask) && prunedTaskResultParamName(ref) == p.Name {
+				provided[p.Name] = &ResolvedResultRef{
+					Value:           p.Value,
+					ResultReference: *ref,
+				}
+			}
+		}
+	}
+	return provided
+}
+
+func prunedTaskResultParamName(ref *v1beta1.ResultRef) string {
+	return fmt.Sprintf("%s.%s.%s.%s", v1beta1.ResultTaskPart, ref.PipelineTask, v1beta1.ResultResultPart, ref.Result)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // TestValidatePipelineTaskResults_ValidStates tests that a pipeline task with
@@ -456,3 +457,65 @@ func TestValidateOptionalWorkspaces_NonOptionalTaskWorkspace(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+// TestValidatePrunedTaskResults tests that pipeline tasks which are run can only reference
+// results of pruned pipeline tasks when the PipelineRun provides them through its params.
+func TestValidatePrunedTaskResults(t *testing.T) {
+	ps := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "build",
+		}, {
+			Name: "deploy",
+			Params: []v1beta1.Param{{
+				Name:  "image",
+				Value: *v1beta1.NewStructuredValues("$(tasks.build.results.image)"),
+			}},
+		}},
+		Finally: []v1beta1.PipelineTask{{
+			Name: "notify",
+			Params: []v1beta1.Param{{
+				Name:  "message",
+				Value: *v1beta1.NewStructuredValues("built $(tasks.build.results.image)"),
+			}},
+		}},
+	}
+	for _, tc := range []struct {
+		desc    string
+		pruned  sets.String
+		params  []v1beta1.Param
+		wantErr string
+	}{{
+		desc:   "referenced task not pruned",
+		pruned: sets.NewString(),
+	}, {
+		desc:   "referencing task pruned",
+		pruned: sets.NewString("deploy"),
+	}, {
+		desc:   "result of pruned task provided through params",
+		pruned: sets.NewString("build"),
+		params: []v1beta1.Param{{
+			Name:  "tasks.build.results.image",
+			Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar@sha256:abcd"),
+		}},
+	}, {
+		desc:    "result of pruned task not provided",
+		pruned:  sets.NewString("build"),
+		params:  []v1beta1.Param{{Name: "image", Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar")}},
+		wantErr: `pipeline task "deploy" references result "image" of pruned pipeline task "build", which must then be provided through the param "tasks.build.results.image"`,
+	}, {
+		desc:    "result of pruned task not provided to finally task",
+		pruned:  sets.NewString("build", "deploy"),
+		wantErr: `pipeline task "notify" references result "image" of pruned pipeline task "build", which must then be provided through the param "tasks.build.results.image"`,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{Params: tc.params}}
+			err := ValidatePrunedTaskResults(ps, pr, tc.pruned)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr):
+				t.Errorf("expected error %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	schedulinglisters "k8s.io/client-go/listers/scheduling/v1"
+	"knative.dev/pkg/controller"
+)
+
+// Run is a PipelineRun or a TaskRun with a PriorityClass.
+type Run interface {
+	GetPriorityClassName() string
+}
+
+// Value returns the priority of the pods of the given PriorityClass, as resolved by Kubernetes:
+// the value of the PriorityClass, or the default priority if it is not set or doesn't exist.
+func Value(lister schedulinglisters.PriorityClassLister, priorityClassName string) int32 {
+	if priorityClassName != "" {
+		if pc, err := lister.Get(priorityClassName); err == nil {
+			return pc.Value
+		}
+	}
+	return defaultValue(lister)
+}
+
+// IsLow returns whether the priority of the run is lower than the default priority.
+func IsLow(lister schedulinglisters.PriorityClassLister, run Run) bool {
+	name := run.GetPriorityClassName()
+	if name == "" {
+		return false
+	}
+	return Value(lister, name) < defaultValue(lister)
+}
+
+// Enqueue returns a function enqueuing runs in the work queue of impl by priority: the runs of
+// a lower priority than the default are enqueued in the slow lane of the work queue, and the
+// others in its fast lane, which is drained first.
+func Enqueue(impl *controller.Impl, lister schedulinglisters.PriorityClassLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		if run, ok := obj.(Run); ok && IsLow(lister, run) {
+			impl.EnqueueSlow(obj)
+			return
+		}
+		impl.Enqueue(obj)
+	}
+}
+
+// defaultValue returns the priority of the pods without a PriorityClass: the value of the global
+// default PriorityClass, or 0 if there isn't any.
+func defaultValue(lister schedulinglisters.PriorityClassLister) int32 {
+	pcs, err := lister.List(labels.Everything())
+	if err != nil {
+		return 0
+	}
+	for _, pc := range pcs {
+		if pc.GlobalDefault {
+			return pc.Value
+		}
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority_test
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/priority"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulinglisters "k8s.io/client-go/listers/scheduling/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func priorityClassLister(t *testing.T, pcs ...*schedulingv1.PriorityClass) schedulinglisters.PriorityClassLister {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, pc := range pcs {
+		if err := indexer.Add(pc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return schedulinglisters.NewPriorityClassLister(indexer)
+}
+
+func priorityClass(name string, value int32, globalDefault bool) *schedulingv1.PriorityClass {
+	return &schedulingv1.PriorityClass{
+		ObjectMeta:    metav1.ObjectMeta{Name: name},
+		Value:         value,
+		GlobalDefault: globalDefault,
+	}
+}
+
+func TestIsLow(t *testing.T) {
+	release := priorityClass("release", 1000, false)
+	nightly := priorityClass("nightly", -10, false)
+	standard := priorityClass("standard", 100, true)
+
+	for _, tc := range []struct {
+		name              string
+		priorityClasses   []*schedulingv1.PriorityClass
+		priorityClassName string
+		want              bool
+	}{{
+		name:            "no priority class",
+		priorityClasses: []*schedulingv1.PriorityClass{release, nightly},
+	}, {
+		name:              "higher than the default priority",
+		priorityClasses:   []*schedulingv1.PriorityClass{release, nightly},
+		priorityClassName: "release",
+	}, {
+		name:              "lower than the default priority",
+		priorityClasses:   []*schedulingv1.PriorityClass{release, nightly},
+		priorityClassName: "nightly",
+		want:              true,
+	}, {
+		name:              "missing priority class",
+		priorityClasses:   []*schedulingv1.PriorityClass{release, nightly},
+		priorityClassName: "missing",
+	}, {
+		name:              "higher than the global default priority class",
+		priorityClasses:   []*schedulingv1.PriorityClass{release, nightly, standard},
+		priorityClassName: "release",
+	}, {
+		name:              "lower than the global default priority class",
+		priorityClasses:   []*schedulingv1.PriorityClass{release, nightly, standard},
+		priorityClassName: "nightly",
+		want:              true,
+	}, {
+		name:              "the global default priority class",
+		priorityClasses:   []*schedulingv1.PriorityClass{release, nightly, standard},
+		priorityClassName: "standard",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{PriorityClassName: tc.priorityClassName}}
+			if got := priority.IsLow(priorityClassLister(t, tc.priorityClasses...), pr); got != tc.want {
+				t.Errorf("IsLow() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	lister := priorityClassLister(t, priorityClass("release", 1000, false), priorityClass("standard", 100, true))
+	for name, want := range map[string]int32{
+		"release":  1000,
+		"standard": 100,
+		"":         100,
+		"missing":  100,
+	} {
+		if got := priority.Value(lister, name); got != want {
+			t.Errorf("Value(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PipelineIndex is the name of the index of PipelineRuns by Pipeline
+	PipelineIndex = "pruner-pipeline"
+	// StandaloneTaskIndex is the name of the index of the TaskRuns not created by a PipelineRun by Task
+	StandaloneTaskIndex = "pruner-standalone-task"
+)
+
+// IndexKey returns the key of the runs of the Pipeline or Task with the given name in the
+// given namespace in the PipelineIndex and StandaloneTaskIndex.
+func IndexKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// PipelineIndexFunc indexes PipelineRuns by the Pipeline they run.
+func PipelineIndexFunc(obj interface{}) ([]string, error) {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return labelIndexKeys(m, pipeline.PipelineLabelKey), nil
+}
+
+// StandaloneTaskIndexFunc indexes the TaskRuns not created by a PipelineRun by the Task they run.
+func StandaloneTaskIndexFunc(obj interface{}) ([]string, error) {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := m.GetLabels()[pipeline.PipelineRunLabelKey]; ok {
+		return nil, nil
+	}
+	return labelIndexKeys(m, pipeline.TaskLabelKey), nil
+}
+
+func labelIndexKeys(m metav1.Object, labelKey string) []string {
+	name, ok := m.GetLabels()[labelKey]
+	if !ok {
+		return nil
+	}
+	return []string{IndexKey(m.GetNamespace(), name)}
+}
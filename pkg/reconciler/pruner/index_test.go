@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPipelineIndexFunc(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		labels map[string]string
+		want   []string
+	}{{
+		name:   "run of a Pipeline",
+		labels: map[string]string{"tekton.dev/pipeline": "build"},
+		want:   []string{"foo/build"},
+	}, {
+		name: "run of an embedded spec",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo", Labels: tc.labels}}
+			got, err := pruner.PipelineIndexFunc(pr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected keys (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestStandaloneTaskIndexFunc(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		labels map[string]string
+		want   []string
+	}{{
+		name:   "standalone run of a Task",
+		labels: map[string]string{"tekton.dev/task": "build"},
+		want:   []string{"foo/build"},
+	}, {
+		name:   "run of a Task created by a PipelineRun",
+		labels: map[string]string{"tekton.dev/task": "build", "tekton.dev/pipelineRun": "pr"},
+	}, {
+		name: "run of an embedded spec",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "foo", Labels: tc.labels}}
+			got, err := pruner.StandaloneTaskIndexFunc(tr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected keys (-want, +got): %s", d)
+			}
+		})
+	}
+}
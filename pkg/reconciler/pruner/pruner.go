@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TTLAfterFinishedAnnotationKey is the namespace annotation overriding the time after which
+	// the completed PipelineRuns and TaskRuns of the namespace are deleted
+	TTLAfterFinishedAnnotationKey = pipeline.GroupName + "/ttl-after-finished"
+	// SuccessfulRunsHistoryLimitAnnotationKey is the namespace annotation overriding the number of
+	// successful runs to keep for each Pipeline or Task of the namespace
+	SuccessfulRunsHistoryLimitAnnotationKey = pipeline.GroupName + "/successful-runs-history-limit"
+	// FailedRunsHistoryLimitAnnotationKey is the namespace annotation overriding the number of
+	// failed runs to keep for each Pipeline or Task of the namespace
+	FailedRunsHistoryLimitAnnotationKey = pipeline.GroupName + "/failed-runs-history-limit"
+	// RetainAnnotationKey is the annotation which, set to "true" on a PipelineRun or TaskRun,
+	// prevents it from being deleted
+	RetainAnnotationKey = pipeline.GroupName + "/retain"
+	// DebugRetentionTTLAnnotationKey is the annotation overriding on a PipelineRun or TaskRun the
+	// time during which it is retained for debugging once it failed
+	DebugRetentionTTLAnnotationKey = pipeline.GroupName + "/debug-retention-ttl"
+
+	// ReasonTTLExpired is the reason a completed run is deleted when its TTL expired
+	ReasonTTLExpired = "TTLExpired"
+	// ReasonHistoryLimitExceeded is the reason a completed run is deleted when more recent
+	// runs of the same Pipeline or Task already fill the history
+	ReasonHistoryLimitExceeded = "HistoryLimitExceeded"
+)
+
+// Settings holds how the completed runs of a namespace are pruned
+type Settings struct {
+	// TTLAfterFinished is the time after which completed runs are deleted, 0 disables it
+	TTLAfterFinished time.Duration
+	// SuccessfulRunsHistoryLimit is the number of successful runs to keep for each Pipeline
+	// or Task, a negative value disables it
+	SuccessfulRunsHistoryLimit int
+	// FailedRunsHistoryLimit is the number of failed runs to keep for each Pipeline or Task,
+	// a negative value disables it
+	FailedRunsHistoryLimit int
+}
+
+// Run holds what the pruner needs to know about a completed PipelineRun or TaskRun
+type Run struct {
+	Name           string
+	CompletionTime time.Time
+	Succeeded      bool
+}
+
+// GetSettings returns the pruning settings of the given namespace: the defaults from the
+// config-defaults ConfigMap, overridden by the annotations of the namespace.
+func GetSettings(ctx context.Context, ns *corev1.Namespace) (Settings, error) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	s := Settings{
+		TTLAfterFinished:           defaults.DefaultRunTTLAfterFinished,
+		SuccessfulRunsHistoryLimit: defaults.DefaultSuccessfulRunsHistoryLimit,
+		FailedRunsHistoryLimit:     defaults.DefaultFailedRunsHistoryLimit,
+	}
+	if ns == nil {
+		return s, nil
+	}
+	if v, ok := ns.Annotations[TTLAfterFinishedAnnotationKey]; ok {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			return s, fmt.Errorf("invalid annotation %s on namespace %s: %q should be a positive duration", TTLAfterFinishedAnnotationKey, ns.Name, v)
+		}
+		s.TTLAfterFinished = ttl
+	}
+	if v, ok := ns.Annotations[SuccessfulRunsHistoryLimitAnnotationKey]; ok {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return s, fmt.Errorf("invalid annotation %s on namespace %s: %q should be an integer", SuccessfulRunsHistoryLimitAnnotationKey, ns.Name, v)
+		}
+		s.SuccessfulRunsHistoryLimit = limit
+	}
+	if v, ok := ns.Annotations[FailedRunsHistoryLimitAnnotationKey]; ok {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return s, fmt.Errorf("invalid annotation %s on namespace %s: %q should be an integer", FailedRunsHistoryLimitAnnotationKey, ns.Name, v)
+		}
+		s.FailedRunsHistoryLimit = limit
+	}
+	return s, nil
+}
+
+// IsRetained returns true if the run is annotated to never be deleted
+func IsRetained(run metav1.Object) bool {
+	retain, _ := strconv.ParseBool(run.GetAnnotations()[RetainAnnotationKey])
+	return retain
+}
+
+// DebugRetentionTTL returns the time during which the given run is retained for debugging once it
+// failed: the default from the config-defaults ConfigMap, overridden by the annotation of the run.
+// It returns the default along with an error if the annotation is invalid.
+func DebugRetentionTTL(ctx context.Context, run metav1.Object) (time.Duration, error) {
+	ttl := config.FromContextOrDefaults(ctx).Defaults.DefaultDebugRetentionTTL
+	v, ok := run.GetAnnotations()[DebugRetentionTTLAnnotationKey]
+	if !ok {
+		return ttl, nil
+	}
+	override, err := time.ParseDuration(v)
+	if err != nil || override < 0 {
+		return ttl, fmt.Errorf("invalid annotation %s on %s: %q should be a positive duration", DebugRetentionTTLAnnotationKey, run.GetName(), v)
+	}
+	return override, nil
+}
+
+// HasHistoryLimits returns true if completed runs exceeding the history limits should be deleted
+func (s Settings) HasHistoryLimits() bool {
+	return s.SuccessfulRunsHistoryLimit >= 0 || s.FailedRunsHistoryLimit >= 0
+}
+
+// TimeToExpiry returns how long is left before the TTL of a run completed at the given time
+// expires, and false if completed runs don't expire.
+func (s Settings) TimeToExpiry(completionTime time.Time, now time.Time) (time.Duration, bool) {
+	if s.TTLAfterFinished <= 0 {
+		return 0, false
+	}
+	return completionTime.Add(s.TTLAfterFinished).Sub(now), true
+}
+
+// RunsExceedingHistory returns the names of the given completed runs of a single Pipeline or Task
+// which are older than the most recent successful and failed runs to keep.
+func (s Settings) RunsExceedingHistory(runs []Run) []string {
+	sorted := make([]Run, len(runs))
+	copy(sorted, runs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CompletionTime.After(sorted[j].CompletionTime)
+	})
+
+	var exceeding []string
+	successful, failed := 0, 0
+	for _, run := range sorted {
+		if run.Succeeded {
+			successful++
+			if s.SuccessfulRunsHistoryLimit >= 0 && successful > s.SuccessfulRunsHistoryLimit {
+				exceeding = append(exceeding, run.Name)
+			}
+		} else {
+			failed++
+			if s.FailedRunsHistoryLimit >= 0 && failed > s.FailedRunsHistoryLimit {
+				exceeding = append(exceeding, run.Name)
+			}
+		}
+	}
+	return exceeding
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetSettings(t *testing.T) {
+	defaults := &config.Defaults{
+		DefaultRunTTLAfterFinished:        time.Hour,
+		DefaultSuccessfulRunsHistoryLimit: 5,
+		DefaultFailedRunsHistoryLimit:     -1,
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{Defaults: defaults})
+
+	for _, tc := range []struct {
+		name string
+		ns   *corev1.Namespace
+		want pruner.Settings
+	}{{
+		name: "no namespace",
+		want: pruner.Settings{TTLAfterFinished: time.Hour, SuccessfulRunsHistoryLimit: 5, FailedRunsHistoryLimit: -1},
+	}, {
+		name: "namespace without annotations",
+		ns:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+		want: pruner.Settings{TTLAfterFinished: time.Hour, SuccessfulRunsHistoryLimit: 5, FailedRunsHistoryLimit: -1},
+	}, {
+		name: "namespace overriding all settings",
+		ns: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+			Annotations: map[string]string{
+				pruner.TTLAfterFinishedAnnotationKey:           "30m",
+				pruner.SuccessfulRunsHistoryLimitAnnotationKey: "-1",
+				pruner.FailedRunsHistoryLimitAnnotationKey:     "3",
+			},
+		}},
+		want: pruner.Settings{TTLAfterFinished: 30 * time.Minute, SuccessfulRunsHistoryLimit: -1, FailedRunsHistoryLimit: 3},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := pruner.GetSettings(ctx, tc.ns)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected settings %s", d)
+			}
+		})
+	}
+}
+
+func TestGetSettings_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+	}{{
+		name:        "invalid ttl",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "tomorrow"},
+	}, {
+		name:        "negative ttl",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "-1h"},
+	}, {
+		name:        "invalid successful runs history limit",
+		annotations: map[string]string{pruner.SuccessfulRunsHistoryLimitAnnotationKey: "many"},
+	}, {
+		name:        "invalid failed runs history limit",
+		annotations: map[string]string{pruner.FailedRunsHistoryLimitAnnotationKey: "1.5"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: tc.annotations}}
+			if _, err := pruner.GetSettings(context.Background(), ns); err == nil {
+				t.Error("Expected an error but got none")
+			}
+		})
+	}
+}
+
+func TestIsRetained(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        bool
+	}{{
+		want: false,
+	}, {
+		annotations: map[string]string{pruner.RetainAnnotationKey: "true"},
+		want:        true,
+	}, {
+		annotations: map[string]string{pruner.RetainAnnotationKey: "false"},
+		want:        false,
+	}, {
+		annotations: map[string]string{pruner.RetainAnnotationKey: "yes please"},
+		want:        false,
+	}} {
+		run := &metav1.ObjectMeta{Annotations: tc.annotations}
+		if got := pruner.IsRetained(run); got != tc.want {
+			t.Errorf("IsRetained(%v) = %t, want %t", tc.annotations, got, tc.want)
+		}
+	}
+}
+
+func TestDebugRetentionTTL(t *testing.T) {
+	ctx := config.ToContext(context.Background(), &config.Config{Defaults: &config.Defaults{DefaultDebugRetentionTTL: time.Hour}})
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        time.Duration
+		wantErr     bool
+	}{{
+		want: time.Hour,
+	}, {
+		annotations: map[string]string{pruner.DebugRetentionTTLAnnotationKey: "72h"},
+		want:        72 * time.Hour,
+	}, {
+		annotations: map[string]string{pruner.DebugRetentionTTLAnnotationKey: "0s"},
+		want:        0,
+	}, {
+		annotations: map[string]string{pruner.DebugRetentionTTLAnnotationKey: "forever"},
+		want:        time.Hour,
+		wantErr:     true,
+	}, {
+		annotations: map[string]string{pruner.DebugRetentionTTLAnnotationKey: "-1h"},
+		want:        time.Hour,
+		wantErr:     true,
+	}} {
+		run := &metav1.ObjectMeta{Name: "run", Annotations: tc.annotations}
+		got, err := pruner.DebugRetentionTTL(ctx, run)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("DebugRetentionTTL(%v) error = %v, want error %t", tc.annotations, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("DebugRetentionTTL(%v) = %s, want %s", tc.annotations, got, tc.want)
+		}
+	}
+}
+
+func TestTimeToExpiry(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := pruner.Settings{TTLAfterFinished: time.Hour}
+
+	if got, expires := s.TimeToExpiry(now.Add(-20*time.Minute), now); !expires || got != 40*time.Minute {
+		t.Errorf("Expected the run to expire in 40m but got %s (%t)", got, expires)
+	}
+	if got, expires := s.TimeToExpiry(now.Add(-2*time.Hour), now); !expires || got > 0 {
+		t.Errorf("Expected the run to have expired but got %s (%t)", got, expires)
+	}
+	if _, expires := (pruner.Settings{}).TimeToExpiry(now, now); expires {
+		t.Error("Expected runs not to expire without a TTL")
+	}
+}
+
+func TestRunsExceedingHistory(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	runs := []pruner.Run{
+		{Name: "success-1", CompletionTime: now.Add(-5 * time.Minute), Succeeded: true},
+		{Name: "failure-1", CompletionTime: now.Add(-4 * time.Minute)},
+		{Name: "success-3", CompletionTime: now.Add(-1 * time.Minute), Succeeded: true},
+		{Name: "success-2", CompletionTime: now.Add(-3 * time.Minute), Succeeded: true},
+		{Name: "failure-2", CompletionTime: now.Add(-2 * time.Minute)},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		settings pruner.Settings
+		want     []string
+	}{{
+		name:     "no limits",
+		settings: pruner.Settings{SuccessfulRunsHistoryLimit: -1, FailedRunsHistoryLimit: -1},
+	}, {
+		name:     "successful runs limit",
+		settings: pruner.Settings{SuccessfulRunsHistoryLimit: 1, FailedRunsHistoryLimit: -1},
+		want:     []string{"success-2", "success-1"},
+	}, {
+		name:     "failed runs limit",
+		settings: pruner.Settings{SuccessfulRunsHistoryLimit: -1, FailedRunsHistoryLimit: 1},
+		want:     []string{"failure-1"},
+	}, {
+		name:     "keep none",
+		settings: pruner.Settings{SuccessfulRunsHistoryLimit: 0, FailedRunsHistoryLimit: 0},
+		want:     []string{"success-3", "failure-2", "success-2", "failure-1", "success-1"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.settings.RunsExceedingHistory(runs)); d != "" {
+				t.Errorf("Unexpected runs exceeding history %s", d)
+			}
+		})
+	}
+}
@@ -49,7 +49,7 @@ import (
 )
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, trustedResourcesExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -66,6 +66,9 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetMetricsConfigName() {
 			metricsExists = true
 		}
+		if cm.Name == config.GetTrustedResourcesConfigName() {
+			trustedResourcesExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -97,6 +100,12 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !trustedResourcesExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 func initializeRunControllerAssets(t *testing.T, d test.Data) (test.Assets, func()) {
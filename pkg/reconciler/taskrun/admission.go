@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/internal/computeresources/quota"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/admission"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+// AdmissionPriorityAnnotationKey is the annotation setting the priority of a TaskRun in the queue of
+// TaskRuns waiting for the ResourceQuotas of their namespace. TaskRuns with a higher priority are
+// admitted first.
+const AdmissionPriorityAnnotationKey = pipeline.GroupName + "/admission-priority"
+
+// admissionRetryInterval is how often the TaskRuns whose pod doesn't fit in the quotas check whether it
+// fits, in case an event freeing up quota was missed.
+const admissionRetryInterval = 5 * time.Minute
+
+// exceededQuotaMessage starts the message of the TaskRuns whose pod doesn't fit in the ResourceQuotas
+// of their namespace.
+const exceededQuotaMessage = "TaskRun Pod exceeded available resources: "
+
+// waitingForQuotaError is returned when the pod of a TaskRun is not created because it would exceed
+// the ResourceQuotas of its namespace, or because other TaskRuns are queued before it.
+type waitingForQuotaError struct {
+	message string
+	// exceeded is true if the pod of the TaskRun doesn't fit in the quotas.
+	exceeded bool
+}
+
+func (e *waitingForQuotaError) Error() string {
+	return e.message
+}
+
+// admitPod returns a waitingForQuotaError if the pod of tr can't be created yet: TaskRuns waiting for
+// quota are admitted in the order of admissionQueue once their pod fits in the quotas of the namespace,
+// and can only go ahead of the TaskRuns whose pod doesn't fit.
+func (c *Reconciler) admitPod(tr *v1beta1.TaskRun, pod *corev1.Pod) error {
+	quotas, err := c.resourceQuotaLister.ResourceQuotas(tr.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list ResourceQuotas of namespace %s: %w", tr.Namespace, err)
+	}
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	trs, err := waitingForQuota(c.taskRunIndexer, tr.Namespace)
+	if err != nil {
+		return err
+	}
+	waiting := []*v1beta1.TaskRun{tr}
+	for _, t := range trs {
+		if t.Name != tr.Name {
+			waiting = append(waiting, t)
+		}
+	}
+	queue := admissionQueue(waiting)
+	for position, t := range queue {
+		if t.Name == tr.Name {
+			break
+		}
+		if !exceedsQuota(t) {
+			return &waitingForQuotaError{message: fmt.Sprintf("TaskRun is queued for admission behind %d other TaskRun(s) waiting for resource quota", position)}
+		}
+	}
+
+	if fits, msg := quota.Fits(pod, quota.PodUsage(pod), quotas); !fits {
+		return &waitingForQuotaError{message: exceededQuotaMessage + msg, exceeded: true}
+	}
+	return nil
+}
+
+// waitingForQuota returns the TaskRuns of namespace waiting for quota.
+func waitingForQuota(indexer cache.Indexer, namespace string) ([]*v1beta1.TaskRun, error) {
+	objs, err := indexer.ByIndex(admission.PendingIndex, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TaskRuns of namespace %s waiting for quota: %w", namespace, err)
+	}
+	trs := make([]*v1beta1.TaskRun, 0, len(objs))
+	for _, obj := range objs {
+		if tr, ok := obj.(*v1beta1.TaskRun); ok && isWaitingForQuota(tr) {
+			trs = append(trs, tr)
+		}
+	}
+	return trs, nil
+}
+
+// waitForQuota marks tr as waiting for the ResourceQuotas of its namespace. Its timeout keeps running
+// while it waits. Only the TaskRuns whose pod doesn't fit are requeued: the others are enqueued by the
+// controller once quota frees up.
+func (c *Reconciler) waitForQuota(ctx context.Context, tr *v1beta1.TaskRun, err *waitingForQuotaError) error {
+	if !err.exceeded {
+		tr.Status.MarkResourceOngoing(podconvert.ReasonQueuedForResourceQuota, err.Error())
+		return nil
+	}
+	tr.Status.MarkResourceOngoing(podconvert.ReasonExceededResourceQuota, err.Error())
+	retry := admissionRetryInterval
+	if timeout := tr.GetTimeout(ctx); timeout != config.NoTimeoutDuration && tr.Status.StartTime != nil {
+		if remaining := timeout - c.Clock.Since(tr.Status.StartTime.Time); remaining < retry {
+			retry = remaining
+		}
+	}
+	return controller.NewRequeueAfter(retry)
+}
+
+// isWaitingForQuota returns whether tr is waiting for the ResourceQuotas of its namespace to create its pod.
+func isWaitingForQuota(tr *v1beta1.TaskRun) bool {
+	if !admission.IsPending(tr) {
+		return false
+	}
+	cond := tr.Status.GetCondition(apis.ConditionSucceeded)
+	return cond != nil && (cond.Reason == podconvert.ReasonExceededResourceQuota || cond.Reason == podconvert.ReasonQueuedForResourceQuota)
+}
+
+// exceedsQuota returns whether tr is waiting for quota because its pod doesn't fit in the quotas. The
+// TaskRuns queued behind it can be admitted before it.
+func exceedsQuota(tr *v1beta1.TaskRun) bool {
+	if !admission.IsPending(tr) {
+		return false
+	}
+	cond := tr.Status.GetCondition(apis.ConditionSucceeded)
+	return cond != nil && cond.Reason == podconvert.ReasonExceededResourceQuota
+}
+
+// blocksAdmission returns whether tr is waiting for quota and the TaskRuns queued behind it can't be
+// admitted before it.
+func blocksAdmission(tr *v1beta1.TaskRun) bool {
+	return isWaitingForQuota(tr) && !exceedsQuota(tr)
+}
+
+// admissionQueue returns the TaskRuns in the order they are admitted: by decreasing admission
+// priority, then first come first served.
+func admissionQueue(trs []*v1beta1.TaskRun) []*v1beta1.TaskRun {
+	queue := make([]*v1beta1.TaskRun, len(trs))
+	copy(queue, trs)
+	sort.SliceStable(queue, func(i, j int) bool {
+		if pi, pj := admissionPriority(queue[i]), admissionPriority(queue[j]); pi != pj {
+			return pi > pj
+		}
+		if ti, tj := queue[i].CreationTimestamp, queue[j].CreationTimestamp; !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return queue[i].Name < queue[j].Name
+	})
+	return queue
+}
+
+// nextToAdmit returns the TaskRuns waiting for quota which can be admitted next: those at the head of
+// the admission queue whose pod didn't fit in the quotas, up to the first one which hasn't been checked.
+func nextToAdmit(waiting []*v1beta1.TaskRun) []*v1beta1.TaskRun {
+	var next []*v1beta1.TaskRun
+	for _, tr := range admissionQueue(waiting) {
+		next = append(next, tr)
+		if !exceedsQuota(tr) {
+			break
+		}
+	}
+	return next
+}
+
+// admissionPriority returns the admission priority of tr, 0 if it is not set or invalid.
+func admissionPriority(tr *v1beta1.TaskRun) int {
+	priority, err := strconv.Atoi(tr.Annotations[AdmissionPriorityAnnotationKey])
+	if err != nil {
+		return 0
+	}
+	return priority
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admission indexes the TaskRuns which may be waiting for the ResourceQuotas of their
+// namespace to create their pod.
+package admission
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// PendingIndex is the name of the index of the TaskRuns without a pod by namespace
+const PendingIndex = "admission-pending"
+
+// IsPending returns whether tr is running but has no pod yet, e.g. because it is waiting for quota.
+func IsPending(tr *v1beta1.TaskRun) bool {
+	return !tr.IsDone() && !tr.IsCancelled() && tr.Status.PodName == ""
+}
+
+// PendingIndexFunc indexes the TaskRuns without a pod by their namespace.
+func PendingIndexFunc(obj interface{}) ([]string, error) {
+	tr, ok := obj.(*v1beta1.TaskRun)
+	if !ok || !IsPending(tr) {
+		return nil, nil
+	}
+	return []string{tr.Namespace}, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/admission"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func taskRun(status corev1.ConditionStatus, podName string) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "foo"},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: status,
+			}}},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: podName},
+		},
+	}
+}
+
+func TestPendingIndexFunc(t *testing.T) {
+	cancelled := taskRun(corev1.ConditionUnknown, "")
+	cancelled.Spec.Status = v1beta1.TaskRunSpecStatusCancelled
+
+	for _, tc := range []struct {
+		name string
+		obj  interface{}
+		want []string
+	}{{
+		name: "without a pod",
+		obj:  taskRun(corev1.ConditionUnknown, ""),
+		want: []string{"foo"},
+	}, {
+		name: "pod created",
+		obj:  taskRun(corev1.ConditionUnknown, "taskrun-pod"),
+	}, {
+		name: "done",
+		obj:  taskRun(corev1.ConditionFalse, ""),
+	}, {
+		name: "cancelled",
+		obj:  cancelled,
+	}, {
+		name: "not a TaskRun",
+		obj:  &v1beta1.PipelineRun{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := admission.PendingIndexFunc(tc.obj)
+			if err != nil {
+				t.Fatalf("PendingIndexFunc() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Index keys %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/parse"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+func queuedTaskRun(t *testing.T, name string, createdAgo time.Duration, priority string) *v1beta1.TaskRun {
+	t.Helper()
+	tr := parse.MustParseTaskRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - reason: QueuedForResourceQuota
+    status: Unknown
+    type: Succeeded
+`, name))
+	tr.CreationTimestamp = metav1.Time{Time: now.Add(-createdAgo)}
+	if priority != "" {
+		tr.Annotations = map[string]string{AdmissionPriorityAnnotationKey: priority}
+	}
+	return tr
+}
+
+// exceedingTaskRun returns a TaskRun waiting for quota whose pod was found not to fit in the quotas.
+func exceedingTaskRun(t *testing.T, name string, createdAgo time.Duration, priority string) *v1beta1.TaskRun {
+	t.Helper()
+	tr := queuedTaskRun(t, name, createdAgo, priority)
+	tr.Status.MarkResourceOngoing(podconvert.ReasonExceededResourceQuota, exceededQuotaMessage+"exceeded quota: pods")
+	return tr
+}
+
+// startedTaskRun returns a TaskRun waiting for quota which started waiting startedAgo.
+func startedTaskRun(t *testing.T, name string, startedAgo time.Duration) *v1beta1.TaskRun {
+	t.Helper()
+	tr := queuedTaskRun(t, name, startedAgo, "")
+	tr.Status.StartTime = &metav1.Time{Time: now.Add(-startedAgo)}
+	return tr
+}
+
+func podsQuota(hard, used string) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "pods", Namespace: "foo"},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse(hard)},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse(hard)},
+			Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse(used)},
+		},
+	}
+}
+
+func TestReconcileResourceQuotaAdmission(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		quotas      []*corev1.ResourceQuota
+		trs         []*v1beta1.TaskRun
+		wantPod     bool
+		wantRequeue time.Duration
+		wantReason  string
+	}{{
+		name:    "no quota",
+		trs:     []*v1beta1.TaskRun{queuedTaskRun(t, "test-taskrun", time.Minute, "")},
+		wantPod: true,
+	}, {
+		name:    "quota left",
+		quotas:  []*corev1.ResourceQuota{podsQuota("2", "1")},
+		trs:     []*v1beta1.TaskRun{queuedTaskRun(t, "test-taskrun", time.Minute, "")},
+		wantPod: true,
+	}, {
+		name:        "quota exhausted",
+		quotas:      []*corev1.ResourceQuota{podsQuota("2", "2")},
+		trs:         []*v1beta1.TaskRun{queuedTaskRun(t, "test-taskrun", time.Minute, "")},
+		wantRequeue: admissionRetryInterval,
+	}, {
+		name:       "queued behind an older taskrun",
+		quotas:     []*corev1.ResourceQuota{podsQuota("2", "1")},
+		wantReason: podconvert.ReasonQueuedForResourceQuota,
+		trs: []*v1beta1.TaskRun{
+			queuedTaskRun(t, "test-taskrun", time.Minute, ""),
+			queuedTaskRun(t, "older-taskrun", time.Hour, ""),
+		},
+	}, {
+		name:   "admitted before an older taskrun of lower priority",
+		quotas: []*corev1.ResourceQuota{podsQuota("2", "1")},
+		trs: []*v1beta1.TaskRun{
+			queuedTaskRun(t, "test-taskrun", time.Minute, "10"),
+			queuedTaskRun(t, "older-taskrun", time.Hour, ""),
+		},
+		wantPod: true,
+	}, {
+		name:   "admitted before an older taskrun exceeding the quota",
+		quotas: []*corev1.ResourceQuota{podsQuota("2", "1")},
+		trs: []*v1beta1.TaskRun{
+			queuedTaskRun(t, "test-taskrun", time.Minute, ""),
+			exceedingTaskRun(t, "older-taskrun", time.Hour, ""),
+		},
+		wantPod: true,
+	}, {
+		name:       "queued behind an older taskrun not checked yet",
+		quotas:     []*corev1.ResourceQuota{podsQuota("2", "1")},
+		wantReason: podconvert.ReasonQueuedForResourceQuota,
+		trs: []*v1beta1.TaskRun{
+			queuedTaskRun(t, "test-taskrun", time.Minute, ""),
+			queuedTaskRun(t, "older-taskrun", time.Hour, ""),
+			exceedingTaskRun(t, "oldest-taskrun", 2*time.Hour, ""),
+		},
+	}, {
+		name:        "requeued until its timeout",
+		quotas:      []*corev1.ResourceQuota{podsQuota("2", "2")},
+		trs:         []*v1beta1.TaskRun{startedTaskRun(t, "test-taskrun", 58*time.Minute)},
+		wantRequeue: 2 * time.Minute,
+	}, {
+		name:       "timed out waiting",
+		quotas:     []*corev1.ResourceQuota{podsQuota("2", "2")},
+		trs:        []*v1beta1.TaskRun{startedTaskRun(t, "test-taskrun", 2*time.Hour)},
+		wantReason: v1beta1.TaskRunReasonTimedOut.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				TaskRuns:       tc.trs,
+				Tasks:          []*v1beta1.Task{simpleTask},
+				ResourceQuotas: tc.quotas,
+				ServiceAccounts: []*corev1.ServiceAccount{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.DefaultServiceAccountValue, Namespace: "foo"},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/test-taskrun")
+			if tc.wantRequeue > 0 {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay != tc.wantRequeue {
+					t.Errorf("Expected the TaskRun to be requeued after %s but got %v", tc.wantRequeue, err)
+				}
+			} else if ok, _ := controller.IsRequeueKey(err); err != nil && !ok {
+				t.Fatalf("Unexpected error reconciling TaskRun: %v", err)
+			}
+
+			reconciledRun, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, "test-taskrun", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			pods, err := clients.Kube.CoreV1().Pods("foo").List(testAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("listing pods: %v", err)
+			}
+			if gotPod := len(pods.Items) > 0; gotPod != tc.wantPod {
+				t.Errorf("Expected a pod to be created: %t, got %d pods", tc.wantPod, len(pods.Items))
+			}
+			reason := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason
+			if tc.wantPod && (reason == podconvert.ReasonExceededResourceQuota || reason == podconvert.ReasonQueuedForResourceQuota) {
+				t.Errorf("Expected the TaskRun to be admitted but it is waiting: %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Message)
+			}
+			if !tc.wantPod {
+				wantReason := podconvert.ReasonExceededResourceQuota
+				if tc.wantReason != "" {
+					wantReason = tc.wantReason
+				}
+				if reason != wantReason {
+					t.Errorf("Expected reason %q but got %q", wantReason, reason)
+				}
+				if reconciledRun.Status.StartTime == nil {
+					t.Error("Expected the timeout of the TaskRun waiting for quota to be running, but it has no start time")
+				}
+			}
+		})
+	}
+}
+
+func TestNextToAdmit(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		waiting []*v1beta1.TaskRun
+		want    []string
+	}{{
+		name: "nothing waiting",
+	}, {
+		name: "first come first served",
+		waiting: []*v1beta1.TaskRun{
+			queuedTaskRun(t, "second", time.Hour, ""),
+			queuedTaskRun(t, "first", 2*time.Hour, ""),
+		},
+		want: []string{"first"},
+	}, {
+		name: "higher priority first",
+		waiting: []*v1beta1.TaskRun{
+			queuedTaskRun(t, "first", 2*time.Hour, ""),
+			queuedTaskRun(t, "low", time.Hour, "-1"),
+			queuedTaskRun(t, "high", time.Minute, "5"),
+		},
+		want: []string{"high"},
+	}, {
+		name: "up to the first taskrun not exceeding the quota",
+		waiting: []*v1beta1.TaskRun{
+			queuedTaskRun(t, "third", time.Hour, ""),
+			exceedingTaskRun(t, "second", 2*time.Hour, ""),
+			queuedTaskRun(t, "fourth", time.Minute, ""),
+			exceedingTaskRun(t, "first", 3*time.Hour, ""),
+		},
+		want: []string{"first", "second", "third"},
+	}, {
+		name: "all exceeding the quota",
+		waiting: []*v1beta1.TaskRun{
+			exceedingTaskRun(t, "second", time.Hour, ""),
+			exceedingTaskRun(t, "first", 2*time.Hour, ""),
+		},
+		want: []string{"first", "second"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, tr := range nextToAdmit(tc.waiting) {
+				got = append(got, tr.Name)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("nextToAdmit() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
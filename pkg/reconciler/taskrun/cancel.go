@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/logging"
+)
+
+// cancellationSlack is how long the pod of a cancelled TaskRun is waited for on top of the
+// termination grace period of its steps, which accounts for the kubelet projecting the
+// cancelled annotation into the pod.
+const cancellationSlack = 30 * time.Second
+
+// cancelGracefully signals the cancellation of tr to the running step of its pod when its steps
+// have a termination grace period, and returns how long to wait for the step to terminate before
+// the pod is deleted. It returns 0 when the pod doesn't need to be waited for anymore, after
+// recording the status of its steps.
+func (c *Reconciler) cancelGracefully(ctx context.Context, tr *v1beta1.TaskRun) (time.Duration, error) {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields != config.AlphaAPIFields ||
+		tr.Status.PodName == "" || tr.Status.TaskSpec == nil {
+		return 0, nil
+	}
+	gracePeriod := podconvert.MaxTerminationGracePeriod(tr.Status.TaskSpec.Steps, tr.Spec.TerminationGracePeriod)
+	if gracePeriod <= 0 {
+		return 0, nil
+	}
+
+	pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+	if k8serrors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pod %s: %w", tr.Status.PodName, err)
+	}
+
+	// Record how the steps terminated, including whether they exited gracefully.
+	status, err := podconvert.MakeTaskRunStatus(logging.FromContext(ctx), *tr, pod)
+	if err != nil {
+		return 0, err
+	}
+	tr.Status.Steps = status.Steps
+	if pod.Status.Phase != corev1.PodRunning {
+		return 0, nil
+	}
+
+	now := c.Clock.Now()
+	signalledAt, signalled := podconvert.CancellationSignalledAt(pod)
+	if !signalled {
+		if err := podconvert.SignalCancellation(ctx, c.KubeClientSet, *pod, now); err != nil {
+			return 0, fmt.Errorf("failed to signal the cancellation to pod %s: %w", pod.Name, err)
+		}
+		signalledAt = now
+	}
+	return signalledAt.Add(gracePeriod + cancellationSlack).Sub(now), nil
+}
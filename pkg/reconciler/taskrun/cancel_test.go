@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/parse"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+)
+
+func TestReconcileCancelledTaskRunGracefully(t *testing.T) {
+	exitedGracefully := true
+	for _, tc := range []struct {
+		name                 string
+		podAnnotations       map[string]string
+		podStatus            corev1.PodStatus
+		wantSignalledAt      string
+		wantRequeue          time.Duration
+		wantCancelled        bool
+		wantExitedGracefully *bool
+	}{{
+		name: "cancellation signalled to the running step",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-simple-step",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+		wantSignalledAt: "2022-01-01T00:00:00Z",
+		wantRequeue:     time.Minute + cancellationSlack,
+	}, {
+		name:           "step still terminating",
+		podAnnotations: map[string]string{"tekton.dev/cancelled": "2021-12-31T23:59:00Z"},
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-simple-step",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+		wantSignalledAt: "2021-12-31T23:59:00Z",
+		wantRequeue:     cancellationSlack,
+	}, {
+		name:           "grace period expired",
+		podAnnotations: map[string]string{"tekton.dev/cancelled": "2021-12-31T23:58:00Z"},
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-simple-step",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+		wantCancelled: true,
+	}, {
+		name:           "step terminated gracefully",
+		podAnnotations: map[string]string{"tekton.dev/cancelled": "2021-12-31T23:59:50Z"},
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-simple-step",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  `[{"key":"ExitedGracefully","value":"true","type":"InternalTektonResult"}]`,
+				}},
+			}},
+		},
+		wantCancelled:        true,
+		wantExitedGracefully: &exitedGracefully,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  status: TaskRunCancelled
+  taskRef:
+    name: test-task
+  terminationGracePeriod: 1m
+status:
+  podName: test-taskrun-pod
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+  taskSpec:
+    steps:
+    - name: simple-step
+      image: foo
+`)
+			tr.Status.StartTime = &metav1.Time{Time: now.Add(-time.Minute)}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-taskrun-pod",
+					Namespace:   "foo",
+					Annotations: map[string]string{"tekton.dev/ready": "READY"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "step-simple-step", Image: "foo"}},
+				},
+				Status: tc.podStatus,
+			}
+			for k, v := range tc.podAnnotations {
+				pod.Annotations[k] = v
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{tr},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				Pods:     []*corev1.Pod{pod},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data:       map[string]string{"enable-api-fields": config.AlphaAPIFields},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/test-taskrun")
+			if tc.wantRequeue > 0 {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay != tc.wantRequeue {
+					t.Errorf("Expected the TaskRun to be requeued after %s but got %v", tc.wantRequeue, err)
+				}
+			} else if err != nil {
+				t.Errorf("Error reconciling: %s", err)
+			}
+
+			reconciledRun, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, "test-taskrun", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if cancelled := condition.IsFalse() && condition.Reason == v1beta1.TaskRunReasonCancelled.String(); cancelled != tc.wantCancelled {
+				t.Errorf("Expected the TaskRun to be cancelled: %t, but got condition %v", tc.wantCancelled, condition)
+			}
+			if len(reconciledRun.Status.Steps) != 1 {
+				t.Fatalf("Expected the state of one step but got %v", reconciledRun.Status.Steps)
+			}
+			if d := cmp.Diff(tc.wantExitedGracefully, reconciledRun.Status.Steps[0].ExitedGracefully); d != "" {
+				t.Errorf("Unexpected ExitedGracefully %s", diff.PrintWantGot(d))
+			}
+
+			reconciledPod, err := clients.Kube.CoreV1().Pods("foo").Get(testAssets.Ctx, "test-taskrun-pod", metav1.GetOptions{})
+			if tc.wantCancelled {
+				if !k8serrors.IsNotFound(err) {
+					t.Errorf("Expected the pod of the cancelled TaskRun to be deleted but got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getting pod: %v", err)
+			}
+			if got := reconciledPod.Annotations["tekton.dev/cancelled"]; got != tc.wantSignalledAt {
+				t.Errorf("Expected the cancellation to be signalled at %q but got %q", tc.wantSignalledAt, got)
+			}
+		})
+	}
+}
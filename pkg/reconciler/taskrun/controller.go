@@ -26,20 +26,31 @@ import (
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
 	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
-	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1alpha1/resolutionrequest"
+	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
 	resourceinformer "github.com/tektoncd/pipeline/pkg/client/resource/injection/informers/resource/v1alpha1/pipelineresource"
 	"github.com/tektoncd/pipeline/pkg/pod"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/priority"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/admission"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	limitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
+	resourcequotainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/resourcequota"
+	priorityclassinformer "knative.dev/pkg/client/injection/kube/informers/scheduling/v1/priorityclass"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 )
 
@@ -53,10 +64,20 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		podInformer := filteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey)
 		resourceInformer := resourceinformer.Get(ctx)
 		limitrangeInformer := limitrangeinformer.Get(ctx)
+		namespaceInformer := namespaceinformer.Get(ctx)
+		priorityClassInformer := priorityclassinformer.Get(ctx)
+		resourceQuotaInformer := resourcequotainformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
-		configStore := config.NewStore(logger.Named("config-store"), taskrunmetrics.MetricsOnStore(logger))
+		configStore := config.NewStore(logger.Named("config-store"), taskrunmetrics.MetricsOnStore(logger), tracing.OnStore(logger, tracing.ControllerServiceName))
 		configStore.WatchConfigs(cmw)
 
+		if err := tknreconciler.AddIndexer(taskRunInformer.Informer(), pruner.StandaloneTaskIndex, pruner.StandaloneTaskIndexFunc); err != nil {
+			logger.Fatalf("Error indexing TaskRuns: %v", err)
+		}
+		if err := tknreconciler.AddIndexer(taskRunInformer.Informer(), admission.PendingIndex, admission.PendingIndexFunc); err != nil {
+			logger.Fatalf("Error indexing TaskRuns: %v", err)
+		}
+
 		entrypointCache, err := pod.NewEntrypointCache(kubeclientset)
 		if err != nil {
 			logger.Fatalf("Error creating entrypoint cache: %v", err)
@@ -68,8 +89,11 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			Images:              opts.Images,
 			Clock:               clock,
 			taskRunLister:       taskRunInformer.Lister(),
+			taskRunIndexer:      taskRunInformer.Informer().GetIndexer(),
 			resourceLister:      resourceInformer.Lister(),
 			limitrangeLister:    limitrangeInformer.Lister(),
+			namespaceLister:     namespaceInformer.Lister(),
+			resourceQuotaLister: resourceQuotaInformer.Lister(),
 			cloudEventClient:    cloudeventclient.Get(ctx),
 			metrics:             taskrunmetrics.Get(ctx),
 			entrypointCache:     entrypointCache,
@@ -84,13 +108,52 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			}
 		})
 
-		taskRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		taskRunInformer.Informer().AddEventHandler(controller.HandleAll(priority.Enqueue(impl, priorityClassInformer.Lister())))
 
 		podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.TaskRun{}),
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
 
+		// TaskRuns waiting for resource quota are admitted once quota frees up: when a pod completes
+		// or is deleted, or when a ResourceQuota of their namespace changes. The TaskRuns queued behind
+		// a TaskRun are also checked once it stops blocking them.
+		enqueueNextToAdmit := func(obj interface{}) {
+			object, err := kmeta.DeletionHandlingAccessor(obj)
+			if err != nil {
+				return
+			}
+			waiting, err := waitingForQuota(taskRunInformer.Informer().GetIndexer(), object.GetNamespace())
+			if err != nil {
+				logger.Error(err)
+				return
+			}
+			for _, next := range nextToAdmit(waiting) {
+				impl.EnqueueKey(types.NamespacedName{Namespace: next.Namespace, Name: next.Name})
+			}
+		}
+		podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, obj interface{}) {
+				if p, ok := obj.(*corev1.Pod); ok && (p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed) {
+					enqueueNextToAdmit(obj)
+				}
+			},
+			DeleteFunc: enqueueNextToAdmit,
+		})
+		resourceQuotaInformer.Informer().AddEventHandler(controller.HandleAll(enqueueNextToAdmit))
+		taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, obj interface{}) {
+				oldTr, ok := old.(*v1beta1.TaskRun)
+				if !ok {
+					return
+				}
+				if tr, ok := obj.(*v1beta1.TaskRun); ok && blocksAdmission(oldTr) && !blocksAdmission(tr) {
+					enqueueNextToAdmit(obj)
+				}
+			},
+			DeleteFunc: enqueueNextToAdmit,
+		})
+
 		return impl
 	}
 }
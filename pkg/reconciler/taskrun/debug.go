@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// DebugStepsAnnotationKey is the annotation requesting a debug pod for each of the comma-separated
+// failed steps of a TaskRun retained for debugging.
+const DebugStepsAnnotationKey = pipeline.GroupName + "/debug-steps"
+
+const (
+	// reasonDebugPodCreated is the reason of the event emitted when a debug pod is created
+	reasonDebugPodCreated = "DebugPodCreated"
+	// reasonDebugPodFailed is the reason of the event emitted when a requested debug pod can't be created
+	reasonDebugPodFailed = "DebugPodFailed"
+)
+
+// reconcileDebugRetention retains the failed tr for debugging until its debug retention TTL expires:
+// it records until when in the status of tr, creates the debug pods requested for its failed steps,
+// and requeues tr for when the retention expires. Once it expired, the debug pods are deleted.
+func (c *Reconciler) reconcileDebugRetention(ctx context.Context, tr *v1beta1.TaskRun) error {
+	if tr.Status.Debug == nil || tr.Status.Debug.RetainedUntil == nil {
+		if tr.Status.CompletionTime == nil || !tr.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+			return nil
+		}
+		ttl, err := pruner.DebugRetentionTTL(ctx, tr)
+		if err != nil {
+			// An invalid annotation is a user error which retrying won't fix: fall back to the default.
+			logging.FromContext(ctx).Warnf("Failed to get the debug retention TTL of TaskRun %s, using the default: %v", tr.Name, err)
+		}
+		if ttl <= 0 {
+			return nil
+		}
+		if tr.Status.Debug == nil {
+			tr.Status.Debug = &v1beta1.TaskRunDebugStatus{}
+		}
+		retainedUntil := metav1.NewTime(tr.Status.CompletionTime.Add(ttl))
+		tr.Status.Debug.RetainedUntil = &retainedUntil
+	}
+
+	remaining := tr.Status.Debug.RetainedUntil.Sub(c.Clock.Now())
+	if remaining <= 0 {
+		return c.deleteDebugPods(ctx, tr)
+	}
+	for _, stepName := range requestedDebugSteps(tr) {
+		if hasDebugPod(tr, stepName) {
+			continue
+		}
+		if err := c.createDebugPod(ctx, tr, stepName, remaining); err != nil {
+			return err
+		}
+	}
+	return controller.NewRequeueAfter(remaining)
+}
+
+// createDebugPod creates a pod to debug the given failed step of tr until its retention expires,
+// and records it in the status of tr.
+func (c *Reconciler) createDebugPod(ctx context.Context, tr *v1beta1.TaskRun, stepName string, lifetime time.Duration) error {
+	recorder := controller.GetEventRecorder(ctx)
+
+	var step *v1beta1.StepState
+	for i := range tr.Status.Steps {
+		if tr.Status.Steps[i].Name == stepName {
+			step = &tr.Status.Steps[i]
+			break
+		}
+	}
+	if step == nil || step.Terminated == nil || step.Terminated.ExitCode == 0 {
+		recorder.Eventf(tr, corev1.EventTypeWarning, reasonDebugPodFailed, "Can't debug step %q: it is not a failed step of TaskRun %s", stepName, tr.Name)
+		return nil
+	}
+
+	failedPod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+	if k8serrors.IsNotFound(err) {
+		recorder.Eventf(tr, corev1.EventTypeWarning, reasonDebugPodFailed, "Can't debug step %q: pod %s of TaskRun %s was deleted", stepName, tr.Status.PodName, tr.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s: %w", tr.Status.PodName, err)
+	}
+
+	debugPod, err := podconvert.MakeDebugPod(tr, failedPod, *step, lifetime)
+	if err != nil {
+		recorder.Eventf(tr, corev1.EventTypeWarning, reasonDebugPodFailed, "Can't debug step %q: %v", stepName, err)
+		return nil
+	}
+	// The debug pod may already exist if the status of tr failed to be updated after it was created.
+	if _, err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Create(ctx, debugPod, metav1.CreateOptions{}); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create debug pod for step %q: %w", stepName, err)
+	}
+	tr.Status.Debug.DebugPods = append(tr.Status.Debug.DebugPods, v1beta1.DebugPod{StepName: stepName, PodName: debugPod.Name})
+	recorder.Eventf(tr, corev1.EventTypeNormal, reasonDebugPodCreated, "Created pod %s to debug step %q", debugPod.Name, stepName)
+	return nil
+}
+
+// deleteDebugPods deletes the debug pods of tr once its retention expired. They are kept in the
+// status of tr, which may be pruned right after.
+func (c *Reconciler) deleteDebugPods(ctx context.Context, tr *v1beta1.TaskRun) error {
+	for _, debugPod := range tr.Status.Debug.DebugPods {
+		err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(ctx, debugPod.PodName, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete debug pod %s: %w", debugPod.PodName, err)
+		}
+	}
+	return nil
+}
+
+// isRetainedForDebugging returns true if tr failed and is retained for debugging at the given time.
+func isRetainedForDebugging(tr *v1beta1.TaskRun, now time.Time) bool {
+	return tr.Status.Debug != nil && tr.Status.Debug.RetainedUntil != nil && now.Before(tr.Status.Debug.RetainedUntil.Time)
+}
+
+// requestedDebugSteps returns the names of the steps of tr for which a debug pod is requested.
+func requestedDebugSteps(tr *v1beta1.TaskRun) []string {
+	var steps []string
+	for _, s := range strings.Split(tr.Annotations[DebugStepsAnnotationKey], ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+func hasDebugPod(tr *v1beta1.TaskRun, stepName string) bool {
+	for _, debugPod := range tr.Status.Debug.DebugPods {
+		if debugPod.StepName == stepName {
+			return true
+		}
+	}
+	return false
+}
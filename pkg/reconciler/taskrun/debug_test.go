@@ -0,0 +1,280 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	eventstest "github.com/tektoncd/pipeline/test/events"
+	"github.com/tektoncd/pipeline/test/parse"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+func failedTaskRunWithPod(t *testing.T, completedAgo time.Duration, annotations map[string]string) (*v1beta1.TaskRun, *corev1.Pod) {
+	t.Helper()
+	tr := completedTaskRun(t, "test-taskrun", corev1.ConditionFalse, completedAgo, map[string]string{"tekton.dev/task": "test-task"})
+	tr.Annotations = annotations
+	tr.Status.PodName = "test-taskrun-pod"
+	tr.Status.Steps = []v1beta1.StepState{{
+		Name:           "fetch",
+		ContainerName:  "step-fetch",
+		ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+	}, {
+		Name:           "build",
+		ContainerName:  "step-build",
+		ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 2}},
+	}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-taskrun-pod",
+			Namespace: "foo",
+			Labels:    map[string]string{v1beta1.ManagedByLabelKey: "tekton-pipelines"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "step-fetch", Image: "alpine/git"}, {Name: "step-build", Image: "golang"}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodFailed},
+	}
+	return tr, pod
+}
+
+func timePtr(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
+
+func TestReconcileDebugRetention(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		annotations   map[string]string
+		completedAgo  time.Duration
+		debugStatus   *v1beta1.TaskRunDebugStatus
+		wantDebug     *v1beta1.TaskRunDebugStatus
+		wantDebugPods []string
+		wantDeleted   []string
+		wantRequeue   time.Duration
+		wantEvents    []string
+	}{{
+		name:         "not retained by default",
+		completedAgo: time.Hour,
+	}, {
+		name:         "retained for debugging",
+		annotations:  map[string]string{pruner.DebugRetentionTTLAnnotationKey: "3h"},
+		completedAgo: time.Hour,
+		wantDebug:    &v1beta1.TaskRunDebugStatus{RetainedUntil: timePtr(now.Add(2 * time.Hour))},
+		wantRequeue:  2 * time.Hour,
+	}, {
+		name: "debug pod requested for a failed step",
+		annotations: map[string]string{
+			pruner.DebugRetentionTTLAnnotationKey: "3h",
+			DebugStepsAnnotationKey:               "build",
+		},
+		completedAgo: time.Hour,
+		wantDebug: &v1beta1.TaskRunDebugStatus{
+			RetainedUntil: timePtr(now.Add(2 * time.Hour)),
+			DebugPods:     []v1beta1.DebugPod{{StepName: "build", PodName: "test-taskrun-debug-build"}},
+		},
+		wantDebugPods: []string{"test-taskrun-debug-build"},
+		wantRequeue:   2 * time.Hour,
+		wantEvents:    []string{"Normal DebugPodCreated Created pod test-taskrun-debug-build to debug step \"build\""},
+	}, {
+		name: "debug pod requested for a successful step",
+		annotations: map[string]string{
+			pruner.DebugRetentionTTLAnnotationKey: "3h",
+			DebugStepsAnnotationKey:               "fetch",
+		},
+		completedAgo: time.Hour,
+		wantDebug:    &v1beta1.TaskRunDebugStatus{RetainedUntil: timePtr(now.Add(2 * time.Hour))},
+		wantRequeue:  2 * time.Hour,
+		wantEvents:   []string{"Warning DebugPodFailed Can't debug step \"fetch\": it is not a failed step of TaskRun test-taskrun"},
+	}, {
+		name: "retention expired",
+		annotations: map[string]string{
+			pruner.DebugRetentionTTLAnnotationKey: "3h",
+			pruner.TTLAfterFinishedAnnotationKey:  "1h",
+			DebugStepsAnnotationKey:               "build",
+		},
+		completedAgo: 4 * time.Hour,
+		debugStatus: &v1beta1.TaskRunDebugStatus{
+			RetainedUntil: timePtr(now.Add(-time.Hour)),
+			DebugPods:     []v1beta1.DebugPod{{StepName: "build", PodName: "test-taskrun-debug-build"}},
+		},
+		wantDeleted: []string{"test-taskrun"},
+		wantEvents:  []string{"Normal TTLExpired Deleted completed TaskRun test-taskrun"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr, pod := failedTaskRunWithPod(t, tc.completedAgo, tc.annotations)
+			tr.Status.Debug = tc.debugStatus
+			pods := []*corev1.Pod{pod}
+			if tc.debugStatus != nil {
+				for _, debugPod := range tc.debugStatus.DebugPods {
+					pods = append(pods, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+						Name:      debugPod.PodName,
+						Namespace: "foo",
+						Labels:    map[string]string{"tekton.dev/debugTaskRun": "test-taskrun"},
+					}})
+				}
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{tr},
+				Pods:     pods,
+				// The pruning annotations are read from the namespace, the debug ones from the TaskRun.
+				Namespaces: []*corev1.Namespace{{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: tc.annotations},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/test-taskrun")
+			if tc.wantRequeue > 0 {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay != tc.wantRequeue {
+					t.Errorf("Expected the TaskRun to be requeued after %s but got %v", tc.wantRequeue, err)
+				}
+			} else if err != nil {
+				t.Errorf("Error reconciling: %s", err)
+			}
+
+			if d := cmp.Diff(tc.wantDeleted, getTaskRunDeletions(clients.Pipeline.Actions())); d != "" {
+				t.Errorf("Unexpected deleted TaskRuns %s", diff.PrintWantGot(d))
+			}
+			if len(tc.wantDeleted) == 0 {
+				reconciledRun, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, "test-taskrun", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("getting updated taskrun: %v", err)
+				}
+				if d := cmp.Diff(tc.wantDebug, reconciledRun.Status.Debug); d != "" {
+					t.Errorf("Unexpected debug status %s", diff.PrintWantGot(d))
+				}
+			}
+
+			debugPods, err := clients.Kube.CoreV1().Pods("foo").List(testAssets.Ctx, metav1.ListOptions{LabelSelector: "tekton.dev/debugTaskRun=test-taskrun"})
+			if err != nil {
+				t.Fatalf("listing pods: %v", err)
+			}
+			var gotDebugPods []string
+			for _, p := range debugPods.Items {
+				gotDebugPods = append(gotDebugPods, p.Name)
+			}
+			if d := cmp.Diff(tc.wantDebugPods, gotDebugPods); d != "" {
+				t.Errorf("Unexpected debug pods %s", diff.PrintWantGot(d))
+			}
+
+			if err := eventstest.CheckEventsOrdered(t, testAssets.Recorder.Events, tc.name, tc.wantEvents); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestReconcilePausedAtBreakpoint(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		ready       bool
+		wantDebug   *v1beta1.TaskRunDebugStatus
+		wantRequeue time.Duration
+	}{{
+		name:  "still paused",
+		ready: true,
+		wantDebug: &v1beta1.TaskRunDebugStatus{
+			PausedStep:  "simple-step",
+			PausedSince: timePtr(now.Add(-90 * time.Minute)),
+		},
+		wantRequeue: 30 * time.Minute,
+	}, {
+		name: "continued",
+		wantDebug: &v1beta1.TaskRunDebugStatus{
+			PausedDuration: &metav1.Duration{Duration: 90 * time.Minute},
+		},
+		wantRequeue: 30 * time.Minute,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  timeout: 1h
+status:
+  podName: test-taskrun-pod
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+`)
+			tr.Status.StartTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+			tr.Status.Debug = &v1beta1.TaskRunDebugStatus{
+				PausedStep:  "simple-step",
+				PausedSince: timePtr(now.Add(-90 * time.Minute)),
+			}
+			pod, err := makePod(tr, simpleTask)
+			if err != nil {
+				t.Fatalf("MakePod: %v", err)
+			}
+			pod.Spec.Containers[0].ReadinessProbe = &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					Exec: &corev1.ExecAction{
+						Command: []string{"/tekton/bin/entrypoint", "breakpoint-paused", "/tekton/run/0/out.breakpoint"},
+					},
+				},
+				PeriodSeconds: 1,
+			}
+			pod.Status = corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "step-simple-step",
+					Ready: tc.ready,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}},
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{tr},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				Pods:     []*corev1.Pod{pod},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+
+			err = testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/test-taskrun")
+			if ok, delay := controller.IsRequeueKey(err); !ok || delay != tc.wantRequeue {
+				t.Errorf("Expected the TaskRun to be requeued after %s but got %v", tc.wantRequeue, err)
+			}
+			reconciledRun, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, "test-taskrun", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			if reconciledRun.IsDone() {
+				t.Errorf("Expected the TaskRun paused at a breakpoint not to time out but it is done: %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+			}
+			if d := cmp.Diff(tc.wantDebug, reconciledRun.Status.Debug); d != "" {
+				t.Errorf("Unexpected debug status %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
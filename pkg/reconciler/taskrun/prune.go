@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// pruneCompletedTaskRuns deletes the completed TaskRuns of the Task of tr which exceed the history
+// limits of the namespace, and tr itself once its TTL expired. TaskRuns created by a PipelineRun are
+// left alone: they are deleted along with their PipelineRun. If the TTL of tr has not expired yet,
+// tr is requeued for when it does.
+func (c *Reconciler) pruneCompletedTaskRuns(ctx context.Context, tr *v1beta1.TaskRun) error {
+	logger := logging.FromContext(ctx)
+
+	if _, ok := tr.Labels[pipeline.PipelineRunLabelKey]; ok {
+		return nil
+	}
+
+	// The lister returns a nil namespace when it is not found, which leaves the defaults.
+	ns, err := c.namespaceLister.Get(tr.Namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get namespace %s: %w", tr.Namespace, err)
+	}
+	settings, err := pruner.GetSettings(ctx, ns)
+	if err != nil {
+		// An invalid annotation is a user error which retrying won't fix: fall back to the defaults.
+		logger.Warnf("Failed to get the pruning settings of namespace %s, using the defaults: %v", tr.Namespace, err)
+		settings, _ = pruner.GetSettings(ctx, nil)
+	}
+
+	if taskName, ok := tr.Labels[pipeline.TaskLabelKey]; ok && settings.HasHistoryLimits() {
+		if err := c.pruneTaskRunsExceedingHistory(ctx, tr.Namespace, taskName, settings); err != nil {
+			return err
+		}
+	}
+
+	if pruner.IsRetained(tr) || tr.Status.CompletionTime == nil {
+		return nil
+	}
+	remaining, expires := settings.TimeToExpiry(tr.Status.CompletionTime.Time, c.Clock.Now())
+	if !expires {
+		return nil
+	}
+	if remaining > 0 {
+		return controller.NewRequeueAfter(remaining)
+	}
+	logger.Infof("Deleting TaskRun %s: completed more than %s ago", tr.Name, settings.TTLAfterFinished)
+	return c.deleteTaskRun(ctx, tr, pruner.ReasonTTLExpired)
+}
+
+// pruneTaskRunsExceedingHistory deletes the completed standalone TaskRuns of the given Task which
+// are older than the most recent ones to keep.
+func (c *Reconciler) pruneTaskRunsExceedingHistory(ctx context.Context, namespace, taskName string, settings pruner.Settings) error {
+	logger := logging.FromContext(ctx)
+
+	objs, err := c.taskRunIndexer.ByIndex(pruner.StandaloneTaskIndex, pruner.IndexKey(namespace, taskName))
+	if err != nil {
+		return fmt.Errorf("failed to list TaskRuns of Task %s: %w", taskName, err)
+	}
+
+	byName := map[string]*v1beta1.TaskRun{}
+	runs := []pruner.Run{}
+	for _, obj := range objs {
+		t, ok := obj.(*v1beta1.TaskRun)
+		if !ok {
+			continue
+		}
+		if !t.IsDone() || t.Status.CompletionTime == nil || pruner.IsRetained(t) || isRetainedForDebugging(t, c.Clock.Now()) {
+			continue
+		}
+		byName[t.Name] = t
+		runs = append(runs, pruner.Run{
+			Name:           t.Name,
+			CompletionTime: t.Status.CompletionTime.Time,
+			Succeeded:      t.Status.GetCondition(apis.ConditionSucceeded).IsTrue(),
+		})
+	}
+
+	for _, name := range settings.RunsExceedingHistory(runs) {
+		logger.Infof("Deleting TaskRun %s: history limit of Task %s exceeded", name, taskName)
+		if err := c.deleteTaskRun(ctx, byName[name], pruner.ReasonHistoryLimitExceeded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Reconciler) deleteTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reason string) error {
+	propagationPolicy := metav1.DeletePropagationBackground
+	err := c.PipelineClientSet.TektonV1beta1().TaskRuns(tr.Namespace).Delete(ctx, tr.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete TaskRun %s: %w", tr.Name, err)
+	}
+	controller.GetEventRecorder(ctx).Eventf(tr, corev1.EventTypeNormal, reason, "Deleted completed TaskRun %s", tr.Name)
+	if err := c.metrics.Pruned(ctx, tr, reason); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to log the metrics : %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/parse"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/controller"
+)
+
+func completedTaskRun(t *testing.T, name string, status corev1.ConditionStatus, completedAgo time.Duration, labels map[string]string) *v1beta1.TaskRun {
+	t.Helper()
+	tr := parse.MustParseTaskRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - reason: Completed
+    status: %q
+    type: Succeeded
+  startTime: %s
+  completionTime: %s
+`, name, status, now.Add(-completedAgo-time.Minute).Format(time.RFC3339), now.Add(-completedAgo).Format(time.RFC3339)))
+	tr.Labels = labels
+	return tr
+}
+
+func getTaskRunDeletions(actions []ktesting.Action) []string {
+	var deleted []string
+	for _, a := range actions {
+		if action, ok := a.(ktesting.DeleteAction); ok && action.GetResource().Resource == "taskruns" {
+			deleted = append(deleted, action.GetName())
+		}
+	}
+	return deleted
+}
+
+func TestReconcilePruneCompletedTaskRuns(t *testing.T) {
+	taskLabels := map[string]string{"tekton.dev/task": "test-task"}
+	pipelineTaskLabels := map[string]string{"tekton.dev/task": "test-task", "tekton.dev/pipelineRun": "test-pipeline-run"}
+
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		trs         []*v1beta1.TaskRun
+		wantDeleted []string
+		wantRequeue time.Duration
+	}{{
+		name: "no pruning by default",
+		trs: []*v1beta1.TaskRun{
+			completedTaskRun(t, "test-taskrun", corev1.ConditionTrue, 48*time.Hour, taskLabels),
+			completedTaskRun(t, "old-taskrun", corev1.ConditionTrue, 72*time.Hour, taskLabels),
+		},
+	}, {
+		name:        "ttl expired",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "1h"},
+		trs: []*v1beta1.TaskRun{
+			completedTaskRun(t, "test-taskrun", corev1.ConditionFalse, 2*time.Hour, taskLabels),
+		},
+		wantDeleted: []string{"test-taskrun"},
+	}, {
+		name:        "ttl not expired yet",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "1h"},
+		trs: []*v1beta1.TaskRun{
+			completedTaskRun(t, "test-taskrun", corev1.ConditionTrue, 15*time.Minute, taskLabels),
+		},
+		wantRequeue: 45 * time.Minute,
+	}, {
+		name:        "ttl expired for a taskrun of a pipelinerun",
+		annotations: map[string]string{pruner.TTLAfterFinishedAnnotationKey: "1h"},
+		trs: []*v1beta1.TaskRun{
+			completedTaskRun(t, "test-taskrun", corev1.ConditionTrue, 2*time.Hour, pipelineTaskLabels),
+		},
+	}, {
+		name:        "history limit exceeded",
+		annotations: map[string]string{pruner.SuccessfulRunsHistoryLimitAnnotationKey: "1"},
+		trs: []*v1beta1.TaskRun{
+			completedTaskRun(t, "test-taskrun", corev1.ConditionTrue, time.Minute, taskLabels),
+			completedTaskRun(t, "successful-1", corev1.ConditionTrue, time.Hour, taskLabels),
+			completedTaskRun(t, "failed-1", corev1.ConditionFalse, time.Hour, taskLabels),
+			completedTaskRun(t, "successful-in-pipelinerun", corev1.ConditionTrue, time.Hour, pipelineTaskLabels),
+		},
+		wantDeleted: []string{"successful-1"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				TaskRuns: tc.trs,
+				Namespaces: []*corev1.Namespace{{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: tc.annotations},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/test-taskrun")
+			if tc.wantRequeue > 0 {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay != tc.wantRequeue {
+					t.Errorf("Expected the TaskRun to be requeued after %s but got %v", tc.wantRequeue, err)
+				}
+			} else if err != nil {
+				t.Errorf("Error reconciling: %s", err)
+			}
+
+			if d := cmp.Diff(tc.wantDeleted, getTaskRunDeletions(testAssets.Clients.Pipeline.Actions())); d != "" {
+				t.Errorf("Unexpected deleted TaskRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
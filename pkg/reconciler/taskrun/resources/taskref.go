@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
 	"github.com/tektoncd/pipeline/pkg/remote/resolution"
 	remoteresource "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -58,14 +59,14 @@ func GetTaskKind(taskrun *v1beta1.TaskRun) v1beta1.TaskKind {
 func GetTaskFuncFromTaskRun(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, requester remoteresource.Requester, taskrun *v1beta1.TaskRun) (GetTask, error) {
 	// if the spec is already in the status, do not try to fetch it again, just use it as source of truth
 	if taskrun.Status.TaskSpec != nil {
-		return func(_ context.Context, name string) (v1beta1.TaskObject, error) {
+		return func(_ context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			return &v1beta1.Task{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      name,
 					Namespace: taskrun.Namespace,
 				},
 				Spec: *taskrun.Status.TaskSpec,
-			}, nil
+			}, nil, nil, nil
 		}, nil
 	}
 	return GetTaskFunc(ctx, k8s, tekton, requester, taskrun, taskrun.Spec.TaskRef, taskrun.Name, taskrun.Namespace, taskrun.Spec.ServiceAccountName)
@@ -75,6 +76,8 @@ func GetTaskFuncFromTaskRun(ctx context.Context, k8s kubernetes.Interface, tekto
 // also requires a kubeclient, tektonclient, namespace, and service account in case it needs to find that task in
 // cluster or authorize against an external repositroy. It will figure out whether it needs to look in the cluster or in
 // a remote image to fetch the  reference. It will also return the "kind" of the task being referenced.
+// The returned function verifies the signature of the fetched task according to the "resource-verification-mode"
+// feature flag.
 func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, requester remoteresource.Requester,
 	owner kmeta.OwnerRefable, tr *v1beta1.TaskRef, trName string, namespace, saName string) (GetTask, error) {
 	cfg := config.FromContextOrDefaults(ctx)
@@ -87,24 +90,31 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 	case cfg.FeatureFlags.EnableTektonOCIBundles && tr != nil && tr.Bundle != "":
 		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
 		// casting it to a TaskObject.
-		return func(ctx context.Context, name string) (v1beta1.TaskObject, error) {
+		return func(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			// If there is a bundle url at all, construct an OCI resolver to fetch the task.
 			kc, err := k8schain.New(ctx, k8s, k8schain.Options{
 				Namespace:          namespace,
 				ServiceAccountName: saName,
 			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to get keychain: %w", err)
+				return nil, nil, nil, fmt.Errorf("failed to get keychain: %w", err)
 			}
-			resolver := oci.NewResolver(tr.Bundle, kc)
+			verification, err := oci.ImageVerificationFromConfig(cfg.TrustedResources)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			resolver := oci.NewResolver(tr.Bundle, kc, oci.WithImageVerification(verification))
 
-			return resolveTask(ctx, resolver, name, kind)
+			task, source, err := resolveTask(ctx, resolver, name, kind)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return verifyTask(ctx, task, source, namespace, trustedresources.BundleResolver)
 		}, nil
 	case cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields && tr != nil && tr.Resolver != "" && requester != nil:
 		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
 		// casting it to a TaskObject.
-		return func(ctx context.Context, name string) (v1beta1.TaskObject, error) {
-			params := map[string]string{}
+		return func(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			var replacedParams []v1beta1.Param
 			if ownerAsTR, ok := owner.(*v1beta1.TaskRun); ok {
 				stringReplacements, arrayReplacements := paramsFromTaskRun(ctx, ownerAsTR)
@@ -115,11 +125,12 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 			} else {
 				replacedParams = append(replacedParams, tr.Params...)
 			}
-			for _, p := range replacedParams {
-				params[p.Name] = p.Value.StringVal
+			resolver := resolution.NewResolver(requester, owner, string(tr.Resolver), trName, namespace, replacedParams)
+			task, source, err := resolveTask(ctx, resolver, name, kind)
+			if err != nil {
+				return nil, nil, nil, err
 			}
-			resolver := resolution.NewResolver(requester, owner, string(tr.Resolver), trName, namespace, params)
-			return resolveTask(ctx, resolver, name, kind)
+			return verifyTask(ctx, task, source, namespace, string(tr.Resolver))
 		}, nil
 
 	default:
@@ -129,26 +140,43 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 			Kind:         kind,
 			Tektonclient: tekton,
 		}
-		return local.GetTask, nil
+		return func(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+			task, err := local.GetTask(ctx, name)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return verifyTask(ctx, task, nil, namespace, "")
+		}, nil
+	}
+}
+
+// verifyTask verifies the signature of task, resolved from source, with the public keys trusted for the
+// given namespace and resolver. The task is not returned if it fails verification and the failure is enforced.
+func verifyTask(ctx context.Context, task v1beta1.TaskObject, source *v1beta1.ConfigSource, namespace, resolver string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+	vr := trustedresources.VerifyTask(ctx, task, namespace, resolver)
+	if vr != nil && vr.Type == trustedresources.VerificationError {
+		return nil, nil, vr, vr.Err
 	}
+	return task, source, vr, nil
 }
 
 // resolveTask accepts an impl of remote.Resolver and attempts to
 // fetch a task with given name. An error is returned if the
 // remoteresource doesn't work or the returned data isn't a valid
-// v1beta1.TaskObject.
-func resolveTask(ctx context.Context, resolver remote.Resolver, name string, kind v1beta1.TaskKind) (v1beta1.TaskObject, error) {
+// v1beta1.TaskObject. The source the task was resolved from is
+// returned along with it.
+func resolveTask(ctx context.Context, resolver remote.Resolver, name string, kind v1beta1.TaskKind) (v1beta1.TaskObject, *v1beta1.ConfigSource, error) {
 	// Because the resolver will only return references with the same kind (eg ClusterTask), this will ensure we
 	// don't accidentally return a Task with the same name but different kind.
-	obj, err := resolver.Get(ctx, strings.TrimSuffix(strings.ToLower(string(kind)), "s"), name)
+	obj, source, err := resolver.Get(ctx, strings.TrimSuffix(strings.ToLower(string(kind)), "s"), name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	taskObj, err := readRuntimeObjectAsTask(ctx, obj)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
+		return nil, nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
 	}
-	return taskObj, nil
+	return taskObj, source, nil
 }
 
 // readRuntimeObjectAsTask tries to convert a generic runtime.Object
@@ -18,6 +18,7 @@ package resources_test
 
 import (
 	"context"
+	"errors"
 	"net/http/httptest"
 	"net/url"
 	"strings"
@@ -29,6 +30,9 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	ttesting "github.com/tektoncd/pipeline/pkg/trustedresources/testing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/parse"
@@ -38,6 +42,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	logtesting "knative.dev/pkg/logging/testing"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -420,10 +425,16 @@ func TestGetTaskFunc(t *testing.T) {
 				},
 			})
 
-			_, err := test.CreateImage(u.Host+"/"+tc.name, tc.remoteTasks...)
+			imgRef, err := test.CreateImage(u.Host+"/"+tc.name, tc.remoteTasks...)
 			if err != nil {
 				t.Fatalf("failed to upload test image: %s", err.Error())
 			}
+			expected := tc.expected
+			if tc.ref.Bundle != "" {
+				// Tasks fetched from bundles are annotated with the digest of the bundle image.
+				expected = expected.DeepCopyObject()
+				expected.(metav1.Object).SetAnnotations(map[string]string{oci.DigestAnnotation: strings.SplitN(imgRef, "@", 2)[1]})
+			}
 
 			trForFunc := &v1beta1.TaskRun{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-tr"},
@@ -436,12 +447,12 @@ func TestGetTaskFunc(t *testing.T) {
 				t.Fatalf("failed to get task fn: %s", err.Error())
 			}
 
-			task, err := fn(ctx, tc.ref.Name)
+			task, _, _, err := fn(ctx, tc.ref.Name)
 			if err != nil {
 				t.Fatalf("failed to call taskfn: %s", err.Error())
 			}
 
-			if diff := cmp.Diff(task, tc.expected); tc.expected != nil && diff != "" {
+			if diff := cmp.Diff(task, expected); tc.expected != nil && diff != "" {
 				t.Error(diff)
 			}
 		})
@@ -496,7 +507,7 @@ echo hello
 	if err != nil {
 		t.Fatalf("failed to get Task fn: %s", err.Error())
 	}
-	actualTask, err := fn(ctx, name)
+	actualTask, _, _, err := fn(ctx, name)
 	if err != nil {
 		t.Fatalf("failed to call Taskfn: %s", err.Error())
 	}
@@ -532,7 +543,7 @@ func TestGetTaskFunc_RemoteResolution(t *testing.T) {
 		t.Fatalf("failed to get task fn: %s", err.Error())
 	}
 
-	resolvedTask, err := fn(ctx, taskRef.Name)
+	resolvedTask, _, _, err := fn(ctx, taskRef.Name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -565,7 +576,7 @@ func TestGetTaskFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 	resolved := test.NewResolvedResource([]byte(taskYAML), nil, nil)
 	requester := &test.Requester{
 		ResolvedResource: resolved,
-		Params:           map[string]string{"foo": "bar"},
+		Params:           []v1beta1.Param{{Name: "foo", Value: *v1beta1.NewStructuredValues("bar")}},
 	}
 	tr := &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
@@ -583,7 +594,7 @@ func TestGetTaskFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 		t.Fatalf("failed to get task fn: %s", err.Error())
 	}
 
-	resolvedTask, err := fn(ctx, taskRef.Name)
+	resolvedTask, _, _, err := fn(ctx, taskRef.Name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -618,7 +629,7 @@ func TestGetTaskFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 		t.Fatalf("failed to get task fn: %s", err.Error())
 	}
 
-	_, err = fnNotMatching(ctx, taskRefNotMatching.Name)
+	_, _, _, err = fnNotMatching(ctx, taskRefNotMatching.Name)
 	if err == nil {
 		t.Fatal("expected error for non-matching params, did not get one")
 	}
@@ -647,11 +658,255 @@ func TestGetPipelineFunc_RemoteResolutionInvalidData(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
-	if _, err := fn(ctx, taskRef.Name); err == nil {
+	if _, _, _, err := fn(ctx, taskRef.Name); err == nil {
 		t.Fatalf("expected error due to invalid pipeline data but saw none")
 	}
 }
 
+func TestGetTaskFunc_Verification(t *testing.T) {
+	ctx := context.Background()
+	signer, publicKey := ttesting.GetSignerAndPublicKey(t)
+
+	signedTask := &v1beta1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "signed", Namespace: "trusted-resources"},
+		Spec:       simpleNamespacedTask.Spec,
+	}
+	if err := trustedresources.SignTask(signedTask, signer); err != nil {
+		t.Fatalf("SignTask() = %v", err)
+	}
+	unsignedTask := &v1beta1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "unsigned", Namespace: "trusted-resources"},
+		Spec:       simpleNamespacedTask.Spec,
+	}
+	tektonclient := fake.NewSimpleClientset(signedTask, unsignedTask)
+
+	for _, tc := range []struct {
+		name        string
+		mode        string
+		keys        *config.TrustedResources
+		task        *v1beta1.Task
+		remote      bool
+		wantResult  trustedresources.VerificationResultType
+		wantSkipped bool
+		wantErr     bool
+	}{{
+		name:       "signed local task",
+		mode:       config.EnforceResourceVerificationMode,
+		keys:       &config.TrustedResources{NamespacePublicKeys: map[string]string{"trusted-resources": publicKey}},
+		task:       signedTask,
+		wantResult: trustedresources.VerificationPass,
+	}, {
+		name:    "unsigned local task enforced",
+		mode:    config.EnforceResourceVerificationMode,
+		keys:    &config.TrustedResources{PublicKeys: publicKey},
+		task:    unsignedTask,
+		wantErr: true,
+	}, {
+		name:       "unsigned local task warned",
+		mode:       config.WarnResourceVerificationMode,
+		keys:       &config.TrustedResources{PublicKeys: publicKey},
+		task:       unsignedTask,
+		wantResult: trustedresources.VerificationWarn,
+	}, {
+		name:        "unsigned local task skipped",
+		mode:        config.SkipResourceVerificationMode,
+		keys:        &config.TrustedResources{},
+		task:        unsignedTask,
+		wantSkipped: true,
+	}, {
+		name:       "signed remote task",
+		mode:       config.EnforceResourceVerificationMode,
+		keys:       &config.TrustedResources{ResolverPublicKeys: map[string]string{"git": publicKey}},
+		task:       signedTask,
+		remote:     true,
+		wantResult: trustedresources.VerificationPass,
+	}, {
+		name:    "signed remote task with keys of another resolver",
+		mode:    config.EnforceResourceVerificationMode,
+		keys:    &config.TrustedResources{ResolverPublicKeys: map[string]string{"hub": publicKey}},
+		task:    signedTask,
+		remote:  true,
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := ctx
+			if tc.remote {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			ctx = ttesting.SetupTrustedResourcesContext(ctx, tc.mode, tc.keys)
+
+			taskRef := &v1beta1.TaskRef{Name: tc.task.Name}
+			var requester *test.Requester
+			if tc.remote {
+				taskRef = &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git"}}
+				data, err := yaml.Marshal(tc.task)
+				if err != nil {
+					t.Fatalf("error marshaling task: %v", err)
+				}
+				requester = test.NewRequester(test.NewResolvedResource(data, nil, nil), nil)
+			}
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "trusted-resources"},
+				Spec:       v1beta1.TaskRunSpec{TaskRef: taskRef},
+			}
+			fn, err := resources.GetTaskFunc(ctx, nil, tektonclient, requester, tr, tr.Spec.TaskRef, "", "trusted-resources", "default")
+			if err != nil {
+				t.Fatalf("failed to get task fn: %v", err)
+			}
+
+			task, _, vr, err := fn(ctx, taskRef.Name)
+			if tc.wantErr {
+				if !errors.Is(err, trustedresources.ErrResourceVerificationFailed) {
+					t.Fatalf("expected %v, got %v", trustedresources.ErrResourceVerificationFailed, err)
+				}
+				if task != nil {
+					t.Errorf("expected no task to be returned, got %v", task)
+				}
+				if vr == nil || vr.Type != trustedresources.VerificationError {
+					t.Errorf("expected an error verification result, got %v", vr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if task.TaskMetadata().Name != tc.task.Name {
+				t.Errorf("expected task %q, got %q", tc.task.Name, task.TaskMetadata().Name)
+			}
+			switch {
+			case tc.wantSkipped && vr != nil:
+				t.Errorf("expected verification to be skipped, got %v", vr)
+			case !tc.wantSkipped && (vr == nil || vr.Type != tc.wantResult):
+				t.Errorf("expected verification result %d, got %v", tc.wantResult, vr)
+			}
+		})
+	}
+}
+
+func TestGetTaskFunc_VerificationOfLocalAndRemoteTask(t *testing.T) {
+	signer, publicKey := ttesting.GetSignerAndPublicKey(t)
+	// The task is signed once as authored, without the types of its param and result.
+	signedTask := &v1beta1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "signed", Namespace: "trusted-resources"},
+		Spec: v1beta1.TaskSpec{
+			Params:  []v1beta1.ParamSpec{{Name: "message"}},
+			Results: []v1beta1.TaskResult{{Name: "digest"}},
+			Steps: []v1beta1.Step{{
+				Image:  "ubuntu",
+				Script: "echo $(params.message)",
+			}},
+		},
+	}
+	if err := trustedresources.SignTask(signedTask, signer); err != nil {
+		t.Fatalf("SignTask() = %v", err)
+	}
+	data, err := yaml.Marshal(signedTask)
+	if err != nil {
+		t.Fatalf("error marshaling task: %v", err)
+	}
+	// The task stored in the cluster has the defaults applied by the webhook.
+	storedTask := signedTask.DeepCopy()
+	storedTask.SetDefaults(context.Background())
+	tektonclient := fake.NewSimpleClientset(storedTask)
+
+	ctx := config.EnableAlphaAPIFields(context.Background())
+	ctx = ttesting.SetupTrustedResourcesContext(ctx, config.EnforceResourceVerificationMode, &config.TrustedResources{PublicKeys: publicKey})
+
+	for _, tc := range []struct {
+		name      string
+		taskRef   *v1beta1.TaskRef
+		requester *test.Requester
+	}{{
+		name:    "local task",
+		taskRef: &v1beta1.TaskRef{Name: "signed"},
+	}, {
+		name:      "remote task",
+		taskRef:   &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git"}},
+		requester: test.NewRequester(test.NewResolvedResource(data, nil, nil), nil),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "trusted-resources"},
+				Spec:       v1beta1.TaskRunSpec{TaskRef: tc.taskRef},
+			}
+			fn, err := resources.GetTaskFunc(ctx, nil, tektonclient, tc.requester, tr, tr.Spec.TaskRef, "", "trusted-resources", "default")
+			if err != nil {
+				t.Fatalf("failed to get task fn: %v", err)
+			}
+			task, _, vr, err := fn(ctx, tc.taskRef.Name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if vr == nil || vr.Type != trustedresources.VerificationPass {
+				t.Errorf("expected verification to pass, got %v", vr)
+			}
+			if d := cmp.Diff(storedTask.Spec, task.TaskSpec()); d != "" {
+				t.Errorf("unexpected task spec %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetTaskFunc_VerificationWithOtherDefaults(t *testing.T) {
+	signer, publicKey := ttesting.GetSignerAndPublicKey(t)
+	// The task is signed as authored, without the type of its param.
+	signedTask := &v1beta1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "signed", Namespace: "trusted-resources"},
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "message"}},
+			Steps: []v1beta1.Step{{
+				Image:  "ubuntu",
+				Script: "echo $(params.message)",
+			}},
+		},
+	}
+	if err := trustedresources.SignTask(signedTask, signer); err != nil {
+		t.Fatalf("SignTask() = %v", err)
+	}
+	data, err := yaml.Marshal(signedTask)
+	if err != nil {
+		t.Fatalf("error marshaling task: %v", err)
+	}
+
+	// The task is verified with config-defaults other than those of the
+	// cluster it was signed for.
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultTimeoutMinutes:      5,
+			DefaultServiceAccount:      "other-sa",
+			DefaultManagedByLabelValue: "other",
+		},
+		FeatureFlags: &config.FeatureFlags{EnableAPIFields: config.AlphaAPIFields},
+	})
+	ctx = ttesting.SetupTrustedResourcesContext(ctx, config.EnforceResourceVerificationMode, &config.TrustedResources{PublicKeys: publicKey})
+
+	taskRef := &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git"}}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trusted-resources"},
+		Spec:       v1beta1.TaskRunSpec{TaskRef: taskRef},
+	}
+	requester := test.NewRequester(test.NewResolvedResource(data, nil, nil), nil)
+	fn, err := resources.GetTaskFunc(ctx, nil, nil, requester, tr, tr.Spec.TaskRef, "", "trusted-resources", "default")
+	if err != nil {
+		t.Fatalf("failed to get task fn: %v", err)
+	}
+	task, _, vr, err := fn(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vr == nil || vr.Type != trustedresources.VerificationPass {
+		t.Errorf("expected verification to pass, got %v", vr)
+	}
+	// The defaults are applied once the task is verified.
+	if got := task.TaskSpec().Params[0].Type; got != v1beta1.ParamTypeString {
+		t.Errorf("expected the defaults of the verified task to be applied, got param type %q", got)
+	}
+}
+
 // This is missing the kind and apiVersion because those are added by
 // the MustParse helpers from the test package.
 var taskYAMLString = `
@@ -664,3 +919,107 @@ spec:
     script: |
       echo "hello world!"
 `
+
+func TestGetTaskFunc_BundleImageVerification(t *testing.T) {
+	// Set up a fake registry to push images to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cfg := config.NewStore(logtesting.TestLogger(t))
+	cfg.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName()},
+		Data: map[string]string{
+			"enable-tekton-oci-bundles": "true",
+		},
+	})
+	ctx = cfg.ToContext(ctx)
+
+	signer, publicKey := ttesting.GetSignerAndPublicKey(t)
+	task := &v1beta1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "simple"},
+	}
+	repo := u.Host + "/bundle-image-verification"
+	signedRef, err := test.CreateImage(repo+":signed", task)
+	if err != nil {
+		t.Fatalf("failed to upload test image: %v", err)
+	}
+	if err := test.SignImage(signedRef, signer); err != nil {
+		t.Fatalf("failed to sign test image: %v", err)
+	}
+	unsignedRef, err := test.CreateImage(repo+":unsigned", task.DeepCopy(), &v1beta1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "dummy"},
+	})
+	if err != nil {
+		t.Fatalf("failed to upload test image: %v", err)
+	}
+
+	kubeclient := fakek8s.NewSimpleClientset(&v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "default",
+		},
+	})
+
+	for _, tc := range []struct {
+		name    string
+		trusted *config.TrustedResources
+		bundle  string
+		wantErr string
+	}{{
+		name:    "tag allowed",
+		trusted: &config.TrustedResources{},
+		bundle:  repo + ":unsigned",
+	}, {
+		name:    "tag rejected",
+		trusted: &config.TrustedResources{RequireBundleDigest: true},
+		bundle:  repo + ":unsigned",
+		wantErr: "must be referenced by digest",
+	}, {
+		name:    "digest required",
+		trusted: &config.TrustedResources{RequireBundleDigest: true},
+		bundle:  unsignedRef,
+	}, {
+		name:    "signed",
+		trusted: &config.TrustedResources{RequireBundleDigest: true, BundleImagePublicKeys: publicKey},
+		bundle:  signedRef,
+	}, {
+		name:    "unsigned",
+		trusted: &config.TrustedResources{BundleImagePublicKeys: publicKey},
+		bundle:  unsignedRef,
+		wantErr: "failed verification",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := ttesting.SetupTrustedResourcesContext(ctx, config.SkipResourceVerificationMode, tc.trusted)
+			ref := &v1beta1.TaskRef{Name: "simple", Bundle: tc.bundle}
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-tr"},
+				Spec:       v1beta1.TaskRunSpec{TaskRef: ref},
+			}
+			fn, err := resources.GetTaskFunc(ctx, kubeclient, fake.NewSimpleClientset(), nil, tr, ref, "", "default", "default")
+			if err != nil {
+				t.Fatalf("failed to get task fn: %v", err)
+			}
+
+			resolved, _, _, err := fn(ctx, ref.Name)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q but got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to call taskfn: %v", err)
+			}
+			if got := resolved.TaskMetadata().Annotations[oci.DigestAnnotation]; got == "" {
+				t.Errorf("expected task to be annotated with %s", oci.DigestAnnotation)
+			}
+		})
+	}
+}
@@ -23,11 +23,15 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// GetTask is a function used to retrieve Tasks.
-type GetTask func(context.Context, string) (v1beta1.TaskObject, error)
+// GetTask is a function used to retrieve Tasks. It also returns the source the Task was resolved
+// from, which is nil for Tasks fetched from the cluster, and the result of the verification of the
+// Task, which is nil if the Task was not verified.
+type GetTask func(context.Context, string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error)
 
 // GetTaskRun is a function used to retrieve TaskRuns
 type GetTaskRun func(string) (*v1beta1.TaskRun, error)
@@ -37,18 +41,24 @@ type GetClusterTask func(name string) (v1beta1.TaskObject, error)
 
 // GetTaskData will retrieve the Task metadata and Spec associated with the
 // provided TaskRun. This can come from a reference Task or from the TaskRun's
-// metadata and embedded TaskSpec.
-func GetTaskData(ctx context.Context, taskRun *v1beta1.TaskRun, getTask GetTask) (*metav1.ObjectMeta, *v1beta1.TaskSpec, error) {
+// metadata and embedded TaskSpec. The source a referenced Task was resolved from
+// and the result of its verification are returned along with its metadata, which
+// is also returned when the Task fails verification.
+func GetTaskData(ctx context.Context, taskRun *v1beta1.TaskRun, getTask GetTask) (*resolutionutil.ResolvedObjectMeta, *v1beta1.TaskSpec, error) {
 	taskMeta := metav1.ObjectMeta{}
 	taskSpec := v1beta1.TaskSpec{}
+	var configSource *v1beta1.ConfigSource
+	var vr *trustedresources.VerificationResult
 	cfg := config.FromContextOrDefaults(ctx)
 	switch {
 	case taskRun.Spec.TaskRef != nil && taskRun.Spec.TaskRef.Name != "":
 		// Get related task for taskrun
-		t, err := getTask(ctx, taskRun.Spec.TaskRef.Name)
+		t, source, verificationResult, err := getTask(ctx, taskRun.Spec.TaskRef.Name)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error when listing tasks for taskRun %s: %w", taskRun.Name, err)
+			return &resolutionutil.ResolvedObjectMeta{VerificationResult: verificationResult}, nil, fmt.Errorf("error when listing tasks for taskRun %s: %w", taskRun.Name, err)
 		}
+		configSource = source
+		vr = verificationResult
 		taskMeta = t.TaskMetadata()
 		taskSpec = t.TaskSpec()
 		taskSpec.SetDefaults(ctx)
@@ -56,18 +66,24 @@ func GetTaskData(ctx context.Context, taskRun *v1beta1.TaskRun, getTask GetTask)
 		taskMeta = taskRun.ObjectMeta
 		taskSpec = *taskRun.Spec.TaskSpec
 	case cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields && taskRun.Spec.TaskRef != nil && taskRun.Spec.TaskRef.Resolver != "":
-		task, err := getTask(ctx, taskRun.Name)
+		task, source, verificationResult, err := getTask(ctx, taskRun.Name)
 		switch {
 		case err != nil:
-			return nil, nil, err
+			return &resolutionutil.ResolvedObjectMeta{VerificationResult: verificationResult}, nil, err
 		case task == nil:
 			return nil, nil, errors.New("resolution of remote resource completed successfully but no task was returned")
 		default:
 			taskMeta = task.TaskMetadata()
 			taskSpec = task.TaskSpec()
+			configSource = source
+			vr = verificationResult
 		}
 	default:
 		return nil, nil, fmt.Errorf("taskRun %s not providing TaskRef or TaskSpec", taskRun.Name)
 	}
-	return &taskMeta, &taskSpec, nil
+	return &resolutionutil.ResolvedObjectMeta{
+		ObjectMeta:         &taskMeta,
+		ConfigSource:       configSource,
+		VerificationResult: vr,
+	}, &taskSpec, nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/test/diff"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -49,7 +50,9 @@ func TestGetTaskSpec_Ref(t *testing.T) {
 			},
 		},
 	}
-	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) { return task, nil }
+	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+		return task, nil, nil, nil
+	}
 	taskMeta, taskSpec, err := GetTaskData(context.Background(), tr, gt)
 
 	if err != nil {
@@ -78,8 +81,8 @@ func TestGetTaskSpec_Embedded(t *testing.T) {
 			},
 		},
 	}
-	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
-		return nil, errors.New("shouldn't be called")
+	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+		return nil, nil, nil, errors.New("shouldn't be called")
 	}
 	taskMeta, taskSpec, err := GetTaskData(context.Background(), tr, gt)
 
@@ -102,8 +105,8 @@ func TestGetTaskSpec_Invalid(t *testing.T) {
 			Name: "mytaskrun",
 		},
 	}
-	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
-		return nil, errors.New("shouldn't be called")
+	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+		return nil, nil, nil, errors.New("shouldn't be called")
 	}
 	_, _, err := GetTaskData(context.Background(), tr, gt)
 	if err == nil {
@@ -122,8 +125,8 @@ func TestGetTaskSpec_Error(t *testing.T) {
 			},
 		},
 	}
-	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
-		return nil, errors.New("something went wrong")
+	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+		return nil, nil, nil, errors.New("something went wrong")
 	}
 	_, _, err := GetTaskData(context.Background(), tr, gt)
 	if err == nil {
@@ -161,11 +164,17 @@ func TestGetTaskData_ResolutionSuccess(t *testing.T) {
 			Script: `echo "hello world!"`,
 		}},
 	}
-	getTask := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
+	sourceConfigSource := &v1beta1.ConfigSource{
+		URI:        "git+https://github.com/tektoncd/catalog.git",
+		Digest:     map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+		EntryPoint: "task/task.yaml",
+		Resolver:   "foo",
+	}
+	getTask := func(ctx context.Context, n string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 		return &v1beta1.Task{
 			ObjectMeta: *sourceMeta.DeepCopy(),
 			Spec:       *sourceSpec.DeepCopy(),
-		}, nil
+		}, sourceConfigSource.DeepCopy(), nil, nil
 	}
 	// Enable alpha fields for remote resolution
 	ctx := context.Background()
@@ -182,6 +191,9 @@ func TestGetTaskData_ResolutionSuccess(t *testing.T) {
 	if d := cmp.Diff(sourceSpec, *resolvedSpec); d != "" {
 		t.Errorf(diff.PrintWantGot(d))
 	}
+	if d := cmp.Diff(sourceConfigSource, resolvedMeta.ConfigSource); d != "" {
+		t.Errorf("unexpected config source: %s", diff.PrintWantGot(d))
+	}
 }
 
 func TestGetPipelineData_ResolutionError(t *testing.T) {
@@ -197,8 +209,8 @@ func TestGetPipelineData_ResolutionError(t *testing.T) {
 			},
 		},
 	}
-	getTask := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
-		return nil, errors.New("something went wrong")
+	getTask := func(ctx context.Context, n string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+		return nil, nil, nil, errors.New("something went wrong")
 	}
 	// Enable alpha fields for remote resolution
 	ctx := context.Background()
@@ -224,8 +236,8 @@ func TestGetTaskData_ResolvedNilTask(t *testing.T) {
 			},
 		},
 	}
-	getTask := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
-		return nil, nil
+	getTask := func(ctx context.Context, n string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
+		return nil, nil, nil, nil
 	}
 	// Enable alpha fields for remote resolution
 	ctx := context.Background()
@@ -18,6 +18,7 @@ package taskrun
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -37,6 +38,7 @@ import (
 	resourcelisters "github.com/tektoncd/pipeline/pkg/client/resource/listers/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/internal/affinityassistant"
 	"github.com/tektoncd/pipeline/pkg/internal/computeresources"
+	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
@@ -47,6 +49,8 @@ import (
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
 	_ "github.com/tektoncd/pipeline/pkg/taskrunmetrics/fake" // Make sure the taskrunmetrics are setup
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -56,6 +60,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/kubernetes"
 	corev1Listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/changeset"
 	"knative.dev/pkg/controller"
@@ -74,8 +79,11 @@ type Reconciler struct {
 
 	// listers index properties about resources
 	taskRunLister       listers.TaskRunLister
+	taskRunIndexer      cache.Indexer
 	resourceLister      resourcelisters.PipelineResourceLister
 	limitrangeLister    corev1Listers.LimitRangeLister
+	namespaceLister     corev1Listers.NamespaceLister
+	resourceQuotaLister corev1Listers.ResourceQuotaLister
 	podLister           corev1Listers.PodLister
 	cloudEventClient    cloudevent.CEClient
 	entrypointCache     podconvert.EntrypointCache
@@ -95,6 +103,8 @@ var (
 func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx, span := tracing.StartRun(ctx, &tr.ObjectMeta, tracing.TaskRunSpanContextAnnotation, "TaskRun", "TaskRun:ReconcileKind")
+	defer span.End()
 
 	// Read the initial condition
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
@@ -139,13 +149,29 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 			return err
 		}
 
-		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
+		if err := c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil); err != nil {
+			return err
+		}
+		// A TaskRun retained for debugging is not pruned until its retention expires.
+		if err := c.reconcileDebugRetention(ctx, tr); err != nil {
+			return err
+		}
+		return c.pruneCompletedTaskRuns(ctx, tr)
 	}
 
 	// If the TaskRun is cancelled, kill resources and update status
 	if tr.IsCancelled() {
+		// Steps with a termination grace period are given the chance to terminate gracefully
+		// before their pod is deleted.
+		remaining, err := c.cancelGracefully(ctx, tr)
+		if err != nil || remaining > 0 {
+			if err := c.finishReconcileUpdateEmitEvents(ctx, tr, before, err); err != nil {
+				return err
+			}
+			return controller.NewRequeueAfter(remaining)
+		}
 		message := fmt.Sprintf("TaskRun %q was cancelled. %s", tr.Name, tr.Spec.StatusMessage)
-		err := c.failTaskRun(ctx, tr, v1beta1.TaskRunReasonCancelled, message)
+		err = c.failTaskRun(ctx, tr, v1beta1.TaskRunReasonCancelled, message)
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
 	}
 
@@ -188,8 +214,8 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	}
 
 	if tr.Status.StartTime != nil {
-		// Compute the time since the task started.
-		elapsed := c.Clock.Since(tr.Status.StartTime.Time)
+		// Compute the time since the task started, without the time it was paused at breakpoints.
+		elapsed := c.Clock.Since(tr.Status.StartTime.Time) - tr.Status.GetPausedDuration(c.Clock.Now())
 		// Snooze this resource until the timeout has elapsed.
 		return controller.NewRequeueAfter(tr.GetTimeout(ctx) - elapsed)
 	}
@@ -234,6 +260,9 @@ func (c *Reconciler) durationAndCountMetrics(ctx context.Context, tr *v1beta1.Ta
 			if err := metrics.CloudEvents(ctx, tr); err != nil {
 				logger.Warnf("Failed to log the metrics : %v", err)
 			}
+			if err := metrics.StepResourceUsage(ctx, tr, before); err != nil {
+				logger.Warnf("Failed to log the metrics : %v", err)
+			}
 		}(c.metrics)
 	}
 }
@@ -291,6 +320,15 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 	// Send k8s events and cloud events (when configured)
 	events.Emit(ctx, beforeCondition, afterCondition, tr)
 
+	if tr.IsDone() {
+		// The root span of the TaskRun, if it has one, lasts until it completes.
+		end := c.Clock.Now()
+		if tr.Status.CompletionTime != nil {
+			end = tr.Status.CompletionTime.Time
+		}
+		tracing.EndRun(ctx, &tr.ObjectMeta, tracing.TaskRunSpanContextAnnotation, "TaskRun", end)
+	}
+
 	_, err := c.updateLabelsAndAnnotations(ctx, tr)
 	if err != nil {
 		logger.Warn("Failed to update TaskRun labels/annotations", zap.Error(err))
@@ -324,11 +362,18 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 	}
 
 	taskMeta, taskSpec, err := resources.GetTaskData(ctx, tr, getTaskfunc)
+	if taskMeta != nil && taskMeta.VerificationResult != nil {
+		tr.Status.SetCondition(taskMeta.VerificationResult.Condition())
+	}
 	switch {
 	case errors.Is(err, remote.ErrorRequestInProgress):
 		message := fmt.Sprintf("TaskRun %s/%s awaiting remote resource", tr.Namespace, tr.Name)
 		tr.Status.MarkResourceOngoing(v1beta1.TaskRunReasonResolvingTaskRef, message)
 		return nil, nil, err
+	case errors.Is(err, trustedresources.ErrResourceVerificationFailed):
+		logger.Errorf("TaskRun %s/%s references a Task that failed verification: %v", tr.Namespace, tr.Name, err)
+		tr.Status.MarkResourceFailed(trustedresources.ReasonResourceVerificationFailed, err)
+		return nil, nil, controller.NewPermanentError(err)
 	case err != nil:
 		logger.Errorf("Failed to determine Task spec to use for taskrun %s: %v", tr.Name, err)
 		if resources.IsGetTaskErrTransient(err) {
@@ -343,6 +388,16 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		}
 	}
 
+	// A Task embedded in a Pipeline comes from the same source as the Pipeline, so the TaskRun
+	// carries the provenance the PipelineRun annotated it with.
+	if tr.Status.Provenance == nil && tr.Spec.TaskSpec != nil {
+		provenance, err := pipelineProvenance(tr)
+		if err != nil {
+			logger.Errorf("Failed to read the provenance of the Pipeline of taskrun %s: %v", tr.Name, err)
+		}
+		tr.Status.Provenance = provenance
+	}
+
 	inputs := []v1beta1.TaskResourceBinding{}
 	outputs := []v1beta1.TaskResourceBinding{}
 	if tr.Spec.Resources != nil {
@@ -439,7 +494,18 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	if tr.Status.PodName != "" {
 		pod, err = c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
 		if k8serrors.IsNotFound(err) {
-			// Keep going, this will result in the Pod being created below.
+			// The informer cache may not have caught up with a Pod we just created, so check
+			// with the API server before considering the Pod was deleted.
+			pod, err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Get(ctx, tr.Status.PodName, metav1.GetOptions{})
+		}
+		if k8serrors.IsNotFound(err) {
+			// The Pod was deleted before the TaskRun completed: replace it, which will result in
+			// the Pod being created below, unless the TaskRun has no infra retries left.
+			pod = nil
+			message := fmt.Sprintf("pod %q was deleted before the TaskRun completed", tr.Status.PodName)
+			if retried := c.retryInfraFailure(ctx, tr, podconvert.ReasonPodDeleted, message); !retried {
+				return nil
+			}
 		} else if err != nil {
 			// This is considered a transient error, so we return error, do not update
 			// the task run condition, and return an error which will cause this key to
@@ -466,6 +532,22 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 		}
 	}
 
+	if pod != nil {
+		if reason, message, ok := podconvert.GetInfraFailure(pod); ok {
+			// The Pod may still be running on a lost node or be about to be preempted.
+			if pod.Status.Phase != corev1.PodFailed && pod.Status.Phase != corev1.PodSucceeded {
+				if err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+					logger.Errorf("Failed to delete pod %q after an infrastructure failure: %v", pod.Name, err)
+					return err
+				}
+			}
+			if retried := c.retryInfraFailure(ctx, tr, reason, message); !retried {
+				return nil
+			}
+			pod = nil
+		}
+	}
+
 	if pod == nil {
 		if tr.HasVolumeClaimTemplate() {
 			if err := c.pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, tr.Spec.Workspaces, *kmeta.NewControllerRef(tr), tr.Namespace); err != nil {
@@ -481,11 +563,21 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 			tr.Spec.Workspaces = taskRunWorkspaces
 		}
 		pod, err = c.createPod(ctx, ts, tr, rtr)
+		var waitErr *waitingForQuotaError
+		if errors.As(err, &waitErr) {
+			logger.Infof("Not creating the pod of TaskRun %q yet: %v", tr.Name, waitErr)
+			return c.waitForQuota(ctx, tr, waitErr)
+		}
 		if err != nil {
 			newErr := c.handlePodCreationError(tr, err)
 			logger.Errorf("Failed to create task run pod for taskrun %q: %v", tr.Name, newErr)
 			return newErr
 		}
+		if len(tr.Status.RetriesStatus) == 0 && len(tr.Status.InfraRetries) == 0 {
+			if err := c.metrics.QueueWait(ctx, tr, c.Clock.Now()); err != nil {
+				logger.Warnf("Failed to log the metrics : %v", err)
+			}
+		}
 	}
 
 	if podconvert.IsPodExceedingNodeResources(pod) {
@@ -506,6 +598,8 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	if err != nil {
 		return err
 	}
+	// The timeout of the TaskRun is suspended while it is paused at a breakpoint.
+	tr.Status.SetPausedStep(podconvert.PausedStep(pod), c.Clock.Now())
 
 	if err := validateTaskRunResults(tr, rtr.TaskSpec); err != nil {
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
@@ -574,6 +668,40 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, tr *v1beta1
 	return newTr, nil
 }
 
+// retryInfraFailure records that the Pod of the TaskRun failed because of the infrastructure it
+// ran on, and clears it from the status so that a new Pod is created. The user's retries of the
+// TaskRun are not consumed. If the TaskRun has no infra retries left, it is marked as failed
+// instead and false is returned.
+func (c *Reconciler) retryInfraFailure(ctx context.Context, tr *v1beta1.TaskRun, reason, message string) bool {
+	logger := logging.FromContext(ctx)
+	recorder := controller.GetEventRecorder(ctx)
+	infraRetries := config.FromContextOrDefaults(ctx).Defaults.DefaultInfraRetries
+	podName := tr.Status.PodName
+	failure := reason
+	if message != "" {
+		failure = fmt.Sprintf("%s: %s", reason, message)
+	}
+
+	if len(tr.Status.InfraRetries) >= infraRetries {
+		msg := fmt.Sprintf("TaskRun %q failed because of the infrastructure after %d infra retries: %s", tr.Name, len(tr.Status.InfraRetries), failure)
+		if err := c.failTaskRun(ctx, tr, v1beta1.TaskRunReasonInfraFailure, msg); err != nil {
+			logger.Errorf("Failed to fail TaskRun %q after an infrastructure failure: %v", tr.Name, err)
+		}
+		return false
+	}
+
+	logger.Infof("Replacing pod %q of TaskRun %q after an infrastructure failure: %s", podName, tr.Name, failure)
+	tr.Status.InfraRetries = append(tr.Status.InfraRetries, v1beta1.InfraRetry{
+		PodName:     podName,
+		Reason:      reason,
+		Message:     message,
+		FailureTime: metav1.Time{Time: c.Clock.Now()},
+	})
+	tr.Status.PodName = ""
+	recorder.Eventf(tr, corev1.EventTypeWarning, reason, "Replacing pod %q (infra retry %d of %d): %s", podName, len(tr.Status.InfraRetries), infraRetries, failure)
+	return true
+}
+
 func (c *Reconciler) handlePodCreationError(tr *v1beta1.TaskRun, err error) error {
 	switch {
 	case isResourceQuotaConflictError(err):
@@ -584,7 +712,7 @@ func (c *Reconciler) handlePodCreationError(tr *v1beta1.TaskRun, err error) erro
 	case isExceededResourceQuotaError(err):
 		// If we are struggling to create the pod, then it hasn't started.
 		tr.Status.StartTime = nil
-		tr.Status.MarkResourceOngoing(podconvert.ReasonExceededResourceQuota, fmt.Sprint("TaskRun Pod exceeded available resources: ", err))
+		tr.Status.MarkResourceOngoing(podconvert.ReasonExceededResourceQuota, fmt.Sprint(exceededQuotaMessage, err))
 		return controller.NewRequeueAfter(time.Minute)
 	case isTaskRunValidationFailed(err):
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
@@ -619,6 +747,7 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 	completionTime := metav1.Time{Time: c.Clock.Now()}
 	// update tr completed time
 	tr.Status.CompletionTime = &completionTime
+	tr.Status.SetPausedStep("", completionTime.Time)
 
 	if tr.Status.PodName == "" {
 		logger.Warnf("task run %q has no pod running yet", tr.Name)
@@ -665,6 +794,8 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 // createPod creates a Pod based on the Task's configuration, with pvcName as a volumeMount
 // TODO(dibyom): Refactor resource setup/substitution logic to its own function in the resources package
 func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources) (*corev1.Pod, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "createPod")
+	defer span.End()
 	logger := logging.FromContext(ctx)
 	inputResources, err := resourceImplBinding(rtr.Inputs, c.Images)
 	if err != nil {
@@ -736,6 +867,10 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1
 		return nil, fmt.Errorf("translating TaskSpec to Pod: %w", err)
 	}
 
+	if err := c.admitPod(tr, pod); err != nil {
+		return nil, err
+	}
+
 	// Stash the podname in case there's create conflict so that we can try
 	// to fetch it.
 	podName := pod.Name
@@ -857,10 +992,28 @@ func applyVolumeClaimTemplates(workspaceBindings []v1beta1.WorkspaceBinding, own
 	return taskRunWorkspaceBindings
 }
 
-func storeTaskSpecAndMergeMeta(tr *v1beta1.TaskRun, ts *v1beta1.TaskSpec, meta *metav1.ObjectMeta) error {
+// pipelineProvenance returns the provenance of the Pipeline the TaskSpec of tr is embedded in, which is
+// nil if tr was not created by a PipelineRun or if its Pipeline was not resolved remotely.
+func pipelineProvenance(tr *v1beta1.TaskRun) (*v1beta1.Provenance, error) {
+	value, ok := tr.Annotations[v1beta1.PipelineProvenanceAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	provenance := &v1beta1.Provenance{}
+	if err := json.Unmarshal([]byte(value), provenance); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s annotation: %w", v1beta1.PipelineProvenanceAnnotationKey, err)
+	}
+	return provenance, nil
+}
+
+func storeTaskSpecAndMergeMeta(tr *v1beta1.TaskRun, ts *v1beta1.TaskSpec, meta *resolutionutil.ResolvedObjectMeta) error {
 	// Only store the TaskSpec once, if it has never been set before.
 	if tr.Status.TaskSpec == nil {
 		tr.Status.TaskSpec = ts
+		// Record the source the Task was resolved from.
+		if meta.ConfigSource != nil {
+			tr.Status.Provenance = &v1beta1.Provenance{ConfigSource: meta.ConfigSource}
+		}
 		// Propagate annotations from Task to TaskRun.
 		if tr.ObjectMeta.Annotations == nil {
 			tr.ObjectMeta.Annotations = make(map[string]string, len(meta.Annotations))
@@ -38,12 +38,19 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	faketaskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun/fake"
+	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/admission"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	trtesting "github.com/tektoncd/pipeline/pkg/trustedresources/testing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	eventstest "github.com/tektoncd/pipeline/test/events"
@@ -467,7 +474,7 @@ func getRunName(tr *v1beta1.TaskRun) string {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, trustedResourcesExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -484,6 +491,9 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetMetricsConfigName() {
 			metricsExists = true
 		}
+		if cm.Name == config.GetTrustedResourcesConfigName() {
+			trustedResourcesExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -515,6 +525,12 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !trustedResourcesExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getTaskRunController returns an instance of the TaskRun controller/reconciler that has been seeded with
@@ -529,6 +545,14 @@ func initializeTaskRunControllerAssets(t *testing.T, d test.Data, opts pipeline.
 	ctx, _ := ttesting.SetupFakeContext(t)
 	ctx, cancel := context.WithCancel(ctx)
 	ensureConfigurationConfigMapsExist(&d)
+	// The controller indexes the TaskRuns, which can only be done before the informer holds any object.
+	taskRunInformer := faketaskruninformer.Get(ctx).Informer()
+	if err := tknreconciler.AddIndexer(taskRunInformer, pruner.StandaloneTaskIndex, pruner.StandaloneTaskIndexFunc); err != nil {
+		t.Fatal(err)
+	}
+	if err := tknreconciler.AddIndexer(taskRunInformer, admission.PendingIndex, admission.PendingIndexFunc); err != nil {
+		t.Fatal(err)
+	}
 	c, informers := test.SeedTestData(t, ctx, d)
 	configMapWatcher := cminformer.NewInformedWatcher(c.Kube, system.Namespace())
 	ctl := NewController(&opts, testClock)(ctx, configMapWatcher)
@@ -1159,9 +1183,10 @@ spec:
 		taskRun: taskRunWithPod,
 		wantEvents: []string{
 			"Normal Started ",
+			`Warning PodDeleted Replacing pod "some-pod-abcdethat-no-longer-exists"`,
 			"Normal Running Not all Steps",
 		},
-		wantPod: expectedPod("test-taskrun-with-pod-pod", "test-task", "test-taskrun-with-pod", "foo", config.DefaultServiceAccountValue, false, nil, []stepForExpectedPod{{
+		wantPod: expectedPod("test-taskrun-with-pod-pod-infra1", "test-task", "test-taskrun-with-pod", "foo", config.DefaultServiceAccountValue, false, nil, []stepForExpectedPod{{
 			name:  "simple-step",
 			image: "foo",
 			cmd:   "/mycmd",
@@ -1461,7 +1486,7 @@ spec:
 		t.Errorf("expected no error. Got error %v", err)
 	}
 
-	client := testAssets.Clients.ResolutionRequests.ResolutionV1alpha1().ResolutionRequests("default")
+	client := testAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("default")
 	resolutionrequests, err := client.List(testAssets.Ctx, metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error listing resource requests: %v", err)
@@ -1491,6 +1516,11 @@ spec:
                 echo "hello world!"
         `)
 	resreq.Status.ResolutionRequestStatusFields.Data = base64.StdEncoding.Strict().EncodeToString(taskBytes)
+	resreq.Status.Annotations = map[string]string{
+		resolutioncommon.AnnotationKeySourceURI:        "git+https://github.com/tektoncd/catalog.git",
+		resolutioncommon.AnnotationKeySourceDigest:     "sha1:a123",
+		resolutioncommon.AnnotationKeySourceEntryPoint: "task/foo/foo.yaml",
+	}
 	resreq.Status.MarkSucceeded()
 	resreq, err = client.UpdateStatus(testAssets.Ctx, resreq, metav1.UpdateOptions{})
 	if err != nil {
@@ -1517,6 +1547,80 @@ spec:
 	if condition != nil && condition.Reason != v1beta1.TaskRunReasonRunning.String() {
 		t.Errorf("Expected reason %q but was %s", v1beta1.TaskRunReasonRunning.String(), condition.Reason)
 	}
+	wantProvenance := &v1beta1.Provenance{
+		ConfigSource: &v1beta1.ConfigSource{
+			URI:        "git+https://github.com/tektoncd/catalog.git",
+			Digest:     map[string]string{"sha1": "a123"},
+			EntryPoint: "task/foo/foo.yaml",
+			Resolver:   v1beta1.ResolverName(resolverName),
+		},
+	}
+	if d := cmp.Diff(wantProvenance, updatedTR.Status.Provenance); d != "" {
+		t.Errorf("Unexpected provenance %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestReconcileEmbeddedTaskSpecWithPipelineProvenance checks that a TaskRun with an embedded
+// TaskSpec records the provenance of the Pipeline its PipelineRun annotated it with, even though
+// the status of the PipelineRun recording it is not in the lister yet.
+func TestReconcileEmbeddedTaskSpecWithPipelineProvenance(t *testing.T) {
+	pr := parse.MustParsePipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  pipelineRef:
+    resolver: git
+`)
+	tr := parse.MustParseTaskRun(t, `
+metadata:
+  name: tr
+  namespace: foo
+  annotations:
+    tekton.dev/pipelineProvenance: '{"configSource":{"uri":"git+https://github.com/tektoncd/catalog.git","digest":{"sha1":"a123"},"entryPoint":"pipeline/foo/foo.yaml","resolver":"git"}}'
+  ownerReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: PipelineRun
+    name: pr
+    controller: true
+spec:
+  taskSpec:
+    steps:
+    - command:
+      - /mycmd
+      image: myimage
+      name: mystep
+`)
+
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		TaskRuns:     []*v1beta1.TaskRun{tr},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, tr.Spec.ServiceAccountName, tr.Namespace)
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error. Got error %v", err)
+	}
+
+	updatedTR, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	wantProvenance := &v1beta1.Provenance{
+		ConfigSource: &v1beta1.ConfigSource{
+			URI:        "git+https://github.com/tektoncd/catalog.git",
+			Digest:     map[string]string{"sha1": "a123"},
+			EntryPoint: "pipeline/foo/foo.yaml",
+			Resolver:   "git",
+		},
+	}
+	if d := cmp.Diff(wantProvenance, updatedTR.Status.Provenance); d != "" {
+		t.Errorf("Unexpected provenance %s", diff.PrintWantGot(d))
+	}
 }
 
 // TestReconcileWithFailingResolver checks that a TaskRun with a failing Resolver
@@ -1569,7 +1673,7 @@ spec:
 		t.Errorf("expected no error. Got error %v", err)
 	}
 
-	client := testAssets.Clients.ResolutionRequests.ResolutionV1alpha1().ResolutionRequests("default")
+	client := testAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("default")
 	resolutionrequests, err := client.List(testAssets.Ctx, metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error listing resource requests: %v", err)
@@ -1773,6 +1877,96 @@ spec:
 
 }
 
+func TestReconcileTaskResourceVerification(t *testing.T) {
+	signer, publicKey := trtesting.GetSignerAndPublicKey(t)
+	signedTask := simpleTask.DeepCopy()
+	signedTask.Name = "signed-task"
+	if err := trustedresources.SignTask(signedTask, signer); err != nil {
+		t.Fatalf("SignTask() = %v", err)
+	}
+	unsignedTask := simpleTask.DeepCopy()
+	unsignedTask.Name = "unsigned-task"
+
+	signedTaskRun := parse.MustParseTaskRun(t, `
+metadata:
+  name: taskrun-signed
+  namespace: foo
+spec:
+  taskRef:
+    name: signed-task
+`)
+	unsignedTaskRun := parse.MustParseTaskRun(t, `
+metadata:
+  name: taskrun-unsigned
+  namespace: foo
+spec:
+  taskRef:
+    name: unsigned-task
+`)
+
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{signedTaskRun, unsignedTaskRun},
+		Tasks:    []*v1beta1.Task{signedTask, unsignedTask},
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+		}},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"resource-verification-mode": config.EnforceResourceVerificationMode,
+			},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetTrustedResourcesConfigName()},
+			Data: map[string]string{
+				config.PublicKeysKey: publicKey,
+			},
+		}},
+	}
+
+	for _, tc := range []struct {
+		name              string
+		taskRun           *v1beta1.TaskRun
+		wantVerified      corev1.ConditionStatus
+		wantPermanentErr  bool
+		wantFailureReason string
+	}{{
+		name:         "signed task",
+		taskRun:      signedTaskRun,
+		wantVerified: corev1.ConditionTrue,
+	}, {
+		name:              "unsigned task",
+		taskRun:           unsignedTaskRun,
+		wantVerified:      corev1.ConditionFalse,
+		wantPermanentErr:  true,
+		wantFailureReason: trustedresources.ReasonResourceVerificationFailed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			reconcileErr := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(tc.taskRun))
+			if tc.wantPermanentErr != controller.IsPermanentError(reconcileErr) {
+				t.Fatalf("Expected permanent error %t when reconciling TaskRun, got %v", tc.wantPermanentErr, reconcileErr)
+			}
+
+			newTr, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tc.taskRun.Namespace).Get(testAssets.Ctx, tc.taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", tc.taskRun.Name, err)
+			}
+			verified := newTr.Status.GetCondition(trustedresources.ConditionTrustedResourcesVerified)
+			if verified == nil || verified.Status != tc.wantVerified {
+				t.Errorf("Expected %s condition with status %s, but had %v", trustedresources.ConditionTrustedResourcesVerified, tc.wantVerified, verified)
+			}
+			if tc.wantFailureReason != "" {
+				condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != tc.wantFailureReason {
+					t.Errorf("Expected TaskRun to fail with reason %q, but had %v", tc.wantFailureReason, condition)
+				}
+			}
+		})
+	}
+}
+
 func TestReconcileGetTaskError(t *testing.T) {
 	tr := parse.MustParseTaskRun(t, `
 metadata:
@@ -1911,6 +2105,158 @@ status:
 	}
 }
 
+func TestReconcileInfraFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		podStatus        corev1.PodStatus
+		infraRetries     []v1beta1.InfraRetry
+		wantPodName      string
+		wantPodDeleted   bool
+		wantInfraRetries []v1beta1.InfraRetry
+		wantReason       string
+	}{{
+		name: "evicted pod is replaced",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+		wantPodName: "test-taskrun-pod-infra1",
+		wantInfraRetries: []v1beta1.InfraRetry{{
+			PodName:     "test-taskrun-pod",
+			Reason:      "Evicted",
+			Message:     "The node was low on resource: memory.",
+			FailureTime: metav1.Time{Time: now},
+		}},
+		wantReason: v1beta1.TaskRunReasonRunning.String(),
+	}, {
+		name: "pod of a lost node is deleted and replaced",
+		podStatus: corev1.PodStatus{
+			Phase:  corev1.PodRunning,
+			Reason: "NodeLost",
+		},
+		infraRetries: []v1beta1.InfraRetry{{
+			PodName: "test-taskrun-pod-infra0",
+			Reason:  "PodDeleted",
+		}},
+		wantPodName:    "test-taskrun-pod-infra2",
+		wantPodDeleted: true,
+		wantInfraRetries: []v1beta1.InfraRetry{{
+			PodName: "test-taskrun-pod-infra0",
+			Reason:  "PodDeleted",
+		}, {
+			PodName:     "test-taskrun-pod",
+			Reason:      "NodeLost",
+			FailureTime: metav1.Time{Time: now},
+		}},
+		wantReason: v1beta1.TaskRunReasonRunning.String(),
+	}, {
+		name: "no infra retries left",
+		podStatus: corev1.PodStatus{
+			Phase:  corev1.PodFailed,
+			Reason: "OutOfpods",
+		},
+		infraRetries: []v1beta1.InfraRetry{{
+			PodName: "test-taskrun-pod-infra0",
+			Reason:  "Evicted",
+		}, {
+			PodName: "test-taskrun-pod-infra1",
+			Reason:  "Evicted",
+		}},
+		wantInfraRetries: []v1beta1.InfraRetry{{
+			PodName: "test-taskrun-pod-infra0",
+			Reason:  "Evicted",
+		}, {
+			PodName: "test-taskrun-pod-infra1",
+			Reason:  "Evicted",
+		}},
+		wantPodDeleted: true,
+		wantReason:     v1beta1.TaskRunReasonInfraFailure.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - reason: Running
+    status: Unknown
+    type: Succeeded
+  podName: test-taskrun-pod
+`)
+			taskRun.Status.StartTime = &metav1.Time{Time: now}
+			taskRun.Status.InfraRetries = tc.infraRetries
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				Pods: []*corev1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "test-taskrun-pod",
+					},
+					Status: tc.podStatus,
+				}},
+				ServiceAccounts: []*corev1.ServiceAccount{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.DefaultServiceAccountValue, Namespace: "foo"},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if ok, _ := controller.IsRequeueKey(err); err != nil && !ok {
+				t.Fatalf("Unexpected error reconciling TaskRun: %v", err)
+			}
+
+			reconciledRun, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, "test-taskrun", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			if d := cmp.Diff(tc.wantInfraRetries, reconciledRun.Status.InfraRetries); d != "" {
+				t.Errorf("Unexpected infra retries %s", diff.PrintWantGot(d))
+			}
+			if reason := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.wantReason {
+				t.Errorf("Expected reason %q but got %q", tc.wantReason, reason)
+			}
+			if len(reconciledRun.Status.RetriesStatus) != 0 {
+				t.Errorf("Expected the retries of the TaskRun not to be consumed, got %d", len(reconciledRun.Status.RetriesStatus))
+			}
+
+			var createdPods, deletedPods []string
+			for _, action := range clients.Kube.Actions() {
+				switch a := action.(type) {
+				case ktesting.CreateAction:
+					if p, ok := a.GetObject().(*corev1.Pod); ok {
+						createdPods = append(createdPods, p.Name)
+					}
+				case ktesting.DeleteAction:
+					if a.GetResource().Resource == "pods" {
+						deletedPods = append(deletedPods, a.GetName())
+					}
+				}
+			}
+			if tc.wantPodName != "" {
+				if d := cmp.Diff([]string{tc.wantPodName}, createdPods); d != "" {
+					t.Errorf("Unexpected created pods %s", diff.PrintWantGot(d))
+				}
+				if reconciledRun.Status.PodName != tc.wantPodName {
+					t.Errorf("Expected pod name %q in the status but got %q", tc.wantPodName, reconciledRun.Status.PodName)
+				}
+			} else if len(createdPods) != 0 {
+				t.Errorf("Expected no pod to be created but got %v", createdPods)
+			}
+			if tc.wantPodDeleted != (len(deletedPods) > 0) {
+				t.Errorf("Expected pod deletion to be %t but got deleted pods %v", tc.wantPodDeleted, deletedPods)
+			}
+		})
+	}
+}
+
 func makePod(taskRun *v1beta1.TaskRun, task *v1beta1.Task) (*corev1.Pod, error) {
 	// TODO(jasonhall): This avoids a circular dependency where
 	// getTaskRunController takes a test.Data which must be populated with
@@ -2528,16 +2874,17 @@ spec:
 
 	// Use the test assets to create a *Reconciler directly for focused testing.
 	r := &Reconciler{
-		KubeClientSet:     testAssets.Clients.Kube,
-		PipelineClientSet: testAssets.Clients.Pipeline,
-		Clock:             testClock,
-		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
-		resourceLister:    testAssets.Informers.PipelineResource.Lister(),
-		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
-		cloudEventClient:  testAssets.Clients.CloudEvents,
-		metrics:           nil, // Not used
-		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		KubeClientSet:       testAssets.Clients.Kube,
+		PipelineClientSet:   testAssets.Clients.Pipeline,
+		Clock:               testClock,
+		taskRunLister:       testAssets.Informers.TaskRun.Lister(),
+		resourceLister:      testAssets.Informers.PipelineResource.Lister(),
+		limitrangeLister:    testAssets.Informers.LimitRange.Lister(),
+		resourceQuotaLister: testAssets.Informers.ResourceQuota.Lister(),
+		cloudEventClient:    testAssets.Clients.CloudEvents,
+		metrics:             nil, // Not used
+		entrypointCache:     nil, // Not used
+		pvcHandler:          volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
 	}
 
 	rtr := &resources.ResolvedTaskResources{
@@ -2631,16 +2978,17 @@ spec:
 	createServiceAccount(t, testAssets, "default", taskRun.Namespace)
 
 	r := &Reconciler{
-		KubeClientSet:     testAssets.Clients.Kube,
-		PipelineClientSet: testAssets.Clients.Pipeline,
-		Clock:             testClock,
-		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
-		resourceLister:    testAssets.Informers.PipelineResource.Lister(),
-		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
-		cloudEventClient:  testAssets.Clients.CloudEvents,
-		metrics:           nil, // Not used
-		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		KubeClientSet:       testAssets.Clients.Kube,
+		PipelineClientSet:   testAssets.Clients.Pipeline,
+		Clock:               testClock,
+		taskRunLister:       testAssets.Informers.TaskRun.Lister(),
+		resourceLister:      testAssets.Informers.PipelineResource.Lister(),
+		limitrangeLister:    testAssets.Informers.LimitRange.Lister(),
+		resourceQuotaLister: testAssets.Informers.ResourceQuota.Lister(),
+		cloudEventClient:    testAssets.Clients.CloudEvents,
+		metrics:             nil, // Not used
+		entrypointCache:     nil, // Not used
+		pvcHandler:          volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
 	}
 
 	rtr := &resources.ResolvedTaskResources{
@@ -2679,16 +3027,17 @@ status:
 
 	// Use the test assets to create a *Reconciler directly for focused testing.
 	c := &Reconciler{
-		KubeClientSet:     testAssets.Clients.Kube,
-		PipelineClientSet: testAssets.Clients.Pipeline,
-		Clock:             testClock,
-		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
-		resourceLister:    testAssets.Informers.PipelineResource.Lister(),
-		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
-		cloudEventClient:  testAssets.Clients.CloudEvents,
-		metrics:           nil, // Not used
-		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		KubeClientSet:       testAssets.Clients.Kube,
+		PipelineClientSet:   testAssets.Clients.Pipeline,
+		Clock:               testClock,
+		taskRunLister:       testAssets.Informers.TaskRun.Lister(),
+		resourceLister:      testAssets.Informers.PipelineResource.Lister(),
+		limitrangeLister:    testAssets.Informers.LimitRange.Lister(),
+		resourceQuotaLister: testAssets.Informers.ResourceQuota.Lister(),
+		cloudEventClient:    testAssets.Clients.CloudEvents,
+		metrics:             nil, // Not used
+		entrypointCache:     nil, // Not used
+		pvcHandler:          volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
 	}
 
 	testcases := []struct {
@@ -4037,16 +4386,17 @@ status:
 
 			// Use the test assets to create a *Reconciler directly for focused testing.
 			c := &Reconciler{
-				KubeClientSet:     testAssets.Clients.Kube,
-				PipelineClientSet: testAssets.Clients.Pipeline,
-				Clock:             testClock,
-				taskRunLister:     testAssets.Informers.TaskRun.Lister(),
-				resourceLister:    testAssets.Informers.PipelineResource.Lister(),
-				limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
-				cloudEventClient:  testAssets.Clients.CloudEvents,
-				metrics:           nil, // Not used
-				entrypointCache:   nil, // Not used
-				pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+				KubeClientSet:       testAssets.Clients.Kube,
+				PipelineClientSet:   testAssets.Clients.Pipeline,
+				Clock:               testClock,
+				taskRunLister:       testAssets.Informers.TaskRun.Lister(),
+				resourceLister:      testAssets.Informers.PipelineResource.Lister(),
+				limitrangeLister:    testAssets.Informers.LimitRange.Lister(),
+				resourceQuotaLister: testAssets.Informers.ResourceQuota.Lister(),
+				cloudEventClient:    testAssets.Clients.CloudEvents,
+				metrics:             nil, // Not used
+				entrypointCache:     nil, // Not used
+				pvcHandler:          volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
 			}
 
 			err := c.failTaskRun(testAssets.Ctx, tc.taskRun, tc.reason, tc.message)
@@ -4086,16 +4436,22 @@ spec:
 	ts1 := v1beta1.TaskSpec{
 		Description: "bar-task",
 	}
+	configSource := &v1beta1.ConfigSource{
+		URI:      "https://abc.com.git",
+		Digest:   map[string]string{"sha1": "xyz"},
+		Resolver: "git",
+	}
 	want := tr.DeepCopy()
 	want.Status = v1beta1.TaskRunStatus{
 		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
-			TaskSpec: ts.DeepCopy(),
+			TaskSpec:   ts.DeepCopy(),
+			Provenance: &v1beta1.Provenance{ConfigSource: configSource.DeepCopy()},
 		},
 	}
 	want.ObjectMeta.Labels["tekton.dev/task"] = tr.ObjectMeta.Name
 
 	// The first time we set it, it should get copied.
-	if err := storeTaskSpecAndMergeMeta(tr, &ts, &tr.ObjectMeta); err != nil {
+	if err := storeTaskSpecAndMergeMeta(tr, &ts, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &tr.ObjectMeta, ConfigSource: configSource}); err != nil {
 		t.Errorf("storeTaskSpec() error = %v", err)
 	}
 	if d := cmp.Diff(tr, want); d != "" {
@@ -4103,7 +4459,7 @@ spec:
 	}
 
 	// The next time, it should not get overwritten
-	if err := storeTaskSpecAndMergeMeta(tr, &ts1, &metav1.ObjectMeta{}); err != nil {
+	if err := storeTaskSpecAndMergeMeta(tr, &ts1, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &metav1.ObjectMeta{}, ConfigSource: &v1beta1.ConfigSource{URI: "https://other.com.git"}}); err != nil {
 		t.Errorf("storeTaskSpec() error = %v", err)
 	}
 	if d := cmp.Diff(tr, want); d != "" {
@@ -4123,7 +4479,7 @@ func Test_storeTaskSpec_metadata(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: taskrunlabels, Annotations: taskrunannotations},
 	}
 	meta := metav1.ObjectMeta{Labels: tasklabels, Annotations: taskannotations}
-	if err := storeTaskSpecAndMergeMeta(tr, &v1beta1.TaskSpec{}, &meta); err != nil {
+	if err := storeTaskSpecAndMergeMeta(tr, &v1beta1.TaskSpec{}, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &meta}); err != nil {
 		t.Errorf("storeTaskSpecAndMergeMeta error = %v", err)
 	}
 	if d := cmp.Diff(tr.ObjectMeta.Labels, wantedlabels); d != "" {
@@ -29,9 +29,13 @@ import (
 	imgname "github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	ociremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
 	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/kmeta"
 )
 
 const (
@@ -50,19 +54,34 @@ type Resolver struct {
 	imageReference string
 	keychain       authn.Keychain
 	timeout        time.Duration
+	verification   ImageVerification
+}
+
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithImageVerification makes the Resolver check that bundle images pass verification before reading them.
+func WithImageVerification(verification ImageVerification) Option {
+	return func(o *Resolver) {
+		o.verification = verification
+	}
 }
 
 // NewResolver is a convenience function to return a new OCI resolver instance as a remote.Resolver with a short, 1m
 // timeout for resolving an individual image.
-func NewResolver(ref string, keychain authn.Keychain) remote.Resolver {
-	return &Resolver{imageReference: ref, keychain: keychain, timeout: time.Second * 60}
+func NewResolver(ref string, keychain authn.Keychain, opts ...Option) remote.Resolver {
+	o := &Resolver{imageReference: ref, keychain: keychain, timeout: time.Second * 60}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
 }
 
 // List retrieves a flat set of Tekton objects
 func (o *Resolver) List(ctx context.Context) ([]remote.ResolvedObject, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, o.timeout)
 	defer cancel()
-	img, err := o.retrieveImage(timeoutCtx)
+	img, _, err := o.retrieveImage(timeoutCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -89,33 +108,33 @@ func (o *Resolver) List(ctx context.Context) ([]remote.ResolvedObject, error) {
 }
 
 // Get retrieves a specific object with the given Kind and name
-func (o *Resolver) Get(ctx context.Context, kind, name string) (runtime.Object, error) {
+func (o *Resolver) Get(ctx context.Context, kind, name string) (runtime.Object, *v1beta1.ConfigSource, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, o.timeout)
 	defer cancel()
-	img, err := o.retrieveImage(timeoutCtx)
+	img, digest, err := o.retrieveImage(timeoutCtx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	manifest, err := img.Manifest()
 	if err != nil {
-		return nil, fmt.Errorf("could not parse image manifest: %w", err)
+		return nil, nil, fmt.Errorf("could not parse image manifest: %w", err)
 	}
 
 	if err := o.checkImageCompliance(manifest); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	layers, err := img.Layers()
 	if err != nil {
-		return nil, fmt.Errorf("could not read image layers: %w", err)
+		return nil, nil, fmt.Errorf("could not read image layers: %w", err)
 	}
 
 	layerMap := map[string]v1.Layer{}
 	for _, l := range layers {
 		digest, err := l.Digest()
 		if err != nil {
-			return nil, fmt.Errorf("failed to find digest for layer: %w", err)
+			return nil, nil, fmt.Errorf("failed to find digest for layer: %w", err)
 		}
 		layerMap[digest.String()] = l
 	}
@@ -128,21 +147,60 @@ func (o *Resolver) Get(ctx context.Context, kind, name string) (runtime.Object,
 			obj, err := readTarLayer(layerMap[l.Digest.String()])
 			if err != nil {
 				// This could still be a raw layer so try to read it as that instead.
-				return readRawLayer(layers[idx])
+				obj, err = readRawLayer(layers[idx])
+				if err != nil {
+					return nil, nil, err
+				}
 			}
-			return obj, nil
+			annotateDigest(obj, digest)
+			return obj, o.configSource(digest, name), nil
 		}
 	}
-	return nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", kind, name)
+	return nil, nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", kind, name)
 }
 
-// retrieveImage will fetch the image's contents and manifest.
-func (o *Resolver) retrieveImage(ctx context.Context) (v1.Image, error) {
+// configSource returns the source of the object with the given name read from the bundle image with digest.
+func (o *Resolver) configSource(digest v1.Hash, name string) *v1beta1.ConfigSource {
+	uri := o.imageReference
+	if imgRef, err := imgname.ParseReference(o.imageReference); err == nil {
+		uri = imgRef.Context().String()
+	}
+	return &v1beta1.ConfigSource{
+		URI:        uri,
+		Digest:     map[string]string{digest.Algorithm: digest.Hex},
+		EntryPoint: name,
+		Resolver:   trustedresources.BundleResolver,
+	}
+}
+
+// retrieveImage will fetch the image's contents and manifest, check that it passes verification and return its digest.
+func (o *Resolver) retrieveImage(ctx context.Context) (v1.Image, v1.Hash, error) {
 	imgRef, err := imgname.ParseReference(o.imageReference)
 	if err != nil {
-		return nil, fmt.Errorf("%s is an unparseable image reference: %w", o.imageReference, err)
+		return nil, v1.Hash{}, fmt.Errorf("%s is an unparseable image reference: %w", o.imageReference, err)
+	}
+	if err := o.verification.CheckReference(imgRef); err != nil {
+		return nil, v1.Hash{}, err
+	}
+	opts := []ociremote.Option{ociremote.WithAuthFromKeychain(o.keychain), ociremote.WithContext(ctx)}
+	img, err := ociremote.Image(imgRef, opts...)
+	if err != nil {
+		return nil, v1.Hash{}, err
+	}
+	digest, err := o.verification.Verify(imgRef, img, opts...)
+	if err != nil {
+		return nil, v1.Hash{}, err
+	}
+	return img, digest, nil
+}
+
+// annotateDigest records the digest of the bundle image obj was read from in its annotations.
+func annotateDigest(obj runtime.Object, digest v1.Hash) {
+	m, ok := obj.(metav1.Object)
+	if !ok {
+		return
 	}
-	return ociremote.Image(imgRef, ociremote.WithAuthFromKeychain(o.keychain), ociremote.WithContext(ctx))
+	m.SetAnnotations(kmeta.UnionMaps(m.GetAnnotations(), map[string]string{DigestAnnotation: digest.String()}))
 }
 
 // checkImageCompliance will perform common checks to ensure the Tekton Bundle is compliant to our spec.
@@ -28,9 +28,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	trtesting "github.com/tektoncd/pipeline/pkg/trustedresources/testing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -204,14 +206,134 @@ func TestOCIResolver(t *testing.T) {
 			}
 
 			for _, obj := range tc.objs {
-				actual, err := resolver.Get(context.Background(), strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind), getObjectName(obj))
+				actual, source, err := resolver.Get(context.Background(), strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind), getObjectName(obj))
 				if err != nil {
 					t.Fatalf("could not retrieve object from image: %#v", err)
 				}
 
-				if d := cmp.Diff(actual, obj); d != "" {
+				// The object is annotated with the digest of the image it was read from.
+				repo, digest, _ := strings.Cut(ref, "@")
+				expected := obj.DeepCopyObject()
+				expected.(metav1.Object).SetAnnotations(map[string]string{oci.DigestAnnotation: digest})
+				if d := cmp.Diff(actual, expected); d != "" {
 					t.Error(diff.PrintWantGot(d))
 				}
+
+				expectedSource := &v1beta1.ConfigSource{
+					URI:        repo,
+					Digest:     map[string]string{"sha256": strings.TrimPrefix(digest, "sha256:")},
+					EntryPoint: getObjectName(obj),
+					Resolver:   "bundles",
+				}
+				if d := cmp.Diff(expectedSource, source); d != "" {
+					t.Errorf("unexpected config source: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestOCIResolverImageVerification(t *testing.T) {
+	// Set up a fake registry to push images to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, publicKey := trtesting.GetSignerAndPublicKey(t)
+	otherSigner, _ := trtesting.GetSignerAndPublicKey(t)
+	keys, err := config.ParsePublicKeys(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "simple-task",
+		},
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "Task",
+		},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		byTag        bool
+		sign         func(ref string) error
+		verification oci.ImageVerification
+		wantErr      string
+	}{{
+		name:         "tag-allowed",
+		byTag:        true,
+		verification: oci.ImageVerification{},
+	}, {
+		name:         "tag-rejected",
+		byTag:        true,
+		verification: oci.ImageVerification{RequireDigest: true},
+		wantErr:      "must be referenced by digest",
+	}, {
+		name:         "digest-required",
+		verification: oci.ImageVerification{RequireDigest: true},
+	}, {
+		name:         "signed",
+		sign:         func(ref string) error { return test.SignImage(ref, signer) },
+		verification: oci.ImageVerification{PublicKeys: keys},
+	}, {
+		name:         "attested",
+		sign:         func(ref string) error { return test.AttestImage(ref, signer) },
+		verification: oci.ImageVerification{PublicKeys: keys},
+	}, {
+		name:         "signed-by-tag",
+		byTag:        true,
+		sign:         func(ref string) error { return test.SignImage(ref, signer) },
+		verification: oci.ImageVerification{PublicKeys: keys},
+	}, {
+		name:         "unsigned",
+		verification: oci.ImageVerification{PublicKeys: keys},
+		wantErr:      "failed verification",
+	}, {
+		name:         "signed-by-untrusted-key",
+		sign:         func(ref string) error { return test.SignImage(ref, otherSigner) },
+		verification: oci.ImageVerification{PublicKeys: keys},
+		wantErr:      "no signature matches a trusted public key",
+	}, {
+		name:         "attested-by-untrusted-key",
+		sign:         func(ref string) error { return test.AttestImage(ref, otherSigner) },
+		verification: oci.ImageVerification{PublicKeys: keys},
+		wantErr:      "no attestation matches a trusted public key",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := fmt.Sprintf("%s/testociverify/%s", u.Host, tc.name)
+			ref, err := test.CreateImage(repo+":latest", task)
+			if err != nil {
+				t.Fatalf("could not push image: %#v", err)
+			}
+			if tc.sign != nil {
+				if err := tc.sign(ref); err != nil {
+					t.Fatalf("could not sign image: %v", err)
+				}
+			}
+			digest := strings.SplitN(ref, "@", 2)[1]
+			if tc.byTag {
+				ref = repo + ":latest"
+			}
+
+			resolver := oci.NewResolver(ref, authn.DefaultKeychain, oci.WithImageVerification(tc.verification))
+			actual, _, err := resolver.Get(context.Background(), "task", "simple-task")
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q but got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not retrieve object from image: %v", err)
+			}
+			if got := actual.(metav1.Object).GetAnnotations()[oci.DigestAnnotation]; got != digest {
+				t.Errorf("expected %s annotation %q but got %q", oci.DigestAnnotation, digest, got)
 			}
 		})
 	}
@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	imgname "github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	ociremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+)
+
+const (
+	// DigestAnnotation is the annotation recording the digest of the bundle image a Tekton object was fetched from.
+	DigestAnnotation = "dev.tekton.image.digest"
+
+	// SignatureAnnotation is the annotation of the layers of a cosign signature image holding the base64 encoded
+	// signature of the layer's contents.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// SignatureTagSuffix is the suffix of the tag of the cosign signature image of a bundle image.
+	SignatureTagSuffix = ".sig"
+	// AttestationTagSuffix is the suffix of the tag of the cosign attestation image of a bundle image.
+	AttestationTagSuffix = ".att"
+
+	// dssePAEPrefix prefixes the pre-authentication encoding of DSSE envelopes.
+	dssePAEPrefix = "DSSEv1"
+)
+
+// ImageVerification configures the checks that bundle images must pass before Tekton objects are read from them.
+type ImageVerification struct {
+	// RequireDigest requires bundle images to be referenced by digest, so that the referenced bundle can't change.
+	RequireDigest bool
+	// PublicKeys, when not empty, requires bundle images to be signed or attested by one of these keys, using the
+	// cosign signature and attestation image formats.
+	PublicKeys []crypto.PublicKey
+}
+
+// ImageVerificationFromConfig returns the ImageVerification of the Tekton Bundles referenced with the bundle field
+// of taskRef and pipelineRef configured in cfg.
+func ImageVerificationFromConfig(cfg *config.TrustedResources) (ImageVerification, error) {
+	if cfg == nil {
+		return ImageVerification{}, nil
+	}
+	keys, err := config.ParsePublicKeys(cfg.BundleImagePublicKeys)
+	if err != nil {
+		return ImageVerification{}, fmt.Errorf("error loading trusted bundle image public keys: %w", err)
+	}
+	return ImageVerification{RequireDigest: cfg.RequireBundleDigest, PublicKeys: keys}, nil
+}
+
+// CheckReference returns an error if ref must be, but isn't, pinned by digest.
+func (v ImageVerification) CheckReference(ref imgname.Reference) error {
+	if _, ok := ref.(imgname.Digest); v.RequireDigest && !ok {
+		return fmt.Errorf("bundle %s must be referenced by digest (@sha256:...)", ref)
+	}
+	return nil
+}
+
+// Verify checks that the bundle image img fetched from ref passes the configured checks, and returns its digest.
+// The signature and attestation images of img are fetched with opts.
+func (v ImageVerification) Verify(ref imgname.Reference, img v1.Image, opts ...ociremote.Option) (v1.Hash, error) {
+	if err := v.CheckReference(ref); err != nil {
+		return v1.Hash{}, err
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("could not read digest of bundle %s: %w", ref, err)
+	}
+	if len(v.PublicKeys) == 0 {
+		return digest, nil
+	}
+
+	signatureErr := v.verifySignatures(ref.Context(), digest, opts)
+	if signatureErr == nil {
+		return digest, nil
+	}
+	attestationErr := v.verifyAttestations(ref.Context(), digest, opts)
+	if attestationErr == nil {
+		return digest, nil
+	}
+	return v1.Hash{}, fmt.Errorf("bundle %s@%s failed verification: %v; %v", ref.Context(), digest, signatureErr, attestationErr)
+}
+
+// simpleSigning is the payload of cosign signatures.
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySignatures returns nil if one of the layers of the cosign signature image of the image with digest in
+// repo is a signature of that digest by one of the trusted keys.
+func (v ImageVerification) verifySignatures(repo imgname.Repository, digest v1.Hash, opts []ociremote.Option) error {
+	payloads, err := readSignedLayers(repo, digest, SignatureTagSuffix, opts)
+	if err != nil {
+		return fmt.Errorf("error fetching signatures: %w", err)
+	}
+	for _, p := range payloads {
+		signature, err := base64.StdEncoding.DecodeString(p.annotations[SignatureAnnotation])
+		if err != nil || !v.verifyMessage(p.content, signature) {
+			continue
+		}
+		payload := simpleSigning{}
+		if err := json.Unmarshal(p.content, &payload); err != nil {
+			continue
+		}
+		if payload.Critical.Image.DockerManifestDigest == digest.String() {
+			return nil
+		}
+	}
+	return errors.New("no signature matches a trusted public key")
+}
+
+// dsseEnvelope is the envelope of cosign attestations.
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		Sig string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// inTotoStatement is the payload of cosign attestations.
+type inTotoStatement struct {
+	Subject []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// verifyAttestations returns nil if one of the layers of the cosign attestation image of the image with digest in
+// repo is an attestation about that digest signed by one of the trusted keys.
+func (v ImageVerification) verifyAttestations(repo imgname.Repository, digest v1.Hash, opts []ociremote.Option) error {
+	payloads, err := readSignedLayers(repo, digest, AttestationTagSuffix, opts)
+	if err != nil {
+		return fmt.Errorf("error fetching attestations: %w", err)
+	}
+	for _, p := range payloads {
+		envelope := dsseEnvelope{}
+		if err := json.Unmarshal(p.content, &envelope); err != nil {
+			continue
+		}
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			continue
+		}
+		pae := fmt.Sprintf("%s %d %s %d %s", dssePAEPrefix, len(envelope.PayloadType), envelope.PayloadType, len(payload), payload)
+		signed := false
+		for _, s := range envelope.Signatures {
+			signature, err := base64.StdEncoding.DecodeString(s.Sig)
+			if err == nil && v.verifyMessage([]byte(pae), signature) {
+				signed = true
+				break
+			}
+		}
+		if !signed {
+			continue
+		}
+		statement := inTotoStatement{}
+		if err := json.Unmarshal(payload, &statement); err != nil {
+			continue
+		}
+		for _, subject := range statement.Subject {
+			if subject.Digest[digest.Algorithm] == digest.Hex {
+				return nil
+			}
+		}
+	}
+	return errors.New("no attestation matches a trusted public key")
+}
+
+func (v ImageVerification) verifyMessage(message, signature []byte) bool {
+	for _, key := range v.PublicKeys {
+		if trustedresources.VerifyMessage(key, message, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// SignatureTag returns the tag of the cosign image holding the signatures, or the attestations, of the image with
+// digest in repo, depending on suffix.
+func SignatureTag(repo imgname.Repository, digest v1.Hash, suffix string) imgname.Tag {
+	return repo.Tag(strings.Replace(digest.String(), ":", "-", 1) + suffix)
+}
+
+type signedLayer struct {
+	content     []byte
+	annotations map[string]string
+}
+
+// readSignedLayers reads the contents and annotations of the layers of the cosign image with the given suffix of
+// the image with digest in repo.
+func readSignedLayers(repo imgname.Repository, digest v1.Hash, suffix string, opts []ociremote.Option) ([]signedLayer, error) {
+	img, err := ociremote.Image(SignatureTag(repo, digest, suffix), opts...)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	layers := make([]signedLayer, 0, len(manifest.Layers))
+	for _, l := range manifest.Layers {
+		layer, err := img.LayerByDigest(l.Digest)
+		if err != nil {
+			return nil, err
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, signedLayer{content: content, annotations: l.Annotations})
+	}
+	return layers, nil
+}
@@ -47,11 +47,26 @@ spec:
 
 func TestGet_Successful(t *testing.T) {
 	for _, tc := range []struct {
-		resolvedData        []byte
-		resolvedAnnotations map[string]string
+		resolvedData         []byte
+		resolvedAnnotations  map[string]string
+		expectedConfigSource *v1beta1.ConfigSource
 	}{{
-		resolvedData:        pipelineBytes,
-		resolvedAnnotations: nil,
+		resolvedData:         pipelineBytes,
+		resolvedAnnotations:  nil,
+		expectedConfigSource: &v1beta1.ConfigSource{Resolver: "git"},
+	}, {
+		resolvedData: pipelineBytes,
+		resolvedAnnotations: map[string]string{
+			resolutioncommon.AnnotationKeySourceURI:        "git+https://github.com/tektoncd/catalog.git",
+			resolutioncommon.AnnotationKeySourceDigest:     "sha1:f99d13e554ffcb696dee719fa85b695cb5b0f428",
+			resolutioncommon.AnnotationKeySourceEntryPoint: "pipeline/foo.yaml",
+		},
+		expectedConfigSource: &v1beta1.ConfigSource{
+			URI:        "git+https://github.com/tektoncd/catalog.git",
+			Digest:     map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+			EntryPoint: "pipeline/foo.yaml",
+			Resolver:   "git",
+		},
 	}} {
 		ctx := context.Background()
 		owner := &v1beta1.PipelineRun{
@@ -69,9 +84,13 @@ func TestGet_Successful(t *testing.T) {
 			ResolvedResource: resolved,
 		}
 		resolver := NewResolver(requester, owner, "git", "", "", nil)
-		if _, err := resolver.Get(ctx, "foo", "bar"); err != nil {
+		_, source, err := resolver.Get(ctx, "foo", "bar")
+		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
+		if d := cmp.Diff(tc.expectedConfigSource, source); d != "" {
+			t.Errorf("unexpected config source: %s", diff.PrintWantGot(d))
+		}
 
 	}
 }
@@ -123,7 +142,7 @@ func TestGet_Errors(t *testing.T) {
 			ResolvedResource: tc.resolvedResource,
 		}
 		resolver := NewResolver(requester, owner, "git", "", "", nil)
-		obj, err := resolver.Get(ctx, "foo", "bar")
+		obj, _, err := resolver.Get(ctx, "foo", "bar")
 		if obj != nil {
 			t.Errorf("received unexpected resolved resource")
 		}
@@ -138,12 +157,25 @@ func TestBuildRequest(t *testing.T) {
 		name            string
 		targetName      string
 		targetNamespace string
+		params          []v1beta1.Param
 	}{{
 		name: "just owner",
 	}, {
 		name:            "with target name and namespace",
 		targetName:      "some-object",
 		targetNamespace: "some-ns",
+	}, {
+		name: "with typed params",
+		params: []v1beta1.Param{{
+			Name:  "url",
+			Value: *v1beta1.NewStructuredValues("https://github.com/tektoncd/catalog.git"),
+		}, {
+			Name:  "paths",
+			Value: *v1beta1.NewStructuredValues("task/a.yaml", "task/b.yaml"),
+		}, {
+			Name:  "signer",
+			Value: *v1beta1.NewObject(map[string]string{"key": "k8s://tekton/signing-secrets"}),
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			owner := &v1beta1.PipelineRun{
@@ -153,7 +185,7 @@ func TestBuildRequest(t *testing.T) {
 				},
 			}
 
-			req, err := buildRequest("git", owner, tc.targetName, tc.targetNamespace, nil)
+			req, err := buildRequest("git", owner, tc.targetName, tc.targetNamespace, tc.params)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -164,13 +196,16 @@ func TestBuildRequest(t *testing.T) {
 			if tc.targetName != "" {
 				reqNameBase = tc.targetNamespace + "/" + tc.targetName
 			}
-			expectedReqName, err := remoteresource.GenerateDeterministicName("git", reqNameBase, nil)
+			expectedReqName, err := remoteresource.GenerateDeterministicName("git", reqNameBase, tc.params)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
 			if expectedReqName != req.Name() {
 				t.Errorf("expected request name %s, but was %s", expectedReqName, req.Name())
 			}
+			if d := cmp.Diff(tc.params, req.Params()); d != "" {
+				t.Errorf("expected matching params but got %s", diff.PrintWantGot(d))
+			}
 		})
 	}
 }
@@ -16,6 +16,7 @@ package remote
 import (
 	"context"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -28,8 +29,9 @@ type ResolvedObject struct {
 
 // Resolver defines a generic API to retrieve Tekton resources from remote locations. It allows 2 principle operations:
 //   - List:     retrieve a flat set of Tekton objects in this remote location
-//   - Get:      retrieves a specific object with the given Kind and name.
+//   - Get:      retrieves a specific object with the given Kind and name, and the source identifying where it was
+//     resolved from.
 type Resolver interface {
 	List(ctx context.Context) ([]ResolvedObject, error)
-	Get(ctx context.Context, kind, name string) (runtime.Object, error)
+	Get(ctx context.Context, kind, name string) (runtime.Object, *v1beta1.ConfigSource, error)
 }
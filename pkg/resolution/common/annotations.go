@@ -20,4 +20,20 @@ const (
 	// AnnotationKeyContentType is the annotation key passed back
 	// with a resolved resource's content type.
 	AnnotationKeyContentType = "content-type"
+
+	// AnnotationKeySourceURI is the annotation key passed back with
+	// the URI of the source a resolved resource was fetched from, such
+	// as "git+https://github.com/tektoncd/catalog.git".
+	AnnotationKeySourceURI = "source-uri"
+
+	// AnnotationKeySourceDigest is the annotation key passed back with
+	// the digest of the source a resolved resource was fetched from,
+	// in the "<algorithm>:<hex>" format, such as the commit of a git
+	// repository or the digest of an image.
+	AnnotationKeySourceDigest = "source-digest"
+
+	// AnnotationKeySourceEntryPoint is the annotation key passed back
+	// with the location of a resolved resource in its source, such as
+	// the path of a file in a git repository.
+	AnnotationKeySourceEntryPoint = "source-entrypoint"
 )
@@ -13,6 +13,8 @@ limitations under the License.
 
 package bundle
 
+import "github.com/tektoncd/pipeline/pkg/remote/oci"
+
 // BundleAnnotationKind is the image layer annotation used to indicate
 // the "kind" of resource stored in a given layer.
 const BundleAnnotationKind = "dev.tekton.image.kind"
@@ -24,3 +26,8 @@ const BundleAnnotationName = "dev.tekton.image.name"
 // BundleAnnotationAPIVersion is the image layer annotation used to
 // indicate the "apiVersion" of resource stored in a given layer.
 const BundleAnnotationAPIVersion = "dev.tekton.image.apiVersion"
+
+// BundleAnnotationDigest is the annotation recording the digest of the
+// bundle image a resource was fetched from. It is set on the resolved
+// resource, and propagated to the TaskRun or PipelineRun using it.
+const BundleAnnotationDigest = oci.DigestAnnotation
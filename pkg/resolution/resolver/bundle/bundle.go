@@ -25,6 +25,11 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/kmeta"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -39,6 +44,8 @@ type RequestOptions struct {
 	Bundle         string
 	EntryName      string
 	Kind           string
+	// Verification holds the checks the bundle image must pass.
+	Verification oci.ImageVerification
 }
 
 // ResolvedResource wraps the content of a matched entry in a bundle.
@@ -61,7 +68,11 @@ func (br *ResolvedResource) Annotations() map[string]string {
 // GetEntry accepts a keychain and options for the request and returns
 // either a successfully resolved bundle entry or an error.
 func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions) (*ResolvedResource, error) {
-	img, err := retrieveImage(ctx, keychain, opts.Bundle)
+	imgRef, err := name.ParseReference(opts.Bundle)
+	if err != nil {
+		return nil, fmt.Errorf("%s is an unparseable image reference: %w", opts.Bundle, err)
+	}
+	img, digest, err := retrieveImage(ctx, keychain, imgRef, opts.Verification)
 	if err != nil {
 		return nil, err
 	}
@@ -98,13 +109,24 @@ func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions)
 			if err != nil {
 				// This could still be a raw layer so try to read it as that instead.
 				obj, err = readRawLayer(layers[idx])
+				if err != nil {
+					return nil, err
+				}
+			}
+			obj, err = annotateDigest(obj, digest)
+			if err != nil {
+				return nil, fmt.Errorf("could not read object with kind: %s and name: %s from image: %w", opts.Kind, opts.EntryName, err)
 			}
 			return &ResolvedResource{
 				data: obj,
 				annotations: map[string]string{
-					BundleAnnotationKind:       lKind,
-					BundleAnnotationName:       lName,
-					BundleAnnotationAPIVersion: l.Annotations[BundleAnnotationAPIVersion],
+					BundleAnnotationKind:                           lKind,
+					BundleAnnotationName:                           lName,
+					BundleAnnotationAPIVersion:                     l.Annotations[BundleAnnotationAPIVersion],
+					BundleAnnotationDigest:                         digest.String(),
+					resolutioncommon.AnnotationKeySourceURI:        imgRef.Context().String(),
+					resolutioncommon.AnnotationKeySourceDigest:     digest.String(),
+					resolutioncommon.AnnotationKeySourceEntryPoint: lName,
 				},
 			}, nil
 		}
@@ -112,13 +134,33 @@ func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions)
 	return nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", opts.Kind, opts.EntryName)
 }
 
-// retrieveImage will fetch the image's contents and manifest.
-func retrieveImage(ctx context.Context, keychain authn.Keychain, ref string) (v1.Image, error) {
-	imgRef, err := name.ParseReference(ref)
+// retrieveImage will fetch the image's contents and manifest, check that
+// it passes verification and return its digest.
+func retrieveImage(ctx context.Context, keychain authn.Keychain, imgRef name.Reference, verification oci.ImageVerification) (v1.Image, v1.Hash, error) {
+	if err := verification.CheckReference(imgRef); err != nil {
+		return nil, v1.Hash{}, err
+	}
+	remoteOpts := []remote.Option{remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx)}
+	img, err := remote.Image(imgRef, remoteOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("%s is an unparseable image reference: %w", ref, err)
+		return nil, v1.Hash{}, err
+	}
+	digest, err := verification.Verify(imgRef, img, remoteOpts...)
+	if err != nil {
+		return nil, v1.Hash{}, err
+	}
+	return img, digest, nil
+}
+
+// annotateDigest records the digest of the bundle image in the
+// annotations of the object read from one of its layers.
+func annotateDigest(data []byte, digest v1.Hash) ([]byte, error) {
+	obj := unstructured.Unstructured{}
+	if err := yaml.Unmarshal(data, &obj.Object); err != nil {
+		return nil, err
 	}
-	return remote.Image(imgRef, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx))
+	obj.SetAnnotations(kmeta.UnionMaps(obj.GetAnnotations(), map[string]string{BundleAnnotationDigest: digest.String()}))
+	return yaml.Marshal(obj.Object)
 }
 
 // checkImageCompliance will perform common checks to ensure the Tekton Bundle is compliant to our spec.
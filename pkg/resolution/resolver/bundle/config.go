@@ -20,3 +20,13 @@ const ConfigServiceAccount = "default-service-account"
 // ConfigKind is the configuration field name for controlling
 // what the layer name in the bundle image is.
 const ConfigKind = "default-kind"
+
+// ConfigRequireDigest is the configuration field name for requiring
+// bundles to be referenced by digest, e.g. "@sha256:...", rather than
+// by a mutable tag.
+const ConfigRequireDigest = "require-digest"
+
+// ConfigPublicKeys is the configuration field name for the PEM encoded
+// public keys trusted to sign bundle images. When set, bundle images
+// must have a cosign signature or attestation made with one of them.
+const ConfigPublicKeys = "public-keys"
@@ -16,8 +16,11 @@ package bundle
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 )
 
@@ -58,7 +61,8 @@ func OptionsFromParams(ctx context.Context, params map[string]string) (RequestOp
 	if !ok || bundleVal == "" {
 		return opts, fmt.Errorf("parameter %q required", ParamBundle)
 	}
-	if _, err := name.ParseReference(bundleVal); err != nil {
+	bundleRef, err := name.ParseReference(bundleVal)
+	if err != nil {
 		return opts, fmt.Errorf("invalid bundle reference: %w", err)
 	}
 
@@ -79,10 +83,38 @@ func OptionsFromParams(ctx context.Context, params map[string]string) (RequestOp
 		kind = kindVal
 	}
 
+	verification, err := verificationFromConfig(conf)
+	if err != nil {
+		return opts, err
+	}
+	if err := verification.CheckReference(bundleRef); err != nil {
+		return opts, err
+	}
+
 	opts.ServiceAccount = sa
 	opts.Bundle = bundleVal
 	opts.EntryName = nameVal
 	opts.Kind = kind
+	opts.Verification = verification
 
 	return opts, nil
 }
+
+// verificationFromConfig returns the checks bundle images must pass
+// according to the resolver's configuration.
+func verificationFromConfig(conf map[string]string) (oci.ImageVerification, error) {
+	verification := oci.ImageVerification{}
+	if requireDigest, ok := conf[ConfigRequireDigest]; ok && requireDigest != "" {
+		v, err := strconv.ParseBool(requireDigest)
+		if err != nil {
+			return verification, fmt.Errorf("invalid value for %q in the bundle resolver configuration: %w", ConfigRequireDigest, err)
+		}
+		verification.RequireDigest = v
+	}
+	keys, err := config.ParsePublicKeys(conf[ConfigPublicKeys])
+	if err != nil {
+		return verification, fmt.Errorf("invalid value for %q in the bundle resolver configuration: %w", ConfigPublicKeys, err)
+	}
+	verification.PublicKeys = keys
+	return verification, nil
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
@@ -39,7 +40,7 @@ const LabelValueBundleResolverType string = "bundles"
 // admins (e.g. via ConfigMap)
 const timeoutDuration = time.Minute
 
-// Resolver implements a framework.Resolver that can fetch files from OCI bundles.
+// Resolver implements a framework.StringParamsResolver that can fetch files from OCI bundles.
 type Resolver struct {
 	kubeClientSet kubernetes.Interface
 }
@@ -97,6 +98,24 @@ func (r *Resolver) Resolve(ctx context.Context, params map[string]string) (frame
 	return GetEntry(ctx, kc, opts)
 }
 
+var _ framework.StringParamsImmutableResolution = &Resolver{}
+
+// IsImmutable returns true if the bundle param references an image by
+// digest, in which case its layers can't change.
+func (r *Resolver) IsImmutable(_ context.Context, params map[string]string) bool {
+	_, err := name.NewDigest(params[ParamBundle])
+	return err == nil
+}
+
+var _ framework.CacheKeyConfig = &Resolver{}
+
+// CacheKeyConfigFields returns the configuration fields bundle images
+// are checked against, so that cached bundles are checked again once
+// they change.
+func (r *Resolver) CacheKeyConfigFields(context.Context) []string {
+	return []string{ConfigRequireDigest, ConfigPublicKeys}
+}
+
 func (r *Resolver) isDisabled(ctx context.Context) bool {
 	cfg := config.FromContextOrDefaults(ctx)
 	if cfg.FeatureFlags.EnableTektonOCIBundles || cfg.FeatureFlags.EnableBundleResolver {
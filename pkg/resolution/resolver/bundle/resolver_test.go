@@ -18,12 +18,25 @@ package bundle
 
 import (
 	"context"
+	"crypto"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	frtesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
+	trtesting "github.com/tektoncd/pipeline/pkg/trustedresources/testing"
+	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 )
 
 func TestGetSelector(t *testing.T) {
@@ -108,6 +121,20 @@ func TestValidateParamsMissing(t *testing.T) {
 
 }
 
+func TestIsImmutable(t *testing.T) {
+	resolver := Resolver{}
+	for bundle, expected := range map[string]bool{
+		"gcr.io/tekton-releases/catalog/upstream/git-clone@sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08": true,
+		"gcr.io/tekton-releases/catalog/upstream/git-clone:0.7":                                                                     false,
+		"gcr.io/tekton-releases/catalog/upstream/git-clone":                                                                         false,
+	} {
+		params := map[string]string{ParamBundle: bundle, ParamName: "git-clone", ParamKind: "task"}
+		if immutable := resolver.IsImmutable(resolverContext(), params); immutable != expected {
+			t.Errorf("expected bundle %q to be immutable: %t but got %t", bundle, expected, immutable)
+		}
+	}
+}
+
 func TestResolveDisabled(t *testing.T) {
 	resolver := Resolver{}
 
@@ -129,6 +156,170 @@ func TestResolveDisabled(t *testing.T) {
 	}
 }
 
+func TestValidateParamsRequireDigest(t *testing.T) {
+	resolver := Resolver{}
+	digestRef := "gcr.io/tekton-releases/catalog/upstream/git-clone@sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	tagRef := "gcr.io/tekton-releases/catalog/upstream/git-clone:0.7"
+
+	for _, tc := range []struct {
+		name    string
+		conf    map[string]string
+		bundle  string
+		wantErr bool
+	}{{
+		name:   "tag allowed by default",
+		conf:   map[string]string{},
+		bundle: tagRef,
+	}, {
+		name:   "digest required",
+		conf:   map[string]string{ConfigRequireDigest: "true"},
+		bundle: digestRef,
+	}, {
+		name:    "tag rejected",
+		conf:    map[string]string{ConfigRequireDigest: "true"},
+		bundle:  tagRef,
+		wantErr: true,
+	}, {
+		name:    "invalid require-digest",
+		conf:    map[string]string{ConfigRequireDigest: "maybe"},
+		bundle:  digestRef,
+		wantErr: true,
+	}, {
+		name:    "invalid public-keys",
+		conf:    map[string]string{ConfigPublicKeys: "not-a-public-key"},
+		bundle:  digestRef,
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(resolverContext(), tc.conf)
+			params := map[string]string{
+				ParamKind:           "task",
+				ParamName:           "git-clone",
+				ParamBundle:         tc.bundle,
+				ParamServiceAccount: "default",
+			}
+			err := resolver.ValidateParams(ctx, params)
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected error validating params")
+			} else if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error validating params: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetEntry(t *testing.T) {
+	// Set up a fake registry to push images to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, publicKey := trtesting.GetSignerAndPublicKey(t)
+	otherSigner, _ := trtesting.GetSignerAndPublicKey(t)
+
+	task := &v1beta1.Task{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "Task",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example-task",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Name: "echo", Image: "ubuntu"}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		conf    map[string]string
+		signer  crypto.Signer
+		byTag   bool
+		wantErr string
+	}{{
+		name:  "by tag",
+		conf:  map[string]string{},
+		byTag: true,
+	}, {
+		name:    "tag rejected",
+		conf:    map[string]string{ConfigRequireDigest: "true"},
+		byTag:   true,
+		wantErr: "must be referenced by digest",
+	}, {
+		name:   "signed",
+		conf:   map[string]string{ConfigRequireDigest: "true", ConfigPublicKeys: publicKey},
+		signer: signer,
+	}, {
+		name:    "unsigned",
+		conf:    map[string]string{ConfigPublicKeys: publicKey},
+		wantErr: "failed verification",
+	}, {
+		name:    "signed by untrusted key",
+		conf:    map[string]string{ConfigPublicKeys: publicKey},
+		signer:  otherSigner,
+		wantErr: "no signature matches a trusted public key",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := fmt.Sprintf("%s/testbundleresolver/%s", u.Host, strings.ReplaceAll(tc.name, " ", "-"))
+			ref, err := test.CreateImage(repo+":latest", task)
+			if err != nil {
+				t.Fatalf("could not push image: %v", err)
+			}
+			if tc.signer != nil {
+				if err := test.SignImage(ref, tc.signer); err != nil {
+					t.Fatalf("could not sign image: %v", err)
+				}
+			}
+			digest := strings.SplitN(ref, "@", 2)[1]
+			if tc.byTag {
+				ref = repo + ":latest"
+			}
+
+			ctx := framework.InjectResolverConfigToContext(resolverContext(), tc.conf)
+			opts := RequestOptions{Bundle: ref, EntryName: task.Name, Kind: "task"}
+			opts.Verification, err = verificationFromConfig(tc.conf)
+			if err != nil {
+				t.Fatalf("unexpected error reading config: %v", err)
+			}
+			resolved, err := GetEntry(ctx, authn.DefaultKeychain, opts)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q but got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error getting entry: %v", err)
+			}
+
+			wantAnnotations := map[string]string{
+				BundleAnnotationDigest:                         digest,
+				resolutioncommon.AnnotationKeySourceURI:        repo,
+				resolutioncommon.AnnotationKeySourceDigest:     digest,
+				resolutioncommon.AnnotationKeySourceEntryPoint: task.Name,
+			}
+			for k, want := range wantAnnotations {
+				if got := resolved.Annotations()[k]; got != want {
+					t.Errorf("expected %s annotation %q but got %q", k, want, got)
+				}
+			}
+			want := task.DeepCopy()
+			want.Annotations[BundleAnnotationDigest] = digest
+			got := &v1beta1.Task{}
+			if err := yaml.Unmarshal(resolved.Data(), got); err != nil {
+				t.Fatalf("could not parse resolved data: %v", err)
+			}
+			if d := cmp.Diff(want, got); d != "" {
+				t.Errorf("unexpected resolved task: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func resolverContext() context.Context {
 	return frtesting.ContextWithBundlesResolverEnabled(context.Background())
 }
@@ -0,0 +1,30 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+const (
+	// AnnotationKeyNamespace is the namespace the resource was fetched
+	// from
+	AnnotationKeyNamespace = "cluster.resolution.tekton.dev/namespace"
+	// AnnotationKeyName is the name of the fetched resource
+	AnnotationKeyName = "cluster.resolution.tekton.dev/name"
+	// AnnotationKeyUID is the uid of the fetched resource
+	AnnotationKeyUID = "cluster.resolution.tekton.dev/uid"
+	// AnnotationKeyResourceVersion is the resourceVersion of the fetched
+	// resource
+	AnnotationKeyResourceVersion = "cluster.resolution.tekton.dev/resource-version"
+)
@@ -0,0 +1,36 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+// ConfigDefaultKind is the configuration field name for the kind of
+// the resources fetched when the kind param isn't set.
+const ConfigDefaultKind = "default-kind"
+
+// ConfigDefaultNamespace is the configuration field name for the
+// namespace the resources are fetched from when the namespace param
+// isn't set.
+const ConfigDefaultNamespace = "default-namespace"
+
+// ConfigAllowedNamespaces is the configuration field name for the comma
+// separated list of namespaces the resources may be fetched from. All
+// namespaces are allowed when it isn't set.
+const ConfigAllowedNamespaces = "allowed-namespaces"
+
+// ConfigBlockedNamespaces is the configuration field name for the comma
+// separated list of namespaces the resources may not be fetched from.
+// It takes precedence over allowed-namespaces.
+const ConfigBlockedNamespaces = "blocked-namespaces"
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+// KindParam is the kind of the resource to fetch, task or pipeline
+const KindParam string = "kind"
+
+// NameParam is the name of the resource to fetch
+const NameParam string = "name"
+
+// NamespaceParam is the namespace of the resource to fetch
+const NamespaceParam string = "namespace"
+
+// ResourceOptions are the options parsed from the params of a resolution
+// request to fetch a resource from the cluster.
+type ResourceOptions struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// OptionsFromParams parses the params from a resolution request and
+// converts them into the options of the resource to fetch from the
+// cluster.
+func OptionsFromParams(ctx context.Context, params map[string]string) (ResourceOptions, error) {
+	opts := ResourceOptions{}
+	conf := framework.GetResolverConfigFromContext(ctx)
+
+	opts.Kind = params[KindParam]
+	if opts.Kind == "" {
+		opts.Kind = conf[ConfigDefaultKind]
+	}
+	if opts.Kind == "" {
+		return opts, fmt.Errorf("parameter %q required", KindParam)
+	}
+	if opts.Kind != "task" && opts.Kind != "pipeline" {
+		return opts, fmt.Errorf("kind param must be task or pipeline but was %q", opts.Kind)
+	}
+
+	opts.Name = params[NameParam]
+	if opts.Name == "" {
+		return opts, fmt.Errorf("parameter %q required", NameParam)
+	}
+
+	opts.Namespace = params[NamespaceParam]
+	if opts.Namespace == "" {
+		opts.Namespace = conf[ConfigDefaultNamespace]
+	}
+	if opts.Namespace == "" {
+		return opts, fmt.Errorf("parameter %q required", NamespaceParam)
+	}
+	if contains(opts.Namespace, namespaceList(conf[ConfigBlockedNamespaces])) {
+		return opts, fmt.Errorf("access to namespace %q is blocked", opts.Namespace)
+	}
+	if allowed := namespaceList(conf[ConfigAllowedNamespaces]); len(allowed) > 0 && !contains(opts.Namespace, allowed) {
+		return opts, fmt.Errorf("access to namespace %q is not allowed", opts.Namespace)
+	}
+
+	return opts, nil
+}
+
+// namespaceList parses a comma separated list of namespaces.
+func namespaceList(value string) []string {
+	var namespaces []string
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// contains returns whether the namespace is in the list.
+func contains(namespace string, list []string) bool {
+	for _, ns := range list {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
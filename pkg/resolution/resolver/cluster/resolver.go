@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	pipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline"
+	taskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/task"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+	"sigs.k8s.io/yaml"
+)
+
+const disabledError = "cannot handle resolution request, enable-cluster-resolver feature flag not true"
+
+// LabelValueClusterResolverType is the value to use for the
+// resolution.tekton.dev/type label on resource requests
+const LabelValueClusterResolverType string = "cluster"
+
+// ClusterResolverName is the name that the cluster resolver should be
+// associated with
+const ClusterResolverName string = "Cluster"
+
+// YAMLContentType is the content type to use when returning yaml
+const YAMLContentType string = "application/x-yaml"
+
+var _ framework.StringParamsResolver = &Resolver{}
+
+// Resolver implements a framework.StringParamsResolver that can fetch Tasks and
+// Pipelines from any namespace of the cluster.
+type Resolver struct {
+	taskLister     listers.TaskLister
+	pipelineLister listers.PipelineLister
+}
+
+// Initialize sets up the listers the resources are fetched with.
+func (r *Resolver) Initialize(ctx context.Context) error {
+	r.taskLister = taskinformer.Get(ctx).Lister()
+	r.pipelineLister = pipelineinformer.Get(ctx).Lister()
+	return nil
+}
+
+// GetName returns the string name that the cluster resolver should be
+// associated with.
+func (r *Resolver) GetName(_ context.Context) string {
+	return ClusterResolverName
+}
+
+// GetSelector returns the labels that resource requests are required to have for
+// the cluster resolver to process them.
+func (r *Resolver) GetSelector(_ context.Context) map[string]string {
+	return map[string]string{
+		resolutioncommon.LabelKeyResolverType: LabelValueClusterResolverType,
+	}
+}
+
+// ValidateParams returns an error if the given parameter map is not
+// valid for a resource request targeting the cluster resolver.
+func (r *Resolver) ValidateParams(ctx context.Context, params map[string]string) error {
+	if r.isDisabled(ctx) {
+		return errors.New(disabledError)
+	}
+	if _, err := OptionsFromParams(ctx, params); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Resolve performs the work of fetching a Task or Pipeline from the
+// cluster given a map of parameters.
+func (r *Resolver) Resolve(ctx context.Context, params map[string]string) (framework.ResolvedResource, error) {
+	if r.isDisabled(ctx) {
+		return nil, errors.New(disabledError)
+	}
+	opts, err := OptionsFromParams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj interface{}
+	var source *metav1.ObjectMeta
+	switch opts.Kind {
+	case "task":
+		task, err := r.taskLister.Tasks(opts.Namespace).Get(opts.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error getting task %q in namespace %q: %w", opts.Name, opts.Namespace, err)
+		}
+		source = &task.ObjectMeta
+		obj = &v1beta1.Task{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1beta1.SchemeGroupVersion.String(),
+				Kind:       "Task",
+			},
+			ObjectMeta: resolvedObjectMeta(source),
+			Spec:       task.Spec,
+		}
+	case "pipeline":
+		pipeline, err := r.pipelineLister.Pipelines(opts.Namespace).Get(opts.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error getting pipeline %q in namespace %q: %w", opts.Name, opts.Namespace, err)
+		}
+		source = &pipeline.ObjectMeta
+		obj = &v1beta1.Pipeline{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1beta1.SchemeGroupVersion.String(),
+				Kind:       "Pipeline",
+			},
+			ObjectMeta: resolvedObjectMeta(source),
+			Spec:       pipeline.Spec,
+		}
+	}
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling %s %q in namespace %q: %w", opts.Kind, opts.Name, opts.Namespace, err)
+	}
+	return &ResolvedClusterResource{
+		Content:         data,
+		Kind:            opts.Kind,
+		Namespace:       source.Namespace,
+		Name:            source.Name,
+		UID:             string(source.UID),
+		ResourceVersion: source.ResourceVersion,
+	}, nil
+}
+
+// resolvedObjectMeta returns the metadata of a resolved resource: the
+// name, namespace, labels and annotations of the resource in the
+// cluster, along with the annotations identifying the exact version
+// that was fetched, which the runs using it inherit.
+func resolvedObjectMeta(source *metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      source.Name,
+		Namespace: source.Namespace,
+		Labels:    kmeta.CopyMap(source.Labels),
+		Annotations: kmeta.UnionMaps(source.Annotations, map[string]string{
+			AnnotationKeyNamespace:       source.Namespace,
+			AnnotationKeyName:            source.Name,
+			AnnotationKeyUID:             string(source.UID),
+			AnnotationKeyResourceVersion: source.ResourceVersion,
+		}),
+	}
+}
+
+var _ framework.ConfigWatcher = &Resolver{}
+
+// GetConfigName returns the name of the cluster resolver's configmap.
+func (r *Resolver) GetConfigName(context.Context) string {
+	return "cluster-resolver-config"
+}
+
+func (r *Resolver) isDisabled(ctx context.Context) bool {
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.FeatureFlags.EnableClusterResolver {
+		return false
+	}
+
+	return true
+}
+
+// ResolvedClusterResource implements framework.ResolvedResource and
+// returns the resolved Task or Pipeline and an annotation map
+// identifying it.
+type ResolvedClusterResource struct {
+	Content         []byte
+	Kind            string
+	Namespace       string
+	Name            string
+	UID             string
+	ResourceVersion string
+}
+
+var _ framework.ResolvedResource = &ResolvedClusterResource{}
+
+// Data returns the bytes of the resource fetched from the cluster.
+func (r *ResolvedClusterResource) Data() []byte {
+	return r.Content
+}
+
+// Annotations returns the metadata that accompanies the resource
+// fetched from the cluster.
+func (r *ResolvedClusterResource) Annotations() map[string]string {
+	sum := sha256.Sum256(r.Content)
+	return map[string]string{
+		AnnotationKeyNamespace:                     r.Namespace,
+		AnnotationKeyName:                          r.Name,
+		AnnotationKeyUID:                           r.UID,
+		AnnotationKeyResourceVersion:               r.ResourceVersion,
+		resolutioncommon.AnnotationKeyContentType:  YAMLContentType,
+		resolutioncommon.AnnotationKeySourceURI:    r.sourceURI(),
+		resolutioncommon.AnnotationKeySourceDigest: "sha256:" + hex.EncodeToString(sum[:]),
+	}
+}
+
+// sourceURI returns the path of the resource fetched from the cluster
+// in the Kubernetes API, suffixed with its uid to identify it even if
+// it is deleted and recreated with the same name.
+func (r *ResolvedClusterResource) sourceURI() string {
+	return fmt.Sprintf("/apis/%s/namespaces/%s/%ss/%s@%s", v1beta1.SchemeGroupVersion.String(), r.Namespace, r.Kind, r.Name, r.UID)
+}
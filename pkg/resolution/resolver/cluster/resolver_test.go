@@ -0,0 +1,295 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	fakepipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline/fake"
+	faketaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/task/fake"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	frtesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetSelector(t *testing.T) {
+	resolver := Resolver{}
+	sel := resolver.GetSelector(resolverContext())
+	if typ, has := sel[resolutioncommon.LabelKeyResolverType]; !has {
+		t.Fatalf("unexpected selector: %v", sel)
+	} else if typ != LabelValueClusterResolverType {
+		t.Fatalf("unexpected type: %q", typ)
+	}
+}
+
+func TestValidateParams(t *testing.T) {
+	resolver := Resolver{}
+
+	params := map[string]string{
+		KindParam:      "task",
+		NameParam:      "foo",
+		NamespaceParam: "ci-catalog",
+	}
+	if err := resolver.ValidateParams(resolverContext(), params); err != nil {
+		t.Fatalf("unexpected error validating params: %v", err)
+	}
+
+	conf := map[string]string{
+		ConfigDefaultKind:      "pipeline",
+		ConfigDefaultNamespace: "ci-catalog",
+	}
+	ctx := framework.InjectResolverConfigToContext(resolverContext(), conf)
+	if err := resolver.ValidateParams(ctx, map[string]string{NameParam: "foo"}); err != nil {
+		t.Fatalf("unexpected error validating params with defaults: %v", err)
+	}
+}
+
+func TestValidateParamsNotEnabled(t *testing.T) {
+	resolver := Resolver{}
+
+	params := map[string]string{
+		KindParam:      "task",
+		NameParam:      "foo",
+		NamespaceParam: "ci-catalog",
+	}
+	err := resolver.ValidateParams(context.Background(), params)
+	if err == nil {
+		t.Fatalf("expected disabled err")
+	}
+	if d := cmp.Diff(disabledError, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateParamsInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		params      map[string]string
+		conf        map[string]string
+		expectedErr string
+	}{{
+		name:        "missing kind",
+		params:      map[string]string{NameParam: "foo", NamespaceParam: "ci-catalog"},
+		expectedErr: `parameter "kind" required`,
+	}, {
+		name:        "invalid kind",
+		params:      map[string]string{KindParam: "clustertask", NameParam: "foo", NamespaceParam: "ci-catalog"},
+		expectedErr: `kind param must be task or pipeline but was "clustertask"`,
+	}, {
+		name:        "missing name",
+		params:      map[string]string{KindParam: "task", NamespaceParam: "ci-catalog"},
+		expectedErr: `parameter "name" required`,
+	}, {
+		name:        "missing namespace",
+		params:      map[string]string{KindParam: "task", NameParam: "foo"},
+		expectedErr: `parameter "namespace" required`,
+	}, {
+		name:        "blocked namespace",
+		params:      map[string]string{KindParam: "task", NameParam: "foo", NamespaceParam: "kube-system"},
+		conf:        map[string]string{ConfigBlockedNamespaces: "tekton-pipelines, kube-system"},
+		expectedErr: `access to namespace "kube-system" is blocked`,
+	}, {
+		name:        "blocked namespace that is allowed too",
+		params:      map[string]string{KindParam: "task", NameParam: "foo", NamespaceParam: "ci-catalog"},
+		conf:        map[string]string{ConfigAllowedNamespaces: "ci-catalog", ConfigBlockedNamespaces: "ci-catalog"},
+		expectedErr: `access to namespace "ci-catalog" is blocked`,
+	}, {
+		name:        "namespace not allowed",
+		params:      map[string]string{KindParam: "task", NameParam: "foo", NamespaceParam: "team-a"},
+		conf:        map[string]string{ConfigAllowedNamespaces: "ci-catalog,shared"},
+		expectedErr: `access to namespace "team-a" is not allowed`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := Resolver{}
+			ctx := framework.InjectResolverConfigToContext(resolverContext(), tc.conf)
+			err := resolver.ValidateParams(ctx, tc.params)
+			if err == nil {
+				t.Fatalf("expected err %q but got none", tc.expectedErr)
+			}
+			if d := cmp.Diff(tc.expectedErr, err.Error()); d != "" {
+				t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolveNotEnabled(t *testing.T) {
+	resolver := Resolver{}
+
+	params := map[string]string{
+		KindParam:      "task",
+		NameParam:      "foo",
+		NamespaceParam: "ci-catalog",
+	}
+	_, err := resolver.Resolve(context.Background(), params)
+	if err == nil {
+		t.Fatalf("expected disabled err")
+	}
+	if d := cmp.Diff(disabledError, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolve(t *testing.T) {
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "build",
+			Namespace:       "ci-catalog",
+			UID:             "task-uid",
+			ResourceVersion: "42",
+			Labels:          map[string]string{"app.kubernetes.io/version": "0.1"},
+			Annotations:     map[string]string{"tekton.dev/displayName": "Build"},
+			ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Name: "build", Image: "golang"}},
+		},
+	}
+	pipeline := &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "release",
+			Namespace:       "ci-catalog",
+			UID:             "pipeline-uid",
+			ResourceVersion: "43",
+		},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "build"}}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name                string
+		params              map[string]string
+		expectedObject      runtime.Object
+		expectedAnnotations map[string]string
+		expectedErr         string
+	}{{
+		name:   "task",
+		params: map[string]string{KindParam: "task", NameParam: "build", NamespaceParam: "ci-catalog"},
+		expectedObject: &v1beta1.Task{
+			TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "build",
+				Namespace: "ci-catalog",
+				Labels:    map[string]string{"app.kubernetes.io/version": "0.1"},
+				Annotations: map[string]string{
+					"tekton.dev/displayName":     "Build",
+					AnnotationKeyNamespace:       "ci-catalog",
+					AnnotationKeyName:            "build",
+					AnnotationKeyUID:             "task-uid",
+					AnnotationKeyResourceVersion: "42",
+				},
+			},
+			Spec: task.Spec,
+		},
+		expectedAnnotations: map[string]string{
+			AnnotationKeyNamespace:                    "ci-catalog",
+			AnnotationKeyName:                         "build",
+			AnnotationKeyUID:                          "task-uid",
+			AnnotationKeyResourceVersion:              "42",
+			resolutioncommon.AnnotationKeyContentType: YAMLContentType,
+			resolutioncommon.AnnotationKeySourceURI:   "/apis/tekton.dev/v1beta1/namespaces/ci-catalog/tasks/build@task-uid",
+		},
+	}, {
+		name:   "pipeline",
+		params: map[string]string{KindParam: "pipeline", NameParam: "release", NamespaceParam: "ci-catalog"},
+		expectedObject: &v1beta1.Pipeline{
+			TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Pipeline"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "release",
+				Namespace: "ci-catalog",
+				Annotations: map[string]string{
+					AnnotationKeyNamespace:       "ci-catalog",
+					AnnotationKeyName:            "release",
+					AnnotationKeyUID:             "pipeline-uid",
+					AnnotationKeyResourceVersion: "43",
+				},
+			},
+			Spec: pipeline.Spec,
+		},
+		expectedAnnotations: map[string]string{
+			AnnotationKeyNamespace:                    "ci-catalog",
+			AnnotationKeyName:                         "release",
+			AnnotationKeyUID:                          "pipeline-uid",
+			AnnotationKeyResourceVersion:              "43",
+			resolutioncommon.AnnotationKeyContentType: YAMLContentType,
+			resolutioncommon.AnnotationKeySourceURI:   "/apis/tekton.dev/v1beta1/namespaces/ci-catalog/pipelines/release@pipeline-uid",
+		},
+	}, {
+		name:        "task not found",
+		params:      map[string]string{KindParam: "task", NameParam: "release", NamespaceParam: "ci-catalog"},
+		expectedErr: `error getting task "release" in namespace "ci-catalog": task.tekton.dev "release" not found`,
+	}, {
+		name:        "pipeline in another namespace",
+		params:      map[string]string{KindParam: "pipeline", NameParam: "release", NamespaceParam: "team-a"},
+		expectedErr: `error getting pipeline "release" in namespace "team-a": pipeline.tekton.dev "release" not found`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := ttesting.SetupFakeContext(t)
+			if err := faketaskinformer.Get(ctx).Informer().GetIndexer().Add(task); err != nil {
+				t.Fatal(err)
+			}
+			if err := fakepipelineinformer.Get(ctx).Informer().GetIndexer().Add(pipeline); err != nil {
+				t.Fatal(err)
+			}
+			resolver := &Resolver{}
+			if err := resolver.Initialize(ctx); err != nil {
+				t.Fatalf("unexpected error initializing the resolver: %v", err)
+			}
+
+			output, err := resolver.Resolve(frtesting.ContextWithClusterResolverEnabled(ctx), tc.params)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatalf("expected err %q but got none", tc.expectedErr)
+				}
+				if d := cmp.Diff(tc.expectedErr, err.Error()); d != "" {
+					t.Fatalf("unexpected error: %s", diff.PrintWantGot(d))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(output.Data(), nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error decoding the resolved resource: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedObject, obj); d != "" {
+				t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+			}
+			sum := sha256.Sum256(output.Data())
+			tc.expectedAnnotations[resolutioncommon.AnnotationKeySourceDigest] = "sha256:" + hex.EncodeToString(sum[:])
+			if d := cmp.Diff(tc.expectedAnnotations, output.Annotations()); d != "" {
+				t.Errorf("unexpected annotations from Resolve: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func resolverContext() context.Context {
+	return frtesting.ContextWithClusterResolverEnabled(context.Background())
+}
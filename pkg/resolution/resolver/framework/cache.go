@@ -0,0 +1,331 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// CacheParam is the parameter of a resolution request overriding
+	// whether the resolved resource is cached. It is handled by the
+	// framework and is not passed on to the resolver.
+	CacheParam = "cache"
+
+	// CacheModeAuto caches the resources resolved from immutable
+	// references, e.g. commit SHAs or image digests. It is the default.
+	CacheModeAuto = "auto"
+	// CacheModeAlways caches the resolved resources, those resolved
+	// from mutable references for cache-ttl only.
+	CacheModeAlways = "always"
+	// CacheModeNever neither reads nor writes the cache.
+	CacheModeNever = "never"
+
+	// ConfigCacheTTL is the configuration field name for how long the
+	// resources resolved from mutable references are cached with the
+	// "always" cache mode. Those resolved from immutable references are
+	// cached until they are evicted.
+	ConfigCacheTTL = "cache-ttl"
+	// ConfigCacheMaxEntries is the configuration field name for the
+	// maximum number of resources cached by a resolver, after which the
+	// least recently used ones are evicted. The cache is disabled when
+	// it is 0.
+	ConfigCacheMaxEntries = "cache-max-entries"
+
+	defaultCacheTTL        = 5 * time.Minute
+	defaultCacheMaxEntries = 1000
+)
+
+var (
+	resolverTag = tag.MustNewKey("resolver")
+
+	cacheHitCount = stats.Int64("resolution_cache_hit_count",
+		"number of resolution requests served from the cache of the resolver",
+		stats.UnitDimensionless)
+	cacheMissCount = stats.Int64("resolution_cache_miss_count",
+		"number of cacheable resolution requests not found in the cache of the resolver",
+		stats.UnitDimensionless)
+
+	registerCacheViewsOnce sync.Once
+)
+
+// registerCacheViews registers the views of the cache metrics, once for
+// all the resolvers.
+func registerCacheViews(ctx context.Context) {
+	registerCacheViewsOnce.Do(func() {
+		if err := view.Register(&view.View{
+			Description: cacheHitCount.Description(),
+			Measure:     cacheHitCount,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{resolverTag},
+		}, &view.View{
+			Description: cacheMissCount.Description(),
+			Measure:     cacheMissCount,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{resolverTag},
+		}); err != nil {
+			logging.FromContext(ctx).Errorf("Failed to register the resolution cache views: %v", err)
+		}
+	})
+}
+
+// ImmutableResolution is an optional interface that a resolver can
+// implement to have the resources it resolves from immutable references
+// cached indefinitely by the framework in the default "auto" cache mode.
+type ImmutableResolution interface {
+	// IsImmutable returns true if the given parameters reference a
+	// resource that can't change, such as a file at a commit SHA or an
+	// image by digest.
+	IsImmutable(context.Context, []pipelinev1beta1.Param) bool
+}
+
+// CacheKeyConfig is an optional interface that a resolver can implement
+// to have fields of its configuration hashed into the cache keys, such
+// as the checks the resolved resources must pass. The resources cached
+// with other values of those fields are then resolved, and checked,
+// again rather than served from the cache.
+type CacheKeyConfig interface {
+	// CacheKeyConfigFields returns the names of the configuration
+	// fields the resolved resources depend on.
+	CacheKeyConfigFields(context.Context) []string
+}
+
+// cachedResource is a copy of a resolved resource kept in the cache.
+type cachedResource struct {
+	data        []byte
+	annotations map[string]string
+	// expires is when the resource must be resolved again, or zero if
+	// it never expires.
+	expires time.Time
+}
+
+var _ ResolvedResource = &cachedResource{}
+
+// Data returns the bytes of the cached resource.
+func (c *cachedResource) Data() []byte {
+	return c.data
+}
+
+// Annotations returns the annotations of the cached resource.
+func (c *cachedResource) Annotations() map[string]string {
+	return c.annotations
+}
+
+// resolverCache is the cache of the resources resolved by a resolver.
+type resolverCache struct {
+	mu      sync.Mutex
+	entries *lru.Cache
+}
+
+func newResolverCache() *resolverCache {
+	entries, _ := lru.New(defaultCacheMaxEntries)
+	return &resolverCache{entries: entries}
+}
+
+// get returns the resource cached under the given key, unless it has
+// expired.
+func (c *resolverCache) get(key string, now time.Time) (ResolvedResource, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	cached := value.(*cachedResource)
+	if !cached.expires.IsZero() && !now.Before(cached.expires) {
+		c.entries.Remove(key)
+		return nil, false
+	}
+	return cached, true
+}
+
+// add caches a copy of the given resource under the given key until
+// expires, or until it is evicted if expires is zero, resizing the
+// cache to maxEntries first.
+func (c *resolverCache) add(key string, resource ResolvedResource, expires time.Time, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Resize(maxEntries)
+	var annotations map[string]string
+	if resource.Annotations() != nil {
+		annotations = make(map[string]string, len(resource.Annotations()))
+		for k, v := range resource.Annotations() {
+			annotations[k] = v
+		}
+	}
+	c.entries.Add(key, &cachedResource{
+		data:        append([]byte(nil), resource.Data()...),
+		annotations: annotations,
+		expires:     expires,
+	})
+}
+
+// cacheModeFromParams returns the cache mode requested by the given
+// parameters, and the parameters without it.
+func cacheModeFromParams(params []pipelinev1beta1.Param) (string, []pipelinev1beta1.Param, error) {
+	mode := CacheModeAuto
+	resolverParams := make([]pipelinev1beta1.Param, 0, len(params))
+	for _, p := range params {
+		if p.Name != CacheParam {
+			resolverParams = append(resolverParams, p)
+			continue
+		}
+		if p.Value.Type == pipelinev1beta1.ParamTypeArray || p.Value.Type == pipelinev1beta1.ParamTypeObject {
+			return "", nil, fmt.Errorf("invalid %s param: must be a string, not %s", CacheParam, p.Value.Type)
+		}
+		mode = p.Value.StringVal
+		switch mode {
+		case CacheModeAuto, CacheModeAlways, CacheModeNever:
+		default:
+			return "", nil, fmt.Errorf("invalid value for %s param %q: must be %s, %s or %s", CacheParam, mode, CacheModeAuto, CacheModeAlways, CacheModeNever)
+		}
+	}
+	return mode, resolverParams, nil
+}
+
+// cacheKey returns the key a resource resolved by the given resolver
+// with the given parameters and configuration fields is cached under.
+// The namespace of the request is part of the key, since the parameters
+// may reference credentials from that namespace.
+func cacheKey(resolverName, namespace string, params []pipelinev1beta1.Param, conf map[string]string) string {
+	sorted := make([]pipelinev1beta1.Param, len(params))
+	copy(sorted, params)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	h := sha256.New()
+	for _, s := range []string{resolverName, namespace} {
+		h.Write([]byte(strconv.Quote(s)))
+	}
+	for _, p := range sorted {
+		h.Write([]byte(strconv.Quote(p.Name) + "=" + paramValueKey(p.Value)))
+	}
+	fields := make([]string, 0, len(conf))
+	for field := range conf {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		h.Write([]byte("config " + strconv.Quote(field) + "=" + strconv.Quote(conf[field])))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// paramValueKey returns the representation of a parameter value that
+// is hashed into cache keys: the quoted string of a string value, and
+// the JSON encoding of an array or object value, which can't be
+// mistaken for a quoted string.
+func paramValueKey(value pipelinev1beta1.ParamValue) string {
+	var encoded []byte
+	switch value.Type {
+	case pipelinev1beta1.ParamTypeArray:
+		encoded, _ = json.Marshal(value.ArrayVal)
+	case pipelinev1beta1.ParamTypeObject:
+		encoded, _ = json.Marshal(value.ObjectVal)
+	default:
+		return strconv.Quote(value.StringVal)
+	}
+	return string(encoded)
+}
+
+// cacheConfig returns the TTL of the resources resolved from mutable
+// references and the maximum number of cached resources configured in
+// the given resolver configuration. Invalid values are ignored.
+func cacheConfig(conf map[string]string) (time.Duration, int) {
+	ttl := defaultCacheTTL
+	if ttlString, ok := conf[ConfigCacheTTL]; ok {
+		if d, err := time.ParseDuration(ttlString); err == nil && d >= 0 {
+			ttl = d
+		}
+	}
+	maxEntries := defaultCacheMaxEntries
+	if maxString, ok := conf[ConfigCacheMaxEntries]; ok {
+		if n, err := strconv.Atoi(maxString); err == nil && n >= 0 {
+			maxEntries = n
+		}
+	}
+	return ttl, maxEntries
+}
+
+// cacheKeyConfig returns the fields of the given configuration that the
+// given resolver hashes into its cache keys.
+func cacheKeyConfig(ctx context.Context, resolver Resolver, conf map[string]string) map[string]string {
+	ckc, ok := optionalInterfaces(resolver).(CacheKeyConfig)
+	if !ok {
+		return nil
+	}
+	keyConf := map[string]string{}
+	for _, field := range ckc.CacheKeyConfigFields(ctx) {
+		keyConf[field] = conf[field]
+	}
+	return keyConf
+}
+
+// resolveWithCache resolves the given parameters with the resolver of
+// the reconciler, unless the resource is found in its cache, and caches
+// the resolved resource as requested by the cache mode.
+func (r *Reconciler) resolveWithCache(ctx context.Context, mode string, params []pipelinev1beta1.Param) (ResolvedResource, error) {
+	if r.cache == nil || mode == CacheModeNever {
+		return r.resolver.Resolve(ctx, params)
+	}
+	immutable := false
+	if ir, ok := r.resolver.(ImmutableResolution); ok {
+		immutable = ir.IsImmutable(ctx, params)
+	}
+	conf := GetResolverConfigFromContext(ctx)
+	ttl, maxEntries := cacheConfig(conf)
+	if maxEntries == 0 || (!immutable && (mode == CacheModeAuto || ttl == 0)) {
+		return r.resolver.Resolve(ctx, params)
+	}
+
+	resolverName := r.resolver.GetName(ctx)
+	key := cacheKey(resolverName, resolutioncommon.RequestNamespace(ctx), params, cacheKeyConfig(ctx, r.resolver, conf))
+	metricsCtx, _ := tag.New(ctx, tag.Upsert(resolverTag, resolverName))
+	if resource, ok := r.cache.get(key, r.Clock.Now()); ok {
+		metrics.Record(metricsCtx, cacheHitCount.M(1))
+		return resource, nil
+	}
+	metrics.Record(metricsCtx, cacheMissCount.M(1))
+
+	resource, err := r.resolver.Resolve(ctx, params)
+	if err != nil || resource == nil {
+		return resource, err
+	}
+	var expires time.Time
+	if !immutable {
+		expires = r.Clock.Now().Add(ttl)
+	}
+	r.cache.add(key, resource, expires, maxEntries)
+	return resource, nil
+}
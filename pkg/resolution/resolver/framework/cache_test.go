@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+// countingResolver is a FakeResolver counting its resolutions, which
+// considers the param values starting with "immutable-" immutable.
+type countingResolver struct {
+	FakeResolver
+	name        string
+	resolutions int
+}
+
+var _ ImmutableResolution = &countingResolver{}
+var _ CacheKeyConfig = &countingResolver{}
+
+func (r *countingResolver) GetName(context.Context) string {
+	return r.name
+}
+
+func (r *countingResolver) Resolve(ctx context.Context, params []pipelinev1beta1.Param) (ResolvedResource, error) {
+	r.resolutions++
+	return r.FakeResolver.Resolve(ctx, params)
+}
+
+func (r *countingResolver) IsImmutable(_ context.Context, params []pipelinev1beta1.Param) bool {
+	for _, p := range params {
+		if p.Name == FakeParamName {
+			return strings.HasPrefix(p.Value.StringVal, "immutable-")
+		}
+	}
+	return false
+}
+
+func (r *countingResolver) CacheKeyConfigFields(context.Context) []string {
+	return []string{"verification"}
+}
+
+// fakeParams returns the params of a request to the fake resolver
+// with the given param value.
+func fakeParams(value string) []pipelinev1beta1.Param {
+	return []pipelinev1beta1.Param{{Name: FakeParamName, Value: *pipelinev1beta1.NewStructuredValues(value)}}
+}
+
+func newCountingResolver(name string) *countingResolver {
+	return &countingResolver{
+		name: name,
+		FakeResolver: FakeResolver{ForParam: map[string]*FakeResolvedResource{
+			"branch":      {Content: "branch content", AnnotationMap: map[string]string{"revision": "branch"}},
+			"immutable-a": {Content: "a content"},
+			"immutable-b": {Content: "b content"},
+		}},
+	}
+}
+
+func TestResolveWithCache(t *testing.T) {
+	type resolution struct {
+		param     string
+		mode      string
+		namespace string
+		conf      map[string]string
+		after     time.Duration
+	}
+	for _, tc := range []struct {
+		name                string
+		conf                map[string]string
+		resolutions         []resolution
+		expectedResolutions int
+	}{{
+		name:                "auto mode caches immutable references indefinitely",
+		resolutions:         []resolution{{param: "immutable-a"}, {param: "immutable-a", after: 24 * time.Hour}},
+		expectedResolutions: 1,
+	}, {
+		name:                "auto mode doesn't cache mutable references",
+		resolutions:         []resolution{{param: "branch"}, {param: "branch"}},
+		expectedResolutions: 2,
+	}, {
+		name:                "always mode caches mutable references",
+		resolutions:         []resolution{{param: "branch", mode: CacheModeAlways}, {param: "branch", mode: CacheModeAlways, after: 4 * time.Minute}},
+		expectedResolutions: 1,
+	}, {
+		name:                "always mode caches mutable references for the ttl",
+		conf:                map[string]string{ConfigCacheTTL: "1m"},
+		resolutions:         []resolution{{param: "branch", mode: CacheModeAlways}, {param: "branch", mode: CacheModeAlways, after: time.Minute}},
+		expectedResolutions: 2,
+	}, {
+		name:                "always mode with a ttl of zero",
+		conf:                map[string]string{ConfigCacheTTL: "0s"},
+		resolutions:         []resolution{{param: "branch", mode: CacheModeAlways}, {param: "branch", mode: CacheModeAlways}},
+		expectedResolutions: 2,
+	}, {
+		name:                "never mode",
+		resolutions:         []resolution{{param: "immutable-a"}, {param: "immutable-a", mode: CacheModeNever}},
+		expectedResolutions: 2,
+	}, {
+		name:                "cache disabled",
+		conf:                map[string]string{ConfigCacheMaxEntries: "0"},
+		resolutions:         []resolution{{param: "immutable-a"}, {param: "immutable-a"}},
+		expectedResolutions: 2,
+	}, {
+		name:                "least recently used entries are evicted",
+		conf:                map[string]string{ConfigCacheMaxEntries: "1"},
+		resolutions:         []resolution{{param: "immutable-a"}, {param: "immutable-b"}, {param: "immutable-b"}, {param: "immutable-a"}},
+		expectedResolutions: 3,
+	}, {
+		name:                "requests from other namespaces are cached separately",
+		resolutions:         []resolution{{param: "immutable-a", namespace: "foo"}, {param: "immutable-a", namespace: "bar"}},
+		expectedResolutions: 2,
+	}, {
+		name: "resources are resolved again when the cache key config changes",
+		resolutions: []resolution{
+			{param: "immutable-a", conf: map[string]string{"verification": "key-a"}},
+			{param: "immutable-a", conf: map[string]string{"verification": "key-a", ConfigCacheTTL: "1m"}},
+			{param: "immutable-a", conf: map[string]string{"verification": "key-b"}},
+			{param: "immutable-a"},
+		},
+		expectedResolutions: 3,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := newCountingResolver("Counting")
+			fakeClock := clock.NewFakePassiveClock(now)
+			r := &Reconciler{resolver: resolver, cache: newResolverCache(), Clock: fakeClock}
+			for _, res := range tc.resolutions {
+				fakeClock.SetTime(fakeClock.Now().Add(res.after))
+				mode := res.mode
+				if mode == "" {
+					mode = CacheModeAuto
+				}
+				namespace := res.namespace
+				if namespace == "" {
+					namespace = "foo"
+				}
+				conf := map[string]string{}
+				for k, v := range tc.conf {
+					conf[k] = v
+				}
+				for k, v := range res.conf {
+					conf[k] = v
+				}
+				ctx := InjectResolverConfigToContext(resolutioncommon.InjectRequestNamespace(context.Background(), namespace), conf)
+				resource, err := r.resolveWithCache(ctx, mode, fakeParams(res.param))
+				if err != nil {
+					t.Fatalf("unexpected error resolving %q: %v", res.param, err)
+				}
+				expected := resolver.ForParam[res.param]
+				if d := cmp.Diff(expected.Data(), resource.Data()); d != "" {
+					t.Errorf("unexpected data for %q: %s", res.param, diff.PrintWantGot(d))
+				}
+				if d := cmp.Diff(expected.Annotations(), resource.Annotations()); d != "" {
+					t.Errorf("unexpected annotations for %q: %s", res.param, diff.PrintWantGot(d))
+				}
+			}
+			if resolver.resolutions != tc.expectedResolutions {
+				t.Errorf("expected %d resolutions but got %d", tc.expectedResolutions, resolver.resolutions)
+			}
+		})
+	}
+}
+
+func TestResolveWithCacheMetrics(t *testing.T) {
+	registerCacheViews(context.Background())
+	resolver := newCountingResolver("Metrics")
+	r := &Reconciler{resolver: resolver, cache: newResolverCache(), Clock: clock.NewFakePassiveClock(now)}
+	ctx := resolutioncommon.InjectRequestNamespace(context.Background(), "foo")
+	for _, param := range []string{"immutable-a", "immutable-a", "immutable-a", "immutable-b", "branch"} {
+		if _, err := r.resolveWithCache(ctx, CacheModeAuto, fakeParams(param)); err != nil {
+			t.Fatalf("unexpected error resolving %q: %v", param, err)
+		}
+	}
+	tags := map[string]string{"resolver": "Metrics"}
+	metricstest.CheckCountData(t, "resolution_cache_hit_count", tags, 2)
+	metricstest.CheckCountData(t, "resolution_cache_miss_count", tags, 2)
+}
+
+func TestCacheModeFromParams(t *testing.T) {
+	mode, params, err := cacheModeFromParams(append(fakeParams("bar"), pipelinev1beta1.Param{Name: CacheParam, Value: *pipelinev1beta1.NewStructuredValues(CacheModeNever)}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mode != CacheModeNever {
+		t.Errorf("expected mode %q but got %q", CacheModeNever, mode)
+	}
+	if d := cmp.Diff(fakeParams("bar"), params); d != "" {
+		t.Errorf("unexpected params: %s", diff.PrintWantGot(d))
+	}
+
+	if mode, _, _ := cacheModeFromParams(fakeParams("bar")); mode != CacheModeAuto {
+		t.Errorf("expected mode %q by default but got %q", CacheModeAuto, mode)
+	}
+
+	_, _, err = cacheModeFromParams([]pipelinev1beta1.Param{{Name: CacheParam, Value: *pipelinev1beta1.NewStructuredValues("sometimes")}})
+	if d := cmp.Diff(`invalid value for cache param "sometimes": must be auto, always or never`, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+
+	_, _, err = cacheModeFromParams([]pipelinev1beta1.Param{{Name: CacheParam, Value: *pipelinev1beta1.NewStructuredValues(CacheModeNever, CacheModeAlways)}})
+	if d := cmp.Diff(`invalid cache param: must be a string, not array`, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	stringParams := []pipelinev1beta1.Param{
+		{Name: "b", Value: *pipelinev1beta1.NewStructuredValues("c")},
+		{Name: "a", Value: *pipelinev1beta1.NewStructuredValues("c")},
+	}
+	reorderedParams := []pipelinev1beta1.Param{stringParams[1], stringParams[0]}
+	if cacheKey("Fake", "foo", stringParams, nil) != cacheKey("Fake", "foo", reorderedParams, nil) {
+		t.Error("expected the cache key not to depend on the order of the params")
+	}
+
+	arrayParams := []pipelinev1beta1.Param{
+		{Name: "b", Value: *pipelinev1beta1.NewStructuredValues("c")},
+		{Name: "a", Value: *pipelinev1beta1.NewStructuredValues("c", "d")},
+	}
+	objectParams := []pipelinev1beta1.Param{
+		{Name: "b", Value: *pipelinev1beta1.NewStructuredValues("c")},
+		{Name: "a", Value: *pipelinev1beta1.NewObject(map[string]string{"c": "d"})},
+	}
+	keys := map[string]bool{}
+	for _, params := range [][]pipelinev1beta1.Param{stringParams, arrayParams, objectParams} {
+		keys[cacheKey("Fake", "foo", params, nil)] = true
+	}
+	if len(keys) != 3 {
+		t.Errorf("expected params of different types to have different cache keys")
+	}
+}
@@ -21,10 +21,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	rrclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
-	rrinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1alpha1/resolutionrequest"
-	rrlister "github.com/tektoncd/pipeline/pkg/client/resolution/listers/resolution/v1alpha1"
+	rrinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
+	rrlister "github.com/tektoncd/pipeline/pkg/client/resolution/listers/resolution/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/resolution/common"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -65,7 +65,9 @@ func NewController(ctx context.Context, resolver Resolver, modifiers ...Reconcil
 			resolutionRequestLister:    rrInformer.Lister(),
 			resolutionRequestClientSet: rrclientset,
 			resolver:                   resolver,
+			cache:                      newResolverCache(),
 		}
+		registerCacheViews(ctx)
 
 		watchConfigChanges(ctx, r, cmw)
 
@@ -100,7 +102,7 @@ func NewController(ctx context.Context, resolver Resolver, modifiers ...Reconcil
 
 func filterResolutionRequestsBySelector(selector map[string]string) func(obj interface{}) bool {
 	return func(obj interface{}) bool {
-		rr, ok := obj.(*v1alpha1.ResolutionRequest)
+		rr, ok := obj.(*v1beta1.ResolutionRequest)
 		if !ok {
 			return false
 		}
@@ -161,7 +163,7 @@ func validateResolver(ctx context.Context, r Resolver) error {
 // configmap, using knative's configmap helpers. This is only done if
 // the resolver implements the framework.ConfigWatcher interface.
 func watchConfigChanges(ctx context.Context, reconciler *Reconciler, cmw configmap.Watcher) {
-	if configWatcher, ok := reconciler.resolver.(ConfigWatcher); ok {
+	if configWatcher, ok := optionalInterfaces(reconciler.resolver).(ConfigWatcher); ok {
 		logger := logging.FromContext(ctx)
 		resolverConfigName := configWatcher.GetConfigName(ctx)
 		if resolverConfigName == "" {
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 )
 
@@ -92,21 +93,22 @@ func (r *FakeResolver) GetSelector(_ context.Context) map[string]string {
 	}
 }
 
-// ValidateParams returns an error if the given parameter map is not
+// ValidateParams returns an error if the given parameters are not
 // valid for a resource request targeting the fake resolver.
-func (r *FakeResolver) ValidateParams(_ context.Context, params map[string]string) error {
+func (r *FakeResolver) ValidateParams(_ context.Context, params []pipelinev1beta1.Param) error {
+	paramsMap := make(map[string]pipelinev1beta1.ParamValue)
+	for _, p := range params {
+		paramsMap[p.Name] = p.Value
+	}
+
 	required := []string{
 		FakeParamName,
 	}
 	missing := []string{}
-	if params == nil {
-		missing = required
-	} else {
-		for _, p := range required {
-			v, has := params[p]
-			if !has || v == "" {
-				missing = append(missing, p)
-			}
+	for _, p := range required {
+		v, has := paramsMap[p]
+		if !has || v.StringVal == "" {
+			missing = append(missing, p)
 		}
 	}
 	if len(missing) > 0 {
@@ -116,10 +118,15 @@ func (r *FakeResolver) ValidateParams(_ context.Context, params map[string]strin
 	return nil
 }
 
-// Resolve performs the work of fetching a file from the fake resolver given a map of
+// Resolve performs the work of fetching a file from the fake resolver given its
 // parameters.
-func (r *FakeResolver) Resolve(_ context.Context, params map[string]string) (ResolvedResource, error) {
-	paramValue := params[FakeParamName]
+func (r *FakeResolver) Resolve(_ context.Context, params []pipelinev1beta1.Param) (ResolvedResource, error) {
+	var paramValue string
+	for _, p := range params {
+		if p.Name == FakeParamName {
+			paramValue = p.Value.StringVal
+		}
+	}
 
 	frr, ok := r.ForParam[paramValue]
 	if !ok {
@@ -19,6 +19,8 @@ package framework
 import (
 	"context"
 	"time"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 )
 
 // Resolver is the interface to implement for type-specific resource
@@ -39,7 +41,8 @@ type Resolver interface {
 
 	// ValidateParams is given the parameters from a resource
 	// request and should return an error if any are missing or invalid.
-	ValidateParams(context.Context, map[string]string) error
+	// Parameters can be strings, arrays or objects.
+	ValidateParams(context.Context, []pipelinev1beta1.Param) error
 
 	// Resolve receives the parameters passed via a resource request
 	// and returns the resolved data along with any annotations
@@ -47,7 +50,7 @@ type Resolver interface {
 	// should be returned instead. If a resolution.Error
 	// is returned then its Reason and Message are used as part of the
 	// response to the request.
-	Resolve(context.Context, map[string]string) (ResolvedResource, error)
+	Resolve(context.Context, []pipelinev1beta1.Param) (ResolvedResource, error)
 }
 
 // ConfigWatcher is the interface to implement if your resolver accepts
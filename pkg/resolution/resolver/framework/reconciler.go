@@ -24,9 +24,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	rrclient "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned"
-	rrv1alpha1 "github.com/tektoncd/pipeline/pkg/client/resolution/listers/resolution/v1alpha1"
+	rrv1beta1 "github.com/tektoncd/pipeline/pkg/client/resolution/listers/resolution/v1beta1"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -51,10 +51,11 @@ type Reconciler struct {
 
 	resolver                   Resolver
 	kubeClientSet              kubernetes.Interface
-	resolutionRequestLister    rrv1alpha1.ResolutionRequestLister
+	resolutionRequestLister    rrv1beta1.ResolutionRequestLister
 	resolutionRequestClientSet rrclient.Interface
 
 	configStore *ConfigStore
+	cache       *resolverCache
 }
 
 var _ reconciler.LeaderAware = &Reconciler{}
@@ -100,12 +101,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	return r.resolve(ctx, key, rr)
 }
 
-func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1alpha1.ResolutionRequest) error {
+func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.ResolutionRequest) error {
 	errChan := make(chan error)
 	resourceChan := make(chan ResolvedResource)
 
 	timeoutDuration := defaultMaximumResolutionDuration
-	if timed, ok := r.resolver.(TimedResolution); ok {
+	if timed, ok := optionalInterfaces(r.resolver).(TimedResolution); ok {
 		timeoutDuration = timed.GetResolutionTimeout(ctx, defaultMaximumResolutionDuration)
 	}
 
@@ -116,7 +117,15 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1alpha1.Resol
 	defer cancelFn()
 
 	go func() {
-		validationError := r.resolver.ValidateParams(resolutionCtx, rr.Spec.Parameters)
+		cacheMode, params, cacheModeError := cacheModeFromParams(rr.Spec.Params)
+		if cacheModeError != nil {
+			errChan <- &resolutioncommon.ErrorInvalidRequest{
+				ResolutionRequestKey: key,
+				Message:              cacheModeError.Error(),
+			}
+			return
+		}
+		validationError := r.resolver.ValidateParams(resolutionCtx, params)
 		if validationError != nil {
 			errChan <- &resolutioncommon.ErrorInvalidRequest{
 				ResolutionRequestKey: key,
@@ -124,7 +133,7 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1alpha1.Resol
 			}
 			return
 		}
-		resource, resolveErr := r.resolver.Resolve(resolutionCtx, rr.Spec.Parameters)
+		resource, resolveErr := r.resolveWithCache(resolutionCtx, cacheMode, params)
 		if resolveErr != nil {
 			errChan <- &resolutioncommon.ErrorGettingResource{
 				ResolverName: r.resolver.GetName(resolutionCtx),
@@ -154,7 +163,7 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1alpha1.Resol
 
 // OnError is used to handle any situation where a ResolutionRequest has
 // reached a terminal situation that cannot be recovered from.
-func (r *Reconciler) OnError(ctx context.Context, rr *v1alpha1.ResolutionRequest, err error) error {
+func (r *Reconciler) OnError(ctx context.Context, rr *v1beta1.ResolutionRequest, err error) error {
 	if rr == nil {
 		return controller.NewPermanentError(err)
 	}
@@ -168,10 +177,10 @@ func (r *Reconciler) OnError(ctx context.Context, rr *v1alpha1.ResolutionRequest
 // MarkFailed updates a ResolutionRequest as having failed. It returns
 // errors that occur during the update process or nil if the update
 // appeared to succeed.
-func (r *Reconciler) MarkFailed(ctx context.Context, rr *v1alpha1.ResolutionRequest, resolutionErr error) error {
+func (r *Reconciler) MarkFailed(ctx context.Context, rr *v1beta1.ResolutionRequest, resolutionErr error) error {
 	key := fmt.Sprintf("%s/%s", rr.Namespace, rr.Name)
 	reason, resolutionErr := resolutioncommon.ReasonError(resolutionErr)
-	latestGeneration, err := r.resolutionRequestClientSet.ResolutionV1alpha1().ResolutionRequests(rr.Namespace).Get(ctx, rr.Name, metav1.GetOptions{})
+	latestGeneration, err := r.resolutionRequestClientSet.ResolutionV1beta1().ResolutionRequests(rr.Namespace).Get(ctx, rr.Name, metav1.GetOptions{})
 	if err != nil {
 		logging.FromContext(ctx).Warnf("error getting latest generation of resolutionrequest %q: %v", key, err)
 		return err
@@ -180,7 +189,7 @@ func (r *Reconciler) MarkFailed(ctx context.Context, rr *v1alpha1.ResolutionRequ
 		return nil
 	}
 	latestGeneration.Status.MarkFailed(reason, resolutionErr.Error())
-	_, err = r.resolutionRequestClientSet.ResolutionV1alpha1().ResolutionRequests(rr.Namespace).UpdateStatus(ctx, latestGeneration, metav1.UpdateOptions{})
+	_, err = r.resolutionRequestClientSet.ResolutionV1beta1().ResolutionRequests(rr.Namespace).UpdateStatus(ctx, latestGeneration, metav1.UpdateOptions{})
 	if err != nil {
 		logging.FromContext(ctx).Warnf("error marking resolutionrequest %q as failed: %v", key, err)
 		return err
@@ -196,7 +205,7 @@ type statusDataPatch struct {
 	Data        string            `json:"data"`
 }
 
-func (r *Reconciler) writeResolvedData(ctx context.Context, rr *v1alpha1.ResolutionRequest, resource ResolvedResource) error {
+func (r *Reconciler) writeResolvedData(ctx context.Context, rr *v1beta1.ResolutionRequest, resource ResolvedResource) error {
 	encodedData := base64.StdEncoding.Strict().EncodeToString(resource.Data())
 	patchBytes, err := json.Marshal(map[string]statusDataPatch{
 		"status": {
@@ -210,7 +219,7 @@ func (r *Reconciler) writeResolvedData(ctx context.Context, rr *v1alpha1.Resolut
 			Original:             fmt.Errorf("error serializing resource request patch: %w", err),
 		})
 	}
-	_, err = r.resolutionRequestClientSet.ResolutionV1alpha1().ResolutionRequests(rr.Namespace).Patch(ctx, rr.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{}, "status")
+	_, err = r.resolutionRequestClientSet.ResolutionV1beta1().ResolutionRequests(rr.Namespace).Patch(ctx, rr.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{}, "status")
 	if err != nil {
 		return r.OnError(ctx, rr, &resolutioncommon.ErrorUpdatingRequest{
 			ResolutionRequestKey: fmt.Sprintf("%s/%s", rr.Namespace, rr.Name),
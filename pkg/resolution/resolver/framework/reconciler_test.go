@@ -26,7 +26,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
-	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/test"
@@ -56,17 +57,17 @@ var (
 func TestReconcile(t *testing.T) {
 	testCases := []struct {
 		name              string
-		inputRequest      *v1alpha1.ResolutionRequest
+		inputRequest      *v1beta1.ResolutionRequest
 		paramMap          map[string]*FakeResolvedResource
 		reconcilerTimeout time.Duration
-		expectedStatus    *v1alpha1.ResolutionRequestStatus
+		expectedStatus    *v1beta1.ResolutionRequestStatus
 		expectedErr       error
 	}{
 		{
 			name: "unknown value",
-			inputRequest: &v1alpha1.ResolutionRequest{
+			inputRequest: &v1beta1.ResolutionRequest{
 				TypeMeta: metav1.TypeMeta{
-					APIVersion: "resolution.tekton.dev/v1alpha1",
+					APIVersion: "resolution.tekton.dev/v1beta1",
 					Kind:       "ResolutionRequest",
 				},
 				ObjectMeta: metav1.ObjectMeta{
@@ -77,19 +78,20 @@ func TestReconcile(t *testing.T) {
 						resolutioncommon.LabelKeyResolverType: LabelValueFakeResolverType,
 					},
 				},
-				Spec: v1alpha1.ResolutionRequestSpec{
-					Parameters: map[string]string{
-						FakeParamName: "bar",
-					},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1beta1.Param{{
+						Name:  FakeParamName,
+						Value: *pipelinev1beta1.NewStructuredValues("bar"),
+					}},
 				},
-				Status: v1alpha1.ResolutionRequestStatus{},
+				Status: v1beta1.ResolutionRequestStatus{},
 			},
 			expectedErr: errors.New("error getting \"Fake\" \"foo/rr\": couldn't find resource for param value bar"),
 		}, {
 			name: "known value",
-			inputRequest: &v1alpha1.ResolutionRequest{
+			inputRequest: &v1beta1.ResolutionRequest{
 				TypeMeta: metav1.TypeMeta{
-					APIVersion: "resolution.tekton.dev/v1alpha1",
+					APIVersion: "resolution.tekton.dev/v1beta1",
 					Kind:       "ResolutionRequest",
 				},
 				ObjectMeta: metav1.ObjectMeta{
@@ -100,12 +102,13 @@ func TestReconcile(t *testing.T) {
 						resolutioncommon.LabelKeyResolverType: LabelValueFakeResolverType,
 					},
 				},
-				Spec: v1alpha1.ResolutionRequestSpec{
-					Parameters: map[string]string{
-						FakeParamName: "bar",
-					},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1beta1.Param{{
+						Name:  FakeParamName,
+						Value: *pipelinev1beta1.NewStructuredValues("bar"),
+					}},
 				},
-				Status: v1alpha1.ResolutionRequestStatus{},
+				Status: v1beta1.ResolutionRequestStatus{},
 			},
 			paramMap: map[string]*FakeResolvedResource{
 				"bar": {
@@ -113,21 +116,21 @@ func TestReconcile(t *testing.T) {
 					AnnotationMap: map[string]string{"foo": "bar"},
 				},
 			},
-			expectedStatus: &v1alpha1.ResolutionRequestStatus{
+			expectedStatus: &v1beta1.ResolutionRequestStatus{
 				Status: duckv1.Status{
 					Annotations: map[string]string{
 						"foo": "bar",
 					},
 				},
-				ResolutionRequestStatusFields: v1alpha1.ResolutionRequestStatusFields{
+				ResolutionRequestStatusFields: v1beta1.ResolutionRequestStatusFields{
 					Data: base64.StdEncoding.Strict().EncodeToString([]byte("some content")),
 				},
 			},
 		}, {
 			name: "error resolving",
-			inputRequest: &v1alpha1.ResolutionRequest{
+			inputRequest: &v1beta1.ResolutionRequest{
 				TypeMeta: metav1.TypeMeta{
-					APIVersion: "resolution.tekton.dev/v1alpha1",
+					APIVersion: "resolution.tekton.dev/v1beta1",
 					Kind:       "ResolutionRequest",
 				},
 				ObjectMeta: metav1.ObjectMeta{
@@ -138,12 +141,13 @@ func TestReconcile(t *testing.T) {
 						resolutioncommon.LabelKeyResolverType: LabelValueFakeResolverType,
 					},
 				},
-				Spec: v1alpha1.ResolutionRequestSpec{
-					Parameters: map[string]string{
-						FakeParamName: "bar",
-					},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1beta1.Param{{
+						Name:  FakeParamName,
+						Value: *pipelinev1beta1.NewStructuredValues("bar"),
+					}},
 				},
-				Status: v1alpha1.ResolutionRequestStatus{},
+				Status: v1beta1.ResolutionRequestStatus{},
 			},
 			paramMap: map[string]*FakeResolvedResource{
 				"bar": {
@@ -151,11 +155,43 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 			expectedErr: errors.New(`error getting "Fake" "foo/rr": fake failure`),
+		}, {
+			name: "invalid cache mode",
+			inputRequest: &v1beta1.ResolutionRequest{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "resolution.tekton.dev/v1beta1",
+					Kind:       "ResolutionRequest",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "rr",
+					Namespace:         "foo",
+					CreationTimestamp: metav1.Time{Time: time.Now()},
+					Labels: map[string]string{
+						resolutioncommon.LabelKeyResolverType: LabelValueFakeResolverType,
+					},
+				},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1beta1.Param{{
+						Name:  FakeParamName,
+						Value: *pipelinev1beta1.NewStructuredValues("bar"),
+					}, {
+						Name:  CacheParam,
+						Value: *pipelinev1beta1.NewStructuredValues("sometimes"),
+					}},
+				},
+				Status: v1beta1.ResolutionRequestStatus{},
+			},
+			paramMap: map[string]*FakeResolvedResource{
+				"bar": {
+					Content: "some content",
+				},
+			},
+			expectedErr: errors.New(`invalid resource request "foo/rr": invalid value for cache param "sometimes": must be auto, always or never`),
 		}, {
 			name: "timeout",
-			inputRequest: &v1alpha1.ResolutionRequest{
+			inputRequest: &v1beta1.ResolutionRequest{
 				TypeMeta: metav1.TypeMeta{
-					APIVersion: "resolution.tekton.dev/v1alpha1",
+					APIVersion: "resolution.tekton.dev/v1beta1",
 					Kind:       "ResolutionRequest",
 				},
 				ObjectMeta: metav1.ObjectMeta{
@@ -166,12 +202,13 @@ func TestReconcile(t *testing.T) {
 						resolutioncommon.LabelKeyResolverType: LabelValueFakeResolverType,
 					},
 				},
-				Spec: v1alpha1.ResolutionRequestSpec{
-					Parameters: map[string]string{
-						FakeParamName: "bar",
-					},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1beta1.Param{{
+						Name:  FakeParamName,
+						Value: *pipelinev1beta1.NewStructuredValues("bar"),
+					}},
 				},
-				Status: v1alpha1.ResolutionRequestStatus{},
+				Status: v1beta1.ResolutionRequestStatus{},
 			},
 			paramMap: map[string]*FakeResolvedResource{
 				"bar": {
@@ -186,7 +223,7 @@ func TestReconcile(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			d := test.Data{
-				ResolutionRequests: []*v1alpha1.ResolutionRequest{tc.inputRequest},
+				ResolutionRequests: []*v1beta1.ResolutionRequest{tc.inputRequest},
 			}
 
 			fakeResolver := &FakeResolver{ForParam: tc.paramMap}
@@ -213,7 +250,7 @@ func TestReconcile(t *testing.T) {
 					}
 				}
 
-				c := testAssets.Clients.ResolutionRequests.ResolutionV1alpha1()
+				c := testAssets.Clients.ResolutionRequests.ResolutionV1beta1()
 				reconciledRR, err := c.ResolutionRequests(tc.inputRequest.Namespace).Get(testAssets.Ctx, tc.inputRequest.Name, metav1.GetOptions{})
 				if err != nil {
 					t.Fatalf("getting updated ResolutionRequest: %v", err)
@@ -253,7 +290,7 @@ func getResolverFrameworkController(ctx context.Context, t *testing.T, d test.Da
 	}, cancel
 }
 
-func getRequestName(rr *v1alpha1.ResolutionRequest) string {
+func getRequestName(rr *v1beta1.ResolutionRequest) string {
 	return strings.Join([]string{rr.Namespace, rr.Name}, "/")
 }
 
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// StringParamsResolver is the interface of resolvers that only accept
+// string parameters, which they receive as a map from parameter names
+// to values. It is the Resolver interface from before parameters were
+// typed, and resolvers implementing it can be run by the framework by
+// wrapping them with FromStringParamsResolver.
+type StringParamsResolver interface {
+	// Initialize is called at the moment the resolver controller is
+	// instantiated and is a good place to setup things like
+	// resource listers.
+	Initialize(context.Context) error
+
+	// GetName should give back the name of the resolver. E.g. "Git"
+	GetName(context.Context) string
+
+	// GetSelector returns the labels that are used to direct resolution
+	// requests to this resolver.
+	GetSelector(context.Context) map[string]string
+
+	// ValidateParams is given the parameters from a resource
+	// request and should return an error if any are missing or invalid.
+	ValidateParams(context.Context, map[string]string) error
+
+	// Resolve receives the parameters passed via a resource request
+	// and returns the resolved data along with any annotations
+	// to include in the response.
+	Resolve(context.Context, map[string]string) (ResolvedResource, error)
+}
+
+// StringParamsImmutableResolution is the ImmutableResolution interface
+// for resolvers implementing StringParamsResolver.
+type StringParamsImmutableResolution interface {
+	// IsImmutable returns true if the given parameters reference a
+	// resource that can't change, such as a file at a commit SHA or an
+	// image by digest.
+	IsImmutable(context.Context, map[string]string) bool
+}
+
+// FromStringParamsResolver returns a Resolver running the given
+// StringParamsResolver. Requests with array or object parameters fail
+// validation without reaching the resolver. The optional interfaces
+// implemented by the given resolver, such as ConfigWatcher and
+// TimedResolution, are honoured as if they were implemented by the
+// returned Resolver.
+func FromStringParamsResolver(resolver StringParamsResolver) Resolver {
+	return &stringParamsResolver{resolver: resolver}
+}
+
+// stringParamsResolver adapts a StringParamsResolver to the Resolver
+// interface.
+type stringParamsResolver struct {
+	resolver StringParamsResolver
+}
+
+var _ Resolver = &stringParamsResolver{}
+var _ ImmutableResolution = &stringParamsResolver{}
+
+// Initialize initializes the adapted resolver.
+func (r *stringParamsResolver) Initialize(ctx context.Context) error {
+	return r.resolver.Initialize(ctx)
+}
+
+// GetName returns the name of the adapted resolver.
+func (r *stringParamsResolver) GetName(ctx context.Context) string {
+	return r.resolver.GetName(ctx)
+}
+
+// GetSelector returns the selector of the adapted resolver.
+func (r *stringParamsResolver) GetSelector(ctx context.Context) map[string]string {
+	return r.resolver.GetSelector(ctx)
+}
+
+// ValidateParams returns an error if any of the given parameters isn't a
+// string, and otherwise validates them with the adapted resolver.
+func (r *stringParamsResolver) ValidateParams(ctx context.Context, params []pipelinev1beta1.Param) error {
+	stringParams, err := stringParamsMap(params)
+	if err != nil {
+		return err
+	}
+	return r.resolver.ValidateParams(ctx, stringParams)
+}
+
+// Resolve resolves the given string parameters with the adapted resolver.
+func (r *stringParamsResolver) Resolve(ctx context.Context, params []pipelinev1beta1.Param) (ResolvedResource, error) {
+	stringParams, err := stringParamsMap(params)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolver.Resolve(ctx, stringParams)
+}
+
+// IsImmutable returns whether the adapted resolver considers the
+// resource referenced by the given parameters immutable, which is false
+// if it doesn't implement StringParamsImmutableResolution.
+func (r *stringParamsResolver) IsImmutable(ctx context.Context, params []pipelinev1beta1.Param) bool {
+	ir, ok := r.resolver.(StringParamsImmutableResolution)
+	if !ok {
+		return false
+	}
+	stringParams, err := stringParamsMap(params)
+	if err != nil {
+		return false
+	}
+	return ir.IsImmutable(ctx, stringParams)
+}
+
+// optionalInterfaces returns the value the optional interfaces of a
+// resolver, such as ConfigWatcher and TimedResolution, are looked up
+// on: the adapted resolver for a resolver returned by
+// FromStringParamsResolver, or the resolver itself.
+func optionalInterfaces(resolver Resolver) interface{} {
+	if adapter, ok := resolver.(*stringParamsResolver); ok {
+		return adapter.resolver
+	}
+	return resolver
+}
+
+// stringParamsMap returns the given parameters as a map from names to
+// values, or an error if any of them isn't a string.
+func stringParamsMap(params []pipelinev1beta1.Param) (map[string]string, error) {
+	stringParams := make(map[string]string, len(params))
+	for _, p := range params {
+		if p.Value.Type == pipelinev1beta1.ParamTypeArray || p.Value.Type == pipelinev1beta1.ParamTypeObject {
+			return nil, fmt.Errorf("param %q must be a string, not %s", p.Name, p.Value.Type)
+		}
+		stringParams[p.Name] = p.Value.StringVal
+	}
+	return stringParams, nil
+}
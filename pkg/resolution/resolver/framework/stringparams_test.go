@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+// stringParamsFakeResolver is a StringParamsResolver recording the
+// params it is given, which considers every resolution immutable and
+// has a resolution timeout of a minute.
+type stringParamsFakeResolver struct {
+	params map[string]string
+}
+
+var _ StringParamsResolver = &stringParamsFakeResolver{}
+var _ StringParamsImmutableResolution = &stringParamsFakeResolver{}
+var _ TimedResolution = &stringParamsFakeResolver{}
+
+func (r *stringParamsFakeResolver) Initialize(context.Context) error {
+	return nil
+}
+
+func (r *stringParamsFakeResolver) GetName(context.Context) string {
+	return "StringParams"
+}
+
+func (r *stringParamsFakeResolver) GetSelector(context.Context) map[string]string {
+	return map[string]string{resolutioncommon.LabelKeyResolverType: "string-params"}
+}
+
+func (r *stringParamsFakeResolver) ValidateParams(_ context.Context, params map[string]string) error {
+	r.params = params
+	return nil
+}
+
+func (r *stringParamsFakeResolver) Resolve(_ context.Context, params map[string]string) (ResolvedResource, error) {
+	r.params = params
+	return &FakeResolvedResource{Content: fmt.Sprint(params)}, nil
+}
+
+func (r *stringParamsFakeResolver) IsImmutable(context.Context, map[string]string) bool {
+	return true
+}
+
+func (r *stringParamsFakeResolver) GetResolutionTimeout(context.Context, time.Duration) time.Duration {
+	return time.Minute
+}
+
+func TestFromStringParamsResolver(t *testing.T) {
+	ctx := context.Background()
+	stringParamsResolver := &stringParamsFakeResolver{}
+	resolver := FromStringParamsResolver(stringParamsResolver)
+
+	if name := resolver.GetName(ctx); name != "StringParams" {
+		t.Errorf("expected name %q but got %q", "StringParams", name)
+	}
+	if d := cmp.Diff(stringParamsResolver.GetSelector(ctx), resolver.GetSelector(ctx)); d != "" {
+		t.Errorf("unexpected selector: %s", diff.PrintWantGot(d))
+	}
+
+	params := []pipelinev1beta1.Param{
+		{Name: "foo", Value: *pipelinev1beta1.NewStructuredValues("bar")},
+		{Name: "baz", Value: pipelinev1beta1.ParamValue{StringVal: "qux"}},
+	}
+	expectedParams := map[string]string{"foo": "bar", "baz": "qux"}
+	if err := resolver.ValidateParams(ctx, params); err != nil {
+		t.Fatalf("unexpected error validating params: %v", err)
+	}
+	if d := cmp.Diff(expectedParams, stringParamsResolver.params); d != "" {
+		t.Errorf("unexpected params validated: %s", diff.PrintWantGot(d))
+	}
+	stringParamsResolver.params = nil
+	if _, err := resolver.Resolve(ctx, params); err != nil {
+		t.Fatalf("unexpected error resolving params: %v", err)
+	}
+	if d := cmp.Diff(expectedParams, stringParamsResolver.params); d != "" {
+		t.Errorf("unexpected params resolved: %s", diff.PrintWantGot(d))
+	}
+	if ir, ok := resolver.(ImmutableResolution); !ok || !ir.IsImmutable(ctx, params) {
+		t.Error("expected the resolver to consider the params immutable")
+	}
+	if _, ok := optionalInterfaces(resolver).(TimedResolution); !ok {
+		t.Error("expected the optional interfaces of the string params resolver to be honoured")
+	}
+}
+
+func TestFromStringParamsResolverRejectsTypedParams(t *testing.T) {
+	ctx := context.Background()
+	stringParamsResolver := &stringParamsFakeResolver{}
+	resolver := FromStringParamsResolver(stringParamsResolver)
+	for _, tc := range []struct {
+		name        string
+		params      []pipelinev1beta1.Param
+		expectedErr string
+	}{{
+		name:        "array param",
+		params:      []pipelinev1beta1.Param{{Name: "foo", Value: *pipelinev1beta1.NewStructuredValues("bar", "baz")}},
+		expectedErr: `param "foo" must be a string, not array`,
+	}, {
+		name:        "object param",
+		params:      []pipelinev1beta1.Param{{Name: "foo", Value: *pipelinev1beta1.NewObject(map[string]string{"bar": "baz"})}},
+		expectedErr: `param "foo" must be a string, not object`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := resolver.ValidateParams(ctx, tc.params)
+			if err == nil {
+				t.Fatal("expected validation error but got none")
+			}
+			if d := cmp.Diff(tc.expectedErr, err.Error()); d != "" {
+				t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+			}
+			if _, err := resolver.Resolve(ctx, tc.params); err == nil {
+				t.Error("expected resolution error but got none")
+			}
+			if resolver.(ImmutableResolution).IsImmutable(ctx, tc.params) {
+				t.Error("expected the params not to be considered immutable")
+			}
+			if stringParamsResolver.params != nil {
+				t.Errorf("expected the string params resolver not to be called, got params %v", stringParamsResolver.params)
+			}
+		})
+	}
+}
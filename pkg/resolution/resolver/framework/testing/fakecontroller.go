@@ -25,7 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
-	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -53,8 +53,8 @@ var (
 // ResolutionRequestStatus and error, both of which can be nil. It instantiates a controller for that resolver and
 // reconciles the given request. It then checks for the expected error, if any, and compares the resulting status with
 // the expected status.
-func RunResolverReconcileTest(ctx context.Context, t *testing.T, d test.Data, resolver framework.Resolver, request *v1alpha1.ResolutionRequest,
-	expectedStatus *v1alpha1.ResolutionRequestStatus, expectedErr error) {
+func RunResolverReconcileTest(ctx context.Context, t *testing.T, d test.Data, resolver framework.Resolver, request *v1beta1.ResolutionRequest,
+	expectedStatus *v1beta1.ResolutionRequestStatus, expectedErr error) {
 	t.Helper()
 
 	testAssets, cancel := GetResolverFrameworkController(ctx, t, d, resolver, setClockOnReconciler)
@@ -74,7 +74,7 @@ func RunResolverReconcileTest(ctx context.Context, t *testing.T, d test.Data, re
 		}
 	}
 
-	c := testAssets.Clients.ResolutionRequests.ResolutionV1alpha1()
+	c := testAssets.Clients.ResolutionRequests.ResolutionV1beta1()
 	reconciledRR, err := c.ResolutionRequests(request.Namespace).Get(testAssets.Ctx, request.Name, metav1.GetOptions{})
 	if err != nil {
 		t.Fatalf("getting updated ResolutionRequest: %v", err)
@@ -119,7 +119,7 @@ func initializeResolverFrameworkControllerAssets(ctx context.Context, t *testing
 	}, cancel
 }
 
-func getRequestName(rr *v1alpha1.ResolutionRequest) string {
+func getRequestName(rr *v1beta1.ResolutionRequest) string {
 	return strings.Join([]string{rr.Namespace, rr.Name}, "/")
 }
 
@@ -130,7 +130,7 @@ func setClockOnReconciler(r *framework.Reconciler) {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, trustedResourcesExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -147,6 +147,9 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetMetricsConfigName() {
 			metricsExists = true
 		}
+		if cm.Name == config.GetTrustedResourcesConfigName() {
+			trustedResourcesExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -178,4 +181,10 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !trustedResourcesExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
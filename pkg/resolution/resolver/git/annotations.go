@@ -17,7 +17,13 @@ limitations under the License.
 package git
 
 const (
-	// AnnotationKeyRevision is the commit hash that was fetched
-	// from git
+	// AnnotationKeyRevision is the revision that was requested from
+	// git, such as a branch, tag or commit hash
 	AnnotationKeyRevision = "revision"
+	// AnnotationKeyOrg is the organization of the repo the file was
+	// fetched from with the API of its SCM provider
+	AnnotationKeyOrg = "org"
+	// AnnotationKeyRepo is the name of the repo the file was fetched
+	// from with the API of its SCM provider
+	AnnotationKeyRepo = "repo"
 )
@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// secretAnnotationPrefix is the prefix of the annotations of a git
+	// secret listing the hosts it may be used for, as with the
+	// credentials of the TaskRuns.
+	secretAnnotationPrefix = "tekton.dev/git-"
+	// sshKnownHostsKey is the key of the known hosts in an ssh-auth
+	// secret.
+	sshKnownHostsKey = "known_hosts"
+	// tokenKey is the key of a bearer token in an opaque secret.
+	tokenKey = "token"
+	// defaultSSHUser is the user authenticating with an ssh key when
+	// the url doesn't specify one.
+	defaultSSHUser = "git"
+)
+
+// getAuth returns the method authenticating to the given repository
+// with the credentials in the secret referenced by the params or the
+// resolver config, if any. The secret is read from the namespace of the
+// resolution request.
+func (r *Resolver) getAuth(ctx context.Context, repo string, params map[string]string) (transport.AuthMethod, error) {
+	secretName, fromConfig := params[SecretParam], false
+	if secretName == "" {
+		secretName, fromConfig = framework.GetResolverConfigFromContext(ctx)[ConfigSecret], true
+	}
+	if secretName == "" {
+		return nil, nil
+	}
+
+	namespace := resolutioncommon.RequestNamespace(ctx)
+	secret, err := r.kubeClientSet.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		// The default secret is optional in each namespace.
+		if fromConfig && apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting secret %q in namespace %q: %w", secretName, namespace, err)
+	}
+	return authFromSecret(ctx, repo, secret)
+}
+
+// authFromSecret returns the method authenticating to the given
+// repository with the credentials in the given secret, which follows
+// the conventions of the git credentials of the TaskRuns.
+func authFromSecret(ctx context.Context, repo string, secret *corev1.Secret) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(repo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid git url %q: %w", repo, err)
+	}
+	if !secretMatchesHost(secret, endpoint.Host) {
+		return nil, fmt.Errorf("secret %q is not annotated with %s* for host %q", secret.Name, secretAnnotationPrefix, endpoint.Host)
+	}
+
+	switch secret.Type {
+	case corev1.SecretTypeBasicAuth:
+		if endpoint.Protocol != "http" && endpoint.Protocol != "https" {
+			return nil, fmt.Errorf("basic-auth secret %q can't be used with the %s url %q", secret.Name, endpoint.Protocol, repo)
+		}
+		return &githttp.BasicAuth{
+			Username: string(secret.Data[corev1.BasicAuthUsernameKey]),
+			Password: string(secret.Data[corev1.BasicAuthPasswordKey]),
+		}, nil
+	case corev1.SecretTypeSSHAuth:
+		if endpoint.Protocol != "ssh" {
+			return nil, fmt.Errorf("ssh-auth secret %q can't be used with the %s url %q", secret.Name, endpoint.Protocol, repo)
+		}
+		user := endpoint.User
+		if user == "" {
+			user = defaultSSHUser
+		}
+		auth, err := gitssh.NewPublicKeys(user, secret.Data[corev1.SSHAuthPrivateKey], "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in secret %q: %w", corev1.SSHAuthPrivateKey, secret.Name, err)
+		}
+		if auth.HostKeyCallback, err = hostKeyCallback(ctx, secret); err != nil {
+			return nil, err
+		}
+		return auth, nil
+	default:
+		token, ok := secret.Data[tokenKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %q must be of type %s or %s, or have a %q key", secret.Name, corev1.SecretTypeBasicAuth, corev1.SecretTypeSSHAuth, tokenKey)
+		}
+		if endpoint.Protocol != "http" && endpoint.Protocol != "https" {
+			return nil, fmt.Errorf("token secret %q can't be used with the %s url %q", secret.Name, endpoint.Protocol, repo)
+		}
+		return &githttp.TokenAuth{Token: string(token)}, nil
+	}
+}
+
+// secretMatchesHost returns true if the given secret has no
+// tekton.dev/git-* annotations, or if one of them is for the given host.
+func secretMatchesHost(secret *corev1.Secret, host string) bool {
+	annotated := false
+	for k, v := range secret.Annotations {
+		if !strings.HasPrefix(k, secretAnnotationPrefix) {
+			continue
+		}
+		annotated = true
+		// The annotations are urls for basic-auth secrets and hosts,
+		// with an optional port, for ssh-auth secrets.
+		if u, err := url.Parse(v); err == nil && u.Host != "" {
+			v = u.Host
+		}
+		if h, _, err := net.SplitHostPort(v); err == nil {
+			v = h
+		}
+		if v == host {
+			return true
+		}
+	}
+	return !annotated
+}
+
+// hostKeyCallback returns the callback checking the key of the ssh
+// host against the known hosts of the given secret. Without known
+// hosts, the host key isn't checked unless the
+// require-git-ssh-secret-known-hosts feature flag is set.
+func hostKeyCallback(ctx context.Context, secret *corev1.Secret) (ssh.HostKeyCallback, error) {
+	knownHosts, ok := secret.Data[sshKnownHostsKey]
+	if !ok {
+		if config.FromContextOrDefaults(ctx).FeatureFlags.RequireGitSSHSecretKnownHosts {
+			return nil, fmt.Errorf("ssh-auth secret %q must have %q included when feature flag \"require-git-ssh-secret-known-hosts\" is set to true",
+				secret.Name, sshKnownHostsKey)
+		}
+		return ssh.InsecureIgnoreHostKey(), nil // #nosec G106 -- known_hosts is optional unless required by the feature flag
+	}
+	// The known hosts can only be read from files.
+	f, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err := f.Write(knownHosts); err != nil {
+		return nil, err
+	}
+	callback, err := gitssh.NewKnownHostsCallback(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s in secret %q: %w", sshKnownHostsKey, secret.Name, err)
+	}
+	return callback, nil
+}
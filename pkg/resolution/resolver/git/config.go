@@ -16,6 +16,12 @@ limitations under the License.
 
 package git
 
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
 // ConfigFieldTimeout is the configuration field name for controlling
 // the maximum duration of a resolution request for a file from git.
 const ConfigFieldTimeout = "fetch-timeout"
@@ -27,3 +33,61 @@ const ConfigURL = "default-url"
 // ConfigRevision is the configuration field name for controlling
 // the revision to fetch the remote resource from.
 const ConfigRevision = "default-revision"
+
+// ConfigSecret is the configuration field name for controlling the
+// name of the secret holding the credentials to fetch the remote
+// resource with, when the request doesn't specify one. It is looked up
+// in the namespace of each resolution request and is optional there.
+const ConfigSecret = "default-secret"
+
+// ConfigOrg is the configuration field name for controlling the
+// organization of the repo to fetch the remote resource from with the
+// API of its SCM provider, when the request doesn't specify one.
+const ConfigOrg = "default-org"
+
+// ConfigSCMType is the configuration field name for controlling the
+// type of the SCM provider whose API to fetch the remote resource with,
+// when the request doesn't specify one.
+const ConfigSCMType = "scm-type"
+
+// ConfigAPITokenSecretName is the configuration field name for
+// controlling the name of the secret holding the token to call the API
+// of the SCM provider with, when the request doesn't specify one. It is
+// looked up in the namespace of each request and is optional there.
+const ConfigAPITokenSecretName = "api-token-secret-name"
+
+// ConfigAPITokenSecretKey is the configuration field name for
+// controlling the key of the token in the secret, when the request
+// doesn't specify one.
+const ConfigAPITokenSecretKey = "api-token-secret-key"
+
+// ConfigServerURLSuffix is the suffix of the configuration field names
+// for controlling the url of the server of each type of SCM provider,
+// such as github-server-url for GitHub Enterprise. Without it, the
+// public service of the provider is used, except for gitea which has
+// none.
+const ConfigServerURLSuffix = "-server-url"
+
+// ConfigRepoCacheMaxSize is the configuration field name for the
+// maximum total size on disk of the cached repositories, as a quantity
+// such as "1Gi". The least recently used repositories are evicted when
+// it's exceeded, and "0" disables the cache.
+const ConfigRepoCacheMaxSize = "repo-cache-max-size"
+
+// defaultRepoCacheMaxSize is the maximum total size on disk of the
+// cached repositories when repo-cache-max-size isn't set.
+const defaultRepoCacheMaxSize = 1 << 30
+
+// repoCacheMaxSize returns the maximum total size on disk of the cached
+// repositories.
+func repoCacheMaxSize(conf map[string]string) (int64, error) {
+	sizeString, ok := conf[ConfigRepoCacheMaxSize]
+	if !ok || sizeString == "" {
+		return defaultRepoCacheMaxSize, nil
+	}
+	size, err := resource.ParseQuantity(sizeString)
+	if err != nil || size.Sign() < 0 {
+		return 0, fmt.Errorf("invalid %s %q in the git resolver config", ConfigRepoCacheMaxSize, sizeString)
+	}
+	return size.Value(), nil
+}
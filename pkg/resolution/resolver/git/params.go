@@ -24,3 +24,25 @@ const PathParam string = "pathInRepo"
 
 // RevisionParam is the git revision that a file should be fetched from
 const RevisionParam string = "revision"
+
+// SecretParam is the name of the secret, in the namespace of the
+// resolution request, holding the credentials to fetch the repo with,
+// or the token to call the API of its SCM provider with
+const SecretParam string = "secret"
+
+// SecretKeyParam is the key of the token in the secret, when fetching a
+// file with the API of the SCM provider of the repo
+const SecretKeyParam string = "secretKey"
+
+// OrgParam is the organization, or owner, of the repo to fetch a file
+// from with the API of its SCM provider
+const OrgParam string = "org"
+
+// RepoParam is the name of the repo to fetch a file from with the API of
+// its SCM provider. Setting it fetches only the file at pathInRepo
+// instead of cloning the repo.
+const RepoParam string = "repo"
+
+// ScmTypeParam is the type of the SCM provider of the repo: github,
+// gitlab, gitea or bitbucket
+const ScmTypeParam string = "scmType"
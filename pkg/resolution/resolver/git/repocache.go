@@ -0,0 +1,448 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	git "github.com/go-git/go-git/v5"
+	gitcfg "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"knative.dev/pkg/logging"
+)
+
+// remoteName is the name of the remote of the cached repositories.
+const remoteName = "origin"
+
+// repoDirNameRegex matches the names of the directories of the cached
+// repositories, the hex encoded SHA-256 hash of their url.
+var repoDirNameRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// repoCache is a cache on disk of bare repositories, one per url, from
+// which the files are read without a checkout. The repositories are
+// fetched incrementally, and evicted in least recently used order when
+// their total size on disk exceeds the configured maximum.
+type repoCache struct {
+	dir string
+
+	mu sync.Mutex
+	// repos are the cached repositories by url.
+	repos map[string]*cachedRepo
+	// lru lists the cached repositories, most recently used first.
+	lru *list.List
+	// size is the total size on disk of the cached repositories.
+	size int64
+}
+
+// cachedRepo is a bare repository of the cache.
+type cachedRepo struct {
+	url string
+	dir string
+
+	// lock serializes the fetches and reads of the repository.
+	lock sync.Mutex
+
+	// users, size and elem are guarded by the mutex of the cache.
+	users int
+	size  int64
+	elem  *list.Element
+}
+
+// newRepoCache returns a cache of the repositories in the given
+// directory, with the repositories already there from a previous run.
+func newRepoCache(ctx context.Context, dir string) *repoCache {
+	c := &repoCache{
+		dir:   dir,
+		repos: map[string]*cachedRepo{},
+		lru:   list.New(),
+	}
+	c.load(ctx)
+	return c
+}
+
+// load adds the repositories of the directory of the cache to it, from
+// the most to the least recently modified. The entries named like the
+// cached repositories that can't be loaded, such as a repository whose
+// creation was interrupted, are removed. Any other entry is left alone,
+// since the directory may be shared.
+func (c *repoCache) load(ctx context.Context) {
+	logger := logging.FromContext(ctx)
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	type loaded struct {
+		repo    *cachedRepo
+		modTime int64
+	}
+	var repos []loaded
+	for _, e := range entries {
+		dir := filepath.Join(c.dir, e.Name())
+		if !repoDirNameRegex.MatchString(e.Name()) {
+			logger.Warnf("Skipping %q in the git resolver cache directory, which isn't a cached repository", dir)
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || !e.IsDir() {
+			logger.Infof("Removing %q from the git resolver cache directory, which isn't a repository", dir)
+			os.RemoveAll(dir)
+			continue
+		}
+		url, err := repoURL(dir)
+		if err != nil || dir != c.repoDir(url) {
+			logger.Infof("Removing the invalid cached repository %q", dir)
+			os.RemoveAll(dir)
+			continue
+		}
+		repos = append(repos, loaded{
+			repo:    &cachedRepo{url: url, dir: dir, size: dirSize(dir)},
+			modTime: info.ModTime().UnixNano(),
+		})
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].modTime > repos[j].modTime })
+	for _, l := range repos {
+		l.repo.elem = c.lru.PushBack(l.repo)
+		c.repos[l.repo.url] = l.repo
+		c.size += l.repo.size
+	}
+}
+
+// repoDir returns the directory of the repository of the given url.
+func (c *repoCache) repoDir(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// acquire returns the cached repository of the given url, locked for
+// the caller until it's released.
+func (c *repoCache) acquire(url string) *cachedRepo {
+	c.mu.Lock()
+	repo, ok := c.repos[url]
+	if ok {
+		c.lru.MoveToFront(repo.elem)
+	} else {
+		repo = &cachedRepo{url: url, dir: c.repoDir(url)}
+		repo.elem = c.lru.PushFront(repo)
+		c.repos[url] = repo
+	}
+	repo.users++
+	c.mu.Unlock()
+
+	repo.lock.Lock()
+	return repo
+}
+
+// release unlocks the given repository, records its size on disk, and
+// evicts the least recently used repositories not in use until the
+// total size of the cache is at most maxSize.
+func (c *repoCache) release(repo *cachedRepo, maxSize int64) {
+	size := dirSize(repo.dir)
+	repo.lock.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repo.users--
+	c.size += size - repo.size
+	repo.size = size
+	for e := c.lru.Back(); e != nil && c.size > maxSize; {
+		prev := e.Prev()
+		if r := e.Value.(*cachedRepo); r.users == 0 {
+			os.RemoveAll(r.dir)
+			c.size -= r.size
+			c.lru.Remove(e)
+			delete(c.repos, r.url)
+		}
+		e = prev
+	}
+}
+
+// readFile fetches the given revision into the repository if needed,
+// and returns the hash of its commit and the content of the file at the
+// given path in it. The remote is always contacted with the given auth,
+// so that only the requests allowed to read the repository can read the
+// cached objects.
+func (repo *cachedRepo) readFile(ctx context.Context, revision, path string, auth transport.AuthMethod) (plumbing.Hash, []byte, error) {
+	repository, err := repo.open()
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	remote, err := repository.Remote(remoteName)
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("clone error: %w", err)
+	}
+
+	repository, hash, err := repo.fetchRevision(ctx, repository, refs, revision, auth)
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	commit, err := repository.CommitObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("revision error: %w", err)
+	}
+	file, err := commit.File(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return plumbing.ZeroHash, nil, fmt.Errorf("error opening file %q: %w", path, fs.ErrNotExist)
+		}
+		return plumbing.ZeroHash, nil, fmt.Errorf("error opening file %q: %w", path, err)
+	}
+	r, err := file.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("error opening file %q: %w", path, err)
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("error reading file %q: %w", path, err)
+	}
+	return hash, content, nil
+}
+
+// fetchRevision fetches the given revision into the repository, and
+// returns the hash of its commit and the repository, which is created
+// again when a shallow repository needs its full history. A commit SHA
+// or a branch or tag name is fetched alone, with a depth of 1 into an
+// empty or shallow repository. Any other revision, or a commit SHA the
+// remote doesn't allow fetching, requires the full history of the
+// repository.
+func (repo *cachedRepo) fetchRevision(ctx context.Context, repository *git.Repository, refs []*plumbing.Reference, revision string, auth transport.AuthMethod) (*git.Repository, plumbing.Hash, error) {
+	empty, shallow, err := repoState(repository)
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+	depth := 0
+	if empty || shallow {
+		depth = 1
+	}
+	if shallow {
+		if err := pruneStaleRefs(repository, refs); err != nil {
+			return nil, plumbing.ZeroHash, err
+		}
+	}
+
+	if commitSHARegex.MatchString(revision) {
+		hash := plumbing.NewHash(revision)
+		if _, err := repository.CommitObject(hash); err == nil {
+			return repository, hash, nil
+		}
+		refSpec := gitcfg.RefSpec(fmt.Sprintf("+%s:refs/commits/%s", revision, revision))
+		err := fetch(ctx, repository, refSpec, depth, auth)
+		if err == nil {
+			return repository, hash, nil
+		}
+		if !errors.Is(err, git.ErrExactSHA1NotSupported) {
+			return nil, plumbing.ZeroHash, err
+		}
+	} else if ref := findRef(refs, revision); ref != nil {
+		refSpec := gitcfg.RefSpec(fmt.Sprintf("+%s:%s", ref.Name(), ref.Name()))
+		if err := fetch(ctx, repository, refSpec, depth, auth); err != nil {
+			return nil, plumbing.ZeroHash, err
+		}
+		h, err := repository.ResolveRevision(plumbing.Revision(ref.Name()))
+		if err != nil {
+			return nil, plumbing.ZeroHash, fmt.Errorf("revision error: %w", err)
+		}
+		return repository, *h, nil
+	}
+
+	// The history of a shallow repository can't be deepened, so it's
+	// fetched again with its full history.
+	if shallow {
+		if repository, err = repo.reset(); err != nil {
+			return nil, plumbing.ZeroHash, err
+		}
+	}
+	for _, refSpec := range []gitcfg.RefSpec{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"} {
+		if err := fetch(ctx, repository, refSpec, 0, auth); err != nil {
+			return nil, plumbing.ZeroHash, err
+		}
+	}
+	h, err := repository.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("revision error: %w", err)
+	}
+	return repository, *h, nil
+}
+
+// open opens the repository, creating it if needed.
+func (repo *cachedRepo) open() (*git.Repository, error) {
+	repository, err := git.PlainOpen(repo.dir)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return repo.reset()
+	}
+	return repository, err
+}
+
+// reset creates the repository again, without any objects.
+func (repo *cachedRepo) reset() (*git.Repository, error) {
+	if err := os.RemoveAll(repo.dir); err != nil {
+		return nil, err
+	}
+	repository, err := git.PlainInit(repo.dir, true)
+	if err != nil {
+		return nil, fmt.Errorf("error creating repository for %q: %w", repo.url, err)
+	}
+	if _, err := repository.CreateRemote(&gitcfg.RemoteConfig{Name: remoteName, URLs: []string{repo.url}}); err != nil {
+		return nil, fmt.Errorf("error creating repository for %q: %w", repo.url, err)
+	}
+	return repository, nil
+}
+
+// repoState returns whether the repository has no refs yet, and whether
+// it's shallow.
+func repoState(repository *git.Repository) (empty bool, shallow bool, err error) {
+	shallows, err := repository.Storer.Shallow()
+	if err != nil {
+		return false, false, err
+	}
+	iter, err := repository.References()
+	if err != nil {
+		return false, false, err
+	}
+	defer iter.Close()
+	empty = true
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference {
+			empty = false
+			return storer.ErrStop
+		}
+		return nil
+	})
+	return empty, len(shallows) > 0, err
+}
+
+// pruneStaleRefs removes the refs of the repository pointing to commits
+// the remote doesn't advertise, such as the previous commit of a branch
+// or a commit fetched by SHA. go-git walks the history of those refs to
+// negotiate a fetch, which fails past the shallow commits. Their objects
+// are kept, so the commits can still be read by hash.
+func pruneStaleRefs(repository *git.Repository, refs []*plumbing.Reference) error {
+	advertised := map[plumbing.Hash]bool{}
+	for _, ref := range refs {
+		advertised[ref.Hash()] = true
+	}
+	iter, err := repository.References()
+	if err != nil {
+		return err
+	}
+	var stale []plumbing.ReferenceName
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference && !advertised[ref.Hash()] {
+			stale = append(stale, ref.Name())
+		}
+		return nil
+	})
+	iter.Close()
+	if err != nil {
+		return err
+	}
+	for _, name := range stale {
+		if err := repository.Storer.RemoveReference(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetch fetches the given refspec from the remote of the repository.
+func fetch(ctx context.Context, repository *git.Repository, refSpec gitcfg.RefSpec, depth int, auth transport.AuthMethod) error {
+	err := repository.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []gitcfg.RefSpec{refSpec},
+		Depth:      depth,
+		Auth:       auth,
+		Tags:       git.NoTags,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("unexpected fetch error: %w", err)
+	}
+	return nil
+}
+
+// findRef returns the ref of the remote the given revision refers to,
+// if any, with the same precedence as git.
+func findRef(refs []*plumbing.Reference, revision string) *plumbing.Reference {
+	byName := map[plumbing.ReferenceName]*plumbing.Reference{}
+	for _, ref := range refs {
+		byName[ref.Name()] = ref
+	}
+	for _, name := range []string{revision, "refs/" + revision, "refs/tags/" + revision, "refs/heads/" + revision} {
+		ref, ok := byName[plumbing.ReferenceName(name)]
+		if !ok {
+			continue
+		}
+		if ref.Type() == plumbing.SymbolicReference {
+			if ref, ok = byName[ref.Target()]; !ok {
+				continue
+			}
+		}
+		return ref
+	}
+	return nil
+}
+
+// repoURL returns the url of the remote of the repository in the given
+// directory.
+func repoURL(dir string) (string, error) {
+	repository, err := git.PlainOpen(dir)
+	if err != nil {
+		return "", err
+	}
+	remote, err := repository.Remote(remoteName)
+	if err != nil {
+		return "", err
+	}
+	if urls := remote.Config().URLs; len(urls) == 1 {
+		return urls[0], nil
+	}
+	return "", fmt.Errorf("repository in %q has no single url", dir)
+}
+
+// dirSize returns the total size of the files in the given directory.
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && !d.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
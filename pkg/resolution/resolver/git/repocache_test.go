@@ -0,0 +1,287 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func resolveContent(ctx context.Context, t *testing.T, resolver *Resolver, repoPath, revision string) string {
+	t.Helper()
+	output, err := resolver.Resolve(ctx, map[string]string{
+		URLParam:      repoPath,
+		PathParam:     "foo/bar.yaml",
+		RevisionParam: revision,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error resolving %q: %v", revision, err)
+	}
+	return string(output.Data())
+}
+
+func isShallow(t *testing.T, dir string) bool {
+	t.Helper()
+	repository, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("error opening cached repo: %v", err)
+	}
+	shallows, err := repository.Storer.Shallow()
+	if err != nil {
+		t.Fatalf("error reading shallow commits of cached repo: %v", err)
+	}
+	return len(shallows) > 0
+}
+
+func TestResolveRepoCacheIncrementalFetch(t *testing.T) {
+	withTemporaryGitConfig(t)
+	repoPath, commits := createTestRepo(t, []commitForRepo{{Dir: "foo", Filename: "bar.yaml", Content: "v1"}})
+	resolver := &Resolver{CacheDir: t.TempDir()}
+	ctx := framework.InjectResolverConfigToContext(resolverContext(), map[string]string{})
+	repoDir := resolver.getRepoCache(ctx).repoDir(repoPath)
+
+	if content := resolveContent(ctx, t, resolver, repoPath, "master"); content != "v1" {
+		t.Errorf("expected v1 but got %q", content)
+	}
+	if !isShallow(t, repoDir) {
+		t.Errorf("expected the branch to be fetched with a depth of 1")
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		t.Fatalf("error opening test repo: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("error getting test worktree: %v", err)
+	}
+	writeAndCommitToTestRepo(t, worktree, repoPath, "foo", "bar.yaml", []byte("v2"))
+
+	// The new commit of the branch is fetched alone into the shallow
+	// repo, which keeps the commit fetched before.
+	if content := resolveContent(ctx, t, resolver, repoPath, "master"); content != "v2" {
+		t.Errorf("expected the new commit of the branch to be fetched but got %q", content)
+	}
+	if !isShallow(t, repoDir) {
+		t.Errorf("expected the new commit of the branch to be fetched with a depth of 1")
+	}
+	cached, err := git.PlainOpen(repoDir)
+	if err != nil {
+		t.Fatalf("error opening cached repo: %v", err)
+	}
+	if _, err := cached.CommitObject(plumbing.NewHash(commits[plumbing.Master.Short()][0])); err != nil {
+		t.Errorf("expected the shallow repo to be fetched into rather than cloned again: %v", err)
+	}
+
+	// The commit is already in the cached repo.
+	if content := resolveContent(ctx, t, resolver, repoPath, commits[plumbing.Master.Short()][0]); content != "v1" {
+		t.Errorf("expected v1 but got %q", content)
+	}
+
+	// Any other revision requires the full history, which is then
+	// fetched incrementally.
+	if content := resolveContent(ctx, t, resolver, repoPath, "master~1"); content != "v1" {
+		t.Errorf("expected v1 but got %q", content)
+	}
+	if isShallow(t, repoDir) {
+		t.Errorf("expected the full history of the branch to be fetched")
+	}
+	writeAndCommitToTestRepo(t, worktree, repoPath, "foo", "bar.yaml", []byte("v3"))
+	if content := resolveContent(ctx, t, resolver, repoPath, "master"); content != "v3" {
+		t.Errorf("expected the new commit of the branch to be fetched but got %q", content)
+	}
+	if isShallow(t, repoDir) {
+		t.Errorf("expected the repo with its full history not to become shallow")
+	}
+}
+
+func TestResolveRepoCacheExactCommitFetch(t *testing.T) {
+	withTemporaryGitConfig(t)
+	repoPath, commits := createTestRepo(t, []commitForRepo{
+		{Dir: "foo", Filename: "bar.yaml", Content: "v1"},
+		{Dir: "foo", Filename: "bar.yaml", Content: "v2"},
+	})
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		t.Fatalf("error opening test repo: %v", err)
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatalf("error reading test repo config: %v", err)
+	}
+	cfg.Raw.Section("uploadpack").SetOption("allowReachableSHA1InWant", "true")
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("error writing test repo config: %v", err)
+	}
+
+	resolver := &Resolver{CacheDir: t.TempDir()}
+	ctx := framework.InjectResolverConfigToContext(resolverContext(), map[string]string{})
+	if content := resolveContent(ctx, t, resolver, repoPath, commits[plumbing.Master.Short()][0]); content != "v1" {
+		t.Errorf("expected v1 but got %q", content)
+	}
+	if !isShallow(t, resolver.getRepoCache(ctx).repoDir(repoPath)) {
+		t.Errorf("expected the commit to be fetched alone with a depth of 1")
+	}
+	// Another commit is fetched alone into the shallow repo.
+	if content := resolveContent(ctx, t, resolver, repoPath, commits[plumbing.Master.Short()][1]); content != "v2" {
+		t.Errorf("expected v2 but got %q", content)
+	}
+	if !isShallow(t, resolver.getRepoCache(ctx).repoDir(repoPath)) {
+		t.Errorf("expected the other commit to be fetched alone with a depth of 1")
+	}
+}
+
+func TestResolveRepoCacheConcurrently(t *testing.T) {
+	withTemporaryGitConfig(t)
+	repoPath, _ := createTestRepo(t, []commitForRepo{{Dir: "foo", Filename: "bar.yaml", Content: "v1"}})
+	resolver := &Resolver{CacheDir: t.TempDir()}
+	ctx := framework.InjectResolverConfigToContext(resolverContext(), map[string]string{})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := resolver.Resolve(ctx, map[string]string{
+				URLParam:      repoPath,
+				PathParam:     "foo/bar.yaml",
+				RevisionParam: "master",
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error resolving concurrently: %v", err)
+		}
+	}
+}
+
+func TestResolveRepoCacheMaxSize(t *testing.T) {
+	withTemporaryGitConfig(t)
+	repoPath, _ := createTestRepo(t, []commitForRepo{{Dir: "foo", Filename: "bar.yaml", Content: "v1"}})
+	cacheDir := t.TempDir()
+	resolver := &Resolver{CacheDir: cacheDir}
+
+	ctx := framework.InjectResolverConfigToContext(resolverContext(), map[string]string{ConfigRepoCacheMaxSize: "0"})
+	if content := resolveContent(ctx, t, resolver, repoPath, "master"); content != "v1" {
+		t.Errorf("expected v1 but got %q", content)
+	}
+	if entries, err := os.ReadDir(cacheDir); err != nil || len(entries) != 0 {
+		t.Errorf("expected no cached repo but got %v, %v", entries, err)
+	}
+
+	ctx = framework.InjectResolverConfigToContext(resolverContext(), map[string]string{ConfigRepoCacheMaxSize: "-1"})
+	_, err := resolver.Resolve(ctx, map[string]string{URLParam: repoPath, PathParam: "foo/bar.yaml", RevisionParam: "master"})
+	if err == nil || err.Error() != `invalid repo-cache-max-size "-1" in the git resolver config` {
+		t.Errorf("expected invalid repo-cache-max-size error but got %v", err)
+	}
+}
+
+func TestRepoCacheEviction(t *testing.T) {
+	cache := newRepoCache(context.Background(), t.TempDir())
+	write := func(url string) *cachedRepo {
+		t.Helper()
+		repo := cache.acquire(url)
+		if err := os.MkdirAll(repo.dir, 0755); err != nil {
+			t.Fatalf("error creating repo dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repo.dir, "data"), make([]byte, 100), 0644); err != nil {
+			t.Fatalf("error writing repo data: %v", err)
+		}
+		return repo
+	}
+	cached := func() []string {
+		var urls []string
+		for url := range cache.repos {
+			urls = append(urls, url)
+		}
+		sort.Strings(urls)
+		return urls
+	}
+
+	for _, url := range []string{"a", "b", "c"} {
+		cache.release(write(url), 250)
+	}
+	if d := cmp.Diff([]string{"b", "c"}, cached()); d != "" {
+		t.Errorf("expected the least recently used repo to be evicted: %s", diff.PrintWantGot(d))
+	}
+	if _, err := os.Stat(cache.repoDir("a")); !os.IsNotExist(err) {
+		t.Errorf("expected the evicted repo to be removed but got %v", err)
+	}
+
+	cache.release(cache.acquire("b"), 250)
+	cache.release(write("d"), 250)
+	if d := cmp.Diff([]string{"b", "d"}, cached()); d != "" {
+		t.Errorf("expected the least recently used repo to be evicted: %s", diff.PrintWantGot(d))
+	}
+
+	inUse := cache.acquire("b")
+	cache.release(write("e"), 0)
+	if d := cmp.Diff([]string{"b"}, cached()); d != "" {
+		t.Errorf("expected the repo in use not to be evicted: %s", diff.PrintWantGot(d))
+	}
+	cache.release(inUse, 0)
+	if len(cache.repos) != 0 || cache.size != 0 {
+		t.Errorf("expected all the repos to be evicted but got %v of size %d", cached(), cache.size)
+	}
+}
+
+func TestRepoCacheLoad(t *testing.T) {
+	withTemporaryGitConfig(t)
+	repoPath, _ := createTestRepo(t, []commitForRepo{{Dir: "foo", Filename: "bar.yaml", Content: "v1"}})
+	cacheDir := t.TempDir()
+	resolver := &Resolver{CacheDir: cacheDir}
+	ctx := framework.InjectResolverConfigToContext(resolverContext(), map[string]string{})
+	resolveContent(ctx, t, resolver, repoPath, "master")
+	if err := os.WriteFile(filepath.Join(cacheDir, "unrelated"), []byte("unrelated"), 0644); err != nil {
+		t.Fatalf("error writing unrelated file: %v", err)
+	}
+	// An interrupted creation of a cached repository.
+	partialDir := resolver.getRepoCache(ctx).repoDir("https://example.com/partial.git")
+	if err := os.MkdirAll(partialDir, 0755); err != nil {
+		t.Fatalf("error creating partial repo dir: %v", err)
+	}
+
+	cache := newRepoCache(ctx, cacheDir)
+	repo, ok := cache.repos[repoPath]
+	if !ok {
+		t.Fatalf("expected the cached repo to be loaded but got %v", cache.repos)
+	}
+	if repo.size == 0 || cache.size != repo.size || repo.size != resolver.getRepoCache(ctx).size {
+		t.Errorf("expected the size of the loaded repo to be %d but got %d", resolver.getRepoCache(ctx).size, repo.size)
+	}
+	if _, err := os.Stat(partialDir); !os.IsNotExist(err) {
+		t.Errorf("expected the partial repo to be removed but got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "unrelated")); err != nil {
+		t.Errorf("expected the unrelated file to be kept but got %v", err)
+	}
+}
@@ -17,22 +17,21 @@ limitations under the License.
 package git
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"io"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/go-git/go-billy/v5/memfs"
-	git "github.com/go-git/go-git/v5"
-	gitcfg "github.com/go-git/go-git/v5/config"
-	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/client/injection/kube/client"
 )
 
 const disabledError = "cannot handle resolution request, enable-git-resolver feature flag not true"
@@ -48,13 +47,22 @@ const GitResolverName string = "Git"
 // YAMLContentType is the content type to use when returning yaml
 const YAMLContentType string = "application/x-yaml"
 
-var _ framework.Resolver = &Resolver{}
+var _ framework.StringParamsResolver = &Resolver{}
 
-// Resolver implements a framework.Resolver that can fetch files from git.
-type Resolver struct{}
+// Resolver implements a framework.StringParamsResolver that can fetch files from git.
+type Resolver struct {
+	// CacheDir is the directory of the cache of the repositories
+	// fetched by the resolver. A temporary directory is used if empty.
+	CacheDir string
+
+	kubeClientSet kubernetes.Interface
+	repoCacheOnce sync.Once
+	repoCache     *repoCache
+}
 
 // Initialize performs any setup required by the gitresolver.
 func (r *Resolver) Initialize(ctx context.Context) error {
+	r.kubeClientSet = client.Get(ctx)
 	return nil
 }
 
@@ -96,6 +104,12 @@ func (r *Resolver) ValidateParams(ctx context.Context, params map[string]string)
 	if len(missing) > 0 {
 		return fmt.Errorf("missing %v", strings.Join(missing, ", "))
 	}
+	if params[URLParam] != "" && params[RepoParam] != "" {
+		return fmt.Errorf("cannot get git resource from both %s and %s", URLParam, RepoParam)
+	}
+	if params[SecretKeyParam] != "" && params[RepoParam] == "" {
+		return fmt.Errorf("cannot set %s without %s, the credentials of a cloned repo are read from the keys of the type of their secret", SecretKeyParam, RepoParam)
+	}
 
 	// TODO(sbwsg): validate repo url is well-formed, git:// or https://
 	// TODO(sbwsg): validate pathInRepo is valid relative pathInRepo
@@ -110,6 +124,10 @@ func (r *Resolver) Resolve(ctx context.Context, params map[string]string) (frame
 		return nil, errors.New(disabledError)
 	}
 
+	if params[RepoParam] != "" {
+		return r.resolveAPIGit(ctx, params)
+	}
+
 	conf := framework.GetResolverConfigFromContext(ctx)
 	repo := params[URLParam]
 	if repo == "" {
@@ -128,63 +146,47 @@ func (r *Resolver) Resolve(ctx context.Context, params map[string]string) (frame
 		}
 	}
 
-	cloneOpts := &git.CloneOptions{
-		URL: repo,
-	}
-	filesystem := memfs.New()
-	repository, err := git.Clone(memory.NewStorage(), filesystem, cloneOpts)
-	if err != nil {
-		return nil, fmt.Errorf("clone error: %w", err)
-	}
-
-	// try fetch the branch when the given revision refers to a branch name
-	refSpec := gitcfg.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s", revision, revision))
-	err = repository.Fetch(&git.FetchOptions{
-		RefSpecs: []gitcfg.RefSpec{refSpec},
-	})
-	if err != nil {
-		var fetchErr git.NoMatchingRefSpecError
-		if !errors.As(err, &fetchErr) {
-			return nil, fmt.Errorf("unexpected fetch error: %v", err)
-		}
-	}
-
-	w, err := repository.Worktree()
+	auth, err := r.getAuth(ctx, repo, params)
 	if err != nil {
-		return nil, fmt.Errorf("worktree error: %v", err)
+		return nil, err
 	}
 
-	h, err := repository.ResolveRevision(plumbing.Revision(revision))
+	maxSize, err := repoCacheMaxSize(conf)
 	if err != nil {
-		return nil, fmt.Errorf("revision error: %v", err)
-	}
-
-	err = w.Checkout(&git.CheckoutOptions{
-		Hash: *h,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("checkout error: %v", err)
+		return nil, err
 	}
+	cache := r.getRepoCache(ctx)
+	cachedRepo := cache.acquire(repo)
+	defer cache.release(cachedRepo, maxSize)
 
 	path := params[PathParam]
-
-	f, err := filesystem.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("error opening file %q: %v", path, err)
-	}
-
-	buf := &bytes.Buffer{}
-	_, err = io.Copy(buf, f)
+	commit, content, err := cachedRepo.readFile(ctx, revision, path, auth)
 	if err != nil {
-		return nil, fmt.Errorf("error reading file %q: %v", path, err)
+		return nil, err
 	}
 
 	return &ResolvedGitResource{
 		Revision: revision,
-		Content:  buf.Bytes(),
+		Content:  content,
+		URL:      repo,
+		Commit:   commit.String(),
+		Path:     path,
 	}, nil
 }
 
+// getRepoCache returns the cache of the repositories fetched by the
+// resolver, in the CacheDir of the resolver or a temporary directory.
+func (r *Resolver) getRepoCache(ctx context.Context) *repoCache {
+	r.repoCacheOnce.Do(func() {
+		dir := r.CacheDir
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "git-resolver-cache")
+		}
+		r.repoCache = newRepoCache(ctx, dir)
+	})
+	return r.repoCache
+}
+
 var _ framework.ConfigWatcher = &Resolver{}
 
 // GetConfigName returns the name of the git resolver's configmap.
@@ -208,6 +210,31 @@ func (r *Resolver) GetResolutionTimeout(ctx context.Context, defaultTimeout time
 	return defaultTimeout
 }
 
+var _ framework.StringParamsImmutableResolution = &Resolver{}
+
+// commitSHARegex matches the full SHA-1 hash of a commit, the only kind of
+// hash go-git can fetch and the SCM providers return.
+var commitSHARegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// IsImmutable returns true if the revision param is a full commit SHA,
+// in which case the file fetched from git can't change.
+func (r *Resolver) IsImmutable(_ context.Context, params map[string]string) bool {
+	return commitSHARegex.MatchString(params[RevisionParam])
+}
+
+var _ framework.CacheKeyConfig = &Resolver{}
+
+// CacheKeyConfigFields returns the configuration fields defaulting the
+// repo a file is fetched from, so that files cached for a commit are
+// fetched again from the right repo once they change.
+func (r *Resolver) CacheKeyConfigFields(context.Context) []string {
+	fields := []string{ConfigURL, ConfigOrg, ConfigSCMType}
+	for _, scmType := range supportedSCMTypes {
+		fields = append(fields, scmType+ConfigServerURLSuffix)
+	}
+	return fields
+}
+
 func (r *Resolver) isDisabled(ctx context.Context) bool {
 	cfg := config.FromContextOrDefaults(ctx)
 	if cfg.FeatureFlags.EnableGitResolver {
@@ -222,6 +249,15 @@ func (r *Resolver) isDisabled(ctx context.Context) bool {
 type ResolvedGitResource struct {
 	Revision string
 	Content  []byte
+	// Org and Repo are set when the file was fetched with the API of
+	// the SCM provider of the repo.
+	Org  string
+	Repo string
+	// URL, Commit and Path identify the file that was fetched. Commit
+	// is the commit the requested revision resolved to.
+	URL    string
+	Commit string
+	Path   string
 }
 
 var _ framework.ResolvedResource = &ResolvedGitResource{}
@@ -234,8 +270,34 @@ func (r *ResolvedGitResource) Data() []byte {
 // Annotations returns the metadata that accompanies the file fetched
 // from git.
 func (r *ResolvedGitResource) Annotations() map[string]string {
-	return map[string]string{
+	annotations := map[string]string{
 		AnnotationKeyRevision:                     r.Revision,
 		resolutioncommon.AnnotationKeyContentType: YAMLContentType,
 	}
+	if r.Org != "" {
+		annotations[AnnotationKeyOrg] = r.Org
+	}
+	if r.Repo != "" {
+		annotations[AnnotationKeyRepo] = r.Repo
+	}
+	if r.URL != "" {
+		annotations[resolutioncommon.AnnotationKeySourceURI] = "git+" + r.URL
+	}
+	if r.Commit != "" {
+		annotations[resolutioncommon.AnnotationKeySourceDigest] = commitDigest(r.Commit)
+	}
+	if r.Path != "" {
+		annotations[resolutioncommon.AnnotationKeySourceEntryPoint] = r.Path
+	}
+	return annotations
+}
+
+// commitDigest returns the digest of a commit in the "<algorithm>:<hex>"
+// format: commits of repositories using SHA-256 object names are 64
+// characters long, the others use SHA-1.
+func commitDigest(commit string) string {
+	if len(commit) == 64 {
+		return "sha256:" + commit
+	}
+	return "sha1:" + commit
 }
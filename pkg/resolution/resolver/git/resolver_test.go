@@ -18,29 +18,48 @@ package git
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
-	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	frtesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/system"
@@ -104,6 +123,31 @@ func TestValidateParamsMissing(t *testing.T) {
 	}
 }
 
+func TestValidateParamsConflictingRepo(t *testing.T) {
+	resolver := Resolver{}
+	params := map[string]string{
+		URLParam:  "https://github.com/tektoncd/catalog.git",
+		RepoParam: "catalog",
+		PathParam: "task/git-clone/0.6/git-clone.yaml",
+	}
+	if err := resolver.ValidateParams(resolverContext(), params); err == nil {
+		t.Fatalf("expected err for both url and repo params")
+	}
+}
+
+func TestValidateParamsSecretKeyWithoutRepo(t *testing.T) {
+	resolver := Resolver{}
+	params := map[string]string{
+		URLParam:       "https://github.com/tektoncd/catalog.git",
+		PathParam:      "task/git-clone/0.6/git-clone.yaml",
+		SecretParam:    "git-credentials",
+		SecretKeyParam: "token",
+	}
+	if err := resolver.ValidateParams(resolverContext(), params); err == nil {
+		t.Fatalf("expected err for secretKey param without repo param")
+	}
+}
+
 func TestGetResolutionTimeoutDefault(t *testing.T) {
 	resolver := Resolver{}
 	defaultTimeout := 30 * time.Minute
@@ -127,6 +171,40 @@ func TestGetResolutionTimeoutCustom(t *testing.T) {
 	}
 }
 
+func TestCacheKeyConfigFields(t *testing.T) {
+	resolver := Resolver{}
+	want := []string{
+		ConfigURL,
+		ConfigOrg,
+		ConfigSCMType,
+		"github-server-url",
+		"gitlab-server-url",
+		"gitea-server-url",
+		"bitbucket-server-url",
+	}
+	if d := cmp.Diff(want, resolver.CacheKeyConfigFields(resolverContext())); d != "" {
+		t.Errorf("unexpected cache key config fields %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestIsImmutable(t *testing.T) {
+	resolver := Resolver{}
+	for revision, expected := range map[string]bool{
+		"aeb957601cf41c012be462827053a21a420befca":                         true,
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08": false,
+		"aeb957601cf41c012be462827053a21a420befc":                          false,
+		"aeb9576": false,
+		"main":    false,
+		"v0.38.2": false,
+		"":        false,
+	} {
+		params := map[string]string{PathParam: "foo", RevisionParam: revision}
+		if immutable := resolver.IsImmutable(resolverContext(), params); immutable != expected {
+			t.Errorf("expected revision %q to be immutable: %t but got %t", revision, expected, immutable)
+		}
+	}
+}
+
 func TestResolveNotEnabled(t *testing.T) {
 	resolver := Resolver{}
 
@@ -246,7 +324,7 @@ func TestResolve(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			repoPath, commits := createTestRepo(t, tc.commits)
-			resolver := &Resolver{}
+			resolver := &Resolver{CacheDir: t.TempDir()}
 
 			params := map[string]string{
 				URLParam:  repoPath,
@@ -280,15 +358,18 @@ func TestResolve(t *testing.T) {
 
 				expectedResource := &ResolvedGitResource{
 					Content: tc.expectedContent,
+					URL:     repoPath,
+					Path:    tc.pathInRepo,
 				}
+				revision := tc.revision
 				switch {
 				case tc.useNthCommit > 0:
-					expectedResource.Revision = commits[plumbing.Master.Short()][tc.useNthCommit]
-				case tc.revision == "":
-					expectedResource.Revision = plumbing.Master.Short()
-				default:
-					expectedResource.Revision = tc.revision
+					revision = commits[plumbing.Master.Short()][tc.useNthCommit]
+				case revision == "":
+					revision = plumbing.Master.Short()
 				}
+				expectedResource.Revision = revision
+				expectedResource.Commit = resolveTestRevision(t, repoPath, revision)
 
 				if d := cmp.Diff(expectedResource, output); d != "" {
 					t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
@@ -308,7 +389,7 @@ func TestController(t *testing.T) {
 		useNthCommit   int
 		specificCommit string
 		pathInRepo     string
-		expectedStatus *v1alpha1.ResolutionRequestStatus
+		expectedStatus *v1beta1.ResolutionRequestStatus
 		expectedErr    error
 	}{
 		{
@@ -319,13 +400,13 @@ func TestController(t *testing.T) {
 				Content:  "some content",
 			}},
 			pathInRepo: "foo/bar/somefile",
-			expectedStatus: &v1alpha1.ResolutionRequestStatus{
+			expectedStatus: &v1beta1.ResolutionRequestStatus{
 				Status: duckv1.Status{
 					Annotations: map[string]string{
 						"content-type": "application/x-yaml",
 					},
 				},
-				ResolutionRequestStatusFields: v1alpha1.ResolutionRequestStatusFields{
+				ResolutionRequestStatusFields: v1beta1.ResolutionRequestStatusFields{
 					Data: base64.StdEncoding.Strict().EncodeToString([]byte("some content")),
 				},
 			},
@@ -343,13 +424,13 @@ func TestController(t *testing.T) {
 			}},
 			revision:   "other-revision",
 			pathInRepo: "foo/bar/somefile",
-			expectedStatus: &v1alpha1.ResolutionRequestStatus{
+			expectedStatus: &v1beta1.ResolutionRequestStatus{
 				Status: duckv1.Status{
 					Annotations: map[string]string{
 						"content-type": "application/x-yaml",
 					},
 				},
-				ResolutionRequestStatusFields: v1alpha1.ResolutionRequestStatusFields{
+				ResolutionRequestStatusFields: v1beta1.ResolutionRequestStatusFields{
 					Data: base64.StdEncoding.Strict().EncodeToString([]byte("some content")),
 				},
 			},
@@ -367,13 +448,13 @@ func TestController(t *testing.T) {
 			}},
 			revision:   "tag1",
 			pathInRepo: "foo/bar/somefile",
-			expectedStatus: &v1alpha1.ResolutionRequestStatus{
+			expectedStatus: &v1beta1.ResolutionRequestStatus{
 				Status: duckv1.Status{
 					Annotations: map[string]string{
 						"content-type": "application/x-yaml",
 					},
 				},
-				ResolutionRequestStatusFields: v1alpha1.ResolutionRequestStatusFields{
+				ResolutionRequestStatusFields: v1beta1.ResolutionRequestStatusFields{
 					Data: base64.StdEncoding.Strict().EncodeToString([]byte("some content")),
 				},
 			},
@@ -390,13 +471,13 @@ func TestController(t *testing.T) {
 			}},
 			pathInRepo:   "foo/bar/somefile",
 			useNthCommit: 1,
-			expectedStatus: &v1alpha1.ResolutionRequestStatus{
+			expectedStatus: &v1beta1.ResolutionRequestStatus{
 				Status: duckv1.Status{
 					Annotations: map[string]string{
 						"content-type": "application/x-yaml",
 					},
 				},
-				ResolutionRequestStatusFields: v1alpha1.ResolutionRequestStatusFields{
+				ResolutionRequestStatusFields: v1beta1.ResolutionRequestStatusFields{
 					Data: base64.StdEncoding.Strict().EncodeToString([]byte("different content")),
 				},
 			},
@@ -408,7 +489,7 @@ func TestController(t *testing.T) {
 				Content:  "some content",
 			}},
 			pathInRepo: "foo/bar/some other file",
-			expectedStatus: &v1alpha1.ResolutionRequestStatus{
+			expectedStatus: &v1beta1.ResolutionRequestStatus{
 				Status: duckv1.Status{
 					Conditions: duckv1.Conditions{{
 						Type:   apis.ConditionSucceeded,
@@ -427,7 +508,7 @@ func TestController(t *testing.T) {
 			}},
 			revision:   "does-not-exist",
 			pathInRepo: "foo/bar/some other file",
-			expectedStatus: &v1alpha1.ResolutionRequestStatus{
+			expectedStatus: &v1beta1.ResolutionRequestStatus{
 				Status: duckv1.Status{
 					Conditions: duckv1.Conditions{{
 						Type:   apis.ConditionSucceeded,
@@ -446,7 +527,7 @@ func TestController(t *testing.T) {
 			}},
 			specificCommit: "does-not-exist",
 			pathInRepo:     "foo/bar/some other file",
-			expectedStatus: &v1alpha1.ResolutionRequestStatus{
+			expectedStatus: &v1beta1.ResolutionRequestStatus{
 				Status: duckv1.Status{
 					Conditions: duckv1.Conditions{{
 						Type:   apis.ConditionSucceeded,
@@ -466,20 +547,25 @@ func TestController(t *testing.T) {
 			repoPath, commits := createTestRepo(t, tc.commits)
 
 			request := createRequest(repoPath, tc.pathInRepo, tc.revision, tc.specificCommit, tc.useNthCommit, commits)
-			resolver := &Resolver{}
+			resolver := &Resolver{CacheDir: t.TempDir()}
 
-			var expectedStatus *v1alpha1.ResolutionRequestStatus
+			var expectedStatus *v1beta1.ResolutionRequestStatus
 			if tc.expectedStatus != nil {
 				expectedStatus = tc.expectedStatus.DeepCopy()
 
 				if tc.expectedErr == nil {
-					reqParams := request.Spec.Parameters
 					// Add the expected commit to the expected status annotations, but only if we expect success.
-					if cmt, ok := reqParams[RevisionParam]; ok {
-						expectedStatus.Annotations[AnnotationKeyRevision] = cmt
-					} else {
-						expectedStatus.Annotations[AnnotationKeyRevision] = plumbing.Master.Short()
+					revision := plumbing.Master.Short()
+					for _, p := range request.Spec.Params {
+						if p.Name == RevisionParam {
+							revision = p.Value.StringVal
+						}
 					}
+					commit := resolveTestRevision(t, repoPath, revision)
+					expectedStatus.Annotations[AnnotationKeyRevision] = revision
+					expectedStatus.Annotations[resolutioncommon.AnnotationKeySourceURI] = "git+" + repoPath
+					expectedStatus.Annotations[resolutioncommon.AnnotationKeySourceDigest] = "sha1:" + commit
+					expectedStatus.Annotations[resolutioncommon.AnnotationKeySourceEntryPoint] = tc.pathInRepo
 				} else {
 					expectedStatus.Status.Conditions[0].Message = tc.expectedErr.Error()
 				}
@@ -500,14 +586,29 @@ func TestController(t *testing.T) {
 						"enable-git-resolver": "true",
 					},
 				}},
-				ResolutionRequests: []*v1alpha1.ResolutionRequest{request},
+				ResolutionRequests: []*v1beta1.ResolutionRequest{request},
 			}
 
-			frtesting.RunResolverReconcileTest(ctx, t, d, resolver, request, expectedStatus, tc.expectedErr)
+			frtesting.RunResolverReconcileTest(ctx, t, d, framework.FromStringParamsResolver(resolver), request, expectedStatus, tc.expectedErr)
 		})
 	}
 }
 
+// resolveTestRevision returns the hash of the commit of the given
+// revision of the test repository at repoPath.
+func resolveTestRevision(t *testing.T, repoPath, revision string) string {
+	t.Helper()
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		t.Fatalf("opening test repo: %v", err)
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		t.Fatalf("resolving revision %q of test repo: %v", revision, err)
+	}
+	return hash.String()
+}
+
 // createTestRepo is used to instantiate a local test repository with the desired commits.
 func createTestRepo(t *testing.T, commits []commitForRepo) (string, map[string][]string) {
 	t.Helper()
@@ -633,10 +734,10 @@ func withTemporaryGitConfig(t *testing.T) {
 	t.Setenv(key, filepath.Join(gitConfigDir, "config"))
 }
 
-func createRequest(repoURL, pathInRepo, revision, specificCommit string, useNthCommit int, commitsByBranch map[string][]string) *v1alpha1.ResolutionRequest {
-	rr := &v1alpha1.ResolutionRequest{
+func createRequest(repoURL, pathInRepo, revision, specificCommit string, useNthCommit int, commitsByBranch map[string][]string) *v1beta1.ResolutionRequest {
+	rr := &v1beta1.ResolutionRequest{
 		TypeMeta: metav1.TypeMeta{
-			APIVersion: "resolution.tekton.dev/v1alpha1",
+			APIVersion: "resolution.tekton.dev/v1beta1",
 			Kind:       "ResolutionRequest",
 		},
 		ObjectMeta: metav1.ObjectMeta{
@@ -647,21 +748,28 @@ func createRequest(repoURL, pathInRepo, revision, specificCommit string, useNthC
 				resolutioncommon.LabelKeyResolverType: LabelValueGitResolverType,
 			},
 		},
-		Spec: v1alpha1.ResolutionRequestSpec{
-			Parameters: map[string]string{
-				URLParam:  repoURL,
-				PathParam: pathInRepo,
-			},
+		Spec: v1beta1.ResolutionRequestSpec{
+			Params: []pipelinev1beta1.Param{{
+				Name:  URLParam,
+				Value: *pipelinev1beta1.NewStructuredValues(repoURL),
+			}, {
+				Name:  PathParam,
+				Value: *pipelinev1beta1.NewStructuredValues(pathInRepo),
+			}},
 		},
 	}
 
 	switch {
 	case useNthCommit > 0:
-		rr.Spec.Parameters[RevisionParam] = commitsByBranch[plumbing.Master.Short()][useNthCommit]
+		revision = commitsByBranch[plumbing.Master.Short()][useNthCommit]
 	case specificCommit != "":
-		rr.Spec.Parameters[RevisionParam] = hex.EncodeToString([]byte(specificCommit))
-	case revision != "":
-		rr.Spec.Parameters[RevisionParam] = revision
+		revision = hex.EncodeToString([]byte(specificCommit))
+	}
+	if revision != "" {
+		rr.Spec.Params = append(rr.Spec.Params, pipelinev1beta1.Param{
+			Name:  RevisionParam,
+			Value: *pipelinev1beta1.NewStructuredValues(revision),
+		})
 	}
 
 	return rr
@@ -670,3 +778,551 @@ func createRequest(repoURL, pathInRepo, revision, specificCommit string, useNthC
 func resolverContext() context.Context {
 	return frtesting.ContextWithGitResolverEnabled(context.Background())
 }
+
+func TestGetAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating ssh key: %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("error getting ssh public key: %v", err)
+	}
+	knownHosts := []byte(knownhosts.Line([]string{"github.com"}, publicKey))
+
+	kubeClientSet := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "foo"},
+		Type:       corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("user"),
+			corev1.BasicAuthPasswordKey: []byte("password"),
+		},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "annotated",
+			Namespace:   "foo",
+			Annotations: map[string]string{"tekton.dev/git-0": "https://github.com"},
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("user"),
+			corev1.BasicAuthPasswordKey: []byte("password"),
+		},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ssh", Namespace: "foo"},
+		Type:       corev1.SecretTypeSSHAuth,
+		Data: map[string][]byte{
+			corev1.SSHAuthPrivateKey: privateKey,
+			"known_hosts":            knownHosts,
+		},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ssh-without-known-hosts", Namespace: "foo"},
+		Type:       corev1.SecretTypeSSHAuth,
+		Data:       map[string][]byte{corev1.SSHAuthPrivateKey: privateKey},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "foo"},
+		Data:       map[string][]byte{"password": []byte("password")},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "bar"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	})
+
+	for _, tc := range []struct {
+		name               string
+		url                string
+		secret             string
+		conf               map[string]string
+		requireKnownHosts  bool
+		expectedAuth       transport.AuthMethod
+		expectedSSHUser    string
+		expectedErr        string
+		expectHostKeyCheck bool
+	}{{
+		name: "no secret",
+		url:  "https://github.com/tektoncd/catalog.git",
+	}, {
+		name:         "basic auth",
+		url:          "https://github.com/tektoncd/catalog.git",
+		secret:       "basic",
+		expectedAuth: &githttp.BasicAuth{Username: "user", Password: "password"},
+	}, {
+		name:         "default secret",
+		url:          "https://github.com/tektoncd/catalog.git",
+		conf:         map[string]string{ConfigSecret: "basic"},
+		expectedAuth: &githttp.BasicAuth{Username: "user", Password: "password"},
+	}, {
+		name: "missing default secret",
+		url:  "https://github.com/tektoncd/catalog.git",
+		conf: map[string]string{ConfigSecret: "missing"},
+	}, {
+		name:         "annotated for the host",
+		url:          "https://github.com/tektoncd/catalog.git",
+		secret:       "annotated",
+		expectedAuth: &githttp.BasicAuth{Username: "user", Password: "password"},
+	}, {
+		name:         "token",
+		url:          "https://github.com/tektoncd/catalog.git",
+		secret:       "token",
+		expectedAuth: &githttp.TokenAuth{Token: "secret-token"},
+	}, {
+		name:               "ssh key with known hosts",
+		url:                "git@github.com:tektoncd/catalog.git",
+		secret:             "ssh",
+		expectedSSHUser:    "git",
+		expectHostKeyCheck: true,
+	}, {
+		name:            "ssh key with user in url",
+		url:             "ssh://tekton@github.com/tektoncd/catalog.git",
+		secret:          "ssh-without-known-hosts",
+		expectedSSHUser: "tekton",
+	}, {
+		name:        "missing secret",
+		url:         "https://github.com/tektoncd/catalog.git",
+		secret:      "missing",
+		expectedErr: `error getting secret "missing" in namespace "foo": secrets "missing" not found`,
+	}, {
+		name:        "secret in another namespace",
+		url:         "https://github.com/tektoncd/catalog.git",
+		secret:      "other-namespace",
+		expectedErr: `error getting secret "other-namespace" in namespace "foo": secrets "other-namespace" not found`,
+	}, {
+		name:        "annotated for another host",
+		url:         "https://gitlab.com/tektoncd/catalog.git",
+		secret:      "annotated",
+		expectedErr: `secret "annotated" is not annotated with tekton.dev/git-* for host "gitlab.com"`,
+	}, {
+		name:        "basic auth with ssh url",
+		url:         "git@github.com:tektoncd/catalog.git",
+		secret:      "basic",
+		expectedErr: `basic-auth secret "basic" can't be used with the ssh url "git@github.com:tektoncd/catalog.git"`,
+	}, {
+		name:        "ssh key with https url",
+		url:         "https://github.com/tektoncd/catalog.git",
+		secret:      "ssh",
+		expectedErr: `ssh-auth secret "ssh" can't be used with the https url "https://github.com/tektoncd/catalog.git"`,
+	}, {
+		name:              "ssh key without required known hosts",
+		url:               "git@github.com:tektoncd/catalog.git",
+		secret:            "ssh-without-known-hosts",
+		requireKnownHosts: true,
+		expectedErr:       `ssh-auth secret "ssh-without-known-hosts" must have "known_hosts" included when feature flag "require-git-ssh-secret-known-hosts" is set to true`,
+	}, {
+		name:        "unsupported secret",
+		url:         "https://github.com/tektoncd/catalog.git",
+		secret:      "opaque",
+		expectedErr: `secret "opaque" must be of type kubernetes.io/basic-auth or kubernetes.io/ssh-auth, or have a "token" key`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &Resolver{kubeClientSet: kubeClientSet}
+			featureFlags, err := config.NewFeatureFlagsFromMap(map[string]string{
+				"require-git-ssh-secret-known-hosts": strconv.FormatBool(tc.requireKnownHosts),
+			})
+			if err != nil {
+				t.Fatalf("error parsing feature flags: %v", err)
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: featureFlags})
+			ctx = framework.InjectResolverConfigToContext(ctx, tc.conf)
+			ctx = resolutioncommon.InjectRequestNamespace(ctx, "foo")
+
+			params := map[string]string{}
+			if tc.secret != "" {
+				params[SecretParam] = tc.secret
+			}
+			auth, err := resolver.getAuth(ctx, tc.url, params)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatalf("expected err %q but got none", tc.expectedErr)
+				}
+				if d := cmp.Diff(tc.expectedErr, err.Error()); d != "" {
+					t.Fatalf("unexpected error: %s", diff.PrintWantGot(d))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error getting auth: %v", err)
+			}
+
+			if tc.expectedSSHUser == "" {
+				if d := cmp.Diff(tc.expectedAuth, auth); d != "" {
+					t.Errorf("unexpected auth: %s", diff.PrintWantGot(d))
+				}
+				return
+			}
+			keys, ok := auth.(*gitssh.PublicKeys)
+			if !ok {
+				t.Fatalf("expected ssh public keys auth but got %T", auth)
+			}
+			if keys.User != tc.expectedSSHUser {
+				t.Errorf("expected ssh user %q but got %q", tc.expectedSSHUser, keys.User)
+			}
+			otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				t.Fatalf("error generating ssh key: %v", err)
+			}
+			otherPublicKey, err := ssh.NewPublicKey(&otherKey.PublicKey)
+			if err != nil {
+				t.Fatalf("error getting ssh public key: %v", err)
+			}
+			remote := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+			if err := keys.HostKeyCallback("github.com:22", remote, publicKey); err != nil {
+				t.Errorf("expected the host key to be accepted but got %v", err)
+			}
+			if err := keys.HostKeyCallback("github.com:22", remote, otherPublicKey); (err != nil) != tc.expectHostKeyCheck {
+				t.Errorf("expected the unknown host key to be rejected: %t, but got %v", tc.expectHostKeyCheck, err)
+			}
+		})
+	}
+}
+
+func TestResolveWithAuth(t *testing.T) {
+	var authorization string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+
+	resolver := &Resolver{CacheDir: t.TempDir(), kubeClientSet: fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	})}
+	ctx := resolutioncommon.InjectRequestNamespace(resolverContext(), "foo")
+	if _, err := resolver.Resolve(ctx, map[string]string{
+		URLParam:      svr.URL + "/tektoncd/catalog.git",
+		PathParam:     "task.yaml",
+		RevisionParam: "main",
+		SecretParam:   "token",
+	}); err == nil {
+		t.Fatal("expected a clone error but got none")
+	}
+	if authorization != "Bearer secret-token" {
+		t.Errorf("expected the repo to be fetched with the token but got authorization %q", authorization)
+	}
+}
+
+// newFakeSCMServer returns a server implementing the APIs resolving a
+// revision to a commit and fetching the contents of a file at a commit
+// of the SCM providers under /<scm type>, except for bitbucket whose API
+// paths are absolute. The commits are looked up by "<repo>@<revision>"
+// in commits, and the files by "<repo>@<commit>:<path>" in files. The
+// private repo can only be read with the given token.
+func newFakeSCMServer(t *testing.T, commits, files map[string]string, token string) *httptest.Server {
+	t.Helper()
+	authorized := func(w http.ResponseWriter, r *http.Request, repo string) bool {
+		if strings.HasSuffix(repo, "/private") &&
+			r.Header.Get("Authorization") != "Bearer "+token &&
+			r.Header.Get("Authorization") != "token "+token &&
+			r.Header.Get("Private-Token") != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	}
+	commit := func(w http.ResponseWriter, r *http.Request, repo, revision string) (string, bool) {
+		if !authorized(w, r, repo) {
+			return "", false
+		}
+		sha, ok := commits[repo+"@"+revision]
+		if !ok {
+			notFound(w)
+		}
+		return sha, ok
+	}
+	content := func(w http.ResponseWriter, r *http.Request, repo, ref, path string) (string, bool) {
+		if !authorized(w, r, repo) {
+			return "", false
+		}
+		data, ok := files[fmt.Sprintf("%s@%s:%s", repo, ref, path)]
+		if !ok {
+			notFound(w)
+		}
+		return data, ok
+	}
+	encoded := func(w http.ResponseWriter, fields map[string]interface{}) {
+		if err := json.NewEncoder(w).Encode(fields); err != nil {
+			t.Errorf("error writing response: %v", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/github/api/v3/repos/", func(w http.ResponseWriter, r *http.Request) {
+		// /repos/<org>/<repo>/commits/<revision>
+		// /repos/<org>/<repo>/contents/<path>?ref=<ref>
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/github/api/v3/repos/"), "/", 4)
+		repo := parts[0] + "/" + parts[1]
+		if parts[2] == "commits" {
+			if sha, ok := commit(w, r, repo, parts[3]); ok {
+				encoded(w, map[string]interface{}{"sha": sha})
+			}
+			return
+		}
+		if data, ok := content(w, r, repo, r.URL.Query().Get("ref"), parts[3]); ok {
+			encoded(w, map[string]interface{}{"path": parts[3], "content": base64.StdEncoding.EncodeToString([]byte(data))})
+		}
+	})
+	mux.HandleFunc("/gitlab/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+		// /projects/<url encoded org/repo>/repository/commits/<url encoded revision>
+		// /projects/<url encoded org/repo>/repository/files/<url encoded path>?ref=<ref>
+		parts := strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), "/gitlab/api/v4/projects/"), "/")
+		repo, _ := url.PathUnescape(parts[0])
+		arg, _ := url.PathUnescape(parts[3])
+		if parts[2] == "commits" {
+			if sha, ok := commit(w, r, repo, arg); ok {
+				encoded(w, map[string]interface{}{"id": sha})
+			}
+			return
+		}
+		if data, ok := content(w, r, repo, r.URL.Query().Get("ref"), arg); ok {
+			encoded(w, map[string]interface{}{"file_path": arg, "content": base64.StdEncoding.EncodeToString([]byte(data))})
+		}
+	})
+	mux.HandleFunc("/gitea/api/v1/version", func(w http.ResponseWriter, r *http.Request) {
+		encoded(w, map[string]interface{}{"version": "1.17.0"})
+	})
+	mux.HandleFunc("/gitea/api/v1/repos/", func(w http.ResponseWriter, r *http.Request) {
+		// /repos/<org>/<repo>/git/commits/<revision>
+		// /repos/<org>/<repo>/contents/<path>?ref=<ref>
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/gitea/api/v1/repos/"), "/", 4)
+		repo := parts[0] + "/" + parts[1]
+		if parts[2] == "git" {
+			if sha, ok := commit(w, r, repo, strings.TrimPrefix(parts[3], "commits/")); ok {
+				encoded(w, map[string]interface{}{"sha": sha, "commit": map[string]interface{}{}})
+			}
+			return
+		}
+		if data, ok := content(w, r, repo, r.URL.Query().Get("ref"), parts[3]); ok {
+			encoded(w, map[string]interface{}{"type": "file", "path": parts[3], "content": base64.StdEncoding.EncodeToString([]byte(data))})
+		}
+	})
+	mux.HandleFunc("/2.0/repositories/", func(w http.ResponseWriter, r *http.Request) {
+		// /repositories/<org>/<repo>/commit/<revision>
+		// /repositories/<org>/<repo>/src/<ref>/<path>
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/2.0/repositories/"), "/", 5)
+		repo := parts[0] + "/" + parts[1]
+		if parts[2] == "commit" {
+			if sha, ok := commit(w, r, repo, parts[3]); ok {
+				encoded(w, map[string]interface{}{"hash": sha})
+			}
+			return
+		}
+		if data, ok := content(w, r, repo, parts[3], parts[4]); ok {
+			fmt.Fprint(w, data)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestResolveAPIGit(t *testing.T) {
+	const (
+		testCommit    = "f99d13e554ffcb696dee719fa85b695cb5b0f428"
+		mainCommit    = "1c9a1d5e0c3c5b2b0b9a7e0e8f0d5b8b3c2a1f00"
+		tagCommit     = "2d8b2e6f1d4d6c3c1c0b8f1f9a1e6c9c4d3b2a11"
+		privateCommit = "3e7c3f7a2e5e7d4d2d1c9a2a0b2f7d0d5e4c3b22"
+		otherCommit   = "4f6d4a8b3f6f8e5e3e2d0b3b1c3a8e1e6f5d4c33"
+	)
+	svr := newFakeSCMServer(t, map[string]string{
+		"tektoncd/catalog@main":   mainCommit,
+		"tektoncd/catalog@v0.1.0": tagCommit,
+		"tektoncd/private@main":   privateCommit,
+		"other-org/catalog@main":  otherCommit,
+	}, map[string]string{
+		"tektoncd/catalog@" + mainCommit + ":task/foo.yaml":       "public content",
+		"tektoncd/catalog@" + tagCommit + ":task/foo.yaml":        "tagged content",
+		"tektoncd/catalog@" + testCommit + ":task/foo.yaml":       "pinned content",
+		"tektoncd/private@" + privateCommit + ":task/foo.yaml":    "private content",
+		"other-org/catalog@" + otherCommit + ":pipeline/bar.yaml": "other content",
+	}, "secret-token")
+	defer svr.Close()
+
+	serverURLs := map[string]string{}
+	for _, scmType := range supportedSCMTypes {
+		serverURLs[scmType+ConfigServerURLSuffix] = svr.URL + "/" + scmType
+	}
+	serverURLs["bitbucket"+ConfigServerURLSuffix] = svr.URL
+	withConf := func(conf map[string]string) map[string]string {
+		merged := map[string]string{ConfigRevision: "main"}
+		for k, v := range serverURLs {
+			merged[k] = v
+		}
+		for k, v := range conf {
+			merged[k] = v
+		}
+		return merged
+	}
+
+	kubeClientSet := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "scm-token", Namespace: "foo"},
+		Data: map[string][]byte{
+			"token":        []byte("secret-token"),
+			"github-token": []byte("secret-token"),
+		},
+	})
+
+	for _, tc := range []struct {
+		name            string
+		params          map[string]string
+		conf            map[string]string
+		expectedContent string
+		expectedCommit  string
+		expectedOrg     string
+		expectedURL     string
+		expectedErr     string
+	}{{
+		name:            "public github repo",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "catalog", PathParam: "task/foo.yaml"},
+		expectedContent: "public content",
+		expectedCommit:  mainCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/github/tektoncd/catalog.git",
+	}, {
+		name:            "tag revision",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "catalog", PathParam: "task/foo.yaml", RevisionParam: "v0.1.0"},
+		expectedContent: "tagged content",
+		expectedCommit:  tagCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/github/tektoncd/catalog.git",
+	}, {
+		name:            "commit revision",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "catalog", PathParam: "task/foo.yaml", RevisionParam: testCommit},
+		expectedContent: "pinned content",
+		expectedCommit:  testCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/github/tektoncd/catalog.git",
+	}, {
+		name:            "default org and scm type",
+		params:          map[string]string{RepoParam: "catalog", PathParam: "pipeline/bar.yaml"},
+		conf:            map[string]string{ConfigOrg: "other-org", ConfigSCMType: "gitlab"},
+		expectedContent: "other content",
+		expectedCommit:  otherCommit,
+		expectedOrg:     "other-org",
+		expectedURL:     svr.URL + "/gitlab/other-org/catalog.git",
+	}, {
+		name:            "private github repo",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml", SecretParam: "scm-token"},
+		expectedContent: "private content",
+		expectedCommit:  privateCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/github/tektoncd/private.git",
+	}, {
+		name:            "private gitlab repo",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml", ScmTypeParam: "gitlab", SecretParam: "scm-token"},
+		expectedContent: "private content",
+		expectedCommit:  privateCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/gitlab/tektoncd/private.git",
+	}, {
+		name:            "private gitea repo",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml", ScmTypeParam: "gitea", SecretParam: "scm-token"},
+		expectedContent: "private content",
+		expectedCommit:  privateCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/gitea/tektoncd/private.git",
+	}, {
+		name:            "private bitbucket repo",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml", ScmTypeParam: "bitbucket", SecretParam: "scm-token"},
+		expectedContent: "private content",
+		expectedCommit:  privateCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/tektoncd/private.git",
+	}, {
+		name:            "token key param",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml", SecretParam: "scm-token", SecretKeyParam: "github-token"},
+		expectedContent: "private content",
+		expectedCommit:  privateCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/github/tektoncd/private.git",
+	}, {
+		name:            "default token secret",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml"},
+		conf:            map[string]string{ConfigAPITokenSecretName: "scm-token", ConfigAPITokenSecretKey: "github-token"},
+		expectedContent: "private content",
+		expectedCommit:  privateCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/github/tektoncd/private.git",
+	}, {
+		name:            "missing default token secret",
+		params:          map[string]string{OrgParam: "tektoncd", RepoParam: "catalog", PathParam: "task/foo.yaml"},
+		conf:            map[string]string{ConfigAPITokenSecretName: "missing"},
+		expectedContent: "public content",
+		expectedCommit:  mainCommit,
+		expectedOrg:     "tektoncd",
+		expectedURL:     svr.URL + "/github/tektoncd/catalog.git",
+	}, {
+		name:        "private repo without token",
+		params:      map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml", ScmTypeParam: "bitbucket"},
+		expectedErr: `error resolving revision "main" of bitbucket repo "tektoncd/private"`,
+	}, {
+		name:        "revision does not exist",
+		params:      map[string]string{OrgParam: "tektoncd", RepoParam: "catalog", PathParam: "task/foo.yaml", RevisionParam: "missing"},
+		expectedErr: `error resolving revision "missing" of github repo "tektoncd/catalog"`,
+	}, {
+		name:        "file does not exist",
+		params:      map[string]string{OrgParam: "tektoncd", RepoParam: "catalog", PathParam: "task/missing.yaml", ScmTypeParam: "gitlab"},
+		expectedErr: `error fetching file "task/missing.yaml" from gitlab repo "tektoncd/catalog" at revision "main"`,
+	}, {
+		name:        "missing org",
+		params:      map[string]string{RepoParam: "catalog", PathParam: "task/foo.yaml"},
+		expectedErr: "org param must be set when the default org was not set during installation of the git resolver",
+	}, {
+		name:        "unsupported scm type",
+		params:      map[string]string{OrgParam: "tektoncd", RepoParam: "catalog", PathParam: "task/foo.yaml", ScmTypeParam: "svn"},
+		expectedErr: `unsupported SCM type "svn", must be one of github, gitlab, gitea, bitbucket`,
+	}, {
+		name:        "gitea without server url",
+		params:      map[string]string{OrgParam: "tektoncd", RepoParam: "catalog", PathParam: "task/foo.yaml", ScmTypeParam: "gitea"},
+		conf:        map[string]string{"gitea" + ConfigServerURLSuffix: ""},
+		expectedErr: "error creating gitea client: No git serverURL was specified",
+	}, {
+		name:        "missing token secret",
+		params:      map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml", SecretParam: "missing"},
+		expectedErr: `error getting secret "missing" in namespace "foo": secrets "missing" not found`,
+	}, {
+		name:        "wrong token key",
+		params:      map[string]string{OrgParam: "tektoncd", RepoParam: "private", PathParam: "task/foo.yaml", SecretParam: "scm-token", SecretKeyParam: "password"},
+		expectedErr: `key "password" not found in secret "scm-token" in namespace "foo"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &Resolver{kubeClientSet: kubeClientSet}
+			ctx := framework.InjectResolverConfigToContext(resolverContext(), withConf(tc.conf))
+			ctx = resolutioncommon.InjectRequestNamespace(ctx, "foo")
+
+			output, err := resolver.Resolve(ctx, tc.params)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatalf("expected err %q but got none", tc.expectedErr)
+				}
+				if !strings.HasPrefix(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected err %q but got %q", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			revision := tc.params[RevisionParam]
+			if revision == "" {
+				revision = "main"
+			}
+			expectedResource := &ResolvedGitResource{
+				Revision: revision,
+				Content:  []byte(tc.expectedContent),
+				Org:      tc.expectedOrg,
+				Repo:     tc.params[RepoParam],
+				URL:      tc.expectedURL,
+				Commit:   tc.expectedCommit,
+				Path:     tc.params[PathParam],
+			}
+			if d := cmp.Diff(expectedResource, output); d != "" {
+				t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm/factory"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultSCMType is the type of the SCM provider when neither the
+	// request nor the config specify one.
+	defaultSCMType = "github"
+)
+
+// supportedSCMTypes are the types of the SCM providers whose API the
+// files can be fetched with.
+var supportedSCMTypes = []string{"github", "gitlab", "gitea", "bitbucket"}
+
+// resolveAPIGit fetches the file at pathInRepo from the repo with the
+// API of its SCM provider, without cloning the repo.
+func (r *Resolver) resolveAPIGit(ctx context.Context, params map[string]string) (framework.ResolvedResource, error) {
+	conf := framework.GetResolverConfigFromContext(ctx)
+
+	scmType := params[ScmTypeParam]
+	if scmType == "" {
+		scmType = conf[ConfigSCMType]
+	}
+	if scmType == "" {
+		scmType = defaultSCMType
+	}
+	if !isSupportedSCMType(scmType) {
+		return nil, fmt.Errorf("unsupported SCM type %q, must be one of %s", scmType, strings.Join(supportedSCMTypes, ", "))
+	}
+
+	org := params[OrgParam]
+	if org == "" {
+		org = conf[ConfigOrg]
+	}
+	if org == "" {
+		return nil, fmt.Errorf("%s param must be set when the default org was not set during installation of the git resolver", OrgParam)
+	}
+	revision := params[RevisionParam]
+	if revision == "" {
+		if revisionString, ok := conf[ConfigRevision]; ok {
+			revision = revisionString
+		} else {
+			return nil, fmt.Errorf("default Git Revision was not set during installation of the git resolver")
+		}
+	}
+
+	token, err := r.getAPIToken(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	serverURL := conf[scmType+ConfigServerURLSuffix]
+	client, err := factory.NewClient(scmType, serverURL, token)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s client: %w", scmType, err)
+	}
+
+	repo := fmt.Sprintf("%s/%s", org, params[RepoParam])
+	// The file is fetched at the commit the revision resolves to, so
+	// that the recorded commit is the one the file was fetched from.
+	commit := revision
+	if !commitSHARegex.MatchString(revision) {
+		c, _, err := client.Git.FindCommit(ctx, repo, revision)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving revision %q of %s repo %q: %w", revision, scmType, repo, err)
+		}
+		if c == nil || !commitSHARegex.MatchString(c.Sha) {
+			return nil, fmt.Errorf("error resolving revision %q of %s repo %q: no commit returned", revision, scmType, repo)
+		}
+		commit = c.Sha
+	}
+	path := params[PathParam]
+	content, _, err := client.Contents.Find(ctx, repo, path, commit)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching file %q from %s repo %q at revision %q: %w", path, scmType, repo, revision, err)
+	}
+
+	return &ResolvedGitResource{
+		Revision: revision,
+		Content:  content.Data,
+		Org:      org,
+		Repo:     params[RepoParam],
+		URL:      scmRepoURL(scmType, serverURL, repo),
+		Commit:   commit,
+		Path:     path,
+	}, nil
+}
+
+// defaultSCMServerURLs are the URLs of the hosted SCM providers, used
+// when no server URL is configured for them.
+var defaultSCMServerURLs = map[string]string{
+	"github":    "https://github.com",
+	"gitlab":    "https://gitlab.com",
+	"bitbucket": "https://bitbucket.org",
+}
+
+// scmRepoURL returns the URL of repo, in the "<org>/<name>" format, on
+// the server of the given SCM provider.
+func scmRepoURL(scmType, serverURL, repo string) string {
+	if serverURL == "" {
+		serverURL = defaultSCMServerURLs[scmType]
+	}
+	return strings.TrimSuffix(serverURL, "/") + "/" + repo + ".git"
+}
+
+// getAPIToken returns the token to call the API of the SCM provider
+// with, from the secret referenced by the params or the resolver config,
+// if any. The secret is read from the namespace of the resolution
+// request.
+func (r *Resolver) getAPIToken(ctx context.Context, params map[string]string) (string, error) {
+	conf := framework.GetResolverConfigFromContext(ctx)
+	secretName, fromConfig := params[SecretParam], false
+	if secretName == "" {
+		secretName, fromConfig = conf[ConfigAPITokenSecretName], true
+	}
+	if secretName == "" {
+		return "", nil
+	}
+	key := params[SecretKeyParam]
+	if key == "" {
+		key = conf[ConfigAPITokenSecretKey]
+	}
+	if key == "" {
+		key = tokenKey
+	}
+
+	namespace := resolutioncommon.RequestNamespace(ctx)
+	secret, err := r.kubeClientSet.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		// The default secret is optional in each namespace.
+		if fromConfig && apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error getting secret %q in namespace %q: %w", secretName, namespace, err)
+	}
+	token, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret %q in namespace %q", key, secretName, namespace)
+	}
+	return string(token), nil
+}
+
+func isSupportedSCMType(scmType string) bool {
+	for _, t := range supportedSCMTypes {
+		if t == scmType {
+			return true
+		}
+	}
+	return false
+}
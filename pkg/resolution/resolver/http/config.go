@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ConfigFieldTimeout is the configuration field name for controlling
+// the maximum duration of a resolution request for a file over http.
+const ConfigFieldTimeout = "fetch-timeout"
+
+// ConfigAllowedURLPrefixes is the configuration field name for the
+// comma separated list of prefixes the requested URLs must start with.
+// No URL is allowed when it isn't set, and any http and https URL is
+// allowed when it is "*".
+const ConfigAllowedURLPrefixes = "allowed-url-prefixes"
+
+// allowAnyURL is the allowed URL prefix allowing any http and https URL.
+const allowAnyURL = "*"
+
+// ConfigMaxBodySize is the configuration field name for the maximum
+// size of a fetched file, as a quantity such as "1Mi".
+const ConfigMaxBodySize = "max-body-size"
+
+// defaultMaxBodySize is the maximum size of a fetched file when
+// max-body-size isn't set.
+const defaultMaxBodySize = 1 << 20
+
+// allowedURLPrefixes returns the prefixes the requested URLs must start
+// with, if any.
+func allowedURLPrefixes(conf map[string]string) []string {
+	var prefixes []string
+	for _, prefix := range strings.Split(conf[ConfigAllowedURLPrefixes], ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// maxBodySize returns the maximum size in bytes of a fetched file.
+func maxBodySize(conf map[string]string) (int64, error) {
+	sizeString, ok := conf[ConfigMaxBodySize]
+	if !ok || sizeString == "" {
+		return defaultMaxBodySize, nil
+	}
+	size, err := resource.ParseQuantity(sizeString)
+	if err != nil || size.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid %s %q in the http resolver config", ConfigMaxBodySize, sizeString)
+	}
+	return size.Value(), nil
+}
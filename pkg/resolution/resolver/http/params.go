@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+// ParamURL is the URL of the file to fetch
+const ParamURL string = "url"
+
+// ParamDigest is the expected digest of the fetched file, in the
+// form sha256:<hex>
+const ParamDigest string = "digest"
+
+// ParamHTTPUsername is the username to authenticate with using basic
+// auth
+const ParamHTTPUsername string = "http-username"
+
+// ParamHTTPPasswordSecret is the name of the secret in the namespace of
+// the request holding the password to authenticate with using basic auth
+const ParamHTTPPasswordSecret string = "http-password-secret"
+
+// ParamHTTPPasswordSecretKey is the key of the password in the
+// http-password-secret secret
+const ParamHTTPPasswordSecretKey string = "http-password-secret-key"
+
+// ParamHTTPTokenSecret is the name of the secret in the namespace of
+// the request holding the token to authenticate with as a bearer
+const ParamHTTPTokenSecret string = "http-token-secret"
+
+// ParamHTTPTokenSecretKey is the key of the token in the
+// http-token-secret secret
+const ParamHTTPTokenSecretKey string = "http-token-secret-key"
+
+// DefaultPasswordSecretKey is the key of the password in the
+// http-password-secret secret when http-password-secret-key isn't set
+const DefaultPasswordSecretKey = "password"
+
+// DefaultTokenSecretKey is the key of the token in the
+// http-token-secret secret when http-token-secret-key isn't set
+const DefaultTokenSecretKey = "token"
+
+// digestAlgorithm is the only algorithm of the digest param supported
+const digestAlgorithm = "sha256"
+
+// RequestOptions are the options parsed from the params of a resolution
+// request to fetch a file over http.
+type RequestOptions struct {
+	URL string
+	// Digest is the expected hex encoded sha256 digest of the file, if any
+	Digest string
+
+	Username          string
+	PasswordSecret    string
+	PasswordSecretKey string
+	TokenSecret       string
+	TokenSecretKey    string
+}
+
+// OptionsFromParams parses the params from a resolution request and
+// converts them into options to pass as part of an http request.
+func OptionsFromParams(ctx context.Context, params map[string]string) (RequestOptions, error) {
+	opts := RequestOptions{}
+	conf := framework.GetResolverConfigFromContext(ctx)
+
+	urlVal, ok := params[ParamURL]
+	if !ok || urlVal == "" {
+		return opts, fmt.Errorf("parameter %q required", ParamURL)
+	}
+	if err := validateURL(urlVal, allowedURLPrefixes(conf)); err != nil {
+		return opts, err
+	}
+	opts.URL = urlVal
+
+	if digestVal, ok := params[ParamDigest]; ok && digestVal != "" {
+		digest, err := parseDigest(digestVal)
+		if err != nil {
+			return opts, err
+		}
+		opts.Digest = digest
+	}
+
+	opts.Username = params[ParamHTTPUsername]
+	opts.PasswordSecret = params[ParamHTTPPasswordSecret]
+	opts.TokenSecret = params[ParamHTTPTokenSecret]
+	switch {
+	case opts.Username != "" && opts.TokenSecret != "":
+		return opts, fmt.Errorf("parameters %q and %q are mutually exclusive", ParamHTTPUsername, ParamHTTPTokenSecret)
+	case opts.Username != "" && opts.PasswordSecret == "":
+		return opts, fmt.Errorf("parameter %q required when %q is set", ParamHTTPPasswordSecret, ParamHTTPUsername)
+	case opts.Username == "" && opts.PasswordSecret != "":
+		return opts, fmt.Errorf("parameter %q required when %q is set", ParamHTTPUsername, ParamHTTPPasswordSecret)
+	}
+	opts.PasswordSecretKey = DefaultPasswordSecretKey
+	if keyVal, ok := params[ParamHTTPPasswordSecretKey]; ok && keyVal != "" {
+		opts.PasswordSecretKey = keyVal
+	}
+	opts.TokenSecretKey = DefaultTokenSecretKey
+	if keyVal, ok := params[ParamHTTPTokenSecretKey]; ok && keyVal != "" {
+		opts.TokenSecretKey = keyVal
+	}
+
+	return opts, nil
+}
+
+// validateURL returns an error if the given URL isn't an http(s) URL
+// matching one of the allowed prefixes. No URL is allowed when there is
+// no prefix, and any http(s) URL is allowed by the "*" prefix.
+func validateURL(rawURL string, allowedPrefixes []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", rawURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url %q must be an http or https URL", rawURL)
+	}
+	// The credentials are passed as params, so that a URL like
+	// https://allowed.example.com@evil.example.com/ can't mislead.
+	if u.User != nil {
+		return fmt.Errorf("url %q must not contain credentials", rawURL)
+	}
+	if len(allowedPrefixes) == 0 {
+		return fmt.Errorf("url %q is not allowed: no url prefix is allowed in the http resolver config", rawURL)
+	}
+	for _, prefix := range allowedPrefixes {
+		matches, err := matchesPrefix(u, prefix)
+		if err != nil {
+			return err
+		}
+		if matches {
+			return nil
+		}
+	}
+	return fmt.Errorf("url %q does not start with any of the allowed prefixes %v", rawURL, allowedPrefixes)
+}
+
+// matchesPrefix returns whether u has the scheme and host of the given
+// allowed prefix, and a path under its path. Paths are compared on
+// segment boundaries once cleaned, so that neither /tasks-private nor
+// /tasks/../private match the /tasks/ prefix.
+func matchesPrefix(u *url.URL, prefix string) (bool, error) {
+	if prefix == allowAnyURL {
+		return true, nil
+	}
+	p, err := url.Parse(prefix)
+	if err != nil || (p.Scheme != "http" && p.Scheme != "https") || p.Host == "" || p.User != nil {
+		return false, fmt.Errorf("invalid allowed url prefix %q in the http resolver config", prefix)
+	}
+	if u.Scheme != p.Scheme || !strings.EqualFold(u.Host, p.Host) {
+		return false, nil
+	}
+	prefixPath := strings.TrimSuffix(p.Path, "/")
+	if prefixPath == "" {
+		return true, nil
+	}
+	urlPath := path.Clean("/" + u.Path)
+	return urlPath == prefixPath || strings.HasPrefix(urlPath, prefixPath+"/"), nil
+}
+
+// parseDigest returns the hex encoded sha256 digest of a digest param.
+func parseDigest(digest string) (string, error) {
+	algorithm, hash, ok := strings.Cut(digest, ":")
+	if !ok || algorithm != digestAlgorithm {
+		return "", fmt.Errorf("invalid digest %q: must be of the form %s:<hex>", digest, digestAlgorithm)
+	}
+	if b, err := hex.DecodeString(hash); err != nil || len(b) != 32 {
+		return "", fmt.Errorf("invalid digest %q: must be of the form %s:<hex>", digest, digestAlgorithm)
+	}
+	return strings.ToLower(hash), nil
+}
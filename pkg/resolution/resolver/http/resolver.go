@@ -0,0 +1,249 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/client/injection/kube/client"
+)
+
+const disabledError = "cannot handle resolution request, enable-http-resolver feature flag not true"
+
+// LabelValueHTTPResolverType is the value to use for the
+// resolution.tekton.dev/type label on resource requests
+const LabelValueHTTPResolverType string = "http"
+
+// HTTPResolverName is the name that the http resolver should be
+// associated with
+const HTTPResolverName string = "HTTP"
+
+// YAMLContentType is the content type to use when returning yaml
+const YAMLContentType string = "application/x-yaml"
+
+var _ framework.StringParamsResolver = &Resolver{}
+
+// Resolver implements a framework.StringParamsResolver that can fetch files from
+// http and https URLs.
+type Resolver struct {
+	kubeClientSet kubernetes.Interface
+}
+
+// Initialize sets up the client used to read the auth secrets.
+func (r *Resolver) Initialize(ctx context.Context) error {
+	r.kubeClientSet = client.Get(ctx)
+	return nil
+}
+
+// GetName returns the string name that the http resolver should be
+// associated with.
+func (r *Resolver) GetName(_ context.Context) string {
+	return HTTPResolverName
+}
+
+// GetSelector returns the labels that resource requests are required to have for
+// the http resolver to process them.
+func (r *Resolver) GetSelector(_ context.Context) map[string]string {
+	return map[string]string{
+		resolutioncommon.LabelKeyResolverType: LabelValueHTTPResolverType,
+	}
+}
+
+// ValidateParams returns an error if the given parameter map is not
+// valid for a resource request targeting the http resolver.
+func (r *Resolver) ValidateParams(ctx context.Context, params map[string]string) error {
+	if r.isDisabled(ctx) {
+		return errors.New(disabledError)
+	}
+	if _, err := OptionsFromParams(ctx, params); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Resolve performs the work of fetching a file over http given a map of
+// parameters.
+func (r *Resolver) Resolve(ctx context.Context, params map[string]string) (framework.ResolvedResource, error) {
+	if r.isDisabled(ctx) {
+		return nil, errors.New(disabledError)
+	}
+	opts, err := OptionsFromParams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	conf := framework.GetResolverConfigFromContext(ctx)
+	maxSize, err := maxBodySize(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to %q: %w", opts.URL, err)
+	}
+	switch {
+	case opts.Username != "":
+		password, err := r.getSecretValue(ctx, opts.PasswordSecret, opts.PasswordSecretKey)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(opts.Username, password)
+	case opts.TokenSecret != "":
+		token, err := r.getSecretValue(ctx, opts.TokenSecret, opts.TokenSecretKey)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	prefixes := allowedURLPrefixes(conf)
+	httpClient := &http.Client{
+		// Redirects must not escape the allowed prefixes either.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return validateURL(req.URL.String(), prefixes)
+		},
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting %q: %w", opts.URL, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requested url %q returned status %q", opts.URL, resp.Status)
+	}
+
+	// Read one more byte than allowed to tell a file of the maximum size
+	// from a larger one.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("requested url %q returned more than %d bytes", opts.URL, maxSize)
+	}
+
+	sum := sha256.Sum256(body)
+	digest := hex.EncodeToString(sum[:])
+	if opts.Digest != "" && opts.Digest != digest {
+		return nil, fmt.Errorf("digest mismatch for %q: expected %s:%s but got %s:%s", opts.URL, digestAlgorithm, opts.Digest, digestAlgorithm, digest)
+	}
+
+	return &ResolvedHTTPResource{
+		URL:     opts.URL,
+		Digest:  digestAlgorithm + ":" + digest,
+		Content: body,
+	}, nil
+}
+
+// getSecretValue returns the value of the given key of a secret in the
+// namespace of the request.
+func (r *Resolver) getSecretValue(ctx context.Context, name, key string) (string, error) {
+	namespace := resolutioncommon.RequestNamespace(ctx)
+	secret, err := r.kubeClientSet.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting secret %q in namespace %q: %w", name, namespace, err)
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret %q in namespace %q", key, name, namespace)
+	}
+	return string(value), nil
+}
+
+var _ framework.ConfigWatcher = &Resolver{}
+
+// GetConfigName returns the name of the http resolver's configmap.
+func (r *Resolver) GetConfigName(context.Context) string {
+	return "http-resolver-config"
+}
+
+var _ framework.TimedResolution = &Resolver{}
+
+// GetResolutionTimeout returns a time.Duration for the amount of time a
+// single http fetch may take. This can be configured with the
+// fetch-timeout field in the http-resolver-config configmap.
+func (r *Resolver) GetResolutionTimeout(ctx context.Context, defaultTimeout time.Duration) time.Duration {
+	conf := framework.GetResolverConfigFromContext(ctx)
+	if timeoutString, ok := conf[ConfigFieldTimeout]; ok {
+		timeout, err := time.ParseDuration(timeoutString)
+		if err == nil {
+			return timeout
+		}
+	}
+	return defaultTimeout
+}
+
+var _ framework.StringParamsImmutableResolution = &Resolver{}
+
+// IsImmutable returns true if the digest param is set, in which case
+// only a file with that digest can be resolved.
+func (r *Resolver) IsImmutable(_ context.Context, params map[string]string) bool {
+	return params[ParamDigest] != ""
+}
+
+func (r *Resolver) isDisabled(ctx context.Context) bool {
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.FeatureFlags.EnableHTTPResolver {
+		return false
+	}
+
+	return true
+}
+
+// ResolvedHTTPResource implements framework.ResolvedResource and returns
+// the resolved file []byte data and an annotation map for any metadata.
+type ResolvedHTTPResource struct {
+	URL     string
+	Digest  string
+	Content []byte
+}
+
+var _ framework.ResolvedResource = &ResolvedHTTPResource{}
+
+// Data returns the bytes of the file fetched over http.
+func (r *ResolvedHTTPResource) Data() []byte {
+	return r.Content
+}
+
+// Annotations returns the metadata that accompanies the file fetched
+// over http.
+func (r *ResolvedHTTPResource) Annotations() map[string]string {
+	return map[string]string{
+		AnnotationKeyURL:                           r.URL,
+		AnnotationKeyDigest:                        r.Digest,
+		resolutioncommon.AnnotationKeyContentType:  YAMLContentType,
+		resolutioncommon.AnnotationKeySourceURI:    r.URL,
+		resolutioncommon.AnnotationKeySourceDigest: r.Digest,
+	}
+}
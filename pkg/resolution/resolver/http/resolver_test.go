@@ -0,0 +1,442 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	frtesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const taskYAML = "apiVersion: tekton.dev/v1beta1\nkind: Task\nmetadata:\n  name: foo\n"
+
+func TestGetSelector(t *testing.T) {
+	resolver := Resolver{}
+	sel := resolver.GetSelector(resolverContext())
+	if typ, has := sel[resolutioncommon.LabelKeyResolverType]; !has {
+		t.Fatalf("unexpected selector: %v", sel)
+	} else if typ != LabelValueHTTPResolverType {
+		t.Fatalf("unexpected type: %q", typ)
+	}
+}
+
+func TestValidateParams(t *testing.T) {
+	resolver := Resolver{}
+	ctx := framework.InjectResolverConfigToContext(resolverContext(), map[string]string{
+		ConfigAllowedURLPrefixes: "https://artifacts.example.com/tasks/",
+	})
+
+	params := map[string]string{
+		ParamURL:    "https://artifacts.example.com/tasks/foo.yaml",
+		ParamDigest: "sha256:" + sha256Hex(taskYAML),
+	}
+	if err := resolver.ValidateParams(ctx, params); err != nil {
+		t.Fatalf("unexpected error validating params: %v", err)
+	}
+
+	paramsWithBasicAuth := map[string]string{
+		ParamURL:                "https://artifacts.example.com/tasks/foo.yaml",
+		ParamHTTPUsername:       "user",
+		ParamHTTPPasswordSecret: "artifacts-auth",
+	}
+	if err := resolver.ValidateParams(ctx, paramsWithBasicAuth); err != nil {
+		t.Fatalf("unexpected error validating params: %v", err)
+	}
+}
+
+func TestValidateParamsAllowedURLPrefixes(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		prefixes    string
+		url         string
+		expectedErr string
+	}{{
+		name:     "matching prefix",
+		prefixes: "https://artifacts.example.com/tasks/",
+		url:      "https://artifacts.example.com/tasks/foo.yaml",
+	}, {
+		name:     "prefix without trailing slash",
+		prefixes: "https://artifacts.example.com/tasks",
+		url:      "https://artifacts.example.com/tasks/foo.yaml",
+	}, {
+		name:     "host prefix",
+		prefixes: "https://artifacts.example.com",
+		url:      "https://artifacts.example.com/tasks/foo.yaml",
+	}, {
+		name:     "any url",
+		prefixes: "*",
+		url:      "http://anywhere.example.com/foo.yaml",
+	}, {
+		name:        "no allowed prefix",
+		url:         "https://artifacts.example.com/tasks/foo.yaml",
+		expectedErr: `url "https://artifacts.example.com/tasks/foo.yaml" is not allowed: no url prefix is allowed in the http resolver config`,
+	}, {
+		name:        "credentials in the url",
+		prefixes:    "https://artifacts.example.com/tasks/",
+		url:         "https://artifacts.example.com@evil.example.com/tasks/foo.yaml",
+		expectedErr: `url "https://artifacts.example.com@evil.example.com/tasks/foo.yaml" must not contain credentials`,
+	}, {
+		name:        "host with the allowed host as prefix",
+		prefixes:    "https://artifacts.example.com/tasks/",
+		url:         "https://artifacts.example.com.evil.example.com/tasks/foo.yaml",
+		expectedErr: `url "https://artifacts.example.com.evil.example.com/tasks/foo.yaml" does not start with any of the allowed prefixes [https://artifacts.example.com/tasks/]`,
+	}, {
+		name:        "other scheme",
+		prefixes:    "https://artifacts.example.com/tasks/",
+		url:         "http://artifacts.example.com/tasks/foo.yaml",
+		expectedErr: `url "http://artifacts.example.com/tasks/foo.yaml" does not start with any of the allowed prefixes [https://artifacts.example.com/tasks/]`,
+	}, {
+		name:        "path with the allowed path as prefix",
+		prefixes:    "https://artifacts.example.com/tasks",
+		url:         "https://artifacts.example.com/tasks-private/foo.yaml",
+		expectedErr: `url "https://artifacts.example.com/tasks-private/foo.yaml" does not start with any of the allowed prefixes [https://artifacts.example.com/tasks]`,
+	}, {
+		name:        "path out of the allowed path",
+		prefixes:    "https://artifacts.example.com/tasks/",
+		url:         "https://artifacts.example.com/tasks/../private/foo.yaml",
+		expectedErr: `url "https://artifacts.example.com/tasks/../private/foo.yaml" does not start with any of the allowed prefixes [https://artifacts.example.com/tasks/]`,
+	}, {
+		name:        "invalid prefix",
+		prefixes:    "artifacts.example.com/tasks/",
+		url:         "https://artifacts.example.com/tasks/foo.yaml",
+		expectedErr: `invalid allowed url prefix "artifacts.example.com/tasks/" in the http resolver config`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := Resolver{}
+			ctx := framework.InjectResolverConfigToContext(resolverContext(), map[string]string{
+				ConfigAllowedURLPrefixes: tc.prefixes,
+			})
+			err := resolver.ValidateParams(ctx, map[string]string{ParamURL: tc.url})
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error validating params: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected err %q but got none", tc.expectedErr)
+			}
+			if d := cmp.Diff(tc.expectedErr, err.Error()); d != "" {
+				t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateParamsNotEnabled(t *testing.T) {
+	resolver := Resolver{}
+
+	params := map[string]string{
+		ParamURL: "https://artifacts.example.com/tasks/foo.yaml",
+	}
+	err := resolver.ValidateParams(context.Background(), params)
+	if err == nil {
+		t.Fatalf("expected disabled err")
+	}
+	if d := cmp.Diff(disabledError, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateParamsInvalid(t *testing.T) {
+	conf := map[string]string{
+		ConfigAllowedURLPrefixes: "https://artifacts.example.com/tasks/, https://mirror.example.com/",
+	}
+	for _, tc := range []struct {
+		name        string
+		params      map[string]string
+		expectedErr string
+	}{{
+		name:        "missing url",
+		params:      map[string]string{},
+		expectedErr: `parameter "url" required`,
+	}, {
+		name:        "not an http url",
+		params:      map[string]string{ParamURL: "file:///etc/passwd"},
+		expectedErr: `url "file:///etc/passwd" must be an http or https URL`,
+	}, {
+		name:        "url not allowed",
+		params:      map[string]string{ParamURL: "https://artifacts.example.com/pipelines/foo.yaml"},
+		expectedErr: `url "https://artifacts.example.com/pipelines/foo.yaml" does not start with any of the allowed prefixes [https://artifacts.example.com/tasks/ https://mirror.example.com/]`,
+	}, {
+		name: "digest of another algorithm",
+		params: map[string]string{
+			ParamURL:    "https://mirror.example.com/foo.yaml",
+			ParamDigest: "sha512:abcd",
+		},
+		expectedErr: `invalid digest "sha512:abcd": must be of the form sha256:<hex>`,
+	}, {
+		name: "digest too short",
+		params: map[string]string{
+			ParamURL:    "https://mirror.example.com/foo.yaml",
+			ParamDigest: "sha256:abcd",
+		},
+		expectedErr: `invalid digest "sha256:abcd": must be of the form sha256:<hex>`,
+	}, {
+		name: "username without password",
+		params: map[string]string{
+			ParamURL:          "https://mirror.example.com/foo.yaml",
+			ParamHTTPUsername: "user",
+		},
+		expectedErr: `parameter "http-password-secret" required when "http-username" is set`,
+	}, {
+		name: "password without username",
+		params: map[string]string{
+			ParamURL:                "https://mirror.example.com/foo.yaml",
+			ParamHTTPPasswordSecret: "artifacts-auth",
+		},
+		expectedErr: `parameter "http-username" required when "http-password-secret" is set`,
+	}, {
+		name: "basic and bearer auth",
+		params: map[string]string{
+			ParamURL:                "https://mirror.example.com/foo.yaml",
+			ParamHTTPUsername:       "user",
+			ParamHTTPPasswordSecret: "artifacts-auth",
+			ParamHTTPTokenSecret:    "artifacts-token",
+		},
+		expectedErr: `parameters "http-username" and "http-token-secret" are mutually exclusive`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := Resolver{}
+			ctx := framework.InjectResolverConfigToContext(resolverContext(), conf)
+			err := resolver.ValidateParams(ctx, tc.params)
+			if err == nil {
+				t.Fatalf("expected err %q but got none", tc.expectedErr)
+			}
+			if d := cmp.Diff(tc.expectedErr, err.Error()); d != "" {
+				t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetResolutionTimeoutDefault(t *testing.T) {
+	resolver := Resolver{}
+	defaultTimeout := 30 * time.Minute
+	timeout := resolver.GetResolutionTimeout(resolverContext(), defaultTimeout)
+	if timeout != defaultTimeout {
+		t.Fatalf("expected default timeout to be returned")
+	}
+}
+
+func TestGetResolutionTimeoutCustom(t *testing.T) {
+	resolver := Resolver{}
+	defaultTimeout := 30 * time.Minute
+	configTimeout := 5 * time.Second
+	config := map[string]string{
+		ConfigFieldTimeout: configTimeout.String(),
+	}
+	ctx := framework.InjectResolverConfigToContext(resolverContext(), config)
+	timeout := resolver.GetResolutionTimeout(ctx, defaultTimeout)
+	if timeout != configTimeout {
+		t.Fatalf("expected timeout from config to be returned")
+	}
+}
+
+func TestIsImmutable(t *testing.T) {
+	resolver := Resolver{}
+	params := map[string]string{ParamURL: "https://artifacts.example.com/tasks/foo.yaml"}
+	if resolver.IsImmutable(resolverContext(), params) {
+		t.Errorf("expected a url without digest not to be immutable")
+	}
+	params[ParamDigest] = "sha256:" + sha256Hex(taskYAML)
+	if !resolver.IsImmutable(resolverContext(), params) {
+		t.Errorf("expected a url with digest to be immutable")
+	}
+}
+
+func TestResolveNotEnabled(t *testing.T) {
+	resolver := Resolver{}
+
+	params := map[string]string{
+		ParamURL: "https://artifacts.example.com/tasks/foo.yaml",
+	}
+	_, err := resolver.Resolve(context.Background(), params)
+	if err == nil {
+		t.Fatalf("expected disabled err")
+	}
+	if d := cmp.Diff(disabledError, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolve(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tasks/foo.yaml":
+			fmt.Fprint(w, taskYAML)
+		case "/private/foo.yaml":
+			if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "secret-password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, taskYAML)
+		case "/token/foo.yaml":
+			if r.Header.Get("Authorization") != "Bearer secret-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, taskYAML)
+		case "/redirect/foo.yaml":
+			http.Redirect(w, r, "/tasks/foo.yaml", http.StatusFound)
+		case "/large.yaml":
+			fmt.Fprint(w, strings.Repeat("a", 2048))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer svr.Close()
+
+	kubeClientSet := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "artifacts-auth", Namespace: "foo"},
+		Data: map[string][]byte{
+			"password": []byte("secret-password"),
+			"token":    []byte("secret-token"),
+		},
+	})
+
+	for _, tc := range []struct {
+		name        string
+		params      map[string]string
+		conf        map[string]string
+		expectedErr string
+	}{{
+		name:   "plain url",
+		params: map[string]string{ParamURL: svr.URL + "/tasks/foo.yaml"},
+	}, {
+		name: "matching digest",
+		params: map[string]string{
+			ParamURL:    svr.URL + "/tasks/foo.yaml",
+			ParamDigest: "sha256:" + sha256Hex(taskYAML),
+		},
+	}, {
+		name: "basic auth",
+		params: map[string]string{
+			ParamURL:                svr.URL + "/private/foo.yaml",
+			ParamHTTPUsername:       "user",
+			ParamHTTPPasswordSecret: "artifacts-auth",
+		},
+	}, {
+		name: "bearer auth",
+		params: map[string]string{
+			ParamURL:             svr.URL + "/token/foo.yaml",
+			ParamHTTPTokenSecret: "artifacts-auth",
+		},
+	}, {
+		name:   "redirect to an allowed url",
+		params: map[string]string{ParamURL: svr.URL + "/redirect/foo.yaml"},
+		conf:   map[string]string{ConfigAllowedURLPrefixes: svr.URL + "/redirect/," + svr.URL + "/tasks/"},
+	}, {
+		name: "mismatching digest",
+		params: map[string]string{
+			ParamURL:    svr.URL + "/tasks/foo.yaml",
+			ParamDigest: "sha256:" + sha256Hex("something else"),
+		},
+		expectedErr: fmt.Sprintf("digest mismatch for %q: expected sha256:%s but got sha256:%s", svr.URL+"/tasks/foo.yaml", sha256Hex("something else"), sha256Hex(taskYAML)),
+	}, {
+		name: "wrong key in secret",
+		params: map[string]string{
+			ParamURL:                   svr.URL + "/private/foo.yaml",
+			ParamHTTPUsername:          "user",
+			ParamHTTPPasswordSecret:    "artifacts-auth",
+			ParamHTTPPasswordSecretKey: "pass",
+		},
+		expectedErr: `key "pass" not found in secret "artifacts-auth" in namespace "foo"`,
+	}, {
+		name: "missing secret",
+		params: map[string]string{
+			ParamURL:             svr.URL + "/token/foo.yaml",
+			ParamHTTPTokenSecret: "missing",
+		},
+		expectedErr: `error getting secret "missing" in namespace "foo": secrets "missing" not found`,
+	}, {
+		name:        "unauthorized",
+		params:      map[string]string{ParamURL: svr.URL + "/private/foo.yaml"},
+		expectedErr: fmt.Sprintf("requested url %q returned status %q", svr.URL+"/private/foo.yaml", "401 Unauthorized"),
+	}, {
+		name:        "redirect to a url that isn't allowed",
+		params:      map[string]string{ParamURL: svr.URL + "/redirect/foo.yaml"},
+		conf:        map[string]string{ConfigAllowedURLPrefixes: svr.URL + "/redirect/"},
+		expectedErr: fmt.Sprintf(`error requesting %q: Get "/tasks/foo.yaml": url %q does not start with any of the allowed prefixes [%s/redirect/]`, svr.URL+"/redirect/foo.yaml", svr.URL+"/tasks/foo.yaml", svr.URL),
+	}, {
+		name:        "body too large",
+		params:      map[string]string{ParamURL: svr.URL + "/large.yaml"},
+		conf:        map[string]string{ConfigMaxBodySize: "1Ki"},
+		expectedErr: fmt.Sprintf("requested url %q returned more than 1024 bytes", svr.URL+"/large.yaml"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &Resolver{kubeClientSet: kubeClientSet}
+			conf := map[string]string{ConfigAllowedURLPrefixes: svr.URL + "/"}
+			for k, v := range tc.conf {
+				conf[k] = v
+			}
+			ctx := framework.InjectResolverConfigToContext(resolverContext(), conf)
+			ctx = resolutioncommon.InjectRequestNamespace(ctx, "foo")
+
+			output, err := resolver.Resolve(ctx, tc.params)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatalf("expected err %q but got none", tc.expectedErr)
+				}
+				if d := cmp.Diff(tc.expectedErr, err.Error()); d != "" {
+					t.Fatalf("unexpected error: %s", diff.PrintWantGot(d))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			if d := cmp.Diff([]byte(taskYAML), output.Data()); d != "" {
+				t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+			}
+			expectedAnnotations := map[string]string{
+				AnnotationKeyURL:                           tc.params[ParamURL],
+				AnnotationKeyDigest:                        "sha256:" + sha256Hex(taskYAML),
+				resolutioncommon.AnnotationKeyContentType:  YAMLContentType,
+				resolutioncommon.AnnotationKeySourceURI:    tc.params[ParamURL],
+				resolutioncommon.AnnotationKeySourceDigest: "sha256:" + sha256Hex(taskYAML),
+			}
+			if d := cmp.Diff(expectedAnnotations, output.Annotations()); d != "" {
+				t.Errorf("unexpected annotations from Resolve: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func resolverContext() context.Context {
+	return frtesting.ContextWithHTTPResolverEnabled(context.Background())
+}
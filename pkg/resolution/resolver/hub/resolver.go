@@ -15,6 +15,8 @@ package hub
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -34,7 +36,7 @@ const (
 	disabledError = "cannot handle resolution request, enable-hub-resolver feature flag not true"
 )
 
-// Resolver implements a framework.Resolver that can fetch files from OCI bundles.
+// Resolver implements a framework.StringParamsResolver that can fetch files from OCI bundles.
 type Resolver struct {
 	// HubURL is the URL for hub resolver
 	HubURL string
@@ -139,12 +141,16 @@ func (r *Resolver) Resolve(ctx context.Context, params map[string]string) (frame
 		return nil, fmt.Errorf("error unmarshalling json response: %w", err)
 	}
 	return &ResolvedHubResource{
+		URL:     url,
 		Content: []byte(hr.Data.YAML),
 	}, nil
 }
 
 // ResolvedHubResource wraps the data we want to return to Pipelines
 type ResolvedHubResource struct {
+	// URL is the hub API URL the resource was fetched from, which
+	// identifies its catalog, kind, name and version.
+	URL     string
 	Content []byte
 }
 
@@ -155,9 +161,14 @@ func (rr *ResolvedHubResource) Data() []byte {
 	return rr.Content
 }
 
-// Annotations returns any metadata needed alongside the data. None atm.
-func (*ResolvedHubResource) Annotations() map[string]string {
-	return nil
+// Annotations returns the URL the resource was fetched from and the
+// digest of its content.
+func (rr *ResolvedHubResource) Annotations() map[string]string {
+	sum := sha256.Sum256(rr.Content)
+	return map[string]string{
+		common.AnnotationKeySourceURI:    rr.URL,
+		common.AnnotationKeySourceDigest: "sha256:" + hex.EncodeToString(sum[:]),
+	}
 }
 
 func (r *Resolver) isDisabled(ctx context.Context) bool {
@@ -18,6 +18,8 @@ package hub
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -220,12 +222,22 @@ func TestResolve(t *testing.T) {
 				}
 
 				expectedResource := &ResolvedHubResource{
+					URL:     fmt.Sprintf(resolver.HubURL, params[ParamCatalog], params[ParamKind], params[ParamName], params[ParamVersion]),
 					Content: tc.expectedRes,
 				}
 
 				if d := cmp.Diff(expectedResource, output); d != "" {
 					t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
 				}
+
+				sum := sha256.Sum256(tc.expectedRes)
+				expectedAnnotations := map[string]string{
+					resolutioncommon.AnnotationKeySourceURI:    expectedResource.URL,
+					resolutioncommon.AnnotationKeySourceDigest: "sha256:" + hex.EncodeToString(sum[:]),
+				}
+				if d := cmp.Diff(expectedAnnotations, output.Annotations()); d != "" {
+					t.Errorf("unexpected annotations from Resolve: %s", diff.PrintWantGot(d))
+				}
 			}
 		})
 	}
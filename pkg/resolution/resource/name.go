@@ -21,6 +21,8 @@ import (
 	"hash"
 	"hash/fnv"
 	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 )
 
 // nameHasher returns the hash.Hash to use when generating names.
@@ -33,21 +35,31 @@ func nameHasher() hash.Hash {
 // will have the format {prefix}-{hash} where {prefix} is
 // given and {hash} is nameHasher(base) + nameHasher(param1) +
 // nameHasher(param2) + ...
-func GenerateDeterministicName(prefix, base string, params map[string]string) (string, error) {
-	paramKeys := []string{}
-	for key := range params {
-		paramKeys = append(paramKeys, key)
-	}
+func GenerateDeterministicName(prefix, base string, params []v1beta1.Param) (string, error) {
+	sortedParams := make([]v1beta1.Param, len(params))
+	copy(sortedParams, params)
+	sort.SliceStable(sortedParams, func(i, j int) bool {
+		return sortedParams[i].Name < sortedParams[j].Name
+	})
 	hasher := nameHasher()
 	if _, err := hasher.Write([]byte(base)); err != nil {
 		return "", err
 	}
-	sort.Strings(paramKeys)
-	for _, key := range paramKeys {
-		if _, err := hasher.Write([]byte(key)); err != nil {
+	for _, p := range sortedParams {
+		if _, err := hasher.Write([]byte(p.Name)); err != nil {
 			return "", err
 		}
-		if _, err := hasher.Write([]byte(params[key])); err != nil {
+		// String values are hashed as is, so that requests with
+		// only string params keep the names they had before params
+		// were typed.
+		value := []byte(p.Value.StringVal)
+		if p.Value.Type == v1beta1.ParamTypeArray || p.Value.Type == v1beta1.ParamTypeObject {
+			var err error
+			if value, err = p.Value.MarshalJSON(); err != nil {
+				return "", err
+			}
+		}
+		if _, err := hasher.Write(value); err != nil {
 			return "", err
 		}
 	}
@@ -19,6 +19,7 @@ package resource
 import (
 	"context"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -43,7 +44,7 @@ type Requester interface {
 type Request interface {
 	Name() string
 	Namespace() string
-	Params() map[string]string
+	Params() []v1beta1.Param
 }
 
 // OwnedRequest is implemented by any type implementing Request that also needs
@@ -48,6 +48,9 @@ var (
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
 	podTag         = tag.MustNewKey("pod")
+	reasonTag      = tag.MustNewKey("reason")
+	priorityTag    = tag.MustNewKey("priority_class")
+	stepTag        = tag.MustNewKey("step")
 
 	trDurationView      *view.View
 	prTRDurationView    *view.View
@@ -55,6 +58,12 @@ var (
 	runningTRsCountView *view.View
 	podLatencyView      *view.View
 	cloudEventsView     *view.View
+	trPrunedCountView   *view.View
+	trQueueWaitView     *view.View
+	stepMemoryView      *view.View
+	stepCPUView         *view.View
+	stepIOReadView      *view.View
+	stepIOWriteView     *view.View
 
 	trDuration = stats.Float64(
 		"taskrun_duration_seconds",
@@ -81,6 +90,30 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	trPrunedCount = stats.Float64("taskrun_pruned_count",
+		"number of completed taskruns deleted by the pruner",
+		stats.UnitDimensionless)
+
+	trQueueWait = stats.Float64("taskrun_queue_wait_seconds",
+		"The time taskruns wait for their pod to be created after their creation in seconds",
+		stats.UnitDimensionless)
+
+	stepMemory = stats.Int64("taskrun_step_peak_memory_bytes",
+		"The highest memory usage of the steps of the taskruns in bytes",
+		stats.UnitBytes)
+
+	stepCPU = stats.Float64("taskrun_step_cpu_seconds",
+		"The CPU time used by the steps of the taskruns in seconds",
+		stats.UnitDimensionless)
+
+	stepIORead = stats.Int64("taskrun_step_io_read_bytes",
+		"The number of bytes read from block devices by the steps of the taskruns",
+		stats.UnitBytes)
+
+	stepIOWrite = stats.Int64("taskrun_step_io_write_bytes",
+		"The number of bytes written to block devices by the steps of the taskruns",
+		stats.UnitBytes)
 )
 
 // Recorder is used to actually record TaskRun metrics
@@ -191,6 +224,18 @@ func viewRegister(cfg *config.Metrics) error {
 		Aggregation: distribution,
 		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
 	}
+	trPrunedCountView = &view.View{
+		Description: trPrunedCount.Description(),
+		Measure:     trPrunedCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{namespaceTag, reasonTag},
+	}
+	trQueueWaitView = &view.View{
+		Description: trQueueWait.Description(),
+		Measure:     trQueueWait,
+		Aggregation: view.Distribution(1, 5, 10, 30, 60, 300, 900, 1800, 3600),
+		TagKeys:     []tag.Key{namespaceTag, priorityTag},
+	}
 	trCountView = &view.View{
 		Description: trCount.Description(),
 		Measure:     trCount,
@@ -214,6 +259,32 @@ func viewRegister(cfg *config.Metrics) error {
 		Aggregation: view.Sum(),
 		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
 	}
+	stepTags := append([]tag.Key{namespaceTag, stepTag}, trunTag...)
+	bytesDistribution := view.Distribution(1<<20, 16<<20, 64<<20, 256<<20, 1<<30, 4<<30, 16<<30, 64<<30)
+	stepMemoryView = &view.View{
+		Description: stepMemory.Description(),
+		Measure:     stepMemory,
+		Aggregation: bytesDistribution,
+		TagKeys:     stepTags,
+	}
+	stepCPUView = &view.View{
+		Description: stepCPU.Description(),
+		Measure:     stepCPU,
+		Aggregation: view.Distribution(1, 5, 10, 30, 60, 300, 900, 1800, 3600),
+		TagKeys:     stepTags,
+	}
+	stepIOReadView = &view.View{
+		Description: stepIORead.Description(),
+		Measure:     stepIORead,
+		Aggregation: bytesDistribution,
+		TagKeys:     stepTags,
+	}
+	stepIOWriteView = &view.View{
+		Description: stepIOWrite.Description(),
+		Measure:     stepIOWrite,
+		Aggregation: bytesDistribution,
+		TagKeys:     stepTags,
+	}
 	return view.Register(
 		trDurationView,
 		prTRDurationView,
@@ -221,6 +292,12 @@ func viewRegister(cfg *config.Metrics) error {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		trPrunedCountView,
+		trQueueWaitView,
+		stepMemoryView,
+		stepCPUView,
+		stepIOReadView,
+		stepIOWriteView,
 	)
 }
 
@@ -232,6 +309,12 @@ func viewUnregister() {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		trPrunedCountView,
+		trQueueWaitView,
+		stepMemoryView,
+		stepCPUView,
+		stepIOReadView,
+		stepIOWriteView,
 	)
 }
 
@@ -341,6 +424,49 @@ func (r *Recorder) DurationAndCount(ctx context.Context, tr *v1beta1.TaskRun, be
 	return nil
 }
 
+// StepResourceUsage logs the resources used by each step of the TaskRun which reported them
+// when the TaskRun completes
+// returns an error if its failed to log the metrics
+func (r *Recorder) StepResourceUsage(ctx context.Context, tr *v1beta1.TaskRun, beforeCondition *apis.Condition) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if !tr.IsDone() || equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	taskName := "anonymous"
+	if tr.Spec.TaskRef != nil {
+		taskName = tr.Spec.TaskRef.Name
+	}
+
+	for _, step := range tr.Status.Steps {
+		if step.ResourceUsage == nil {
+			continue
+		}
+		ctx, err := tag.New(
+			ctx,
+			append([]tag.Mutator{tag.Insert(namespaceTag, tr.Namespace),
+				tag.Insert(stepTag, step.Name)},
+				r.insertTaskTag(taskName, tr.Name)...)...)
+		if err != nil {
+			return err
+		}
+		usage := step.ResourceUsage
+		metrics.Record(ctx, stepMemory.M(usage.PeakMemory.Value()))
+		metrics.Record(ctx, stepCPU.M(usage.CPUTime.Seconds()))
+		metrics.Record(ctx, stepIORead.M(usage.IOReadBytes))
+		metrics.Record(ctx, stepIOWrite.M(usage.IOWriteBytes))
+	}
+
+	return nil
+}
+
 // RunningTaskRuns logs the number of TaskRuns running right now
 // returns an error if its failed to log the metrics
 func (r *Recorder) RunningTaskRuns(ctx context.Context, lister listers.TaskRunLister) error {
@@ -395,6 +521,50 @@ func (r *Recorder) ReportRunningTaskRuns(ctx context.Context, lister listers.Tas
 	}
 }
 
+// Pruned logs the deletion of a completed TaskRun by the pruner, for the given reason
+// returns an error if its failed to log the metrics
+func (r *Recorder) Pruned(ctx context.Context, tr *v1beta1.TaskRun, reason string) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ctx, err := tag.New(
+		ctx,
+		tag.Insert(namespaceTag, tr.Namespace),
+		tag.Insert(reasonTag, reason))
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, trPrunedCount.M(1))
+	return nil
+}
+
+// QueueWait logs the time the TaskRun waited for its pod to be created at podCreated, by PriorityClass
+// returns an error if its failed to log the metrics
+func (r *Recorder) QueueWait(ctx context.Context, tr *v1beta1.TaskRun, podCreated time.Time) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ctx, err := tag.New(
+		ctx,
+		tag.Insert(namespaceTag, tr.Namespace),
+		tag.Insert(priorityTag, tr.GetPriorityClassName()))
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, trQueueWait.M(podCreated.Sub(tr.CreationTimestamp.Time).Seconds()))
+	return nil
+}
+
 // RecordPodLatency logs the duration required to schedule the pod for TaskRun
 // returns an error if its failed to log the metrics
 func (r *Recorder) RecordPodLatency(ctx context.Context, pod *corev1.Pod, tr *v1beta1.TaskRun) error {
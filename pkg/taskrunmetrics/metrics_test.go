@@ -25,12 +25,14 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	faketaskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun/fake"
 	"github.com/tektoncd/pipeline/pkg/names"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -78,6 +80,15 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.CloudEvents(ctx, &v1beta1.TaskRun{}); err == nil {
 		t.Error("Cloud Events recording expected to return error but got nil")
 	}
+	if err := metrics.Pruned(ctx, &v1beta1.TaskRun{}, "TTLExpired"); err == nil {
+		t.Error("Pruned recording expected to return error but got nil")
+	}
+	if err := metrics.QueueWait(ctx, &v1beta1.TaskRun{}, time.Now()); err == nil {
+		t.Error("QueueWait recording expected to return error but got nil")
+	}
+	if err := metrics.StepResourceUsage(ctx, &v1beta1.TaskRun{}, beforeCondition); err == nil {
+		t.Error("StepResourceUsage recording expected to return error but got nil")
+	}
 }
 
 func TestMetricsOnStore(t *testing.T) {
@@ -422,6 +433,102 @@ func TestRecordRunningTaskRunsCount(t *testing.T) {
 	metricstest.CheckLastValueData(t, "running_taskruns_count", map[string]string{}, 1)
 }
 
+func TestRecordPrunedTaskRuns(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns"}}
+	if err := metrics.Pruned(ctx, tr, "TTLExpired"); err != nil {
+		t.Errorf("Pruned: %v", err)
+	}
+	metricstest.CheckCountData(t, "taskrun_pruned_count", map[string]string{
+		"namespace": "ns",
+		"reason":    "TTLExpired",
+	}, 1)
+}
+
+func TestRecordTaskRunQueueWait(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	created := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	priorityClassName := "nightly"
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns", CreationTimestamp: metav1.Time{Time: created}},
+		Spec:       v1beta1.TaskRunSpec{PodTemplate: &pod.Template{PriorityClassName: &priorityClassName}},
+	}
+	if err := metrics.QueueWait(ctx, tr, created.Add(90*time.Second)); err != nil {
+		t.Errorf("QueueWait: %v", err)
+	}
+	metricstest.CheckDistributionData(t, "taskrun_queue_wait_seconds", map[string]string{
+		"namespace":      "ns",
+		"priority_class": "nightly",
+	}, 1, 90, 90)
+}
+
+func TestRecordStepResourceUsage(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns"},
+		Spec:       v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: "task-1"}},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					Name: "build",
+					ResourceUsage: &v1beta1.StepResourceUsage{
+						PeakMemory:   resource.MustParse("64Mi"),
+						CPUTime:      metav1.Duration{Duration: 90 * time.Second},
+						IOReadBytes:  1024,
+						IOWriteBytes: 2048,
+					},
+				}, {
+					Name: "unsampled",
+				}},
+			},
+		},
+	}
+	beforeCondition := &apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+	}
+	if err := metrics.StepResourceUsage(ctx, tr, beforeCondition); err != nil {
+		t.Errorf("StepResourceUsage: %v", err)
+	}
+	tags := map[string]string{
+		"namespace": "ns",
+		"step":      "build",
+		"task":      "task-1",
+		"taskrun":   "taskrun-1",
+	}
+	metricstest.CheckDistributionData(t, "taskrun_step_peak_memory_bytes", tags, 1, 64<<20, 64<<20)
+	metricstest.CheckDistributionData(t, "taskrun_step_cpu_seconds", tags, 1, 90, 90)
+	metricstest.CheckDistributionData(t, "taskrun_step_io_read_bytes", tags, 1, 1024, 1024)
+	metricstest.CheckDistributionData(t, "taskrun_step_io_write_bytes", tags, 1, 2048, 2048)
+}
+
 func TestRecordPodLatency(t *testing.T) {
 	creationTime := metav1.Now()
 
@@ -690,7 +797,7 @@ func TestRecordCloudEvents(t *testing.T) {
 }
 
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count", "taskrun_pruned_count", "taskrun_queue_wait_seconds", "taskrun_step_peak_memory_bytes", "taskrun_step_cpu_seconds", "taskrun_step_io_read_bytes", "taskrun_step_io_write_bytes")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// rootSpanContextKey is the key of the context of the root span of a run being ended in the
+// context passed to the tracer, whose IDs the span is given.
+type rootSpanContextKey struct{}
+
+// idGenerator generates random IDs for the spans, like the default generator of the SDK, except
+// for the root span of a run being ended, which is given the IDs of the span context the run
+// stored when it started, so that the spans of its reconciles and steps are its children.
+type idGenerator struct {
+	sync.Mutex
+	random *rand.Rand
+}
+
+var _ sdktrace.IDGenerator = (*idGenerator)(nil)
+
+func newIDGenerator() *idGenerator {
+	var seed int64
+	_ = binary.Read(crand.Reader, binary.LittleEndian, &seed)
+	return &idGenerator{random: rand.New(rand.NewSource(seed))}
+}
+
+// NewIDs implements sdktrace.IDGenerator.
+func (g *idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	if sc, ok := ctx.Value(rootSpanContextKey{}).(trace.SpanContext); ok {
+		return sc.TraceID(), sc.SpanID()
+	}
+	g.Lock()
+	defer g.Unlock()
+	tid := trace.TraceID{}
+	_, _ = g.random.Read(tid[:])
+	sid := trace.SpanID{}
+	_, _ = g.random.Read(sid[:])
+	return tid, sid
+}
+
+// NewSpanID implements sdktrace.IDGenerator.
+func (g *idGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	g.Lock()
+	defer g.Unlock()
+	sid := trace.SpanID{}
+	_, _ = g.random.Read(sid[:])
+	return sid
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PipelineRunSpanContextAnnotation is the annotation storing the context of the root span of a
+	// PipelineRun, which the spans of its reconciles are children of.
+	PipelineRunSpanContextAnnotation = "tekton.dev/pipelinerunSpanContext"
+	// TaskRunSpanContextAnnotation is the annotation storing the context of the root span of a
+	// TaskRun, which the spans of its reconciles and of its steps are children of. It is set by
+	// the PipelineRun creating the TaskRun, if any, and propagated to the pod of the TaskRun.
+	TaskRunSpanContextAnnotation = "tekton.dev/taskrunSpanContext"
+
+	// TraceParentEnvVar and TraceStateEnvVar are the environment variables passing the context
+	// of the span of a TaskRun to the entrypoint of its steps, and to the processes they run.
+	TraceParentEnvVar = "TRACEPARENT"
+	TraceStateEnvVar  = "TRACESTATE"
+	// OTLPEndpointEnvVar is the environment variable passing the base URL of the OTLP/HTTP
+	// collector the spans of the steps are exported to.
+	OTLPEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// StepNameEnvVar is the environment variable passing the name of the step to its entrypoint,
+	// which names its span after it.
+	StepNameEnvVar = "TEKTON_STEP_NAME"
+)
+
+// rootSpanStartTimeKey is the key of the time the root span of a run started at in the annotation
+// storing its context. It is only set while the span is open, from the first reconcile of the
+// run until it is done.
+const rootSpanStartTimeKey = "startTime"
+
+// propagator propagates the span contexts in the W3C Trace Context format.
+var propagator = propagation.TraceContext{}
+
+// StartRun starts the span of a reconcile of a run, as a child of the root span of the run
+// whose context is stored in the given annotation of the run. If the run has no root span yet,
+// it is started and its context and start time stored in the annotation, unless tracing is
+// disabled. The root span lasts as long as the run, across reconciles and restarts of the
+// controller, so it is ended by EndRun once the run is done rather than by this process.
+func StartRun(ctx context.Context, meta *metav1.ObjectMeta, annotation, kind, spanName string) (context.Context, trace.Span) {
+	attributes := runAttributes(meta, kind)
+	runCtx := ExtractAnnotation(ctx, meta.Annotations, annotation)
+	if !trace.SpanContextFromContext(runCtx).IsValid() {
+		start := time.Now()
+		runCtx, _ = Tracer().Start(ctx, kind, attributes, trace.WithTimestamp(start))
+		carrier := propagation.MapCarrier{}
+		propagator.Inject(runCtx, carrier)
+		if len(carrier) != 0 {
+			carrier[rootSpanStartTimeKey] = start.Format(time.RFC3339Nano)
+			writeAnnotation(&meta.Annotations, annotation, carrier)
+		}
+	}
+	return Tracer().Start(runCtx, spanName, attributes)
+}
+
+// EndRun ends the root span of a run at the given time, if it was started by StartRun and
+// isn't ended yet. The span is recreated with the context and start time stored in the given
+// annotation of the run, which no longer stores the start time once the span is ended.
+func EndRun(ctx context.Context, meta *metav1.ObjectMeta, annotation, kind string, end time.Time) {
+	carrier, ok := readAnnotation(meta.Annotations, annotation)
+	if !ok {
+		return
+	}
+	start, err := time.Parse(time.RFC3339Nano, carrier[rootSpanStartTimeKey])
+	if err != nil {
+		return
+	}
+	if sc := trace.SpanContextFromContext(propagator.Extract(ctx, carrier)); sc.IsValid() {
+		ctx = context.WithValue(ctx, rootSpanContextKey{}, sc)
+		_, root := Tracer().Start(ctx, kind, trace.WithNewRoot(), runAttributes(meta, kind), trace.WithTimestamp(start))
+		root.End(trace.WithTimestamp(end))
+	}
+	delete(carrier, rootSpanStartTimeKey)
+	writeAnnotation(&meta.Annotations, annotation, carrier)
+}
+
+// runAttributes returns the attributes of the spans of a run.
+func runAttributes(meta *metav1.ObjectMeta, kind string) trace.SpanStartEventOption {
+	return trace.WithAttributes(
+		attribute.String("namespace", meta.Namespace),
+		attribute.String(strings.ToLower(kind), meta.Name),
+	)
+}
+
+// InjectAnnotation stores the span context of the given context in the given annotation, if
+// it is valid.
+func InjectAnnotation(ctx context.Context, annotations *map[string]string, annotation string) {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return
+	}
+	writeAnnotation(annotations, annotation, carrier)
+}
+
+// ExtractAnnotation returns a copy of the given context with the remote span context stored in
+// the given annotation, if any.
+func ExtractAnnotation(ctx context.Context, annotations map[string]string, annotation string) context.Context {
+	carrier, ok := readAnnotation(annotations, annotation)
+	if !ok {
+		return ctx
+	}
+	return propagator.Extract(ctx, carrier)
+}
+
+func writeAnnotation(annotations *map[string]string, annotation string, carrier propagation.MapCarrier) {
+	value, err := json.Marshal(carrier)
+	if err != nil {
+		return
+	}
+	if *annotations == nil {
+		*annotations = map[string]string{}
+	}
+	(*annotations)[annotation] = string(value)
+}
+
+func readAnnotation(annotations map[string]string, annotation string) (propagation.MapCarrier, bool) {
+	value, ok := annotations[annotation]
+	if !ok {
+		return nil, false
+	}
+	carrier := propagation.MapCarrier{}
+	if err := json.Unmarshal([]byte(value), &carrier); err != nil {
+		return nil, false
+	}
+	return carrier, true
+}
+
+// Env returns the environment variables passing the span context of the given context, if it
+// is valid.
+func Env(ctx context.Context) []corev1.EnvVar {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	var env []corev1.EnvVar
+	if traceParent := carrier.Get("traceparent"); traceParent != "" {
+		env = append(env, corev1.EnvVar{Name: TraceParentEnvVar, Value: traceParent})
+	}
+	if traceState := carrier.Get("tracestate"); traceState != "" {
+		env = append(env, corev1.EnvVar{Name: TraceStateEnvVar, Value: traceState})
+	}
+	return env
+}
+
+// ExtractEnv returns a copy of the given context with the remote span context passed in the
+// environment variables looked up with getenv, if any.
+func ExtractEnv(ctx context.Context, getenv func(string) string) context.Context {
+	return propagator.Extract(ctx, propagation.MapCarrier{
+		"traceparent": getenv(TraceParentEnvVar),
+		"tracestate":  getenv(TraceStateEnvVar),
+	})
+}
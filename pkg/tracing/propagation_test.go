@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStartRun(t *testing.T) {
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithIDGenerator(newIDGenerator())))
+
+	meta := &metav1.ObjectMeta{Name: "pr", Namespace: "ns"}
+	_, first := StartRun(context.Background(), meta, PipelineRunSpanContextAnnotation, "PipelineRun", "PipelineRun:ReconcileKind")
+	first.End()
+	if _, ok := meta.Annotations[PipelineRunSpanContextAnnotation]; !ok {
+		t.Fatalf("Expected the span context of the PipelineRun to be stored in its annotations but got %v", meta.Annotations)
+	}
+	annotation := meta.Annotations[PipelineRunSpanContextAnnotation]
+	_, second := StartRun(context.Background(), meta, PipelineRunSpanContextAnnotation, "PipelineRun", "PipelineRun:ReconcileKind")
+	second.End()
+	if meta.Annotations[PipelineRunSpanContextAnnotation] != annotation {
+		t.Errorf("Expected the span context of the PipelineRun to be unchanged but got %q", meta.Annotations[PipelineRunSpanContextAnnotation])
+	}
+	if spans := recorder.Ended(); len(spans) != 2 {
+		t.Fatalf("Expected only the spans of the 2 reconciles to be ended before the PipelineRun is done but got %d spans", len(spans))
+	}
+
+	EndRun(context.Background(), meta, PipelineRunSpanContextAnnotation, "PipelineRun", time.Now())
+	// The root span is only ended once.
+	EndRun(context.Background(), meta, PipelineRunSpanContextAnnotation, "PipelineRun", time.Now())
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected the spans of the 2 reconciles and the root span but got %d spans", len(spans))
+	}
+	root := spans[2]
+	if root.Name() != "PipelineRun" || root.Parent().IsValid() {
+		t.Errorf("Expected the last span to be the root span of the PipelineRun but got %q", root.Name())
+	}
+	for _, s := range spans[:2] {
+		if s.Name() != "PipelineRun:ReconcileKind" || s.Parent().SpanID() != root.SpanContext().SpanID() || s.Parent().TraceID() != root.SpanContext().TraceID() {
+			t.Errorf("Expected span %q to be a child of the root span of the PipelineRun", s.Name())
+		}
+		if s.StartTime().Before(root.StartTime()) || s.EndTime().After(root.EndTime()) {
+			t.Errorf("Expected the root span of the PipelineRun from %v to %v to cover span %q from %v to %v", root.StartTime(), root.EndTime(), s.Name(), s.StartTime(), s.EndTime())
+		}
+	}
+	carrier, _ := readAnnotation(meta.Annotations, PipelineRunSpanContextAnnotation)
+	if _, ok := carrier[rootSpanStartTimeKey]; ok {
+		t.Errorf("Expected the start time of the ended root span to be removed from the annotation but got %v", carrier)
+	}
+	if !trace.SpanContextFromContext(ExtractAnnotation(context.Background(), meta.Annotations, PipelineRunSpanContextAnnotation)).IsValid() {
+		t.Errorf("Expected the span context of the PipelineRun to be kept in its annotations but got %v", meta.Annotations)
+	}
+}
+
+func TestEndRun_NotStartedByRun(t *testing.T) {
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithIDGenerator(newIDGenerator())))
+
+	// The TaskRun of a PipelineRun is a child of the span creating it, which the PipelineRun ends.
+	ctx, create := Tracer().Start(context.Background(), "createTaskRun")
+	meta := &metav1.ObjectMeta{Name: "tr", Namespace: "ns"}
+	InjectAnnotation(ctx, &meta.Annotations, TaskRunSpanContextAnnotation)
+	create.End()
+	annotation := meta.Annotations[TaskRunSpanContextAnnotation]
+
+	EndRun(context.Background(), meta, TaskRunSpanContextAnnotation, "TaskRun", time.Now())
+	if spans := recorder.Ended(); len(spans) != 1 {
+		t.Errorf("Expected no root span to be ended for the TaskRun but got %d spans", len(spans))
+	}
+	if meta.Annotations[TaskRunSpanContextAnnotation] != annotation {
+		t.Errorf("Expected the span context of the TaskRun to be unchanged but got %q", meta.Annotations[TaskRunSpanContextAnnotation])
+	}
+}
+
+func TestStartRun_Disabled(t *testing.T) {
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	meta := &metav1.ObjectMeta{Name: "tr", Namespace: "ns"}
+	_, span := StartRun(context.Background(), meta, TaskRunSpanContextAnnotation, "TaskRun", "TaskRun:ReconcileKind")
+	span.End()
+	if meta.Annotations != nil {
+		t.Errorf("Expected no annotations when tracing is disabled but got %v", meta.Annotations)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer(TracerName).Start(context.Background(), "taskrun")
+	defer span.End()
+
+	env := map[string]string{}
+	for _, e := range Env(ctx) {
+		env[e.Name] = e.Value
+	}
+	if _, ok := env[TraceParentEnvVar]; !ok {
+		t.Fatalf("Expected %s to be set but got %v", TraceParentEnvVar, env)
+	}
+	extracted := trace.SpanContextFromContext(ExtractEnv(context.Background(), func(name string) string { return env[name] }))
+	if !extracted.Equal(span.SpanContext().WithRemote(true)) {
+		t.Errorf("Expected the span context %v to be extracted but got %v", span.SpanContext(), extracted)
+	}
+
+	if env := Env(context.Background()); len(env) != 0 {
+		t.Errorf("Expected no environment variables without a span but got %v", env)
+	}
+}
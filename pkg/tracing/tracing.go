@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// TracerName is the name of the tracer creating the spans of Tekton.
+	TracerName = "tekton.dev/pipeline"
+	// ControllerServiceName is the name of the service of the spans of the controller.
+	ControllerServiceName = "tekton-pipelines-controller"
+	// EntrypointServiceName is the name of the service of the spans of the steps.
+	EntrypointServiceName = "tekton-pipelines-entrypoint"
+)
+
+// shutdownTimeout is how long the spans of a provider being replaced are given to be exported.
+const shutdownTimeout = 30 * time.Second
+
+// Tracer returns the tracer creating the spans of Tekton with the current provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// NewTracerProvider returns a provider of tracers exporting the spans of the given service as
+// configured, and the function shutting it down once its spans are exported.
+func NewTracerProvider(ctx context.Context, cfg config.Tracing, serviceName string) (trace.TracerProvider, func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var opts []sdktrace.TracerProviderOption
+	closeExporter := func() error { return nil }
+	switch cfg.Backend {
+	case config.TracingBackendOTLP:
+		u, err := url.Parse(cfg.OTLPEndpoint)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing the OTLP endpoint %q: %w", cfg.OTLPEndpoint, err)
+		}
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+		if u.Scheme == "http" {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		// As with OTEL_EXPORTER_OTLP_ENDPOINT, the endpoint is the base URL of the collector.
+		clientOpts = append(clientOpts, otlptracehttp.WithURLPath(path.Join("/", u.Path, "v1/traces")))
+		if exporter, err = otlptracehttp.New(ctx, clientOpts...); err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case config.TracingBackendFile:
+		f, err := os.OpenFile(cfg.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, nil, err
+		}
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(f)); err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		// The spans are written as soon as they end, so that they can be read right away.
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+		closeExporter = f.Close
+	default:
+		return trace.NewNoopTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	provider := sdktrace.NewTracerProvider(append(opts,
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithIDGenerator(newIDGenerator()),
+	)...)
+	return provider, func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeErr := closeExporter(); err == nil {
+			err = closeErr
+		}
+		return err
+	}, nil
+}
+
+var (
+	providerMutex    sync.Mutex
+	providerConfig   *config.Tracing
+	providerShutdown func(context.Context) error
+)
+
+// OnStore returns a function that checks if tracing is configured for a config.Store, and sets
+// the global provider of tracers exporting the spans of the given service accordingly if so
+func OnStore(logger *zap.SugaredLogger, serviceName string) func(name string, value interface{}) {
+	return func(name string, value interface{}) {
+		if name != config.GetMetricsConfigName() {
+			return
+		}
+		cfg, ok := value.(*config.Metrics)
+		if !ok {
+			logger.Error("Failed to do type insertion for extracting tracing config")
+			return
+		}
+		if err := setTracerProvider(cfg.Tracing, serviceName); err != nil {
+			logger.Errorf("Failed to set the tracer provider: %v", err)
+		}
+	}
+}
+
+// setTracerProvider replaces the global provider of tracers with one exporting the spans as
+// configured, unless it already does.
+func setTracerProvider(cfg config.Tracing, serviceName string) error {
+	providerMutex.Lock()
+	defer providerMutex.Unlock()
+	if providerConfig != nil && *providerConfig == cfg {
+		return nil
+	}
+
+	provider, shutdown, err := NewTracerProvider(context.Background(), cfg, serviceName)
+	if err != nil {
+		return err
+	}
+	otel.SetTracerProvider(provider)
+	if providerShutdown != nil {
+		go func(shutdown func(context.Context) error) {
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			_ = shutdown(ctx)
+		}(providerShutdown)
+	}
+	providerConfig, providerShutdown = &cfg, shutdown
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+// readSpanNames returns the names of the spans written in the given file by the file backend.
+func readSpanNames(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Error opening the spans file: %v", err)
+	}
+	defer f.Close()
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var span struct{ Name string }
+		if err := json.Unmarshal(scanner.Bytes(), &span); err != nil {
+			t.Fatalf("Error parsing span %q: %v", scanner.Text(), err)
+		}
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+func TestNewTracerProvider_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+	provider, shutdown, err := NewTracerProvider(context.Background(), config.Tracing{
+		Backend:  config.TracingBackendFile,
+		FilePath: path,
+	}, "test")
+	if err != nil {
+		t.Fatalf("NewTracerProvider: %v", err)
+	}
+	ctx, parent := provider.Tracer(TracerName).Start(context.Background(), "parent")
+	_, child := provider.Tracer(TracerName).Start(ctx, "child")
+	child.End()
+	parent.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if d := cmp.Diff([]string{"child", "parent"}, readSpanNames(t, path)); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestNewTracerProvider_None(t *testing.T) {
+	provider, shutdown, err := NewTracerProvider(context.Background(), config.Tracing{Backend: config.TracingBackendNone}, "test")
+	if err != nil {
+		t.Fatalf("NewTracerProvider: %v", err)
+	}
+	defer shutdown(context.Background())
+	_, span := provider.Tracer(TracerName).Start(context.Background(), "span")
+	defer span.End()
+	if span.SpanContext().IsValid() {
+		t.Error("Expected the spans not to be recorded when tracing is disabled")
+	}
+}
+
+func TestOnStore(t *testing.T) {
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	onStore := OnStore(zap.NewNop().Sugar(), "test")
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+
+	onStore(config.GetMetricsConfigName(), &config.Metrics{
+		Tracing: config.Tracing{Backend: config.TracingBackendFile, FilePath: path},
+	})
+	_, span := Tracer().Start(context.Background(), "traced")
+	span.End()
+
+	// Other configs don't change the provider
+	onStore(config.GetFeatureFlagsConfigName(), &config.FeatureFlags{})
+	_, span = Tracer().Start(context.Background(), "traced-again")
+	span.End()
+
+	onStore(config.GetMetricsConfigName(), &config.Metrics{
+		Tracing: config.Tracing{Backend: config.TracingBackendNone},
+	})
+	_, span = Tracer().Start(context.Background(), "untraced")
+	span.End()
+	if span.SpanContext().IsValid() {
+		t.Error("Expected the spans not to be recorded once tracing is disabled")
+	}
+
+	if d := cmp.Diff([]string{"traced", "traced-again"}, readSpanNames(t, path)); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
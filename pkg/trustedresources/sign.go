@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// SignTask signs the spec of task with signer and stores the signature in the
+// SignatureAnnotation of task. The spec is signed with its defaults applied, as
+// it is stored in the cluster and verified wherever the Task is fetched from.
+func SignTask(task *v1beta1.Task, signer crypto.Signer) error {
+	t := task.DeepCopy()
+	t.SetDefaults(context.Background())
+	signature, err := sign(t.Spec, signer)
+	if err != nil {
+		return err
+	}
+	if task.Annotations == nil {
+		task.Annotations = map[string]string{}
+	}
+	task.Annotations[SignatureAnnotation] = signature
+	return nil
+}
+
+// SignPipeline signs the spec of pipeline with signer and stores the signature
+// in the SignatureAnnotation of pipeline. The spec is signed with its defaults
+// applied, as it is stored in the cluster and verified wherever the Pipeline is
+// fetched from.
+func SignPipeline(pipeline *v1beta1.Pipeline, signer crypto.Signer) error {
+	p := pipeline.DeepCopy()
+	p.SetDefaults(context.Background())
+	signature, err := sign(p.Spec, signer)
+	if err != nil {
+		return err
+	}
+	if pipeline.Annotations == nil {
+		pipeline.Annotations = map[string]string{}
+	}
+	pipeline.Annotations[SignatureAnnotation] = signature
+	return nil
+}
+
+// sign returns the base64 encoded signature of the JSON encoding of spec.
+func sign(spec interface{}, signer crypto.Signer) (string, error) {
+	message, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	signature, err := SignMessage(message, signer)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// SignMessage signs message with signer, so that the signature can be checked with VerifyMessage.
+func SignMessage(message []byte, signer crypto.Signer) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		// Ed25519 signs the message itself rather than its digest.
+		return signer.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(message)
+	return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
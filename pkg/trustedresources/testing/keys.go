@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+)
+
+// GetSignerAndPublicKey generates an ECDSA key pair and returns the signer
+// along with its PEM encoded public key.
+func GetSignerAndPublicKey(t *testing.T) (crypto.Signer, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	return key, PublicKeyPEM(t, key.Public())
+}
+
+// PublicKeyPEM returns the PEM encoding of key.
+func PublicKeyPEM(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("error marshaling public key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// SetupTrustedResourcesContext returns a copy of ctx with the "resource-verification-mode"
+// feature flag set to mode and with trustedResources as the trusted resources config.
+func SetupTrustedResourcesContext(ctx context.Context, mode string, trustedResources *config.TrustedResources) context.Context {
+	cfg := *config.FromContextOrDefaults(ctx)
+	featureFlags := *cfg.FeatureFlags
+	featureFlags.ResourceVerificationMode = mode
+	cfg.FeatureFlags = &featureFlags
+	cfg.TrustedResources = trustedResources
+	return config.ToContext(ctx, &cfg)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trustedresources verifies the signatures of Tasks and Pipelines
+// against the public keys configured in the config-trusted-resources ConfigMap.
+package trustedresources
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// SignatureAnnotation is the annotation holding the base64 encoded signature
+	// of the spec of a Task or Pipeline.
+	SignatureAnnotation = "tekton.dev/signature"
+
+	// ConditionTrustedResourcesVerified is the condition recording the outcome of
+	// the verification of the Task or Pipeline referenced by a TaskRun or PipelineRun.
+	ConditionTrustedResourcesVerified apis.ConditionType = "TrustedResourcesVerified"
+
+	// ReasonResourceVerified indicates that the referenced Task or Pipeline has a
+	// valid signature from a trusted key.
+	ReasonResourceVerified = "ResourceVerified"
+	// ReasonResourceVerificationFailed indicates that the referenced Task or
+	// Pipeline does not have a valid signature from a trusted key.
+	ReasonResourceVerificationFailed = "ResourceVerificationFailed"
+
+	// BundleResolver is the resolver under which the public keys trusted for the Tasks and
+	// Pipelines fetched from the Tekton Bundles referenced with the bundle field are configured.
+	BundleResolver = "bundles"
+)
+
+// ErrResourceVerificationFailed is returned when a Task or Pipeline fails
+// verification and the "resource-verification-mode" feature flag is "enforce".
+var ErrResourceVerificationFailed = errors.New("resource verification failed")
+
+// VerificationResultType is the outcome of the verification of a Task or Pipeline.
+type VerificationResultType int
+
+const (
+	// VerificationPass means the resource has a valid signature from a trusted key.
+	VerificationPass VerificationResultType = iota
+	// VerificationWarn means the resource failed verification in "warn" mode.
+	VerificationWarn
+	// VerificationError means the resource failed verification in "enforce" mode.
+	VerificationError
+)
+
+// VerificationResult holds the outcome of the verification of a Task or Pipeline.
+type VerificationResult struct {
+	Type VerificationResultType
+	// Err is the reason the resource failed verification.
+	Err error
+}
+
+// Condition returns the ConditionTrustedResourcesVerified condition recording the result.
+func (r *VerificationResult) Condition() *apis.Condition {
+	switch r.Type {
+	case VerificationPass:
+		return &apis.Condition{
+			Type:   ConditionTrustedResourcesVerified,
+			Status: corev1.ConditionTrue,
+			Reason: ReasonResourceVerified,
+		}
+	case VerificationWarn:
+		return &apis.Condition{
+			Type:     ConditionTrustedResourcesVerified,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   ReasonResourceVerificationFailed,
+			Message:  r.Err.Error(),
+		}
+	default:
+		return &apis.Condition{
+			Type:     ConditionTrustedResourcesVerified,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityError,
+			Reason:   ReasonResourceVerificationFailed,
+			Message:  r.Err.Error(),
+		}
+	}
+}
+
+// VerifyTask verifies the signature of task against the public keys trusted for the Tasks
+// referenced by runs in namespace and fetched by resolver, which is empty for Tasks fetched
+// from the cluster. It returns nil when the "resource-verification-mode" feature flag is "skip".
+// The task is verified with its defaults applied, so that it verifies the same whether it was
+// defaulted by the webhook when stored in the cluster or fetched remotely as authored.
+func VerifyTask(ctx context.Context, task v1beta1.TaskObject, namespace, resolver string) *VerificationResult {
+	t := task.Copy()
+	t.SetDefaults(context.Background())
+	meta := t.TaskMetadata()
+	return verify(ctx, "task", meta.Name, meta.Annotations, t.TaskSpec(), namespace, resolver)
+}
+
+// VerifyPipeline verifies the signature of pipeline against the public keys trusted for the
+// Pipelines referenced by runs in namespace and fetched by resolver, which is empty for Pipelines
+// fetched from the cluster. It returns nil when the "resource-verification-mode" feature flag is "skip".
+// The pipeline is verified with its defaults applied, so that it verifies the same whether it was
+// defaulted by the webhook when stored in the cluster or fetched remotely as authored.
+func VerifyPipeline(ctx context.Context, pipeline v1beta1.PipelineObject, namespace, resolver string) *VerificationResult {
+	p := pipeline.Copy()
+	p.SetDefaults(context.Background())
+	meta := p.PipelineMetadata()
+	return verify(ctx, "pipeline", meta.Name, meta.Annotations, p.PipelineSpec(), namespace, resolver)
+}
+
+func verify(ctx context.Context, kind, name string, annotations map[string]string, spec interface{}, namespace, resolver string) *VerificationResult {
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.FeatureFlags == nil {
+		return nil
+	}
+	var resultType VerificationResultType
+	switch cfg.FeatureFlags.ResourceVerificationMode {
+	case config.EnforceResourceVerificationMode:
+		resultType = VerificationError
+	case config.WarnResourceVerificationMode:
+		resultType = VerificationWarn
+	default:
+		return nil
+	}
+
+	trusted := cfg.TrustedResources
+	if trusted == nil {
+		trusted = &config.TrustedResources{}
+	}
+	err := verifySignature(annotations, spec, trusted, namespace, resolver)
+	if err == nil {
+		return &VerificationResult{Type: VerificationPass}
+	}
+	err = fmt.Errorf("%s %q failed verification: %v", kind, name, err)
+	if resultType == VerificationError {
+		err = fmt.Errorf("%w: %v", ErrResourceVerificationFailed, err)
+	}
+	return &VerificationResult{Type: resultType, Err: err}
+}
+
+func verifySignature(annotations map[string]string, spec interface{}, trusted *config.TrustedResources, namespace, resolver string) error {
+	encoded, ok := annotations[SignatureAnnotation]
+	if !ok {
+		return fmt.Errorf("%q annotation is missing", SignatureAnnotation)
+	}
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid %q annotation: %v", SignatureAnnotation, err)
+	}
+	keys, err := trusted.PublicKeysFor(namespace, resolver)
+	if err != nil {
+		return fmt.Errorf("error loading trusted public keys: %v", err)
+	}
+	if len(keys) == 0 {
+		return errors.New("no trusted public keys are configured")
+	}
+	message, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if VerifyMessage(key, message, signature) {
+			return nil
+		}
+	}
+	return errors.New("signature does not match any trusted public key")
+}
+
+// VerifyMessage returns true if signature is a valid signature of message by key. ECDSA and RSA
+// (PKCS #1 v1.5) signatures are made over the SHA-256 digest of message, and Ed25519 signatures
+// over message itself.
+func VerifyMessage(key crypto.PublicKey, message, signature []byte) bool {
+	digest := sha256.Sum256(message)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, message, signature)
+	default:
+		return false
+	}
+}
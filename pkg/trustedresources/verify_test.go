@@ -0,0 +1,330 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	ttesting "github.com/tektoncd/pipeline/pkg/trustedresources/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func getTask() *v1beta1.Task {
+	return &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "foo"},
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "message"}},
+			Steps: []v1beta1.Step{{
+				Image:  "ubuntu",
+				Script: "echo $(params.message)",
+			}},
+		},
+	}
+}
+
+func getPipeline() *v1beta1.Pipeline {
+	return &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:    "task",
+				TaskRef: &v1beta1.TaskRef{Name: "test-task"},
+			}},
+		},
+	}
+}
+
+func TestVerifyTask(t *testing.T) {
+	ctx := context.Background()
+	signer, publicKey := ttesting.GetSignerAndPublicKey(t)
+	_, otherPublicKey := ttesting.GetSignerAndPublicKey(t)
+
+	signedTask := getTask()
+	if err := trustedresources.SignTask(signedTask, signer); err != nil {
+		t.Fatalf("SignTask() = %v", err)
+	}
+	tamperedTask := signedTask.DeepCopy()
+	tamperedTask.Spec.Steps[0].Image = "alpine"
+	invalidSignatureTask := signedTask.DeepCopy()
+	invalidSignatureTask.Annotations[trustedresources.SignatureAnnotation] = "not-base64!"
+
+	for _, tc := range []struct {
+		name             string
+		mode             string
+		trustedResources *config.TrustedResources
+		task             v1beta1.TaskObject
+		resolver         string
+		want             *trustedresources.VerificationResultType
+	}{{
+		name:             "skip mode",
+		mode:             config.SkipResourceVerificationMode,
+		trustedResources: &config.TrustedResources{},
+		task:             getTask(),
+	}, {
+		name:             "signed by a key trusted for all resources",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: otherPublicKey + publicKey},
+		task:             signedTask,
+		want:             resultType(trustedresources.VerificationPass),
+	}, {
+		name:             "signed by a key trusted for the namespace",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{NamespacePublicKeys: map[string]string{"foo": publicKey}},
+		task:             signedTask,
+		want:             resultType(trustedresources.VerificationPass),
+	}, {
+		name:             "signed by a key trusted for another namespace",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: otherPublicKey, NamespacePublicKeys: map[string]string{"bar": publicKey}},
+		task:             signedTask,
+		want:             resultType(trustedresources.VerificationError),
+	}, {
+		name:             "signed by a key trusted for the resolver",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{ResolverPublicKeys: map[string]string{"git": publicKey}},
+		task:             signedTask,
+		resolver:         "git",
+		want:             resultType(trustedresources.VerificationPass),
+	}, {
+		name:             "signed by a key trusted for the resolver but fetched from the cluster",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: otherPublicKey, ResolverPublicKeys: map[string]string{"git": publicKey}},
+		task:             signedTask,
+		want:             resultType(trustedresources.VerificationError),
+	}, {
+		name:             "signed by an untrusted key",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: otherPublicKey},
+		task:             signedTask,
+		want:             resultType(trustedresources.VerificationError),
+	}, {
+		name:             "unsigned task",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: publicKey},
+		task:             getTask(),
+		want:             resultType(trustedresources.VerificationError),
+	}, {
+		name:             "tampered task",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: publicKey},
+		task:             tamperedTask,
+		want:             resultType(trustedresources.VerificationError),
+	}, {
+		name:             "invalid signature",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: publicKey},
+		task:             invalidSignatureTask,
+		want:             resultType(trustedresources.VerificationError),
+	}, {
+		name:             "no trusted keys",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{},
+		task:             signedTask,
+		want:             resultType(trustedresources.VerificationError),
+	}, {
+		name:             "tampered task in warn mode",
+		mode:             config.WarnResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: publicKey},
+		task:             tamperedTask,
+		want:             resultType(trustedresources.VerificationWarn),
+	}, {
+		name:             "signed cluster task",
+		mode:             config.EnforceResourceVerificationMode,
+		trustedResources: &config.TrustedResources{PublicKeys: publicKey},
+		task:             &v1beta1.ClusterTask{ObjectMeta: signedTask.ObjectMeta, Spec: signedTask.Spec},
+		want:             resultType(trustedresources.VerificationPass),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := ttesting.SetupTrustedResourcesContext(ctx, tc.mode, tc.trustedResources)
+			vr := trustedresources.VerifyTask(ctx, tc.task, "foo", tc.resolver)
+			checkVerificationResult(t, vr, tc.want)
+		})
+	}
+}
+
+func TestVerifyPipeline(t *testing.T) {
+	ctx := context.Background()
+	signer, publicKey := ttesting.GetSignerAndPublicKey(t)
+
+	signedPipeline := getPipeline()
+	if err := trustedresources.SignPipeline(signedPipeline, signer); err != nil {
+		t.Fatalf("SignPipeline() = %v", err)
+	}
+	tamperedPipeline := signedPipeline.DeepCopy()
+	tamperedPipeline.Spec.Tasks[0].TaskRef.Name = "other-task"
+
+	for _, tc := range []struct {
+		name     string
+		mode     string
+		pipeline v1beta1.PipelineObject
+		want     *trustedresources.VerificationResultType
+	}{{
+		name:     "skip mode",
+		mode:     config.SkipResourceVerificationMode,
+		pipeline: tamperedPipeline,
+	}, {
+		name:     "signed pipeline",
+		mode:     config.EnforceResourceVerificationMode,
+		pipeline: signedPipeline,
+		want:     resultType(trustedresources.VerificationPass),
+	}, {
+		name:     "tampered pipeline",
+		mode:     config.EnforceResourceVerificationMode,
+		pipeline: tamperedPipeline,
+		want:     resultType(trustedresources.VerificationError),
+	}, {
+		name:     "unsigned pipeline in warn mode",
+		mode:     config.WarnResourceVerificationMode,
+		pipeline: getPipeline(),
+		want:     resultType(trustedresources.VerificationWarn),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := ttesting.SetupTrustedResourcesContext(ctx, tc.mode, &config.TrustedResources{PublicKeys: publicKey})
+			vr := trustedresources.VerifyPipeline(ctx, tc.pipeline, "foo", "")
+			checkVerificationResult(t, vr, tc.want)
+		})
+	}
+}
+
+func TestVerifyTaskKeyTypes(t *testing.T) {
+	ctx := context.Background()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating RSA key: %v", err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating Ed25519 key: %v", err)
+	}
+	ecdsaKey, _ := ttesting.GetSignerAndPublicKey(t)
+
+	for _, tc := range []struct {
+		name   string
+		signer crypto.Signer
+	}{{
+		name:   "ecdsa",
+		signer: ecdsaKey,
+	}, {
+		name:   "rsa",
+		signer: rsaKey,
+	}, {
+		name:   "ed25519",
+		signer: ed25519Key,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			task := getTask()
+			if err := trustedresources.SignTask(task, tc.signer); err != nil {
+				t.Fatalf("SignTask() = %v", err)
+			}
+			ctx := ttesting.SetupTrustedResourcesContext(ctx, config.EnforceResourceVerificationMode, &config.TrustedResources{
+				PublicKeys: ttesting.PublicKeyPEM(t, tc.signer.Public()),
+			})
+			vr := trustedresources.VerifyTask(ctx, task, "foo", "")
+			checkVerificationResult(t, vr, resultType(trustedresources.VerificationPass))
+		})
+	}
+}
+
+func TestVerificationResultCondition(t *testing.T) {
+	err := errors.New("task \"foo\" failed verification")
+	for _, tc := range []struct {
+		name string
+		vr   trustedresources.VerificationResult
+		want *apis.Condition
+	}{{
+		name: "pass",
+		vr:   trustedresources.VerificationResult{Type: trustedresources.VerificationPass},
+		want: &apis.Condition{
+			Type:   trustedresources.ConditionTrustedResourcesVerified,
+			Status: corev1.ConditionTrue,
+			Reason: trustedresources.ReasonResourceVerified,
+		},
+	}, {
+		name: "warn",
+		vr:   trustedresources.VerificationResult{Type: trustedresources.VerificationWarn, Err: err},
+		want: &apis.Condition{
+			Type:     trustedresources.ConditionTrustedResourcesVerified,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   trustedresources.ReasonResourceVerificationFailed,
+			Message:  err.Error(),
+		},
+	}, {
+		name: "error",
+		vr:   trustedresources.VerificationResult{Type: trustedresources.VerificationError, Err: err},
+		want: &apis.Condition{
+			Type:     trustedresources.ConditionTrustedResourcesVerified,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityError,
+			Reason:   trustedresources.ReasonResourceVerificationFailed,
+			Message:  err.Error(),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.vr.Condition()); d != "" {
+				t.Errorf("Condition() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func resultType(t trustedresources.VerificationResultType) *trustedresources.VerificationResultType {
+	return &t
+}
+
+func checkVerificationResult(t *testing.T, vr *trustedresources.VerificationResult, want *trustedresources.VerificationResultType) {
+	t.Helper()
+	if want == nil {
+		if vr != nil {
+			t.Fatalf("expected verification to be skipped, got %v", vr)
+		}
+		return
+	}
+	if vr == nil {
+		t.Fatalf("expected verification result %d, got nil", *want)
+	}
+	if vr.Type != *want {
+		t.Fatalf("expected verification result %d, got %d: %v", *want, vr.Type, vr.Err)
+	}
+	switch vr.Type {
+	case trustedresources.VerificationPass:
+		if vr.Err != nil {
+			t.Errorf("expected no error, got %v", vr.Err)
+		}
+	case trustedresources.VerificationWarn:
+		if vr.Err == nil || errors.Is(vr.Err, trustedresources.ErrResourceVerificationFailed) {
+			t.Errorf("expected a non enforced verification error, got %v", vr.Err)
+		}
+	case trustedresources.VerificationError:
+		if !errors.Is(vr.Err, trustedresources.ErrResourceVerificationFailed) {
+			t.Errorf("expected %v, got %v", trustedresources.ErrResourceVerificationFailed, vr.Err)
+		}
+	}
+}
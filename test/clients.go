@@ -46,7 +46,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1beta1"
 	resolutionversioned "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned"
-	resolutionv1alpha1 "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned/typed/resolution/v1alpha1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned/typed/resolution/v1beta1"
 	resourceversioned "github.com/tektoncd/pipeline/pkg/client/resource/clientset/versioned"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/client/resource/clientset/versioned/typed/resource/v1alpha1"
 	"k8s.io/client-go/kubernetes"
@@ -64,7 +64,7 @@ type clients struct {
 	PipelineRunClient       v1beta1.PipelineRunInterface
 	PipelineResourceClient  resourcev1alpha1.PipelineResourceInterface
 	RunClient               v1alpha1.RunInterface
-	ResolutionRequestclient resolutionv1alpha1.ResolutionRequestInterface
+	ResolutionRequestclient resolutionv1beta1.ResolutionRequestInterface
 }
 
 // newClients instantiates and returns several clientsets required for making requests to the
@@ -105,6 +105,6 @@ func newClients(t *testing.T, configPath, clusterName, namespace string) *client
 	c.PipelineRunClient = cs.TektonV1beta1().PipelineRuns(namespace)
 	c.PipelineResourceClient = rcs.TektonV1alpha1().PipelineResources(namespace)
 	c.RunClient = cs.TektonV1alpha1().Runs(namespace)
-	c.ResolutionRequestclient = rrcs.ResolutionV1alpha1().ResolutionRequests(namespace)
+	c.ResolutionRequestclient = rrcs.ResolutionV1beta1().ResolutionRequests(namespace)
 	return c
 }
@@ -25,7 +25,7 @@ import (
 	// Link in the fakes so they get injected into injection.Fake
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	resolutionv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	fakepipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	informersv1alpha1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1alpha1"
@@ -38,9 +38,9 @@ import (
 	faketaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/task/fake"
 	faketaskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun/fake"
 	fakeresolutionclientset "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned/fake"
-	resolutioninformersv1alpha1 "github.com/tektoncd/pipeline/pkg/client/resolution/informers/externalversions/resolution/v1alpha1"
+	resolutioninformersv1beta1 "github.com/tektoncd/pipeline/pkg/client/resolution/informers/externalversions/resolution/v1beta1"
 	fakeresolutionrequestclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client/fake"
-	fakeresolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1alpha1/resolutionrequest/fake"
+	fakeresolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest/fake"
 	fakeresourceclientset "github.com/tektoncd/pipeline/pkg/client/resource/clientset/versioned/fake"
 	resourceinformersv1alpha1 "github.com/tektoncd/pipeline/pkg/client/resource/informers/externalversions/resource/v1alpha1"
 	fakeresourceclient "github.com/tektoncd/pipeline/pkg/client/resource/injection/client/fake"
@@ -48,11 +48,13 @@ import (
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	schedulinginformers "k8s.io/client-go/informers/scheduling/v1"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	ktesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
@@ -60,8 +62,11 @@ import (
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	fakeconfigmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	fakelimitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange/fake"
+	fakenamespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
 	fakefilteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered/fake"
+	fakeresourcequotainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/resourcequota/fake"
 	fakeserviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/fake"
+	fakepriorityclassinformer "knative.dev/pkg/client/injection/kube/informers/scheduling/v1/priorityclass/fake"
 	"knative.dev/pkg/controller"
 )
 
@@ -80,7 +85,9 @@ type Data struct {
 	ConfigMaps         []*corev1.ConfigMap
 	ServiceAccounts    []*corev1.ServiceAccount
 	LimitRange         []*corev1.LimitRange
-	ResolutionRequests []*resolutionv1alpha1.ResolutionRequest
+	ResourceQuotas     []*corev1.ResourceQuota
+	PriorityClasses    []*schedulingv1.PriorityClass
+	ResolutionRequests []*resolutionv1beta1.ResolutionRequest
 }
 
 // Clients holds references to clients which are useful for reconciler tests.
@@ -105,7 +112,10 @@ type Informers struct {
 	ConfigMap         coreinformers.ConfigMapInformer
 	ServiceAccount    coreinformers.ServiceAccountInformer
 	LimitRange        coreinformers.LimitRangeInformer
-	ResolutionRequest resolutioninformersv1alpha1.ResolutionRequestInformer
+	Namespace         coreinformers.NamespaceInformer
+	ResourceQuota     coreinformers.ResourceQuotaInformer
+	PriorityClass     schedulinginformers.PriorityClassInformer
+	ResolutionRequest resolutioninformersv1beta1.ResolutionRequestInformer
 }
 
 // Assets holds references to the controller, logs, clients, and informers.
@@ -188,6 +198,9 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		ConfigMap:         fakeconfigmapinformer.Get(ctx),
 		ServiceAccount:    fakeserviceaccountinformer.Get(ctx),
 		LimitRange:        fakelimitrangeinformer.Get(ctx),
+		Namespace:         fakenamespaceinformer.Get(ctx),
+		ResourceQuota:     fakeresourcequotainformer.Get(ctx),
+		PriorityClass:     fakepriorityclassinformer.Get(ctx),
 		ResolutionRequest: fakeresolutionrequestinformer.Get(ctx),
 	}
 
@@ -250,12 +263,27 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Kube.PrependReactor("*", "namespaces", AddToInformer(t, i.Namespace.Informer().GetIndexer()))
 	for _, n := range d.Namespaces {
 		n := n.DeepCopy() // Avoid assumptions that the informer's copy is modified.
 		if _, err := c.Kube.CoreV1().Namespaces().Create(ctx, n, metav1.CreateOptions{}); err != nil {
 			t.Fatal(err)
 		}
 	}
+	c.Kube.PrependReactor("*", "resourcequotas", AddToInformer(t, i.ResourceQuota.Informer().GetIndexer()))
+	for _, rq := range d.ResourceQuotas {
+		rq := rq.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Kube.CoreV1().ResourceQuotas(rq.Namespace).Create(ctx, rq, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Kube.PrependReactor("*", "priorityclasses", AddToInformer(t, i.PriorityClass.Informer().GetIndexer()))
+	for _, pc := range d.PriorityClasses {
+		pc := pc.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Kube.SchedulingV1().PriorityClasses().Create(ctx, pc, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.Kube.PrependReactor("*", "configmaps", AddToInformer(t, i.ConfigMap.Informer().GetIndexer()))
 	for _, cm := range d.ConfigMaps {
 		cm := cm.DeepCopy() // Avoid assumptions that the informer's copy is modified.
@@ -273,7 +301,7 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 	c.ResolutionRequests.PrependReactor("*", "resolutionrequests", AddToInformer(t, i.ResolutionRequest.Informer().GetIndexer()))
 	for _, rr := range d.ResolutionRequests {
 		rr := rr.DeepCopy() // Avoid assumptions that the informer's copy is modified.
-		if _, err := c.ResolutionRequests.ResolutionV1alpha1().ResolutionRequests(rr.Namespace).Create(ctx, rr, metav1.CreateOptions{}); err != nil {
+		if _, err := c.ResolutionRequests.ResolutionV1beta1().ResolutionRequests(rr.Namespace).Create(ctx, rr, metav1.CreateOptions{}); err != nil {
 			t.Fatal(err)
 		}
 	}
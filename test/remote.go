@@ -19,16 +19,23 @@ package test
 import (
 	"archive/tar"
 	"bytes"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	remoteimg "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	tkremote "github.com/tektoncd/pipeline/pkg/remote/oci"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/yaml"
 )
@@ -115,6 +122,89 @@ func CreateImageWithAnnotations(ref string, mapper ObjectAnnotationMapper, objs
 	return imgRef.Context().Digest(digest.String()).String(), nil
 }
 
+// SignImage pushes a cosign signature of the image with the digest ref, made with signer.
+func SignImage(ref string, signer crypto.Signer) error {
+	digest, err := name.NewDigest(ref)
+	if err != nil {
+		return fmt.Errorf("image must be referenced by digest: %w", err)
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"critical": map[string]interface{}{
+			"identity": map[string]string{"docker-reference": digest.Context().String()},
+			"image":    map[string]string{"docker-manifest-digest": digest.DigestStr()},
+			"type":     "cosign container image signature",
+		},
+		"optional": nil,
+	})
+	if err != nil {
+		return err
+	}
+	signature, err := trustedresources.SignMessage(payload, signer)
+	if err != nil {
+		return err
+	}
+	return appendSignedLayer(digest, tkremote.SignatureTagSuffix, static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+		map[string]string{tkremote.SignatureAnnotation: base64.StdEncoding.EncodeToString(signature)})
+}
+
+// AttestImage pushes a cosign attestation about the image with the digest ref, signed with signer.
+func AttestImage(ref string, signer crypto.Signer) error {
+	digest, err := name.NewDigest(ref)
+	if err != nil {
+		return fmt.Errorf("image must be referenced by digest: %w", err)
+	}
+	hash, err := v1.NewHash(digest.DigestStr())
+	if err != nil {
+		return err
+	}
+	payloadType := "application/vnd.in-toto+json"
+	payload, err := json.Marshal(map[string]interface{}{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://slsa.dev/provenance/v0.2",
+		"subject": []map[string]interface{}{{
+			"name":   digest.Context().String(),
+			"digest": map[string]string{hash.Algorithm: hash.Hex},
+		}},
+		"predicate": map[string]interface{}{},
+	})
+	if err != nil {
+		return err
+	}
+	pae := fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+	signature, err := trustedresources.SignMessage([]byte(pae), signer)
+	if err != nil {
+		return err
+	}
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payloadType": payloadType,
+		"payload":     base64.StdEncoding.EncodeToString(payload),
+		"signatures":  []map[string]string{{"sig": base64.StdEncoding.EncodeToString(signature)}},
+	})
+	if err != nil {
+		return err
+	}
+	return appendSignedLayer(digest, tkremote.AttestationTagSuffix, static.NewLayer(envelope, "application/vnd.dsse.envelope.v1+json"), nil)
+}
+
+// appendSignedLayer adds layer to the cosign image with the given tag suffix of the image with digest, creating it
+// if needed.
+func appendSignedLayer(digest name.Digest, suffix string, layer v1.Layer, annotations map[string]string) error {
+	hash, err := v1.NewHash(digest.DigestStr())
+	if err != nil {
+		return err
+	}
+	tag := tkremote.SignatureTag(digest.Context(), hash, suffix)
+	img, err := remoteimg.Image(tag)
+	if err != nil {
+		img = mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	}
+	img, err = mutate.Append(img, mutate.Addendum{Layer: layer, Annotations: annotations})
+	if err != nil {
+		return fmt.Errorf("could not add layer to image %w", err)
+	}
+	return remoteimg.Write(tag, img)
+}
+
 // Return the ObjectMetadata.Name field which every resource should have.
 func getObjectName(obj runtime.Object) string {
 	return reflect.Indirect(reflect.ValueOf(obj)).FieldByName("ObjectMeta").FieldByName("Name").String()
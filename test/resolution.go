@@ -2,8 +2,11 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/google/go-cmp/cmp"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
 )
 
@@ -39,7 +42,7 @@ type Requester struct {
 	// An error to return when a request is submitted.
 	SubmitErr error
 	// Params that should match those on the request in order to return the resolved resource
-	Params map[string]string
+	Params []pipelinev1beta1.Param
 }
 
 // Submit implements resolution.Requester, accepting the name of a
@@ -49,20 +52,30 @@ func (r *Requester) Submit(ctx context.Context, resolverName resolution.Resolver
 	if len(r.Params) == 0 {
 		return r.ResolvedResource, r.SubmitErr
 	}
-	reqParams := make(map[string]string)
-	for k, v := range req.Params() {
-		reqParams[k] = v
+	reqParams := make(map[string]pipelinev1beta1.ParamValue)
+	for _, p := range req.Params() {
+		reqParams[p.Name] = p.Value
 	}
 
-	for k, v := range r.Params {
-		if reqValue, ok := reqParams[k]; !ok || reqValue != v {
-			return nil, fmt.Errorf("expected %s param to be %s, but was %s", k, v, reqValue)
+	for _, p := range r.Params {
+		if reqValue, ok := reqParams[p.Name]; !ok || !cmp.Equal(reqValue, p.Value) {
+			return nil, fmt.Errorf("expected %s param to be %s, but was %s", p.Name, paramValueString(p.Value), paramValueString(reqValue))
 		}
 	}
 
 	return r.ResolvedResource, r.SubmitErr
 }
 
+// paramValueString returns a string value as is, and an array or object
+// value in its JSON encoding.
+func paramValueString(value pipelinev1beta1.ParamValue) string {
+	if value.Type == pipelinev1beta1.ParamTypeArray || value.Type == pipelinev1beta1.ParamTypeObject {
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+	return value.StringVal
+}
+
 // ResolvedResource implements resolution.ResolvedResource and makes
 // it easier to mock the resolved content of a fetched pipeline or task.
 type ResolvedResource struct {